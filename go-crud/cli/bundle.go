@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/surrealdb/go-crud-bench/internal/config"
+	"github.com/surrealdb/go-crud-bench/internal/dbutils"
+)
+
+// newExportWorkloadCommand creates the command which packages a benchmark
+// into a workload bundle
+func newExportWorkloadCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-workload <bundle.tgz>",
+		Short: "Package the workload of a benchmark into a bundle which reproduces it elsewhere",
+		Long: `Package the effective configuration of a benchmark, taking the same flags as
+the run command, into a single shareable bundle: every workload flag including
+the defaults, the value template, the scan specifications, the random seed and
+the digest of the Docker image. Run exactly that benchmark elsewhere with:
+
+	crud-bench --workload-bundle bundle.tgz
+
+A random seed is chosen unless --seed is given. The image digest is recorded
+when --image names an image available locally.`,
+		Args: cobra.ExactArgs(1),
+		Run:  runExportWorkload,
+	}
+	addRunFlags(cmd)
+	return cmd
+}
+
+func runExportWorkload(cmd *cobra.Command, args []string) {
+	// Reproducing the dataset requires a fixed seed
+	if seed, _ := cmd.Flags().GetInt64("seed"); seed == 0 {
+		cmd.Flags().Set("seed", strconv.FormatInt(time.Now().UnixNano(), 10))
+	}
+
+	cfg, err := config.FromCommand(cmd)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	bundle, err := config.NewBundle(cmd, cfg)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Pin the image to its digest, as tags can be moved to other images
+	if cfg.Image == "" {
+		fmt.Printf("No --image given, the bundle uses the default image of %s at the time it is run\n", cfg.Database)
+	} else if digest, err := dbutils.ImageDigest(cfg.Image); err != nil {
+		fmt.Printf("Warning: %v, the bundle records the image tag only\n", err)
+	} else if digest == "" {
+		fmt.Printf("Warning: image %s has no repository digest, the bundle records the image tag only\n", cfg.Image)
+	} else {
+		bundle.ImageDigest = digest
+	}
+
+	if err := bundle.Write(args[0]); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Workload bundle written to %s (workload hash %s, seed %d)\n", args[0], bundle.WorkloadHash, bundle.Seed)
+}
+
+// applyWorkloadBundle sets the flags of a run command to the values of the
+// workload bundle given with --workload-bundle. It runs before the required
+// flags are checked, so that the bundle can provide them.
+func applyWorkloadBundle(cmd *cobra.Command, args []string) error {
+	path, _ := cmd.Flags().GetString("workload-bundle")
+	if path == "" {
+		return nil
+	}
+	bundle, err := config.LoadBundle(path)
+	if err != nil {
+		return err
+	}
+	if err := bundle.Apply(cmd); err != nil {
+		return err
+	}
+	fmt.Printf("Running workload bundle %s created %s (workload hash %s, seed %d)\n", path, bundle.Created.Format(time.RFC3339), bundle.WorkloadHash, bundle.Seed)
+	return nil
+}
+
+// applyFlagFiles sets the flags of a run command not given on the command line
+// from the config file given with --config, then from the workload bundle, so
+// that a config file may also name the bundle it runs. The CRUD_BENCH_*
+// environment variables are applied first, as the run commands of a suite do
+// not inherit the persistent hook of the root command.
+func applyFlagFiles(cmd *cobra.Command, args []string) error {
+	if err := config.ApplyEnv(cmd); err != nil {
+		return err
+	}
+	if path, _ := cmd.Flags().GetString("config"); path != "" {
+		if err := config.ApplyFile(cmd, path); err != nil {
+			return err
+		}
+	}
+	return applyWorkloadBundle(cmd, args)
+}
@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+	"github.com/surrealdb/go-crud-bench/internal/codec"
+	"github.com/surrealdb/go-crud-bench/internal/config"
+	"github.com/surrealdb/go-crud-bench/internal/databases"
+	"github.com/surrealdb/go-crud-bench/internal/generators"
+)
+
+// newCalibrateCommand creates the command which measures the noise floor of
+// the benchmark harness on this host
+func newCalibrateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "calibrate",
+		Short: "Measure the noise floor of the benchmark harness on this host",
+		Long: `Measure the noise floor of the benchmark harness on this host, taking the same
+flags as the run command: the workload runs against the dry and map adapters,
+which do no database work, and values of the configured size are echoed over
+loopback TCP connections, all at the configured concurrency.
+
+The noise floor is stored in the file given with --calibration, by default in
+the user configuration directory, and every later run on this host reports its
+throughput as a share of it, so that results limited by the client rather than
+the database stand out.`,
+		Args: cobra.NoArgs,
+		Run:  runCalibrate,
+	}
+	addRunFlags(cmd)
+
+	// The calibration chooses the adapters, so --database is not required
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if err := applyFlagFiles(cmd, args); err != nil {
+			return err
+		}
+		if !cmd.Flags().Changed("database") {
+			cmd.Flags().Set("database", "dry")
+		}
+		return nil
+	}
+	return cmd
+}
+
+func runCalibrate(cmd *cobra.Command, args []string) {
+	cfg, err := config.FromCommand(cmd)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if err := codec.Use(cfg.JSONEncoder); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	path := cfg.Calibration
+	if path == "" {
+		if path, err = benchmark.DefaultCalibrationPath(); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	host, _ := os.Hostname()
+	calibration := &benchmark.Calibration{
+		Created: time.Now().UTC(),
+		Host:    host,
+		Samples: cfg.Samples,
+		Clients: cfg.Clients,
+		Threads: cfg.Threads,
+	}
+
+	// Run the workload against the adapters which do no database work
+	for _, database := range []string{"dry", "map"} {
+		fmt.Printf("Calibrating with the %s adapter...\n", database)
+		adapterCfg := *cfg
+		adapterCfg.Database = database
+		adapterCfg.Endpoint = ""
+		adapterCfg.Image = ""
+		adapterCfg.Network = nil
+		adapter, err := databases.NewAdapter(database, "", "", false, cfg.YugabyteAPI, config.ConnectionOptions{})
+		if err != nil {
+			fmt.Printf("Error: failed to create %s adapter: %v\n", database, err)
+			os.Exit(1)
+		}
+		results, err := benchmark.NewRunner(adapter, &adapterCfg).Run(ctx)
+		if err != nil {
+			fmt.Printf("Error: failed to calibrate with the %s adapter: %v\n", database, err)
+			os.Exit(1)
+		}
+		if database == "dry" {
+			calibration.Dry = benchmark.NoiseFloors(results)
+		} else {
+			calibration.Map = benchmark.NoiseFloors(results)
+		}
+	}
+
+	// Echo values of the configured size over loopback
+	fmt.Println("Calibrating with a loopback echo...")
+	sample, err := generators.GenerateSample(cfg.Value)
+	if err != nil {
+		fmt.Printf("Error: failed to generate sample: %v\n", err)
+		os.Exit(1)
+	}
+	calibration.Loopback, err = benchmark.RunLoopback(ctx, cfg.Clients*cfg.Threads, cfg.Samples, len(sample))
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("\nNoise floor with %d clients and %d threads:\n", cfg.Clients, cfg.Threads)
+	for _, adapter := range []struct {
+		name   string
+		floors []benchmark.NoiseFloor
+	}{
+		{"dry", calibration.Dry},
+		{"map", calibration.Map},
+		{"loopback", []benchmark.NoiseFloor{calibration.Loopback}},
+	} {
+		for _, floor := range adapter.floors {
+			fmt.Printf("  %-8s %-6s %-20s %12.0f ops/sec  p50 %v  p99 %v\n",
+				adapter.name, floor.Operation, floor.Name, floor.Throughput, floor.P50, floor.P99)
+		}
+	}
+
+	if err := calibration.Save(path); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("\nCalibration saved to %s\n", path)
+}
+
+// loadCalibration reads the noise floor stored at the given path, or at the
+// default location when none is given, returning nil when the host has not
+// been calibrated
+func loadCalibration(path string) (*benchmark.Calibration, error) {
+	if path != "" {
+		calibration, err := benchmark.LoadCalibration(path)
+		if err == nil && calibration == nil {
+			return nil, fmt.Errorf("calibration %s does not exist, create it with crud-bench calibrate", path)
+		}
+		return calibration, err
+	}
+	path, err := benchmark.DefaultCalibrationPath()
+	if err != nil {
+		return nil, nil
+	}
+	return benchmark.LoadCalibration(path)
+}
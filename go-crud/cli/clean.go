@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+	"github.com/surrealdb/go-crud-bench/internal/config"
+	"github.com/surrealdb/go-crud-bench/internal/databases"
+)
+
+// newCleanCommand creates the command which drops the tables of a namespaced
+// run on a shared endpoint
+func newCleanCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clean",
+		Short: "Drop the benchmark tables of a namespaced run left behind on a shared endpoint",
+		Run:   runClean,
+	}
+	cmd.Flags().StringVarP(&database, "database", "d", "", "The database to clean up")
+	cmd.MarkFlagRequired("database")
+	cmd.Flags().StringVarP(&endpoint, "endpoint", "e", "", "The endpoint of the shared database")
+	cmd.MarkFlagRequired("endpoint")
+	cmd.Flags().StringVar(&namespace, "namespace", "", "The namespace of the run whose tables are dropped, as recorded in its results file")
+	cmd.MarkFlagRequired("namespace")
+	addConnectionFlags(cmd)
+	cmd.Flags().StringVar(&yugabyteAPI, "yugabyte-api", "ysql", "The YugabyteDB API of the shared endpoint (ysql, ycql)")
+	return cmd
+}
+
+func runClean(cmd *cobra.Command, args []string) {
+	conn := config.ConnectionOptions{
+		Username:      username,
+		Password:      password,
+		Database:      dbName,
+		Port:          port,
+		TLS:           useTLS || tlsCA != "" || tlsCert != "" || tlsSkipVerify,
+		TLSCA:         tlsCA,
+		TLSCert:       tlsCert,
+		TLSKey:        tlsKey,
+		TLSSkipVerify: tlsSkipVerify,
+	}
+	if err := config.ValidateNamespace(namespace); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	adapter, err := databases.NewAdapter(database, endpoint, "", false, yugabyteAPI, conn)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	janitor, ok := adapter.(benchmark.Janitor)
+	if !ok {
+		fmt.Printf("Error: database %s does not support namespaced runs\n", adapter.Name())
+		os.Exit(1)
+	}
+
+	dropped, err := janitor.DropNamespace(context.Background(), namespace)
+	for _, table := range dropped {
+		fmt.Printf("Dropped %s\n", table)
+	}
+	if err != nil {
+		fmt.Printf("Error cleaning up: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Removed %d tables of namespace %s from %s\n", len(dropped), namespace, adapter.Name())
+}
@@ -0,0 +1,274 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/surrealdb/go-crud-bench/internal/config"
+)
+
+var (
+	// CLI flags
+	name       string
+	database   string
+	image      string
+	privileged bool
+	endpoint   string
+	blocking   int
+	workers    int
+	clients    int
+	threads    int
+	sharedPool bool
+	samples    int
+	random     bool
+	keyType    string
+	value      string
+	showSample bool
+	pid        int
+	scans      string
+	indexBuild string
+	compaction bool
+	network    string
+	slo        string
+	timeUnit   string
+	color      string
+	output     string
+	tune       map[string]string
+	checksum   bool
+	signKey    string
+	repeat     int
+	cooldown   time.Duration
+	maxLoad    float64
+	thermal    bool
+	dropCaches bool
+
+	debugQueries       int
+	debugParams        bool
+	keyEncoding        string
+	namespace          string
+	consistencyProbes  int
+	probeEndpoint      string
+	anomalyCheck       int
+	cleanupPolicy      string
+	scanConcurrency    int
+	selfTest           bool
+	readMulti          int
+	existsCheck        bool
+	casWrites          int
+	casKeys            int
+	increments         int
+	counters           int
+	changeFeed         bool
+	batchSize          int
+	charts             string
+	uploadURL          string
+	deleteMode         string
+	deleteBatch        int
+	softDelete         float64
+	updateMode         string
+	versions           int
+	children           int
+	graphEdges         int
+	graphHops          int
+	batchSweep         bool
+	maxClientMem       string
+	yugabyteAPI        string
+	opTimeout          time.Duration
+	workloadMix        string
+	distribution       string
+	progressFile       string
+	runDuration        time.Duration
+	rate               int
+	warmup             string
+	seed               int64
+	workloadBundle     string
+	controlSocket      string
+	serial             bool
+	rerun              string
+	calibration        string
+	outputFormats      []string
+	reportHTML         string
+	jsonEncoder        string
+	recordFormat       string
+	progressMode       string
+	timeSeriesInterval time.Duration
+	watchdogInterval   time.Duration
+	historyStore       string
+	bindAddresses      []string
+	referencePath      string
+	configFile         string
+	username           string
+	password           string
+	dbName             string
+	port               int
+	useTLS             bool
+	tlsCA              string
+	tlsCert            string
+	tlsKey             string
+	tlsSkipVerify      bool
+)
+
+// Execute runs the crud-bench command line with the arguments of the
+// process. Programs embedding crud-bench install their middleware with
+// middleware.Use before calling it.
+func Execute() {
+	rootCmd := &cobra.Command{
+		Use:   "crud-bench",
+		Short: "CRUD benchmarking tool for various databases",
+		Long: `The crud-bench benchmarking tool is an open-source benchmarking tool for testing 
+and comparing the performance of a number of different workloads on embedded, 
+networked, and remote databases. It can be used to compare both SQL and NoSQL platforms.`,
+		Run: runBenchmark,
+	}
+
+	// CRUD_BENCH_* environment variables set the flags of every command not
+	// given on the command line, before a config file or workload bundle
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		return config.ApplyEnv(cmd)
+	}
+
+	// Running the root command runs a benchmark, for backward compatibility
+	addRunFlags(rootCmd)
+
+	// Define subcommands
+	runCmd := &cobra.Command{
+		Use:   "run",
+		Short: "Run a benchmark against a database (default command)",
+		Run:   runBenchmark,
+	}
+	addRunFlags(runCmd)
+
+	rootCmd.AddCommand(
+		runCmd,
+		newCompareCommand(),
+		newHistoryCommand(),
+		newReportCommand(),
+		newListCommand(),
+		newCleanCommand(),
+		newDoctorCommand(),
+		newSelfTestCommand(),
+		newCalibrateCommand(),
+		newSuiteCommand(),
+		newExportWorkloadCommand(),
+		newCtlCommand(),
+	)
+
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+// addRunFlags defines the benchmark flags on a command
+func addRunFlags(cmd *cobra.Command) {
+	// A config file and a workload bundle provide the flags not given on the
+	// command line
+	cmd.PreRunE = applyFlagFiles
+
+	cmd.Flags().StringVarP(&name, "name", "n", "", "An optional name for the test, used as a suffix for the JSON result file name")
+	cmd.Flags().StringVarP(&database, "database", "d", "", "The database to benchmark, or a comma separated list of databases to compare with the same workload")
+	cmd.MarkFlagRequired("database")
+	cmd.Flags().StringVarP(&image, "image", "i", "", "Specify a custom Docker image")
+	cmd.Flags().BoolVarP(&privileged, "privileged", "p", false, "Whether to run Docker in privileged mode")
+	cmd.Flags().StringVarP(&endpoint, "endpoint", "e", "", "Specify a custom endpoint to connect to")
+	addConnectionFlags(cmd)
+	cmd.Flags().IntVarP(&blocking, "blocking", "b", 0, "Maximum number of calls in flight at once to embedded databases, whose calls block a thread (0 for no limit)")
+	cmd.Flags().IntVarP(&workers, "workers", "w", 0, "Maximum number of calls in flight at once to any database (0 for no limit)")
+	cmd.Flags().IntVarP(&clients, "clients", "c", 1, "Number of concurrent clients")
+	cmd.Flags().IntVarP(&threads, "threads", "t", 1, "Number of concurrent threads per client")
+	cmd.Flags().BoolVar(&sharedPool, "shared-pool", false, "Share one connection pool between all clients instead of connecting every client separately")
+	cmd.Flags().IntVarP(&samples, "samples", "s", 0, "Number of samples to be created, read, updated, and deleted")
+	cmd.MarkFlagRequired("samples")
+	cmd.Flags().BoolVarP(&random, "random", "r", false, "Generate the keys in a pseudo-randomized order")
+	cmd.Flags().StringVarP(&keyType, "key", "k", "integer", "The type of the key")
+	cmd.Flags().StringVarP(&value, "value", "v", "{\n\t\"text\": \"string:50\",\n\t\"integer\": \"int\"\n}", "Size of the text value")
+	cmd.Flags().BoolVar(&showSample, "show-sample", false, "Print-out an example of a generated value")
+	cmd.Flags().IntVar(&pid, "pid", 0, "Collect system information for a given pid")
+	cmd.Flags().StringVarP(&scans, "scans", "a", "[\n\t{ \"name\": \"count_all\", \"samples\": 100, \"projection\": \"COUNT\" },\n\t{ \"name\": \"limit_id\", \"samples\": 100, \"projection\": \"ID\", \"limit\": 100, \"expect\": 100 }\n]", "An array of scan specifications")
+	cmd.Flags().StringVar(&indexBuild, "index-build", "", "Build a secondary index on the given value field while a read/write workload runs")
+	cmd.Flags().BoolVar(&compaction, "compaction", false, "Trigger a manual compaction after the update phase and measure its cost")
+	cmd.Flags().StringVar(&network, "network-profile", "", "Simulate network latency to the database (same-az, cross-az, cross-region, mobile)")
+	cmd.Flags().StringVar(&slo, "slo", "", "Comma-separated latency SLO bounds used to classify operations (e.g. 1ms,10ms,100ms)")
+	cmd.Flags().StringVar(&timeUnit, "time-unit", "ms", "Time unit used in the console results table (s, ms, us)")
+	cmd.Flags().StringVar(&color, "color", "auto", "Colorize console output (auto, always, never), auto honours NO_COLOR")
+	cmd.Flags().StringVar(&output, "output", "text", "Stdout mode (text, json-stream), json-stream writes only JSON events to stdout")
+	cmd.Flags().StringToStringVar(&tune, "tune", nil, "Override adapter tuning presets (e.g. max_open_conns=50,conn_max_lifetime=10m)")
+	cmd.Flags().BoolVar(&checksum, "checksum", false, "Write a SHA-256 checksum sidecar next to the results file")
+	cmd.Flags().StringVar(&signKey, "sign-key", "", "Sign the results checksum with the given Ed25519 private key (PEM, PKCS #8)")
+	cmd.Flags().IntVar(&repeat, "repeat", 1, "Number of times to repeat the benchmark")
+	cmd.Flags().DurationVar(&cooldown, "cooldown", 0, "Time to wait between consecutive runs (e.g. 60s)")
+	cmd.Flags().Float64Var(&maxLoad, "max-load", 0, "Wait between runs until the host 1-minute load average is below this value")
+	cmd.Flags().BoolVar(&thermal, "thermal", false, "Sample CPU frequency and temperature during phases and flag throttling")
+	cmd.Flags().BoolVar(&dropCaches, "drop-caches", false, "Evict the database data from the OS page cache before read phases (Linux only, requires root)")
+	cmd.Flags().IntVar(&debugQueries, "debug-queries", 0, "Log the first N statements issued per phase by the adapter")
+	cmd.Flags().BoolVar(&debugParams, "debug-query-params", false, "Include statement parameters in the query log instead of redacting them")
+	cmd.Flags().StringVar(&keyEncoding, "key-encoding", "string", "How keys are passed to key-value adapters supporting native keys (string, bytes, int64)")
+	cmd.Flags().StringVar(&namespace, "namespace", "", "Suffix for the benchmark table, generated automatically when --endpoint is set")
+	cmd.Flags().IntVar(&consistencyProbes, "consistency-probes", 0, "Measure read-after-write visibility lag with the given number of probes after the update phase")
+	cmd.Flags().StringVar(&probeEndpoint, "probe-endpoint", "", "Endpoint (e.g. a read replica) used by the consistency probe to read back writes")
+	cmd.Flags().IntVar(&anomalyCheck, "anomaly-check", 0, "Run the given number of contended operations on hot keys and check the history for consistency anomalies")
+	cmd.Flags().StringVar(&cleanupPolicy, "cleanup-policy", "warn", "What to do when cleanup fails (fail, warn, janitor), janitor drops the tables of the namespace of the run")
+	cmd.Flags().IntVar(&scanConcurrency, "scan-concurrency", 0, "Also run the scans concurrently with up to N scans in flight and report both timings")
+	cmd.Flags().BoolVar(&selfTest, "selftest", false, "Measure client key/value generation and JSON encoding throughput before the run")
+	cmd.Flags().IntVar(&readMulti, "read-multi", 0, "Number of keys fetched per multi-key read in an extra read phase (0 disables)")
+	cmd.Flags().BoolVar(&existsCheck, "exists", false, "Run an existence check phase over the written keys and as many absent keys")
+	cmd.Flags().IntVar(&casWrites, "cas", 0, "Number of contended compare-and-set writes to run after the update phase (0 disables)")
+	cmd.Flags().IntVar(&casKeys, "cas-keys", 8, "Number of hot keys shared by the compare-and-set writers")
+	cmd.Flags().IntVar(&increments, "increments", 0, "Number of atomic counter increments to run after the update phase (0 disables)")
+	cmd.Flags().IntVar(&counters, "counters", 8, "Number of counters shared by the increment workers")
+	cmd.Flags().BoolVar(&changeFeed, "change-feed", false, "Subscribe to the change feed during the create phase and measure write-to-notification latency")
+	cmd.Flags().IntVar(&batchSize, "batch-size", 1, "Number of records inserted per batch in the create phase, for databases supporting batches")
+	cmd.Flags().StringVar(&charts, "charts", "", "Render latency and throughput charts next to the results file (svg, png)")
+	cmd.Flags().StringVar(&uploadURL, "upload", "", "Upload the results files to object storage once the run completes (s3://bucket/prefix, gs://bucket/prefix)")
+	cmd.Flags().StringVar(&deleteMode, "delete-mode", "row", "How the delete phase removes the records (row, batch, truncate)")
+	cmd.Flags().IntVar(&deleteBatch, "delete-batch", 100, "Number of records removed per batch with --delete-mode batch")
+	cmd.Flags().Float64Var(&softDelete, "soft-delete", 0, "Fraction of the records to soft delete before re-reading them with a deleted_at filter (0 disables)")
+	cmd.Flags().StringVar(&updateMode, "update-mode", "overwrite", "How the update phase writes the new values (overwrite, versioned)")
+	cmd.Flags().IntVar(&versions, "versions", 3, "Number of versions appended per record with --update-mode versioned")
+	cmd.Flags().IntVar(&children, "children", 0, "Number of child rows per record in a second table referencing it with a foreign key (0 uses a single table)")
+	cmd.Flags().IntVar(&graphEdges, "graph-edges", 0, "Number of edges from every record to random others for the graph traversal phase (0 disables)")
+	cmd.Flags().IntVar(&graphHops, "graph-hops", 2, "Number of hops followed by every graph traversal")
+	cmd.Flags().BoolVar(&batchSweep, "batch-sweep", false, "Sweep the batch sizes 1, 10, 100 and 1000 after the delete phase and report the optimal batch size")
+	cmd.Flags().StringVar(&maxClientMem, "max-client-mem", "", "Ceiling on the resident memory of the benchmark client (e.g. 2GB), pausing the workers when it is approached")
+	cmd.Flags().StringVar(&yugabyteAPI, "yugabyte-api", "ysql", "The YugabyteDB API to benchmark (ysql, ycql)")
+	cmd.Flags().DurationVar(&opTimeout, "op-timeout", 0, "Timeout of every operation, failing operations which take longer (0 disables)")
+	cmd.Flags().StringVar(&workloadMix, "workload", "", "Run a mixed workload of interleaved operations with the given ratios instead of the read, update and scan phases (e.g. read=95,update=5)")
+	cmd.Flags().StringVar(&distribution, "distribution", "uniform", "Distribution of the keys accessed by the read, update, delete and mixed phases (uniform, zipfian, latest, hotspot)")
+	cmd.Flags().StringVar(&progressFile, "progress-file", "", "Keep a JSON file with the progress of the run up to date for external watchdogs (e.g. progress.json)")
+	cmd.Flags().DurationVar(&runDuration, "duration", 0, "Run the read, update and mixed phases for this long, cycling through the created records, instead of once per sample (e.g. 60s)")
+	cmd.Flags().IntVar(&rate, "rate", 0, "Target rate of operations per second across all clients and threads, measuring latency against the intended schedule (0 runs at saturation)")
+	cmd.Flags().StringVar(&warmup, "warmup", "", "Read the created records for a number of operations or a duration before the measured phases, without recording results (e.g. 10000 or 30s)")
+	cmd.Flags().Int64Var(&seed, "seed", 0, "Seed of the random generators of keys and values, making the generated dataset reproducible (0 seeds from the clock)")
+	cmd.Flags().StringVar(&workloadBundle, "workload-bundle", "", "Run the workload of a bundle exported with export-workload, flags given on the command line taking precedence (e.g. bundle.tgz)")
+	cmd.Flags().StringVar(&controlSocket, "control-socket", "", "Serve a unix socket through which crud-bench ctl changes the target rate and threads of the running benchmark (e.g. crud-bench.sock)")
+	cmd.Flags().BoolVar(&serial, "serial", false, "Run every operation in sequence on a single goroutine and log each one, to debug adapters apart from concurrency")
+	cmd.Flags().StringVar(&rerun, "rerun", "", "Provision the database container exactly as recorded in a previous results file (e.g. results-postgres-20250101-120000.json)")
+	cmd.Flags().StringVar(&calibration, "calibration", "", "The noise floor file written by crud-bench calibrate (defaults to crud-bench/calibration.json in the user configuration directory)")
+	cmd.Flags().StringSliceVar(&outputFormats, "output-format", []string{"json"}, "Formats of the results files written after the run (json, csv, markdown), comma separated")
+	cmd.Flags().StringVar(&reportHTML, "report-html", "", "Render a self-contained HTML report with charts and the run metadata to this file (e.g. report.html)")
+	cmd.Flags().StringVar(&jsonEncoder, "json-encoder", "std", "JSON encoder used by the adapters to marshal and unmarshal records (std, jsoniter, sonic)")
+	cmd.Flags().StringVar(&recordFormat, "record-format", "map", "How records are passed to adapters (map, or payload for pre-encoded JSON on key-value adapters supporting it)")
+	cmd.Flags().StringVar(&progressMode, "progress", "auto", "Show the progress of every phase on stderr (auto, line, plain, off); auto rewrites a single line on a terminal and prints a line every 10s otherwise")
+	cmd.Flags().DurationVar(&timeSeriesInterval, "timeseries-interval", time.Second, "Record the operations and mean latency of every interval of each phase in the results file, 0 to disable")
+	cmd.Flags().DurationVar(&watchdogInterval, "watchdog-interval", 2*time.Second, "How often the managed database container is checked during phases, failing the phase if it crashed, 0 to disable")
+	cmd.Flags().StringVar(&historyStore, "history", "", "Append every run with its configuration, results and environment to a history store (sqlite:path)")
+	cmd.Flags().StringSliceVar(&bindAddresses, "bind-address", []string{"0.0.0.0"}, "Host addresses the ports of managed containers are published on, IPv4 or IPv6, comma separated; adapters connect to the first")
+	cmd.Flags().StringVar(&referencePath, "reference", "", "Compare the results with published reference results read from this file or http(s) URL")
+	cmd.Flags().StringVar(&configFile, "config", "", "Read the flags from a YAML or TOML file keyed by flag name, flags given on the command line taking precedence (e.g. bench.yaml)")
+}
+
+// addConnectionFlags defines the flags which complete the endpoint of a
+// networked database on a command
+func addConnectionFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&username, "username", "", "User name to connect to the endpoint with, overriding the one of the endpoint")
+	cmd.Flags().StringVar(&password, "password", "", "Password to connect to the endpoint with, overriding the one of the endpoint (prefer CRUD_BENCH_PASSWORD)")
+	cmd.Flags().StringVar(&dbName, "db-name", "", "Database holding the benchmark table on the endpoint: the keyspace of CQL databases, the database number of Redis")
+	cmd.Flags().IntVar(&port, "port", 0, "Port of the endpoint, overriding the port of the endpoint or the default port of the database")
+	cmd.Flags().BoolVar(&useTLS, "tls", false, "Connect to the endpoint over TLS, verifying the server against the system roots")
+	cmd.Flags().StringVar(&tlsCA, "tls-ca", "", "PEM file of the certificate authorities verifying the server, implies --tls")
+	cmd.Flags().StringVar(&tlsCert, "tls-cert", "", "PEM file of the TLS client certificate, implies --tls")
+	cmd.Flags().StringVar(&tlsKey, "tls-key", "", "PEM file of the key of the TLS client certificate")
+	cmd.Flags().BoolVar(&tlsSkipVerify, "tls-skip-verify", false, "Do not verify the certificate of the server, implies --tls")
+}
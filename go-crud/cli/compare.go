@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/surrealdb/go-crud-bench/internal/report"
+)
+
+// comparePolicy is the tolerance policy of crud-bench compare, and
+// compareThreshold the tolerance of the metric classes it does not set
+var (
+	comparePolicy    string
+	compareThreshold float64
+)
+
+// newCompareCommand creates the command which compares two results files
+func newCompareCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "compare <baseline.json> <candidate.json>",
+		Short: "Compare the wall time, throughput and latencies of two benchmark results files",
+		Args:  cobra.ExactArgs(2),
+		Run:   runCompare,
+	}
+	cmd.Flags().StringVar(&color, "color", "auto", "Colorize console output (auto, always, never), auto honours NO_COLOR")
+	cmd.Flags().StringVar(&timeUnit, "time-unit", "ms", "Time unit used in the console results table (s, ms, us)")
+	cmd.Flags().StringVar(&comparePolicy, "policy", "", "A JSON file of the tolerances per metric class and phase, exiting with status 1 on regressions beyond them")
+	cmd.Flags().Float64Var(&compareThreshold, "threshold", 0, "Tolerance in percent of the baseline for every metric class the policy does not set, exiting with status 1 on regressions beyond it (0 disables)")
+	return cmd
+}
+
+func runCompare(cmd *cobra.Command, args []string) {
+	baseline, err := report.LoadResults(args[0])
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	candidate, err := report.LoadResults(args[1])
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	var policy *report.Policy
+	if comparePolicy != "" {
+		policy, err = report.LoadPolicy(comparePolicy)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if compareThreshold < 0 {
+		fmt.Println("Error: threshold must not be negative")
+		os.Exit(1)
+	}
+	if compareThreshold > 0 {
+		policy = policy.WithThreshold(compareThreshold)
+	}
+
+	fmt.Printf("Baseline:  %s (%s)\n", args[0], baseline.Database)
+	fmt.Printf("Candidate: %s (%s)\n\n", args[1], candidate.Database)
+	regressions := report.PrintComparison(os.Stdout, baseline, candidate, policy, report.TableOptions{
+		Unit:  timeUnit,
+		Color: report.ColorEnabled(color, os.Stdout),
+	})
+
+	// Regressions beyond the tolerances of a policy or the threshold fail
+	// the comparison
+	if policy != nil && regressions > 0 {
+		switch {
+		case comparePolicy != "" && compareThreshold > 0:
+			fmt.Printf("\n%d regressions beyond the tolerances of %s and the %g%% threshold\n", regressions, comparePolicy, compareThreshold)
+		case comparePolicy != "":
+			fmt.Printf("\n%d regressions beyond the tolerances of %s\n", regressions, comparePolicy)
+		default:
+			fmt.Printf("\n%d regressions beyond the %g%% threshold\n", regressions, compareThreshold)
+		}
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+)
+
+// ctlSocket is the control socket of the benchmark controlled by crud-bench ctl
+var ctlSocket string
+
+// newCtlCommand creates the command which reconfigures a running benchmark
+func newCtlCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ctl",
+		Short: "Change the target rate or threads of a benchmark running with --control-socket, or annotate it",
+		Long: `Change the target rate or the threads of a running benchmark through the
+control socket it serves with --control-socket, without restarting it. Every
+change is recorded as an annotation in the results file and on the charts, and
+so are external events, such as failovers, reported with annotate.
+
+A new rate applies at once, restarting the schedule of the running phase. A
+lower thread count parks the surplus workers of the running phase in the
+create, read, update, delete, existence check, mixed, timed and warmup phases;
+other changes apply from the next phase on.`,
+	}
+	cmd.PersistentFlags().StringVar(&ctlSocket, "socket", "crud-bench.sock", "The control socket of the running benchmark")
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "set-rate <ops/sec>",
+			Short: "Set the target rate of operations per second (0 runs at saturation)",
+			Args:  cobra.ExactArgs(1),
+			Run: func(cmd *cobra.Command, args []string) {
+				printStatus(runCtl(http.MethodPost, "/rate", numericValue(args[0])))
+			},
+		},
+		&cobra.Command{
+			Use:   "set-threads <threads>",
+			Short: "Set the number of threads per client",
+			Args:  cobra.ExactArgs(1),
+			Run: func(cmd *cobra.Command, args []string) {
+				printStatus(runCtl(http.MethodPost, "/threads", numericValue(args[0])))
+			},
+		},
+		&cobra.Command{
+			Use:   "status",
+			Short: "Print the running phase, target rate and threads",
+			Args:  cobra.NoArgs,
+			Run: func(cmd *cobra.Command, args []string) {
+				printStatus(runCtl(http.MethodGet, "/status", nil))
+			},
+		},
+		&cobra.Command{
+			Use:   "annotate <text>",
+			Short: "Mark an external event, such as a failover, on the timeline of the running phase",
+			Args:  cobra.MinimumNArgs(1),
+			Run: func(cmd *cobra.Command, args []string) {
+				body := runCtl(http.MethodPost, "/annotate", url.Values{"text": {strings.Join(args, " ")}})
+				var annotation benchmark.Annotation
+				if err := json.Unmarshal(body, &annotation); err != nil {
+					fmt.Printf("Error: invalid response: %v\n", err)
+					os.Exit(1)
+				}
+				fmt.Printf("Annotated %s at %s: %s\n", annotation.Phase, annotation.Time.Format(time.RFC3339), annotation.Text)
+			},
+		},
+	)
+	return cmd
+}
+
+// numericValue returns the query setting a numeric value, exiting if the
+// value is not a number
+func numericValue(value string) url.Values {
+	if _, err := strconv.Atoi(value); err != nil {
+		fmt.Printf("Error: invalid value %q, expected a number\n", value)
+		os.Exit(1)
+	}
+	return url.Values{"value": {value}}
+}
+
+// runCtl sends a request with the given query to the control socket and
+// returns the response
+func runCtl(method, path string, query url.Values) []byte {
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	client := &http.Client{
+		Timeout: 5 * time.Second,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", ctlSocket)
+			},
+		},
+	}
+	req, err := http.NewRequest(method, "http://crud-bench"+path, nil)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		fmt.Printf("Error: failed to reach the benchmark at %s: %v\n", ctlSocket, err)
+		os.Exit(1)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if resp.StatusCode != http.StatusOK {
+		fmt.Printf("Error: %s\n", strings.TrimSpace(string(body)))
+		os.Exit(1)
+	}
+	return body
+}
+
+// printStatus prints the status of the benchmark returned by the control socket
+func printStatus(body []byte) {
+	var status benchmark.ControlStatus
+	if err := json.Unmarshal(body, &status); err != nil {
+		fmt.Printf("Error: invalid response: %v\n", err)
+		os.Exit(1)
+	}
+	rate := "saturation"
+	if status.Rate > 0 {
+		rate = fmt.Sprintf("%d ops/sec", status.Rate)
+	}
+	fmt.Printf("Phase: %s\nRate: %s\nThreads: %d per client (%d clients)\n", status.Phase, rate, status.Threads, status.Clients)
+}
@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+	"github.com/surrealdb/go-crud-bench/internal/docker"
+)
+
+// newDoctorCommand creates the command which checks the benchmark host
+func newDoctorCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "doctor",
+		Short: "Check that the host is ready to run benchmarks",
+		Args:  cobra.NoArgs,
+		Run:   runDoctor,
+	}
+}
+
+func runDoctor(cmd *cobra.Command, args []string) {
+	failed := false
+	check := func(name string, ok bool, detail string) {
+		status := "ok"
+		if !ok {
+			status = "warn"
+		}
+		fmt.Printf("[%-4s] %-16s %s\n", status, name, detail)
+	}
+
+	// Managed containers require a reachable Docker daemon
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if version, err := docker.Ping(ctx); err != nil {
+		check("docker", false, err.Error())
+		failed = true
+	} else {
+		check("docker", true, fmt.Sprintf("daemon version %s", version))
+	}
+
+	check("cpus", true, fmt.Sprintf("%d logical CPUs", runtime.NumCPU()))
+
+	// A busy host skews the results
+	if load, err := benchmark.LoadAverage(); err != nil {
+		check("load", false, err.Error())
+	} else {
+		check("load", load < float64(runtime.NumCPU())/2, fmt.Sprintf("1-minute load average %.2f", load))
+	}
+
+	// Frequency scaling adds variance between runs
+	if governor, err := os.ReadFile("/sys/devices/system/cpu/cpu0/cpufreq/scaling_governor"); err == nil {
+		governor := strings.TrimSpace(string(governor))
+		check("cpu governor", governor == "performance", governor)
+	}
+
+	// Page cache eviction needs root privileges
+	check("root", os.Geteuid() == 0, fmt.Sprintf("uid %d, --drop-caches requires root", os.Geteuid()))
+
+	// Network profiles run tc inside the container, but it is a useful hint on the host
+	if _, err := exec.LookPath("tc"); err != nil {
+		check("tc", false, "not found, network profiles require tc in the database image")
+	} else {
+		check("tc", true, "available")
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
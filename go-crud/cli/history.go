@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/surrealdb/go-crud-bench/internal/config"
+	"github.com/surrealdb/go-crud-bench/internal/history"
+	"github.com/surrealdb/go-crud-bench/internal/report"
+)
+
+// Filters of crud-bench history
+var (
+	historyDatabase string
+	historyName     string
+	historyWorkload string
+	historyPhase    string
+	historyLimit    int
+)
+
+// newHistoryCommand creates the command which lists and trends the runs of a
+// history store
+func newHistoryCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history <sqlite:history.db>",
+		Short: "List the runs of a history store, or trend a phase across them",
+		Args:  cobra.ExactArgs(1),
+		Run:   runHistory,
+	}
+	cmd.Flags().StringVarP(&historyDatabase, "database", "d", "", "Only show runs against this database")
+	cmd.Flags().StringVar(&historyName, "name", "", "Only show runs with this name")
+	cmd.Flags().StringVar(&historyWorkload, "workload-hash", "", "Only show runs of this workload")
+	cmd.Flags().StringVar(&historyPhase, "phase", "", "Trend the throughput and latencies of this phase, named as in the results table, across the runs")
+	cmd.Flags().IntVar(&historyLimit, "limit", 20, "Number of most recent runs shown, 0 for all")
+	cmd.Flags().StringVar(&timeUnit, "time-unit", "ms", "Time unit used in the console tables (s, ms, us)")
+	return cmd
+}
+
+func runHistory(cmd *cobra.Command, args []string) {
+	target, err := config.ParseHistoryStore(args[0])
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if _, err := os.Stat(target.Path); err != nil {
+		fmt.Printf("Error: history %s not found: %v\n", target, err)
+		os.Exit(1)
+	}
+	store, err := history.Open(target)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	defer store.Close()
+
+	filter := history.Filter{
+		Database:     historyDatabase,
+		Name:         historyName,
+		WorkloadHash: historyWorkload,
+		Limit:        historyLimit,
+	}
+	ctx := context.Background()
+	if historyPhase != "" {
+		err = printTrend(ctx, store, filter)
+	} else {
+		err = printRuns(ctx, store, filter)
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// printRuns lists the runs matching the filter, most recent first
+func printRuns(ctx context.Context, store *history.Store, filter history.Filter) error {
+	runs, err := store.Runs(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if len(runs) == 0 {
+		fmt.Println("No runs recorded")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "RUN\tSTARTED\tDATABASE\tNAME\tWORKLOAD\tDURATION\tPHASES\tHOST")
+	fmt.Fprintln(tw, "---\t-------\t--------\t----\t--------\t--------\t------\t----")
+	for _, run := range runs {
+		name := run.Name
+		if name == "" {
+			name = "-"
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%d\t%s\n", run.ID, run.Started.Local().Format("2006-01-02 15:04:05"),
+			run.Database, name, shortWorkloadHash(run.WorkloadHash), run.Duration.Round(time.Millisecond), len(run.Results), run.Environment.Host)
+	}
+	return tw.Flush()
+}
+
+// printTrend shows the throughput and latencies of a phase across the runs
+// matching the filter, oldest first, with the change of the throughput from
+// the previous run of the same workload
+func printTrend(ctx context.Context, store *history.Store, filter history.Filter) error {
+	points, err := store.Trend(ctx, historyPhase, filter)
+	if err != nil {
+		return err
+	}
+	if len(points) == 0 {
+		fmt.Printf("No runs recorded the %s phase\n", historyPhase)
+		return nil
+	}
+
+	// Changes are only meaningful between runs of the same workload
+	workloads := map[string]bool{}
+	for _, p := range points {
+		workloads[p.WorkloadHash] = true
+	}
+	if len(workloads) > 1 {
+		fmt.Printf("Warning: the runs were produced by %d different workloads, select one with --workload-hash\n\n", len(workloads))
+	}
+
+	unit := timeUnit
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "RUN\tSTARTED\tDATABASE\tWORKLOAD\tOPS/SEC\tCHANGE\tWALL (%s)\tP50 (%s)\tP95 (%s)\tP99 (%s)\tERRORS\n", unit, unit, unit, unit)
+	fmt.Fprintln(tw, "---\t-------\t--------\t--------\t-------\t------\t---------\t--------\t--------\t--------\t------")
+	previous := map[string]float64{}
+	for _, p := range points {
+		change := "-"
+		if before := previous[p.WorkloadHash]; before > 0 {
+			change = fmt.Sprintf("%+.1f%%", (p.Throughput-before)/before*100)
+		}
+		previous[p.WorkloadHash] = p.Throughput
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%.0f\t%s\t%s\t%s\t%s\t%s\t%d\n", p.RunID, p.Started.Local().Format("2006-01-02 15:04:05"),
+			p.Database, shortWorkloadHash(p.WorkloadHash), p.Throughput, change, report.FormatDuration(p.Duration, unit),
+			report.FormatDuration(p.P50, unit), report.FormatDuration(p.P95, unit), report.FormatDuration(p.P99, unit), p.Errors)
+	}
+	return tw.Flush()
+}
+
+// shortWorkloadHash abbreviates a workload hash for the history tables
+func shortWorkloadHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
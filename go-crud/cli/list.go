@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/surrealdb/go-crud-bench/internal/config"
+	"github.com/surrealdb/go-crud-bench/internal/databases"
+)
+
+// newListCommand creates the command which lists the supported options
+func newListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the supported databases, key types and network profiles",
+		Args:  cobra.NoArgs,
+		Run:   runList,
+	}
+}
+
+func runList(cmd *cobra.Command, args []string) {
+	fmt.Println("Databases:")
+	for _, db := range config.ValidDatabases {
+		status := "implemented"
+		if _, err := databases.NewAdapter(db, "", "", false, "", config.ConnectionOptions{}); errors.Is(err, databases.ErrNotBuilt) {
+			status = "not built"
+		} else if err != nil {
+			status = "planned"
+		}
+		fmt.Printf("  %-22s %s\n", db, status)
+	}
+
+	fmt.Printf("\nKey types: %s\n", strings.Join(config.ValidKeyTypes, ", "))
+
+	fmt.Println("\nNetwork profiles:")
+	for _, name := range config.NetworkProfileNames() {
+		profile, _ := config.LookupNetworkProfile(name)
+		fmt.Printf("  %-22s delay %v, jitter %v, loss %g%%\n", name, profile.Delay, profile.Jitter, profile.Loss)
+	}
+}
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+	"github.com/surrealdb/go-crud-bench/internal/config"
+	"github.com/surrealdb/go-crud-bench/internal/report"
+)
+
+// runMatrix runs the same workload against every database of the
+// configuration one after the other, with a cooldown in between, then prints
+// the matrix comparing their phases and saves it next to the results files.
+// A failed database does not stop the remaining ones.
+func runMatrix(ctx context.Context, cfg *config.Config, stream *report.JSONStream, out io.Writer) error {
+	workload, err := cfg.Workload()
+	if err != nil {
+		return err
+	}
+	workloadHash, err := workload.Hash()
+	if err != nil {
+		return err
+	}
+
+	var entries []report.MatrixEntry
+	failed := 0
+	for i, database := range cfg.Databases {
+		if ctx.Err() != nil {
+			break
+		}
+		if i > 0 {
+			if err := benchmark.Cooldown(ctx, out, cfg.Cooldown, cfg.MaxLoad); err != nil {
+				return fmt.Errorf("failed to wait for cooldown: %w", err)
+			}
+		}
+		fmt.Fprintf(out, "\n=== Matrix database %d/%d: %s ===\n\n", i+1, len(cfg.Databases), database)
+
+		dbCfg := *cfg
+		dbCfg.Database = database
+		results, err := runRepeated(ctx, &dbCfg, stream, out)
+		entry := report.MatrixEntry{Database: database, Results: results}
+		if err != nil {
+			fmt.Fprintf(out, "Error: %v\n", err)
+			entry.Error = err.Error()
+			failed++
+		}
+		entries = append(entries, entry)
+	}
+
+	fmt.Fprintf(out, "\n=== Comparison of %d databases, workload hash %s ===\n\n", len(entries), workloadHash)
+	report.PrintMatrix(out, entries, report.TableOptions{
+		Unit:  cfg.TimeUnit,
+		Color: report.ColorEnabled(cfg.Color, out),
+	})
+
+	// Save the matrix along with the results file of every database
+	if slices.Contains(cfg.OutputFormats, "json") {
+		outputFilename := fmt.Sprintf("results-matrix-%s.json", time.Now().Format("20060102-150405"))
+		if cfg.Name != "" {
+			outputFilename = fmt.Sprintf("results-matrix-%s-%s.json", cfg.Name, time.Now().Format("20060102-150405"))
+		}
+		data, err := json.MarshalIndent(map[string]interface{}{
+			"databases":     cfg.Databases,
+			"samples":       cfg.Samples,
+			"clients":       cfg.Clients,
+			"threads":       cfg.Threads,
+			"workload":      workload,
+			"workload_hash": workloadHash,
+			"matrix":        entries,
+		}, "", "  ")
+		if err != nil {
+			fmt.Fprintf(out, "Error marshaling matrix: %v\n", err)
+		} else if err := os.WriteFile(outputFilename, data, 0644); err != nil {
+			fmt.Fprintf(out, "Error writing matrix file: %v\n", err)
+		} else {
+			fmt.Fprintf(out, "\nMatrix saved to %s\n", outputFilename)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d databases failed", failed, len(cfg.Databases))
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/surrealdb/go-crud-bench/internal/report"
+)
+
+// newReportCommand creates the command which prints a saved results file
+func newReportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "report <results.json>",
+		Short: "Print the results table of a saved benchmark results file",
+		Args:  cobra.ExactArgs(1),
+		Run:   runReport,
+	}
+	cmd.Flags().StringVar(&timeUnit, "time-unit", "ms", "Time unit used in the console results table (s, ms, us)")
+	cmd.Flags().StringVar(&color, "color", "auto", "Colorize console output (auto, always, never), auto honours NO_COLOR")
+	return cmd
+}
+
+func runReport(cmd *cobra.Command, args []string) {
+	results, err := report.LoadResults(args[0])
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Database %s, %d samples, %d clients, %d threads, completed in %s\n",
+		results.Database, results.Samples, results.Clients, results.Threads, results.Duration)
+	if results.WorkloadHash != "" {
+		fmt.Printf("Workload hash %s\n", results.WorkloadHash)
+	}
+	fmt.Println()
+
+	report.PrintTable(os.Stdout, results.Operations, report.TableOptions{
+		Unit:  timeUnit,
+		Color: report.ColorEnabled(color, os.Stdout),
+	})
+	report.PrintHints(os.Stdout, results.Operations, report.ColorEnabled(color, os.Stdout))
+	report.PrintNoiseFloor(os.Stdout, results.Operations, results.NoiseFloor, report.ColorEnabled(color, os.Stdout))
+}
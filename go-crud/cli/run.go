@@ -0,0 +1,569 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+	"github.com/surrealdb/go-crud-bench/internal/codec"
+	"github.com/surrealdb/go-crud-bench/internal/config"
+	"github.com/surrealdb/go-crud-bench/internal/databases"
+	"github.com/surrealdb/go-crud-bench/internal/dbutils"
+	"github.com/surrealdb/go-crud-bench/internal/generators"
+	"github.com/surrealdb/go-crud-bench/internal/history"
+	"github.com/surrealdb/go-crud-bench/internal/report"
+	"github.com/surrealdb/go-crud-bench/internal/upload"
+)
+
+func runBenchmark(cmd *cobra.Command, args []string) {
+	out := humanOutput(cmd)
+	if err := executeBenchmark(cmd, out); err != nil {
+		fmt.Fprintf(out, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// humanOutput returns the writer of the messages and tables of the benchmark
+// command, stderr in json-stream mode where stdout carries only events
+func humanOutput(cmd *cobra.Command) io.Writer {
+	if output, _ := cmd.Flags().GetString("output"); output == "json-stream" {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// executeBenchmark runs the benchmark configured by the flags of the command,
+// repeating it if requested, and prints its messages and tables to out
+func executeBenchmark(cmd *cobra.Command, out io.Writer) error {
+	// Parse configuration
+	cfg, err := config.FromCommand(cmd)
+	if err != nil {
+		return err
+	}
+	if err := codec.Use(cfg.JSONEncoder); err != nil {
+		return err
+	}
+
+	// Show sample if requested
+	if cfg.ShowSample {
+		sampleJSON, err := generators.GenerateSample(cfg.Value)
+		if err != nil {
+			return fmt.Errorf("failed to generate sample: %w", err)
+		}
+		fmt.Println(sampleJSON)
+		return nil
+	}
+
+	// Create context with cancellation
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Handle signals for graceful shutdown
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signalCh)
+	go func() {
+		select {
+		case <-signalCh:
+			fmt.Fprintln(out, "\nReceived interrupt signal. Shutting down...")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	// In json-stream mode stdout carries only events, out being stderr
+	var stream *report.JSONStream
+	if cfg.Output == "json-stream" {
+		stream = report.NewJSONStream(os.Stdout)
+	}
+
+	// Compare several databases with the same workload
+	if len(cfg.Databases) > 1 {
+		return runMatrix(ctx, cfg, stream, out)
+	}
+
+	_, err = runRepeated(ctx, cfg, stream, out)
+	return err
+}
+
+// runRepeated runs the benchmark, repeating it with a cooldown in between if
+// requested, and returns the results of the last run
+func runRepeated(ctx context.Context, cfg *config.Config, stream *report.JSONStream, out io.Writer) ([]benchmark.Result, error) {
+	var results []benchmark.Result
+	for run := 1; run <= cfg.Repeat; run++ {
+		if run > 1 {
+			if err := benchmark.Cooldown(ctx, out, cfg.Cooldown, cfg.MaxLoad); err != nil {
+				return results, fmt.Errorf("failed to wait for cooldown: %w", err)
+			}
+		}
+
+		var err error
+		if results, err = runOnce(ctx, cfg, stream, out, run); err != nil {
+			return results, fmt.Errorf("failed to run benchmark: %w", err)
+		}
+	}
+
+	return results, nil
+}
+
+// runOnce runs a single benchmark against a freshly created adapter and saves its results
+func runOnce(ctx context.Context, cfg *config.Config, stream *report.JSONStream, out io.Writer, run int) ([]benchmark.Result, error) {
+	// Describe the workload so that identical runs can be recognised
+	workload, err := cfg.Workload()
+	if err != nil {
+		return nil, err
+	}
+	workloadHash, err := workload.Hash()
+	if err != nil {
+		return nil, err
+	}
+
+	// Measure the client overhead so readers can tell whether the client was the bottleneck
+	var selfTestResult *benchmark.SelfTest
+	if cfg.SelfTest {
+		selfTestResult, err = benchmark.RunSelfTest(cfg.KeyType, cfg.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to run self-test: %w", err)
+		}
+		fmt.Fprintf(out, "Client self-test: %v\n", selfTestResult)
+	}
+
+	// Reference the noise floor of this host measured by crud-bench calibrate
+	calibration, err := loadCalibration(cfg.Calibration)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create database adapter
+	adapter, err := databases.NewAdapter(cfg.Database, cfg.Endpoint, cfg.Image, cfg.Privileged, cfg.YugabyteAPI, cfg.Connection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create database adapter: %w", err)
+	}
+
+	// Publish the ports of managed containers on the configured addresses
+	dbutils.SetBindAddresses(cfg.BindAddresses)
+
+	// Provision the container recorded by a previous run
+	if cfg.Rerun != "" {
+		if err := replayContainer(cfg.Rerun, adapter.Name()); err != nil {
+			return nil, err
+		}
+	}
+
+	// Create benchmark runner
+	runner := benchmark.NewRunner(adapter, cfg)
+	runner.Output = out
+	if stream != nil {
+		runner.Observer = stream
+	}
+
+	// Record the progress of every phase for the throughput chart
+	var timeline *report.Timeline
+	if cfg.Charts != "" || cfg.ReportHTML != "" {
+		timeline = report.NewTimeline(runner.Observer)
+		runner.Observer = timeline
+	}
+
+	// Keep the progress file up to date for external watchdogs
+	var progress *report.ProgressFile
+	if cfg.ProgressFile != "" {
+		progress, err = report.NewProgressFile(cfg.ProgressFile, runner.Observer, adapter.Name(), cfg.Samples, run)
+		if err != nil {
+			return nil, err
+		}
+		runner.Observer = progress
+	}
+
+	// Show the progress of every phase on the console
+	if cfg.Progress != "off" {
+		terminal := cfg.Progress == "line" || (cfg.Progress == "auto" && report.IsTerminal(os.Stderr))
+		runner.Observer = report.NewProgressLine(os.Stderr, terminal, runner.Observer, cfg.Samples, cfg.Duration)
+	}
+
+	// Serve the control socket for live reconfiguration of the running benchmark
+	var ctl *benchmark.ControlServer
+	if cfg.ControlSocket != "" {
+		ctl, err = runner.ListenControl(cfg.ControlSocket)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(out, "Control socket listening on %s\n", cfg.ControlSocket)
+	}
+
+	// Seed the generators so that every run generates the same dataset
+	if cfg.Seed != 0 {
+		generators.Seed(cfg.Seed)
+	}
+
+	// Run benchmark
+	fmt.Fprintf(out, "Starting benchmark for %s with %d samples...\n", adapter.Name(), cfg.Samples)
+	fmt.Fprintf(out, "Workload hash %s\n", workloadHash)
+	if cfg.Namespace != "" {
+		fmt.Fprintf(out, "Using namespace %s\n", cfg.Namespace)
+	}
+	startTime := time.Now()
+
+	// Describe the run in the results document
+	document := func(results []benchmark.Result, duration time.Duration) map[string]interface{} {
+		outputData := map[string]interface{}{
+			"database":      adapter.Name(),
+			"samples":       cfg.Samples,
+			"clients":       cfg.Clients,
+			"threads":       cfg.Threads,
+			"duration":      duration.String(),
+			"operations":    results,
+			"workload":      workload,
+			"workload_hash": workloadHash,
+		}
+		if cfg.Repeat > 1 {
+			outputData["run"] = run
+		}
+		if cfg.WorkloadBundle != "" {
+			outputData["workload_bundle"] = cfg.WorkloadBundle
+		}
+		if cfg.JSONEncoder != "std" {
+			outputData["json_encoder"] = cfg.JSONEncoder
+		}
+		if cfg.Namespace != "" {
+			outputData["namespace"] = cfg.Namespace
+		}
+		if cfg.Network != nil {
+			outputData["network_profile"] = cfg.Network
+		}
+		if runner.Tuning != nil {
+			outputData["tuning"] = runner.Tuning
+		}
+		if selfTestResult != nil {
+			outputData["selftest"] = selfTestResult
+		}
+		if calibration != nil {
+			outputData["noise_floor"] = calibration
+		}
+		if runner.Provisioning != nil {
+			outputData["provisioning"] = runner.Provisioning
+		}
+		if runner.Container != nil {
+			outputData["container"] = runner.Container
+		}
+		if len(runner.Annotations) > 0 {
+			outputData["annotations"] = runner.Annotations
+		}
+		if runner.Warmup != nil {
+			outputData["warmup"] = runner.Warmup
+		}
+		if runner.Profile != nil {
+			outputData["dataset_profile"] = runner.Profile
+		}
+		if runner.MemoryEvents != nil {
+			outputData["client_memory_events"] = runner.MemoryEvents
+		}
+		if runner.PageCacheDrops != nil {
+			outputData["page_cache_drops"] = runner.PageCacheDrops
+		}
+		if runner.HostSamples != nil {
+			outputData["host_samples"] = runner.HostSamples
+		}
+		if runner.TimeSeries != nil {
+			outputData["timeseries"] = runner.TimeSeries
+		}
+		return outputData
+	}
+
+	// Save whatever the run collected when it is interrupted
+	if slices.Contains(cfg.OutputFormats, "json") {
+		runner.OnInterrupt = func(results []benchmark.Result) {
+			outputFilename := resultsFilename(cfg, adapter.Name(), run)
+			outputData := document(results, time.Since(startTime))
+			outputData["interrupted"] = true
+			jsonData, err := json.MarshalIndent(outputData, "", "  ")
+			if err != nil {
+				fmt.Fprintf(out, "Error marshaling partial results: %v\n", err)
+				return
+			}
+			if err := os.WriteFile(outputFilename, jsonData, 0644); err != nil {
+				fmt.Fprintf(out, "Error writing partial results file: %v\n", err)
+				return
+			}
+			fmt.Fprintf(out, "\nPartial results of the interrupted run saved to %s\n", outputFilename)
+		}
+	}
+
+	results, err := runner.Run(ctx)
+	duration := time.Since(startTime)
+	if ctl != nil {
+		ctl.Close()
+	}
+	if stream != nil {
+		stream.RunEnd(duration, results, err)
+	}
+	if progress != nil {
+		if closeErr := progress.Close(err); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		if crash := runner.Crash; crash != nil {
+			printCrash(out, crash)
+		}
+		// Print the results of the phases run until the failure, including
+		// the errors of the failed phase
+		if len(results) > 0 {
+			fmt.Fprintln(out)
+			report.PrintTable(out, results, report.TableOptions{
+				Unit:  cfg.TimeUnit,
+				Color: report.ColorEnabled(cfg.Color, out),
+				SLO:   cfg.SLO,
+			})
+		}
+		return results, err
+	}
+
+	// Print results
+	fmt.Fprintf(out, "\nBenchmark completed in %v\n", duration)
+	if p := runner.Provisioning; p != nil {
+		fmt.Fprintf(out, "Initialize took %v\n", p.Initialize)
+		if p.PoolWarmup != nil {
+			fmt.Fprintf(out, "Pool warmup took %v (%d connections)\n", p.PoolWarmup.Duration.Round(time.Millisecond), p.PoolWarmup.Connections)
+		}
+		fmt.Fprintf(out, "Cleanup took %v\n", p.Cleanup)
+	}
+	fmt.Fprintln(out)
+
+	// Print results table
+	report.PrintTable(out, results, report.TableOptions{
+		Unit:  cfg.TimeUnit,
+		Color: report.ColorEnabled(cfg.Color, out),
+		SLO:   cfg.SLO,
+	})
+	report.PrintHints(out, results, report.ColorEnabled(cfg.Color, out))
+	report.PrintNoiseFloor(out, results, calibration, report.ColorEnabled(cfg.Color, out))
+
+	// Compare the results with published reference results
+	var reference *report.ReferenceComparison
+	if cfg.Reference != "" {
+		references, err := report.LoadReferences(ctx, cfg.Reference)
+		if err != nil {
+			fmt.Fprintf(out, "\nWarning: %v\n", err)
+		} else if reference = references.Compare(adapter.Name(), workloadHash, cfg.Clients, cfg.Threads, results); reference == nil {
+			fmt.Fprintf(out, "\nNo reference results for %s in %s\n", adapter.Name(), references.Source)
+		} else {
+			report.PrintReference(out, reference, report.TableOptions{
+				Unit:  cfg.TimeUnit,
+				Color: report.ColorEnabled(cfg.Color, out),
+			})
+		}
+	}
+
+	// Save results to JSON file
+	outputFilename := resultsFilename(cfg, adapter.Name(), run)
+	outputData := document(results, duration)
+
+	// Keep track of the files written next to the results file, for the upload
+	var written []string
+	resultsWritten := false
+
+	if slices.Contains(cfg.OutputFormats, "json") {
+		jsonData, err := json.MarshalIndent(outputData, "", "  ")
+		if err != nil {
+			fmt.Fprintf(out, "Error marshaling results: %v\n", err)
+		} else {
+			if err := os.WriteFile(outputFilename, jsonData, 0644); err != nil {
+				fmt.Fprintf(out, "Error writing results file: %v\n", err)
+			} else {
+				fmt.Fprintf(out, "\nResults saved to %s\n", outputFilename)
+				resultsWritten = true
+
+				// Write a checksum sidecar so the results can be verified later
+				if cfg.Checksum || cfg.SignKey != "" {
+					sidecar, err := report.WriteSidecar(outputFilename, cfg.SignKey)
+					if err != nil {
+						fmt.Fprintf(out, "Error writing checksum: %v\n", err)
+					} else {
+						fmt.Fprintf(out, "Checksum saved to %s\n", sidecar)
+						written = append(written, sidecar)
+					}
+				}
+			}
+		}
+	}
+
+	// Write the results in the other requested formats next to the JSON file
+	base := strings.TrimSuffix(outputFilename, ".json")
+	title := fmt.Sprintf("%s, %d samples, %d clients, %d threads", adapter.Name(), cfg.Samples, cfg.Clients, cfg.Threads)
+	for _, format := range cfg.OutputFormats {
+		if format == "json" {
+			continue
+		}
+		path, err := report.WriteResults(base, format, title, cfg.TimeUnit, results)
+		if err != nil {
+			fmt.Fprintf(out, "Error writing %s results: %v\n", format, err)
+			continue
+		}
+		fmt.Fprintf(out, "Results saved to %s\n", path)
+		written = append(written, path)
+	}
+
+	// Render the charts next to the results file
+	if cfg.Charts != "" {
+		charts, err := report.WriteCharts(base, cfg.Charts, cfg.TimeUnit, results, timeline)
+		if err != nil {
+			fmt.Fprintf(out, "Error writing charts: %v\n", err)
+		}
+		for _, chart := range charts {
+			fmt.Fprintf(out, "Chart saved to %s\n", chart)
+		}
+		written = append(written, charts...)
+	}
+
+	// Render the HTML report
+	if cfg.ReportHTML != "" {
+		path := cfg.ReportHTML
+		if cfg.Repeat > 1 {
+			ext := filepath.Ext(path)
+			path = fmt.Sprintf("%s-run%d%s", strings.TrimSuffix(path, ext), run, ext)
+		}
+		page := htmlReport(cfg, adapter.Name(), workloadHash, startTime, duration, results, timeline)
+		page.Reference = reference
+		if err := report.WriteHTML(path, page); err != nil {
+			fmt.Fprintf(out, "Error writing HTML report: %v\n", err)
+		} else {
+			fmt.Fprintf(out, "HTML report saved to %s\n", path)
+			written = append(written, path)
+		}
+	}
+
+	// Append the run to the history store
+	if cfg.History != nil {
+		if err := recordHistory(ctx, out, cfg, history.Run{
+			Started:      startTime,
+			Database:     adapter.Name(),
+			Name:         cfg.Name,
+			WorkloadHash: workloadHash,
+			Duration:     duration,
+			Environment:  history.CurrentEnvironment(),
+			Workload:     workload,
+			Document:     outputData,
+			Results:      results,
+		}); err != nil {
+			fmt.Fprintf(out, "Error recording the run in the history: %v\n", err)
+		}
+	}
+
+	// Push the files to object storage below the checksum of the results file,
+	// unless it could not be written
+	if cfg.Upload != nil && !resultsWritten {
+		fmt.Fprintf(out, "Skipping the upload, as the results file was not written\n")
+	} else if cfg.Upload != nil {
+		urls, err := upload.Files(ctx, cfg.Upload, outputFilename, written)
+		if err != nil {
+			return results, fmt.Errorf("failed to upload results: %w", err)
+		}
+		for _, u := range urls {
+			fmt.Fprintf(out, "Uploaded %s\n", u)
+		}
+	}
+
+	return results, nil
+}
+
+// resultsFilename returns the name of the JSON results file of a run
+func resultsFilename(cfg *config.Config, database string, run int) string {
+	suffix := time.Now().Format("20060102-150405")
+	if cfg.Repeat > 1 {
+		suffix = fmt.Sprintf("%s-run%d", suffix, run)
+	}
+	filename := fmt.Sprintf("results-%s-%s.json", database, suffix)
+	if cfg.Name != "" {
+		filename = fmt.Sprintf("results-%s-%s-%s.json", database, cfg.Name, suffix)
+	}
+	return filename
+}
+
+// replayContainer replays the container specification recorded in a results
+// file, which must have been run against the same database
+func replayContainer(path, database string) error {
+	file, err := report.LoadResults(path)
+	if err != nil {
+		return err
+	}
+	if file.Database != database {
+		return fmt.Errorf("results file %s was recorded against %s, not %s", path, file.Database, database)
+	}
+	if file.Container == nil {
+		return fmt.Errorf("results file %s has no container specification, it was not run against a managed container", path)
+	}
+	dbutils.ReplayContainer(file.Container)
+	return nil
+}
+
+// recordHistory appends a run to the configured history store
+func recordHistory(ctx context.Context, out io.Writer, cfg *config.Config, run history.Run) error {
+	store, err := history.Open(cfg.History)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	id, err := store.Record(ctx, run)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Run recorded as #%d in %s\n", id, cfg.History)
+	return nil
+}
+
+// printCrash shows how the managed database container stopped, with the last
+// lines it logged
+func printCrash(out io.Writer, crash *benchmark.Crash) {
+	fmt.Fprintf(out, "\nDatabase container stopped during the %s phase with exit code %d", crash.Phase, crash.ExitCode)
+	if crash.OOMKilled {
+		fmt.Fprint(out, ", killed out of memory")
+	}
+	fmt.Fprintln(out)
+	if len(crash.Logs) > 0 {
+		fmt.Fprintf(out, "Last %d lines of the container logs:\n", len(crash.Logs))
+		for _, line := range crash.Logs {
+			fmt.Fprintf(out, "  %s\n", line)
+		}
+	}
+}
+
+// htmlReport describes a finished run for the HTML report
+func htmlReport(cfg *config.Config, database, workloadHash string, start time.Time, duration time.Duration, results []benchmark.Result, timeline *report.Timeline) report.HTMLReport {
+	title := fmt.Sprintf("crud-bench results for %s", database)
+	if cfg.Name != "" {
+		title += fmt.Sprintf(" (%s)", cfg.Name)
+	}
+	metadata := [][2]string{
+		{"Database", database},
+		{"Started", start.Format(time.RFC1123)},
+		{"Duration", duration.Round(time.Millisecond).String()},
+		{"Samples", fmt.Sprintf("%d", cfg.Samples)},
+		{"Clients", fmt.Sprintf("%d", cfg.Clients)},
+		{"Threads", fmt.Sprintf("%d", cfg.Threads)},
+		{"Key type", cfg.KeyType},
+		{"Workload hash", workloadHash},
+	}
+	if cfg.Image != "" {
+		metadata = append(metadata, [2]string{"Image", cfg.Image})
+	}
+	if cfg.Endpoint != "" {
+		metadata = append(metadata, [2]string{"Endpoint", cfg.Endpoint})
+	}
+	return report.HTMLReport{
+		Title:    title,
+		Metadata: metadata,
+		Unit:     cfg.TimeUnit,
+		Results:  results,
+		Timeline: timeline,
+	}
+}
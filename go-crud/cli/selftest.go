@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+	"github.com/surrealdb/go-crud-bench/internal/codec"
+)
+
+// newSelfTestCommand creates the command which measures the client overhead
+func newSelfTestCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "selftest",
+		Short: "Measure key/value generation and JSON encoding throughput on this machine",
+		Args:  cobra.NoArgs,
+		Run:   runSelfTest,
+	}
+	cmd.Flags().StringVarP(&keyType, "key", "k", "integer", "The type of the key")
+	cmd.Flags().StringVarP(&value, "value", "v", "{\n\t\"text\": \"string:50\",\n\t\"integer\": \"int\"\n}", "Size of the text value")
+	cmd.Flags().StringVar(&jsonEncoder, "json-encoder", "std", "JSON encoder to measure (std, jsoniter, sonic)")
+	return cmd
+}
+
+func runSelfTest(cmd *cobra.Command, args []string) {
+	if err := codec.Use(jsonEncoder); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	result, err := benchmark.RunSelfTest(keyType, value)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Key generation:   %12.0f keys/s\n", result.KeysPerSec)
+	fmt.Printf("Value generation: %12.0f values/s\n", result.ValuesPerSec)
+	fmt.Printf("JSON marshal:     %12.0f values/s (%d bytes per value)\n", result.MarshalPerSec, result.ValueBytes)
+}
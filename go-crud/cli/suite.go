@@ -0,0 +1,211 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Statuses of the entries of a suite manifest. An entry whose benchmark
+// aborted the process is left running.
+const (
+	suitePending   = "pending"
+	suiteRunning   = "running"
+	suiteCompleted = "completed"
+	suiteFailed    = "failed"
+)
+
+// resumeSuite skips the benchmarks completed by a previous run of the suite
+var resumeSuite bool
+
+// suiteEntry is a single benchmark of a suite, run with the given arguments
+type suiteEntry struct {
+	Name string   `json:"name"`
+	Args []string `json:"args"`
+}
+
+// suiteManifest records the progress of a suite run next to the suite file,
+// so that a failed run can be resumed from the failed benchmark
+type suiteManifest struct {
+	Suite   string               `json:"suite"`
+	Started time.Time            `json:"started"`
+	Entries []suiteManifestEntry `json:"entries"`
+}
+
+// suiteManifestEntry records the status of a single benchmark of a suite
+type suiteManifestEntry struct {
+	Name     string     `json:"name"`
+	Args     []string   `json:"args"`
+	Status   string     `json:"status"`
+	Started  *time.Time `json:"started,omitempty"`
+	Finished *time.Time `json:"finished,omitempty"`
+}
+
+// newSuiteCommand creates the command which runs a suite of benchmarks
+func newSuiteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "suite <suite.json>",
+		Short: "Run a suite of benchmarks described in a JSON file",
+		Long: `Run a suite of benchmarks in sequence. The suite file contains an array of
+entries, each with a name and the arguments of the run command, for example:
+
+[
+	{ "name": "mysql", "args": ["-d", "mysql", "-s", "10000"] },
+	{ "name": "postgres", "args": ["-d", "postgres", "-s", "10000"] }
+]
+
+The progress of the run is recorded in a manifest next to the suite file
+(suite.manifest.json for suite.json). With --resume the benchmarks completed
+by the previous run are skipped, resuming the suite from the failed one.`,
+		Args: cobra.ExactArgs(1),
+		Run:  runSuite,
+	}
+	cmd.Flags().BoolVar(&resumeSuite, "resume", false, "Skip the benchmarks completed by the previous run of the suite")
+	return cmd
+}
+
+func runSuite(cmd *cobra.Command, args []string) {
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		fmt.Printf("Error reading suite file: %v\n", err)
+		os.Exit(1)
+	}
+
+	var entries []suiteEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		fmt.Printf("Error parsing suite file: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Record the progress of the run, carrying over the completed benchmarks when resuming
+	manifestPath := strings.TrimSuffix(args[0], ".json") + ".manifest.json"
+	manifest := newSuiteManifest(args[0], entries)
+	if resumeSuite {
+		previous, err := loadSuiteManifest(manifestPath)
+		if err != nil {
+			fmt.Printf("Error reading suite manifest: %v\n", err)
+			os.Exit(1)
+		}
+		if previous == nil {
+			fmt.Printf("No manifest found at %s, running the whole suite\n", manifestPath)
+		} else {
+			manifest.resume(previous)
+		}
+	}
+	if err := manifest.save(manifestPath); err != nil {
+		fmt.Printf("Error writing suite manifest: %v\n", err)
+		os.Exit(1)
+	}
+
+	for i, entry := range entries {
+		if manifest.Entries[i].Status == suiteCompleted {
+			fmt.Printf("\n=== Suite benchmark %d/%d: %s completed by the previous run, skipping ===\n", i+1, len(entries), entry.Name)
+			continue
+		}
+		fmt.Printf("\n=== Suite benchmark %d/%d: %s ===\n\n", i+1, len(entries), entry.Name)
+
+		// A fresh command resets every flag to its default between benchmarks
+		runCmd := &cobra.Command{
+			Use: "run",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return executeBenchmark(cmd, humanOutput(cmd))
+			},
+			SilenceUsage:  true,
+			SilenceErrors: true,
+		}
+		addRunFlags(runCmd)
+		runCmd.SetArgs(entry.Args)
+
+		manifest.start(i)
+		if err := manifest.save(manifestPath); err != nil {
+			fmt.Printf("Error writing suite manifest: %v\n", err)
+			os.Exit(1)
+		}
+		err := runCmd.Execute()
+		manifest.finish(i, err)
+		if saveErr := manifest.save(manifestPath); saveErr != nil {
+			fmt.Printf("Error writing suite manifest: %v\n", saveErr)
+			os.Exit(1)
+		}
+		if err != nil {
+			fmt.Printf("Error in suite benchmark %s: %v\n", entry.Name, err)
+			fmt.Printf("Resume the suite from this benchmark with --resume\n")
+			os.Exit(1)
+		}
+	}
+}
+
+// newSuiteManifest creates the manifest of a suite run with every benchmark pending
+func newSuiteManifest(suite string, entries []suiteEntry) *suiteManifest {
+	manifest := &suiteManifest{Suite: suite, Started: time.Now()}
+	for _, entry := range entries {
+		manifest.Entries = append(manifest.Entries, suiteManifestEntry{Name: entry.Name, Args: entry.Args, Status: suitePending})
+	}
+	return manifest
+}
+
+// loadSuiteManifest reads the manifest of a previous suite run, returning nil
+// if there is none
+func loadSuiteManifest(path string) (*suiteManifest, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest suiteManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &manifest, nil
+}
+
+// resume carries over the benchmarks completed by a previous run. Only a
+// benchmark with the same name and arguments at the same position counts as
+// completed, so that benchmarks edited since are run again.
+func (m *suiteManifest) resume(previous *suiteManifest) {
+	m.Started = previous.Started
+	for i := range m.Entries {
+		if i >= len(previous.Entries) {
+			break
+		}
+		entry, old := &m.Entries[i], previous.Entries[i]
+		if old.Status == suiteCompleted && old.Name == entry.Name && slices.Equal(old.Args, entry.Args) {
+			*entry = old
+		}
+	}
+}
+
+// start marks a benchmark as running
+func (m *suiteManifest) start(i int) {
+	now := time.Now()
+	m.Entries[i].Status = suiteRunning
+	m.Entries[i].Started = &now
+	m.Entries[i].Finished = nil
+}
+
+// finish marks a benchmark as completed, or failed if it returned an error
+func (m *suiteManifest) finish(i int, err error) {
+	now := time.Now()
+	m.Entries[i].Status = suiteCompleted
+	if err != nil {
+		m.Entries[i].Status = suiteFailed
+	}
+	m.Entries[i].Finished = &now
+}
+
+// save writes the manifest, replacing the previous one
+func (m *suiteManifest) save(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,237 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/surrealdb/go-crud-bench/internal/generators"
+)
+
+// OperationCheck represents a correctness spot-check under concurrency
+const OperationCheck Operation = "CHECK"
+
+// anomalyHotKeys is the number of contended keys used by the anomaly check
+const anomalyHotKeys = 4
+
+// anomalyField is the value field carrying the unique id of each write
+const anomalyField = "_op"
+
+// anomalyReportLimit is the number of detected anomalies printed to the console
+const anomalyReportLimit = 5
+
+// historyOp is a single read or write recorded in the concurrent history
+type historyOp struct {
+	write bool
+	key   int
+	value string
+	call  time.Duration
+	ret   time.Duration
+	err   error
+}
+
+// runAnomalyCheck runs a read/write contention workload on a small hot key set,
+// records the operation history and checks it for register anomalies which
+// no linearizable store can produce
+func (r *Runner) runAnomalyCheck(ctx context.Context) error {
+	total := r.Config.AnomalyCheck
+	hot := anomalyHotKeys
+	if len(r.keys) < hot {
+		hot = len(r.keys)
+	}
+	if hot == 0 {
+		return fmt.Errorf("no records available for the anomaly check")
+	}
+	keys := r.keys[:hot]
+	// The history records the values read back by their string keys
+	if err := r.requireStringKeys("anomaly checks"); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(r.Output, "Running CHECK linearizability spot-check with %d operations on %d hot keys...\n", total, hot)
+
+	// Parse the value template
+	valueTemplate, err := generators.ParseTemplate(r.Config.Value)
+	if err != nil {
+		return fmt.Errorf("failed to process value template: %w", err)
+	}
+	newValue := func(rnd generators.Random, id string) map[string]interface{} {
+		value := generators.NewValue(rnd, valueTemplate)
+		value[anomalyField] = id
+		return value
+	}
+
+	// Write a known initial value to every hot key
+	origin := time.Now()
+	var history []historyOp
+	for k, key := range keys {
+		id := fmt.Sprintf("init-%d", k)
+		call := time.Since(origin)
+		err := r.invoke(ctx, &Call{Operation: OperationUpdate, Key: key, Value: newValue(generators.RecordRandom("check:init", k), id)}, func(ctx context.Context, call *Call) error {
+			return r.Adapter.Update(ctx, call.Key, call.Value)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to initialise hot key %s: %w", key, err)
+		}
+		history = append(history, historyOp{write: true, key: k, value: id, call: call, ret: time.Since(origin)})
+	}
+
+	// Run the contention workload, alternating reads and writes per worker
+	workers := r.Config.Clients * r.threads
+	histories := make([][]historyOp, workers)
+	var wg sync.WaitGroup
+	startTime := time.Now()
+
+	steps := &steps{total: total}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func(workerID int) {
+			defer wg.Done()
+			ctx := r.clientContext(ctx, workerID)
+
+			for {
+				i, ok := steps.take()
+				if !ok {
+					return
+				}
+				if ctx.Err() != nil {
+					return
+				}
+
+				op := historyOp{write: i%2 == 0, key: (i / 2) % hot}
+				op.call = time.Since(origin)
+				if op.write {
+					op.value = fmt.Sprintf("w%d-%d", workerID, i)
+					op.err = r.invoke(ctx, &Call{Operation: OperationUpdate, Key: keys[op.key], Value: newValue(generators.RecordRandom("check", i), op.value)}, func(ctx context.Context, call *Call) error {
+						return r.Adapter.Update(ctx, call.Key, call.Value)
+					})
+				} else {
+					call := &Call{Operation: OperationRead, Key: keys[op.key]}
+					op.err = r.invoke(ctx, call, func(ctx context.Context, call *Call) (err error) {
+						call.Record, err = r.Adapter.Read(ctx, call.Key)
+						return err
+					})
+					got := call.Record
+					if op.err == nil {
+						op.value, _ = got[anomalyField].(string)
+					}
+				}
+				op.ret = time.Since(origin)
+				r.completed.Add(1)
+				histories[workerID] = append(histories[workerID], op)
+			}
+		}(w)
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	duration := time.Since(startTime)
+
+	for _, h := range histories {
+		history = append(history, h...)
+	}
+	anomalies, counts := checkRegisterHistory(history, keys)
+
+	// Record result
+	var errors int64
+	for _, op := range history[hot:] {
+		if op.err != nil {
+			errors++
+		}
+	}
+	metrics := map[string]float64{"anomalies": float64(len(anomalies))}
+	for kind, count := range counts {
+		metrics[kind] = float64(count)
+	}
+	r.Results = append(r.Results, Result{
+		Operation: OperationCheck,
+		Name:      "linearizability",
+		Duration:  duration,
+		Count:     total,
+		Errors:    errors,
+		Metrics:   metrics,
+	})
+
+	for i, anomaly := range anomalies {
+		if i == anomalyReportLimit {
+			fmt.Fprintf(r.Output, "  ... and %d more\n", len(anomalies)-anomalyReportLimit)
+			break
+		}
+		fmt.Fprintf(r.Output, "  anomaly: %s\n", anomaly)
+	}
+	fmt.Fprintf(r.Output, "CHECK completed in %v (%d anomalies detected)\n", duration, len(anomalies))
+	return nil
+}
+
+// checkRegisterHistory checks a history of reads and writes on independent
+// registers for anomalies. Writes must carry unique values. The check is sound
+// but not complete: every reported anomaly is a real linearizability violation,
+// but a history without anomalies is not proven linearizable.
+func checkRegisterHistory(history []historyOp, keys []string) ([]string, map[string]int) {
+	var anomalies []string
+	counts := map[string]int{}
+	report := func(kind, format string, args ...interface{}) {
+		counts[kind]++
+		anomalies = append(anomalies, fmt.Sprintf(kind+": "+format, args...))
+	}
+
+	for k, key := range keys {
+		writes := map[string]historyOp{}
+		var completed []historyOp
+		var reads []historyOp
+		for _, op := range history {
+			if op.key != k {
+				continue
+			}
+			switch {
+			case op.write && op.err != nil:
+				// A failed write may still take effect at any later point
+				op.ret = time.Duration(1<<63 - 1)
+				writes[op.value] = op
+			case op.write:
+				writes[op.value] = op
+				completed = append(completed, op)
+			case op.err == nil:
+				reads = append(reads, op)
+			}
+		}
+		sort.Slice(reads, func(i, j int) bool { return reads[i].call < reads[j].call })
+
+		for i, read := range reads {
+			w, ok := writes[read.value]
+			if !ok {
+				report("unknown_value", "key %s read %q which was never written", key, read.value)
+				continue
+			}
+			if w.call > read.ret {
+				report("future_read", "key %s read %q before its write started", key, read.value)
+				continue
+			}
+
+			// A write which started and finished between the observed write and the read overwrote it
+			for _, other := range completed {
+				if other.value != w.value && w.ret < other.call && other.ret < read.call {
+					report("stale_read", "key %s read %q after it was overwritten by %q", key, read.value, other.value)
+					break
+				}
+			}
+
+			// A later read must not observe a write which strictly preceded the one already observed
+			for _, later := range reads[i+1:] {
+				if read.ret >= later.call {
+					continue
+				}
+				if lw, ok := writes[later.value]; ok && lw.ret < w.call {
+					report("read_inversion", "key %s read %q after %q had already been read", key, later.value, read.value)
+					break
+				}
+			}
+		}
+	}
+
+	return anomalies, counts
+}
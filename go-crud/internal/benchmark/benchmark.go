@@ -2,34 +2,62 @@ package benchmark
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync/atomic"
 	"time"
 
 	"github.com/surrealdb/go-crud-bench/internal/config"
+	"github.com/surrealdb/go-crud-bench/internal/dbutils"
+	"github.com/surrealdb/go-crud-bench/internal/docker"
+	"github.com/surrealdb/go-crud-bench/internal/generators"
+	"github.com/surrealdb/go-crud-bench/middleware"
 )
 
 // Operation represents a benchmark operation type
-type Operation string
+type Operation = middleware.Operation
 
 const (
 	// OperationCreate represents a create operation
-	OperationCreate Operation = "CREATE"
+	OperationCreate = middleware.OperationCreate
 	// OperationRead represents a read operation
-	OperationRead Operation = "READ"
+	OperationRead = middleware.OperationRead
 	// OperationUpdate represents an update operation
-	OperationUpdate Operation = "UPDATE"
+	OperationUpdate = middleware.OperationUpdate
 	// OperationDelete represents a delete operation
-	OperationDelete Operation = "DELETE"
+	OperationDelete = middleware.OperationDelete
 	// OperationScan represents a scan operation
-	OperationScan Operation = "SCAN"
+	OperationScan = middleware.OperationScan
 )
 
 // Result represents the result of a benchmark operation
 type Result struct {
-	Operation Operation
-	Name      string
-	Duration  time.Duration
-	Error     error
-	Count     int
+	Operation    Operation
+	Name         string
+	Duration     time.Duration
+	Error        error
+	Count        int
+	Errors       int64
+	ErrorClasses map[ErrorClass]int64 `json:",omitempty"`
+	Metrics      map[string]float64   `json:",omitempty"`
+	SLO          []SLOBucket          `json:",omitempty"`
+	Latency      *LatencyStats        `json:",omitempty"`
+	Histogram    []HistogramBucket    `json:",omitempty"`
+	Hints        []Hint               `json:",omitempty"`
+	Skipped      string               `json:",omitempty"`
+	// Partial marks the result of a phase which failed or was interrupted
+	// before it completed, covering the operations completed until then
+	Partial bool `json:",omitempty"`
+}
+
+// Throughput returns the number of operations completed per second
+func (r *Result) Throughput() float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+	return float64(r.Count) / r.Duration.Seconds()
 }
 
 // Adapter defines the interface that all database adapters must implement
@@ -64,49 +92,477 @@ type Runner struct {
 	Adapter  Adapter
 	Config   *config.Config
 	Results  []Result
+
+	// Tuning holds the effective adapter tuning settings applied for the run
+	Tuning map[string]string
+
+	// HostSamples holds the CPU frequency and temperature timeline of the run
+	HostSamples []HostSample
+
+	// TimeSeries holds the operations completed in every interval of the phases
+	TimeSeries []TimeSample
+
+	// MemoryEvents records the pauses of the workers caused by the client
+	// memory approaching the configured ceiling
+	MemoryEvents []MemoryEvent
+
+	// PageCacheDrops records the page cache evictions performed during the run
+	PageCacheDrops []PageCacheDrop
+
+	// Annotations records the changes made to the running benchmark through
+	// the control socket
+	Annotations []Annotation
+
+	// Warmup holds the reads issued before the measured phases, which are
+	// not part of the results
+	Warmup *Result
+
+	// Profile describes the records generated by the create phase
+	Profile *generators.DatasetProfile
+
+	// Container holds the create specification of the managed database
+	// container, from which a rerun provisions an identical one
+	Container *docker.Spec
+
+	// Crash records the managed database container stopping during a phase
+	Crash *Crash
+
+	// Provisioning holds the time spent initializing and cleaning up the adapter
+	Provisioning *Provisioning
+
+	// Interrupted is set when the run was cancelled before it completed
+	Interrupted bool
+
+	// OnInterrupt is called with the results collected so far when the run
+	// is interrupted, before the adapter is cleaned up, so that they can be
+	// saved while the database still runs
+	OnInterrupt func(results []Result)
+
+	// Observer optionally receives lifecycle and progress events
+	Observer Observer
+
+	// Middleware wraps every call issued to the adapter by the phases, the
+	// first middleware being the outermost, starting with the middleware
+	// installed with middleware.Use
+	Middleware []Middleware
+
+	// Output receives the messages and tables printed during the run,
+	// standard output unless set otherwise
+	Output io.Writer
+
+	// clients is set when every client has its own connection pool
+	clients bool
+
+	// limits bound the calls in flight set by the workers and blocking
+	// threads, shared by every phase, and limiter applies them in the chain
+	limits  []callLimit
+	limiter Middleware
+
+	// queryLog logs the first statements of each phase when query debugging is enabled
+	queryLog *dbutils.QueryLog
+
+	// recorder is the recorder of the running phase, from which an
+	// interrupted phase records its partial result
+	recorder atomic.Pointer[recorder]
+
+	// completed counts the operations completed in the current phase
+	completed atomic.Int64
+
+	// failed counts the completed operations of the current phase which failed
+	failed atomic.Int64
+
+	// latencySum sums the latencies in nanoseconds of the operations of the
+	// current phase which succeeded
+	latencySum atomic.Int64
+
+	// crashed is set once the managed database container is known to have
+	// stopped, from which point failed operations are classified as crashed
+	crashed atomic.Bool
+
+	// feed tracks the change notifications of the create phase
+	feed *feedTracker
+
+	// keys holds the keys written during the create phase
+	keys []string
+
+	// mix holds the key space of the mixed workload
+	mix *mixKeys
+
+	// profiler samples the records generated by the create phase
+	profiler *generators.Profiler
+
+	// pacer spaces the operations of the running phase at the target rate
+	pacer atomic.Pointer[pacer]
+
+	// threads is the number of threads per client of the running phase, the
+	// configured count unless changed through the control socket
+	threads int
+
+	// control holds the settings changed through the control socket
+	control *control
+
+	// memory pauses the workers when the client memory approaches the ceiling
+	memory *memoryGuard
 }
 
 // NewRunner creates a new benchmark runner
 func NewRunner(adapter Adapter, cfg *config.Config) *Runner {
 	return &Runner{
 		Adapter: adapter,
-		Config:  cfg,
-		Results: []Result{},
+		Config:     cfg,
+		Results:    []Result{},
+		Middleware: middleware.Installed(),
+		Output:     os.Stdout,
 	}
 }
 
 // Run executes the benchmark
-func (r *Runner) Run(ctx context.Context) ([]Result, error) {
-	// Initialize the database
-	if err := r.Adapter.Initialize(ctx); err != nil {
-		return nil, err
+func (r *Runner) Run(ctx context.Context) (results []Result, err error) {
+	// Apply the adapter tuning preset and any overrides
+	if tunable, ok := r.Adapter.(Tunable); ok {
+		tuning, err := tunable.Tune(r.Config.Tuning)
+		if err != nil {
+			return nil, err
+		}
+		r.Tuning = tuning
+	} else if len(r.Config.Tuning) > 0 {
+		return nil, fmt.Errorf("database %s does not support tuning settings", r.Adapter.Name())
 	}
-	
-	// Ensure cleanup happens
+
+	// Start with the configured threads per client, which the control socket
+	// may change from one phase to the next
+	r.threads = r.Config.Threads
+
+	// Log every operation in serial mode, numbering them across the run
+	if r.Config.Serial {
+		r.Middleware = append([]Middleware{middleware.Log(r.Output)}, r.Middleware...)
+	}
+
+	// Bound the calls in flight
+	r.limits = r.newLimits()
+	r.limiter = r.newLimiter()
+
+	// Isolate the run in its own table on shared endpoints
+	if r.Config.Namespace != "" {
+		namespaced, ok := r.Adapter.(Namespaced)
+		if !ok {
+			return nil, fmt.Errorf("database %s does not support namespaced runs", r.Adapter.Name())
+		}
+		namespaced.SetNamespace(r.Config.Namespace)
+	}
+
+	// Log the statements issued by the adapter if requested
+	if r.Config.DebugQueries > 0 {
+		logger, ok := r.Adapter.(QueryLogger)
+		if !ok {
+			return nil, fmt.Errorf("database %s does not support query logging", r.Adapter.Name())
+		}
+		r.queryLog = dbutils.NewQueryLog(r.Output, r.Config.DebugQueries, r.Config.DebugQueryParams)
+		logger.SetQueryLog(r.queryLog)
+	}
+
+	// Record the provisioning steps of the adapter
+	steps := &dbutils.Steps{}
+	if recorder, ok := r.Adapter.(StepRecorder); ok {
+		recorder.SetSteps(steps)
+	}
+
+	// Print the messages of the adapter to the output of the run
+	if printer, ok := r.Adapter.(Printer); ok {
+		printer.SetOutput(r.Output)
+	}
+	r.Provisioning = &Provisioning{}
+
+	// Ensure cleanup happens, also after a partially failed initialization,
+	// handing the results of an interrupted run over first
 	defer func() {
-		_ = r.Adapter.Cleanup(ctx)
+		if err != nil && interrupted(ctx) {
+			r.Interrupted = true
+			if r.OnInterrupt != nil {
+				r.OnInterrupt(r.Results)
+			}
+		}
+		cleanupStart := time.Now()
+		cleanupErr := r.cleanup()
+		r.Provisioning.Cleanup = ProvisionTiming{Duration: time.Since(cleanupStart), Steps: steps.Take()}
+		if cleanupErr != nil && err == nil {
+			err = cleanupErr
+		}
 	}()
-	
-	// Run the benchmark operations
-	if err := r.runCreate(ctx); err != nil {
+
+	// Initialize the database
+	initStart := time.Now()
+	err = r.Adapter.Initialize(ctx)
+	r.Provisioning.Initialize = ProvisionTiming{Duration: time.Since(initStart), Steps: steps.Take()}
+	if err != nil {
+		return nil, err
+	}
+
+	// Record the specification of the managed container for reruns
+	if provider, ok := r.Adapter.(ContainerProvider); ok && provider.Container() != nil {
+		spec, err := provider.Container().Spec(ctx)
+		if err != nil {
+			fmt.Fprintf(r.Output, "Warning: failed to record the container specification: %v\n", err)
+		}
+		r.Container = spec
+	}
+
+	// Shape the container network if a profile was requested
+	if r.Config.Network != nil {
+		if err := r.applyNetworkProfile(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	// Connect every client separately, then establish the connections before
+	// the first measured operations
+	if err := r.connectClients(ctx); err != nil {
+		return nil, err
+	}
+	if err := r.warmPool(ctx); err != nil {
+		return nil, err
+	}
+
+	// Pause the workers if the client memory approaches the ceiling
+	ctx, stopMemoryGuard := r.startMemoryGuard(ctx)
+	defer stopMemoryGuard()
+
+	// Run the benchmark operations, optionally subscribed to the change feed
+	create, read := r.runCreate, r.runRead
+	if r.Config.ChangeFeed {
+		create = r.runCreateWithFeed
+	}
+
+	// Store every record as a parent row with child rows for the relational workload
+	if r.Config.Children > 0 {
+		adapter, err := r.relational()
+		if err != nil {
+			return nil, err
+		}
+		if err := adapter.CreateChildTable(ctx); err != nil {
+			return nil, fmt.Errorf("failed to create child table: %w", err)
+		}
+		defer func() {
+			if dropErr := adapter.DropChildTable(ctx); dropErr != nil && err == nil {
+				err = fmt.Errorf("failed to drop child table: %w", dropErr)
+			}
+		}()
+		create, read = r.runCreateRelational, r.runReadRelational
+	}
+
+	// Profile a sample of the records generated by the create phase
+	r.profiler = generators.NewProfiler(r.Config.Samples)
+	created := len(r.Results)
+	err = r.runPhase(ctx, "create", create)
+	r.Profile, r.profiler = r.profiler.Profile(), nil
+	if err != nil {
 		return r.Results, err
 	}
-	
-	if err := r.runRead(ctx); err != nil {
+	if p := r.Profile; p != nil {
+		fmt.Fprintf(r.Output, "Dataset profile: %d fields, %.0f bytes per record, %s logical (%d records sampled)\n",
+			len(p.Fields), p.AvgRecordBytes, formatBytes(uint64(p.LogicalBytes)), p.Sampled)
+	}
+
+	// Measure the disk space the loaded dataset occupies
+	if len(r.Results) > created {
+		r.measureDiskUsage(ctx, &r.Results[len(r.Results)-1])
+	}
+
+	// Warm up before the measured phases, without recording results
+	if r.Config.WarmupOps > 0 || r.Config.WarmupDuration > 0 {
+		if err := r.runPhase(ctx, "warmup", r.runWarmup); err != nil {
+			return r.Results, err
+		}
+	}
+
+	// Interleave the operations of a mixed workload instead of running phases
+	if len(r.Config.Mix) > 0 {
+		if err := r.runPhase(ctx, "mixed", r.runMixed); err != nil {
+			return r.Results, err
+		}
+		if err := r.runPhase(ctx, "delete", r.runMixedDelete); err != nil {
+			return r.Results, err
+		}
+		return r.Results, nil
+	}
+
+	if r.Config.DropCaches {
+		if err := r.dropPageCache("read"); err != nil {
+			return r.Results, err
+		}
+	}
+
+	if err := r.runPhase(ctx, "read", read); err != nil {
 		return r.Results, err
 	}
+
+	if r.Config.ReadMulti > 0 {
+		if err := r.runPhase(ctx, "read_multi", r.runReadMulti); err != nil {
+			return r.Results, err
+		}
+	}
+
+	if r.Config.Exists {
+		if err := r.runPhase(ctx, "exists", r.runExists); err != nil {
+			return r.Results, err
+		}
+	}
 	
-	if err := r.runUpdate(ctx); err != nil {
+	if err := r.runPhase(ctx, "update", r.runUpdate); err != nil {
 		return r.Results, err
 	}
+
+	if r.Config.CAS > 0 {
+		if err := r.runPhase(ctx, "cas", r.runCAS); err != nil {
+			return r.Results, err
+		}
+	}
+
+	if r.Config.Increments > 0 {
+		if err := r.runPhase(ctx, "increment", r.runIncrement); err != nil {
+			return r.Results, err
+		}
+	}
+
+	if r.Config.ConsistencyProbes > 0 {
+		if err := r.runPhase(ctx, "probe", r.runConsistencyProbe); err != nil {
+			return r.Results, err
+		}
+	}
+
+	if r.Config.AnomalyCheck > 0 {
+		if err := r.runPhase(ctx, "check", r.runAnomalyCheck); err != nil {
+			return r.Results, err
+		}
+	}
 	
-	if err := r.runScans(ctx); err != nil {
+	if r.Config.IndexBuild != "" {
+		if err := r.runPhase(ctx, "index", r.runIndexBuild); err != nil {
+			return r.Results, err
+		}
+	}
+	
+	if r.Config.Compaction {
+		if err := r.runPhase(ctx, "compact", r.runCompaction); err != nil {
+			return r.Results, err
+		}
+	}
+
+	if r.Config.GraphEdges > 0 {
+		if err := r.runPhase(ctx, "graph", r.runGraph); err != nil {
+			return r.Results, err
+		}
+	}
+
+	if r.Config.DropCaches {
+		if err := r.dropPageCache("scan"); err != nil {
+			return r.Results, err
+		}
+	}
+	
+	if err := r.runPhase(ctx, "scan", r.runScans); err != nil {
 		return r.Results, err
 	}
+
+	if r.Config.SoftDelete > 0 {
+		if err := r.runPhase(ctx, "soft_delete", r.runSoftDelete); err != nil {
+			return r.Results, err
+		}
+	}
 	
-	if err := r.runDelete(ctx); err != nil {
+	if err := r.runPhase(ctx, "delete", r.runDelete); err != nil {
 		return r.Results, err
 	}
+
+	if r.Config.BatchSweep {
+		if err := r.runPhase(ctx, "batch_sweep", r.runBatchSweep); err != nil {
+			return r.Results, err
+		}
+	}
 	
 	return r.Results, nil
-} 
\ No newline at end of file
+}
+
+// runPhase executes a benchmark phase and annotates the results it records
+func (r *Runner) runPhase(ctx context.Context, name string, phase func(context.Context) error) error {
+	index := len(r.Results)
+	before := r.connectionStats()
+	r.completed.Store(0)
+	r.failed.Store(0)
+	r.latencySum.Store(0)
+	r.recorder.Store(nil)
+	r.queryLog.SetPhase(name)
+	pauses := r.memory.startPhase(name)
+	usage := r.startUsage(ctx)
+	r.startControl(name)
+
+	// Report progress while the phase runs
+	if r.Observer != nil {
+		r.Observer.PhaseStart(name)
+		progressCtx, stopProgress := context.WithCancel(ctx)
+		defer stopProgress()
+		go r.reportProgress(progressCtx, name, time.Now())
+	}
+
+	// Sample CPU frequency and temperature while the phase runs
+	var thermal chan []HostSample
+	var throttles int64
+	stopThermal := func() {}
+	if r.Config.Thermal {
+		var thermalCtx context.Context
+		thermal = make(chan []HostSample, 1)
+		throttles = throttleCount()
+		thermalCtx, stopThermal = context.WithCancel(ctx)
+		go r.sampleThermal(thermalCtx, name, thermal)
+	}
+
+	// Sample the operations completed in every interval while the phase runs
+	var series chan []TimeSample
+	stopSeries := func() {}
+	if r.Config.TimeSeriesInterval > 0 {
+		var seriesCtx context.Context
+		series = make(chan []TimeSample, 1)
+		seriesCtx, stopSeries = context.WithCancel(ctx)
+		go r.sampleTimeSeries(seriesCtx, name, time.Now(), series)
+	}
+
+	// Check that the managed database container keeps running
+	phaseCtx, stopWatchdog := r.startWatchdog(ctx, name)
+
+	err := phase(phaseCtx)
+	crash := stopWatchdog(err != nil)
+
+	// Keep the operations completed, and the errors counted, by a phase which
+	// failed or was interrupted by a signal
+	if err != nil {
+		r.recordPartial(name, index)
+	}
+
+	stopThermal()
+	if thermal != nil {
+		r.recordThermal(<-thermal, throttles, index)
+	}
+	stopSeries()
+	if series != nil {
+		r.TimeSeries = append(r.TimeSeries, <-series...)
+	}
+	r.recordConnectionStats(before, index)
+	r.recordMemory(pauses, index)
+	r.recordUsage(ctx, usage, index)
+	r.recordRate(index)
+	r.recordThreads(index)
+	r.recordHints(index)
+
+	// Report the memory ceiling rather than the cancellation it caused
+	if cause := context.Cause(ctx); err != nil && r.memory != nil && cause != nil && !errors.Is(cause, context.Canceled) {
+		err = cause
+	}
+	// Report the crash of the database rather than the errors it caused
+	if crash != nil {
+		err = crash.Err()
+	}
+	if r.Observer != nil {
+		r.Observer.PhaseEnd(name, r.Results[index:], err)
+	}
+	return err
+}
@@ -0,0 +1,180 @@
+package benchmark
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Calibration is the noise floor of the benchmark harness on a host: the
+// throughput and latency of the dry and map adapters, which do no database
+// work, and of a loopback network echo, measured at a given concurrency.
+// Results close to it are limited by the client rather than the database.
+type Calibration struct {
+	Created  time.Time    `json:"created"`
+	Host     string       `json:"host"`
+	Samples  int          `json:"samples"`
+	Clients  int          `json:"clients"`
+	Threads  int          `json:"threads"`
+	Dry      []NoiseFloor `json:"dry"`
+	Map      []NoiseFloor `json:"map"`
+	Loopback NoiseFloor   `json:"loopback"`
+}
+
+// NoiseFloor is the throughput and latency of an operation measured by a
+// calibration run
+type NoiseFloor struct {
+	Operation  Operation     `json:"operation"`
+	Name       string        `json:"name"`
+	Throughput float64       `json:"throughput"`
+	P50        time.Duration `json:"p50"`
+	P99        time.Duration `json:"p99"`
+}
+
+// NoiseFloors converts the results of a calibration run into noise floors
+func NoiseFloors(results []Result) []NoiseFloor {
+	floors := make([]NoiseFloor, 0, len(results))
+	for _, result := range results {
+		floor := NoiseFloor{Operation: result.Operation, Name: result.Name, Throughput: result.Throughput()}
+		if result.Latency != nil {
+			floor.P50 = result.Latency.P50
+			floor.P99 = result.Latency.P99
+		}
+		floors = append(floors, floor)
+	}
+	return floors
+}
+
+// Floor returns the noise floor of the adapter measured for the given
+// operation, or nil when the calibration did not run it
+func Floor(floors []NoiseFloor, operation Operation, name string) *NoiseFloor {
+	for i := range floors {
+		if floors[i].Operation == operation && floors[i].Name == name {
+			return &floors[i]
+		}
+	}
+	return nil
+}
+
+// DefaultCalibrationPath returns where crud-bench calibrate stores the noise
+// floor of this host unless told otherwise
+func DefaultCalibrationPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate the user configuration directory: %w", err)
+	}
+	return filepath.Join(dir, "crud-bench", "calibration.json"), nil
+}
+
+// LoadCalibration reads the noise floor stored at the given path, returning
+// nil when the host has not been calibrated
+func LoadCalibration(path string) (*Calibration, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read calibration: %w", err)
+	}
+	var calibration Calibration
+	if err := json.Unmarshal(data, &calibration); err != nil {
+		return nil, fmt.Errorf("failed to parse calibration %s: %w", path, err)
+	}
+	return &calibration, nil
+}
+
+// Save stores the noise floor at the given path
+func (c *Calibration) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode calibration: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create calibration directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write calibration: %w", err)
+	}
+	return nil
+}
+
+// RunLoopback measures the round trips of payloads of the given size echoed
+// over loopback TCP connections, one per worker, which bounds the throughput
+// and latency of any networked database on this host
+func RunLoopback(ctx context.Context, workers, samples, size int) (NoiseFloor, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return NoiseFloor{}, fmt.Errorf("failed to listen on loopback: %w", err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				io.Copy(conn, conn)
+			}()
+		}
+	}()
+
+	hist := newHistogram()
+	payload := make([]byte, max(size, 1))
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	var echoErr error
+
+	start := time.Now()
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			conn, err := net.Dial("tcp", listener.Addr().String())
+			if err != nil {
+				errOnce.Do(func() { echoErr = fmt.Errorf("failed to connect to loopback echo: %w", err) })
+				return
+			}
+			defer conn.Close()
+
+			reply := make([]byte, len(payload))
+			for next.Add(1) <= int64(samples) && ctx.Err() == nil {
+				opStart := time.Now()
+				if _, err := conn.Write(payload); err != nil {
+					errOnce.Do(func() { echoErr = fmt.Errorf("failed to write to loopback echo: %w", err) })
+					return
+				}
+				if _, err := io.ReadFull(conn, reply); err != nil {
+					errOnce.Do(func() { echoErr = fmt.Errorf("failed to read from loopback echo: %w", err) })
+					return
+				}
+				hist.record(time.Since(opStart))
+			}
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+	if echoErr != nil {
+		return NoiseFloor{}, echoErr
+	}
+	if err := ctx.Err(); err != nil {
+		return NoiseFloor{}, err
+	}
+
+	return NoiseFloor{
+		Name:       "echo",
+		Throughput: float64(hist.count()) / elapsed.Seconds(),
+		P50:        hist.quantile(0.50),
+		P99:        hist.quantile(0.99),
+	}, nil
+}
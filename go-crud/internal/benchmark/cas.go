@@ -0,0 +1,176 @@
+package benchmark
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/surrealdb/go-crud-bench/internal/generators"
+)
+
+// OperationCAS represents a conditional compare-and-set write
+const OperationCAS Operation = "CAS"
+
+// VersionField is the value field holding the version compared by conditional
+// writes. Records without the field are at version 0.
+const VersionField = "_version"
+
+// CompareAndSetter is implemented by adapters that support conditional writes
+// (UPDATE ... WHERE version = ?, Redis WATCH/MULTI, MongoDB filtered replaces)
+type CompareAndSetter interface {
+	// CompareAndSet replaces the value of a record only if its stored version
+	// equals expected. The value carries the new version. It reports whether
+	// the write was applied, a conflict not being an error.
+	CompareAndSet(ctx context.Context, key string, expected int64, value map[string]interface{}) (bool, error)
+}
+
+// Version returns the version of a record value, or 0 when it has none
+func Version(value map[string]interface{}) int64 {
+	switch v := value[VersionField].(type) {
+	case float64:
+		return int64(v)
+	case int64:
+		return v
+	case uint64:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case int:
+		return int64(v)
+	case json.Number:
+		n, _ := v.Int64()
+		return n
+	default:
+		return 0
+	}
+}
+
+// runCAS runs read-modify-write cycles with conditional writes on a small hot
+// key set, so that concurrent workers contend, and reports the success and
+// conflict rates of the writes
+func (r *Runner) runCAS(ctx context.Context) error {
+	total := r.Config.CAS
+	hot := r.Config.CASKeys
+	if len(r.keys) < hot {
+		hot = len(r.keys)
+	}
+	if hot == 0 {
+		return fmt.Errorf("no records available for conditional writes")
+	}
+	keys := r.keys[:hot]
+
+	setter, ok := r.Adapter.(CompareAndSetter)
+	if !ok {
+		return fmt.Errorf("database %s does not support conditional writes", r.Adapter.Name())
+	}
+	if err := r.requireStringKeys("conditional writes"); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(r.Output, "Running CAS benchmark with %d conditional writes on %d hot keys...\n", total, hot)
+
+	// Parse the value template
+	valueTemplate, err := generators.ParseTemplate(r.Config.Value)
+	if err != nil {
+		return fmt.Errorf("failed to process value template: %w", err)
+	}
+
+	// Start timer
+	startTime := time.Now()
+	rec := r.newRecorder()
+	var applied, conflicts atomic.Int64
+	var next atomic.Int64
+
+	var wg sync.WaitGroup
+	workers := r.Config.Clients * r.threads
+	errCh := make(chan error, workers)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			ctx := r.clientContext(ctx, w)
+
+			for {
+				i := int(next.Add(1)) - 1
+				if i >= total {
+					return
+				}
+				if ctx.Err() != nil {
+					errCh <- ctx.Err()
+					return
+				}
+
+				// Read the current version of the record
+				key := keys[i%hot]
+				call := &Call{Operation: OperationRead, Key: key}
+				err := r.invoke(ctx, call, func(ctx context.Context, call *Call) (err error) {
+					call.Record, err = r.Adapter.Read(ctx, call.Key)
+					return err
+				})
+				if err != nil {
+					errCh <- fmt.Errorf("failed to read record %s: %w", key, err)
+					return
+				}
+				expected := Version(call.Record)
+
+				value := generators.NewValue(generators.RecordRandom("cas", i), valueTemplate)
+				value[VersionField] = expected + 1
+
+				// Only the conditional write itself is timed
+				opStart := r.pace(ctx)
+				var ok bool
+				err = r.invoke(ctx, &Call{Operation: OperationCAS, Key: key, Value: value}, func(ctx context.Context, call *Call) (err error) {
+					ok, err = setter.CompareAndSet(ctx, call.Key, expected, call.Value)
+					return err
+				})
+				rec.observe(time.Since(opStart), err)
+				if err != nil {
+					errCh <- fmt.Errorf("failed to write record %s: %w", key, err)
+					return
+				}
+				if ok {
+					applied.Add(1)
+				} else {
+					conflicts.Add(1)
+				}
+			}
+		}()
+	}
+
+	// Wait for all goroutines to finish
+	wg.Wait()
+
+	// Check for errors
+	close(errCh)
+	for err := range errCh {
+		if err != nil {
+			return err
+		}
+	}
+
+	// Record result
+	duration := time.Since(startTime)
+	result := Result{
+		Operation: OperationCAS,
+		Name:      "cas_contended",
+		Duration:  duration,
+		Count:     total,
+		Metrics: map[string]float64{
+			"hot_keys":      float64(hot),
+			"applied":       float64(applied.Load()),
+			"conflicts":     float64(conflicts.Load()),
+			"success_rate":  float64(applied.Load()) / float64(total),
+			"conflict_rate": float64(conflicts.Load()) / float64(total),
+		},
+	}
+	rec.apply(&result)
+	r.Results = append(r.Results, result)
+
+	fmt.Fprintf(r.Output, "CAS completed in %v: %d applied, %d conflicts\n", duration, applied.Load(), conflicts.Load())
+	return nil
+}
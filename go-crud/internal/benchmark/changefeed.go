@@ -0,0 +1,110 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// OperationFeed represents a change notification delivered to a subscriber
+const OperationFeed Operation = "FEED"
+
+// feedDrainTimeout is how long notifications are awaited after the last write
+const feedDrainTimeout = 10 * time.Second
+
+// ChangeFeed is implemented by adapters that can notify a subscriber of new
+// records (PostgreSQL LISTEN/NOTIFY, MongoDB change streams, Redis keyspace
+// notifications, SurrealDB live queries)
+type ChangeFeed interface {
+	// Subscribe delivers the key of every record created from now on to the
+	// handler, until stop is called. It returns once the subscription is active.
+	Subscribe(ctx context.Context, handler func(key string)) (stop func() error, err error)
+}
+
+// feedTracker matches change notifications with the writes that caused them
+type feedTracker struct {
+	sent     sync.Map
+	received atomic.Int64
+	rec      *recorder
+	done     chan struct{}
+	expected int64
+}
+
+// send records the start time of the write of a key
+func (f *feedTracker) send(key string, start time.Time) {
+	if f == nil {
+		return
+	}
+	f.sent.Store(key, start)
+}
+
+// notify records the latency of a notification, ignoring duplicates
+func (f *feedTracker) notify(key string) {
+	start, ok := f.sent.LoadAndDelete(key)
+	if !ok {
+		return
+	}
+	f.rec.observe(time.Since(start.(time.Time)), nil)
+	if f.received.Add(1) == f.expected {
+		close(f.done)
+	}
+}
+
+// runCreateWithFeed subscribes to the change feed of the adapter during the
+// create phase and reports the write-to-notification latency distribution
+func (r *Runner) runCreateWithFeed(ctx context.Context) error {
+	feed, ok := r.Adapter.(ChangeFeed)
+	if !ok {
+		return fmt.Errorf("database %s does not support change feeds", r.Adapter.Name())
+	}
+
+	// Notifications are not operations of the create phase, so they do not
+	// count towards its progress
+	rec := r.newRecorder()
+	rec.done = &atomic.Int64{}
+	tracker := &feedTracker{rec: rec, done: make(chan struct{}), expected: int64(r.Config.Samples)}
+
+	stop, err := feed.Subscribe(ctx, tracker.notify)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to change feed: %w", err)
+	}
+
+	r.feed = tracker
+	startTime := time.Now()
+	err = r.runCreate(ctx)
+	r.feed = nil
+	if err != nil {
+		_ = stop()
+		return err
+	}
+	writesDone := time.Now()
+
+	// Wait for the remaining notifications
+	select {
+	case <-tracker.done:
+	case <-time.After(feedDrainTimeout):
+	case <-ctx.Done():
+	}
+	if err := stop(); err != nil {
+		return fmt.Errorf("failed to stop change feed: %w", err)
+	}
+
+	received := tracker.received.Load()
+	result := Result{
+		Operation: OperationFeed,
+		Name:      "change_feed",
+		Duration:  time.Since(startTime),
+		Count:     int(received),
+		Metrics: map[string]float64{
+			"missed":   float64(tracker.expected - received),
+			"drain_ms": float64(time.Since(writesDone).Microseconds()) / 1000,
+		},
+	}
+	rec.apply(&result)
+	r.Results = append(r.Results, result)
+
+	fmt.Fprintf(r.Output, "FEED received %d of %d notifications\n", received, tracker.expected)
+	return nil
+}
@@ -0,0 +1,50 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// cleanupTimeout bounds the time spent cleaning up after a run
+const cleanupTimeout = 2 * time.Minute
+
+// cleanup cleans up the adapter with a fresh context, since the run context
+// may already be cancelled on interrupt, and applies the cleanup policy to any
+// failure. Only the fail policy returns an error.
+func (r *Runner) cleanup() error {
+	ctx, cancel := context.WithTimeout(context.Background(), cleanupTimeout)
+	defer cancel()
+
+	err := r.Adapter.Cleanup(ctx)
+	if err == nil {
+		return nil
+	}
+
+	switch r.Config.CleanupPolicy {
+	case "fail":
+		return fmt.Errorf("cleanup failed: %w", err)
+	case "janitor":
+		fmt.Fprintf(r.Output, "Warning: cleanup failed: %v\n", err)
+		// Only the tables of this run are dropped, and those of a managed
+		// container go with the container
+		if r.Config.Namespace == "" || r.Config.Endpoint == "" {
+			return nil
+		}
+		janitor, ok := r.Adapter.(Janitor)
+		if !ok {
+			fmt.Fprintf(r.Output, "Warning: database %s has no janitor, leftovers must be removed manually\n", r.Adapter.Name())
+			return nil
+		}
+		dropped, err := janitor.DropNamespace(ctx, r.Config.Namespace)
+		if err != nil {
+			fmt.Fprintf(r.Output, "Warning: janitor failed: %v\n", err)
+			return nil
+		}
+		fmt.Fprintf(r.Output, "Janitor removed %d tables of namespace %s\n", len(dropped), r.Config.Namespace)
+	default:
+		fmt.Fprintf(r.Output, "Warning: cleanup failed: %v\n", err)
+	}
+
+	return nil
+}
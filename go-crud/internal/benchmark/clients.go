@@ -0,0 +1,48 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/surrealdb/go-crud-bench/internal/dbutils"
+)
+
+// ClientConnector is implemented by adapters which can connect every client
+// of the benchmark separately, each with its own connection pool, rather than
+// sharing one pool between all clients and threads
+type ClientConnector interface {
+	// ConnectClients opens a pool of the given number of connections for each
+	// of the clients. The operations issued with a context carrying a client
+	// (see dbutils.WithClient) go through its pool, the others through the
+	// shared pool. The pools are closed by Cleanup.
+	ConnectClients(ctx context.Context, clients, connections int) error
+}
+
+// connectClients gives every client its own connection pool, unless there is
+// a single client or the pool is shared on request
+func (r *Runner) connectClients(ctx context.Context) error {
+	connector, ok := r.Adapter.(ClientConnector)
+	if !ok || r.Config.Clients <= 1 || r.Config.SharedPool {
+		return nil
+	}
+
+	start := time.Now()
+	if err := connector.ConnectClients(ctx, r.Config.Clients, r.Config.Threads); err != nil {
+		return fmt.Errorf("failed to connect the clients: %w", err)
+	}
+	r.clients = true
+	fmt.Fprintf(r.Output, "Connected %d clients with %d connections each in %v\n", r.Config.Clients, r.Config.Threads, time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+// clientContext returns the context of the operations of a worker, carrying
+// its client when the clients are connected separately. The workers are dealt
+// out to the clients in turn, so that parking the surplus workers of a lower
+// thread count leaves every client with the same number of threads.
+func (r *Runner) clientContext(ctx context.Context, worker int) context.Context {
+	if !r.clients {
+		return ctx
+	}
+	return dbutils.WithClient(ctx, worker%r.Config.Clients)
+}
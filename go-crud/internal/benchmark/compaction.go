@@ -0,0 +1,95 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// OperationCompact represents a manual compaction or garbage collection operation
+const OperationCompact Operation = "COMPACT"
+
+// compactionSampleSize is the number of reads used to measure latency around a compaction
+const compactionSampleSize = 1000
+
+// Compactor is implemented by adapters that can trigger background maintenance
+// (LSM compaction, vacuuming, table optimisation) on demand
+type Compactor interface {
+	// Compact runs a full manual compaction and returns once it has completed
+	Compact(ctx context.Context) error
+}
+
+// runCompaction triggers a manual compaction and reports its duration along
+// with the read latency measured before and after it
+func (r *Runner) runCompaction(ctx context.Context) error {
+	compactor, ok := r.Adapter.(Compactor)
+	if !ok {
+		return fmt.Errorf("database %s does not support manual compaction", r.Adapter.Name())
+	}
+
+	fmt.Fprintf(r.Output, "Running COMPACT benchmark...\n")
+
+	// Measure read latency before compaction
+	before, err := r.sampleReadLatency(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to measure read latency before compaction: %w", err)
+	}
+
+	// Trigger compaction
+	startTime := time.Now()
+	if err := compactor.Compact(ctx); err != nil {
+		return fmt.Errorf("failed to compact database: %w", err)
+	}
+	duration := time.Since(startTime)
+
+	// Measure read latency after compaction
+	after, err := r.sampleReadLatency(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to measure read latency after compaction: %w", err)
+	}
+
+	// Record result
+	r.Results = append(r.Results, Result{
+		Operation: OperationCompact,
+		Name:      "compact",
+		Duration:  duration,
+		Count:     1,
+		Metrics: map[string]float64{
+			"read_mean_before_us": before,
+			"read_mean_after_us":  after,
+		},
+	})
+
+	fmt.Fprintf(r.Output, "COMPACT completed in %v (read mean latency %.1fµs -> %.1fµs)\n", duration, before, after)
+	return nil
+}
+
+// sampleReadLatency reads a sample of the created keys sequentially and
+// returns the mean read latency in microseconds
+func (r *Runner) sampleReadLatency(ctx context.Context) (float64, error) {
+	count := len(r.keys)
+	if count > compactionSampleSize {
+		count = compactionSampleSize
+	}
+	if count == 0 {
+		return 0, nil
+	}
+
+	ks, err := r.newKeySet(r.keys)
+	if err != nil {
+		return 0, err
+	}
+
+	// Spread the sample evenly across the key space
+	step := len(r.keys) / count
+	var total time.Duration
+	for i := 0; i < count; i++ {
+		start := time.Now()
+		if _, err := ks.read(ctx, i*step); err != nil {
+			return 0, err
+		}
+		total += time.Since(start)
+	}
+
+	return meanMicros(int64(total), int64(count)), nil
+}
@@ -0,0 +1,56 @@
+package benchmark
+
+import "fmt"
+
+// churnWarningRatio is the fraction of operations opening a new connection above
+// which the connection pool is reported as reconnecting too often
+const churnWarningRatio = 0.01
+
+// ConnectionStatsProvider is implemented by network adapters which can report
+// connection pool and connection churn statistics
+type ConnectionStatsProvider interface {
+	// ConnectionStats returns cumulative connection counters
+	ConnectionStats() map[string]float64
+}
+
+// connectionStats returns a snapshot of the adapter connection counters
+func (r *Runner) connectionStats() map[string]float64 {
+	provider, ok := r.Adapter.(ConnectionStatsProvider)
+	if !ok {
+		return nil
+	}
+	return provider.ConnectionStats()
+}
+
+// recordConnectionStats attaches the connection counters accumulated since the
+// given snapshot to the results recorded from index onwards
+func (r *Runner) recordConnectionStats(before map[string]float64, index int) {
+	if before == nil {
+		return
+	}
+	after := r.connectionStats()
+
+	for i := index; i < len(r.Results); i++ {
+		result := &r.Results[i]
+		if result.Metrics == nil {
+			result.Metrics = map[string]float64{}
+		}
+		for name, value := range after {
+			switch name {
+			case "conn_open":
+				// Gauges are reported as-is
+				result.Metrics[name] = value
+			default:
+				// Counters are reported as the delta over the phase
+				result.Metrics[name] = value - before[name]
+			}
+		}
+
+		// Warn about pools which reconnect for a significant share of operations
+		opened := result.Metrics["conn_opened"]
+		if result.Count > 0 && opened/float64(result.Count) > churnWarningRatio {
+			fmt.Fprintf(r.Output, "Warning: %s opened %.0f connections for %d operations, the connection pool may be reconnecting per operation\n",
+				result.Name, opened, result.Count)
+		}
+	}
+}
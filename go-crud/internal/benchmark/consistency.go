@@ -0,0 +1,144 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/surrealdb/go-crud-bench/internal/dbutils"
+	"github.com/surrealdb/go-crud-bench/internal/generators"
+)
+
+// OperationProbe represents a read-after-write consistency probe
+const OperationProbe Operation = "PROBE"
+
+// probeField is the value field carrying the marker written by each probe
+const probeField = "_probe"
+
+// probeTimeout is how long a probe waits for its write to become visible
+const probeTimeout = 10 * time.Second
+
+// Bounds of the backoff between the reads polling a probe, which doubles
+// after every stale read so that a lagging replica is not read in a busy loop
+const (
+	probeMinBackoff = 50 * time.Microsecond
+	probeMaxBackoff = 5 * time.Millisecond
+)
+
+// ProbeOpener is implemented by adapters that can read through a connection
+// other than the write pool, either a separate session or a read replica
+type ProbeOpener interface {
+	// OpenProbe opens a dedicated read connection to the given endpoint, or to
+	// the benchmarked database when the endpoint is empty
+	OpenProbe(ctx context.Context, endpoint string) (*dbutils.ProbeConn, error)
+}
+
+// runConsistencyProbe writes a unique marker to existing records and polls
+// until each marker is readable, reporting the visibility lag distribution
+func (r *Runner) runConsistencyProbe(ctx context.Context) error {
+	probes := r.Config.ConsistencyProbes
+	if len(r.keys) == 0 {
+		return fmt.Errorf("no records available for the consistency probe")
+	}
+	// The probe connections read the markers back by their string keys
+	if err := r.requireStringKeys("consistency probes"); err != nil {
+		return err
+	}
+
+	// Read through a dedicated connection or replica when the adapter allows it
+	read := r.Adapter.Read
+	source := "same connection pool"
+	if opener, ok := r.Adapter.(ProbeOpener); ok {
+		conn, err := opener.OpenProbe(ctx, r.Config.ProbeEndpoint)
+		if err != nil {
+			return fmt.Errorf("failed to open probe connection: %w", err)
+		}
+		defer conn.Close()
+		read = conn.Read
+		source = "separate connection"
+		if r.Config.ProbeEndpoint != "" {
+			source = "probe endpoint"
+		}
+	} else if r.Config.ProbeEndpoint != "" {
+		return fmt.Errorf("database %s does not support reading from a probe endpoint", r.Adapter.Name())
+	}
+
+	fmt.Fprintf(r.Output, "Running PROBE read-after-write benchmark with %d probes (%s)...\n", probes, source)
+
+	// Parse the value template
+	valueTemplate, err := generators.ParseTemplate(r.Config.Value)
+	if err != nil {
+		return fmt.Errorf("failed to process value template: %w", err)
+	}
+
+	startTime := time.Now()
+	rec := r.newRecorder()
+	var staleReads, firstRead int64
+
+	for i := 0; i < probes; i++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		key := r.keys[i%len(r.keys)]
+		marker := fmt.Sprintf("%d-%d", i, time.Now().UnixNano())
+		value := generators.NewValue(generators.RecordRandom("probe", i), valueTemplate)
+		value[probeField] = marker
+
+		err := r.invoke(ctx, &Call{Operation: OperationUpdate, Key: key, Value: value}, func(ctx context.Context, call *Call) error {
+			return r.Adapter.Update(ctx, call.Key, call.Value)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write probe %d: %w", i, err)
+		}
+		written := time.Now()
+
+		// Poll until the marker is visible, measuring the lag to the start of
+		// the first read which observed it
+		var lag time.Duration
+		var probeErr error
+		backoff := probeMinBackoff
+		for attempt := 0; ; attempt++ {
+			readStart := time.Now()
+			got, err := read(ctx, key)
+			if err == nil && got[probeField] == marker {
+				lag = readStart.Sub(written)
+				if attempt == 0 {
+					firstRead++
+				}
+				break
+			}
+			staleReads++
+			if time.Since(written) > probeTimeout {
+				probeErr = fmt.Errorf("probe %d not visible after %v", i, probeTimeout)
+				break
+			}
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			backoff = min(backoff*2, probeMaxBackoff)
+		}
+		rec.observe(lag, probeErr)
+	}
+
+	// Record result
+	duration := time.Since(startTime)
+	result := Result{
+		Operation: OperationProbe,
+		Name:      "read_after_write",
+		Duration:  duration,
+		Count:     probes,
+		Metrics: map[string]float64{
+			"stale_reads":        float64(staleReads),
+			"visible_first_read": float64(firstRead) / float64(probes),
+		},
+	}
+	rec.apply(&result)
+	r.Results = append(r.Results, result)
+
+	fmt.Fprintf(r.Output, "PROBE completed in %v (%.1f%% visible on first read, %d stale reads)\n",
+		duration, float64(firstRead)/float64(probes)*100, staleReads)
+	return nil
+}
@@ -0,0 +1,244 @@
+package benchmark
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// controlPoll is how often a worker parked by a lowered thread count checks
+// whether it may resume
+const controlPoll = 50 * time.Millisecond
+
+// maxAnnotation is the maximum length of an annotation sent through the
+// control socket
+const maxAnnotation = 200
+
+// Annotation is a change made to the running benchmark through the control
+// socket, such as a new target rate, or an external event such as a failover
+// reported through it
+type Annotation struct {
+	Time     time.Time `json:"time"`
+	Phase    string    `json:"phase"`
+	Text     string    `json:"text"`
+	External bool      `json:"external,omitempty"`
+}
+
+// ControlStatus is the state of the running benchmark reported by the
+// control socket
+type ControlStatus struct {
+	Phase   string `json:"phase"`
+	Rate    int    `json:"rate"`
+	Clients int    `json:"clients"`
+	Threads int    `json:"threads"`
+}
+
+// control holds the target rate and thread count set through the control
+// socket. They are applied to the pacer and workers of every phase as it
+// starts, leaving the configuration of the run as it was given, and the
+// thread count of every phase is recorded in its results. A new rate also applies to
+// the running phase, and so does a lower thread count in phases running for a
+// number of steps or a duration, where the workers above it are parked.
+type control struct {
+	mu      sync.Mutex
+	phase   string
+	rate    int
+	clients int
+	threads atomic.Int64
+}
+
+// ControlServer serves the control socket of a running benchmark
+type ControlServer struct {
+	runner *Runner
+	path   string
+	server *http.Server
+}
+
+// ListenControl serves a control socket at the given path, through which
+// crud-bench ctl changes the target rate and thread count of the running
+// benchmark. It must be called before the benchmark runs.
+func (r *Runner) ListenControl(path string) (*ControlServer, error) {
+	// Replace a socket left behind by a benchmark which is no longer running
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("control socket %s is in use by another benchmark", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove stale control socket: %w", err)
+		}
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on control socket: %w", err)
+	}
+
+	c := &control{rate: r.Config.Rate, clients: r.Config.Clients}
+	c.threads.Store(int64(r.Config.Threads))
+	r.control = c
+
+	s := &ControlServer{runner: r, path: path}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /status", s.handleStatus)
+	mux.HandleFunc("POST /rate", s.handleRate)
+	mux.HandleFunc("POST /threads", s.handleThreads)
+	mux.HandleFunc("POST /annotate", s.handleAnnotate)
+	s.server = &http.Server{Handler: mux}
+	go s.server.Serve(listener)
+	return s, nil
+}
+
+// Close stops serving the control socket and removes it
+func (s *ControlServer) Close() error {
+	err := s.server.Close()
+	if removeErr := os.Remove(s.path); removeErr != nil && !errors.Is(removeErr, os.ErrNotExist) && err == nil {
+		err = removeErr
+	}
+	return err
+}
+
+// handleStatus reports the running phase and the current settings
+func (s *ControlServer) handleStatus(w http.ResponseWriter, req *http.Request) {
+	s.writeStatus(w)
+}
+
+// handleRate sets the target rate, 0 running at saturation
+func (s *ControlServer) handleRate(w http.ResponseWriter, req *http.Request) {
+	rate, err := strconv.Atoi(req.URL.Query().Get("value"))
+	if err != nil || rate < 0 {
+		http.Error(w, "rate must be a non-negative number of operations per second", http.StatusBadRequest)
+		return
+	}
+
+	r, c := s.runner, s.runner.control
+	c.mu.Lock()
+	c.rate = rate
+	if p := r.pacer.Load(); p != nil {
+		p.setRate(rate)
+	}
+	c.mu.Unlock()
+
+	if rate == 0 {
+		r.annotate("rate set to saturation")
+	} else {
+		r.annotate(fmt.Sprintf("rate set to %d ops/sec", rate))
+	}
+	s.writeStatus(w)
+}
+
+// handleThreads sets the number of threads per client
+func (s *ControlServer) handleThreads(w http.ResponseWriter, req *http.Request) {
+	threads, err := strconv.Atoi(req.URL.Query().Get("value"))
+	if err != nil || threads < 1 {
+		http.Error(w, "threads must be a positive number", http.StatusBadRequest)
+		return
+	}
+
+	s.runner.control.threads.Store(int64(threads))
+	s.runner.annotate(fmt.Sprintf("threads set to %d per client", threads))
+	s.writeStatus(w)
+}
+
+// handleAnnotate records an external event, such as a failover or a
+// deployment, on the timeline of the benchmark
+func (s *ControlServer) handleAnnotate(w http.ResponseWriter, req *http.Request) {
+	text := strings.TrimSpace(req.FormValue("text"))
+	if text == "" || len(text) > maxAnnotation {
+		http.Error(w, fmt.Sprintf("annotation text must be between 1 and %d characters", maxAnnotation), http.StatusBadRequest)
+		return
+	}
+
+	annotation := s.runner.addAnnotation(text, true)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(annotation)
+}
+
+// writeStatus responds with the status of the benchmark
+func (s *ControlServer) writeStatus(w http.ResponseWriter) {
+	c := s.runner.control
+	c.mu.Lock()
+	status := ControlStatus{Phase: c.phase, Rate: c.rate, Clients: c.clients, Threads: int(c.threads.Load())}
+	c.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
+// startControl applies the settings changed through the control socket to
+// the phase about to start, and starts its pacer
+func (r *Runner) startControl(phase string) {
+	c := r.control
+	if c == nil {
+		r.startPacer(r.Config.Rate)
+		return
+	}
+
+	// Hold the lock so that a rate set meanwhile reaches the new pacer
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.phase = phase
+	r.threads = int(c.threads.Load())
+	r.startPacer(c.rate)
+}
+
+// recordThreads attaches the threads per client of a phase run with a control
+// socket to its results, as the count may differ from the configured one
+func (r *Runner) recordThreads(index int) {
+	if r.control == nil {
+		return
+	}
+	for i := index; i < len(r.Results); i++ {
+		result := &r.Results[i]
+		if result.Metrics == nil {
+			result.Metrics = map[string]float64{}
+		}
+		result.Metrics["threads"] = float64(r.threads)
+	}
+}
+
+// admit parks a worker of a phase running for a number of steps or a
+// duration while the thread count set through the control socket leaves it
+// idle, returning once it may resume or the phase is over
+func (r *Runner) admit(ctx context.Context, worker int, s *steps) {
+	c := r.control
+	if c == nil {
+		return
+	}
+	for worker >= c.clients*int(c.threads.Load()) && ctx.Err() == nil && !s.over() {
+		time.Sleep(controlPoll)
+	}
+}
+
+// annotate records a change made to the running benchmark
+func (r *Runner) annotate(text string) {
+	r.addAnnotation(text, false)
+}
+
+// addAnnotation records an annotation of the running phase and passes it on
+// to the observer
+func (r *Runner) addAnnotation(text string, external bool) Annotation {
+	c := r.control
+	c.mu.Lock()
+	annotation := Annotation{Time: time.Now(), Phase: c.phase, Text: text, External: external}
+	r.Annotations = append(r.Annotations, annotation)
+	c.mu.Unlock()
+
+	if external {
+		fmt.Fprintf(r.Output, "Annotation: %s during %s\n", text, annotation.Phase)
+	} else {
+		fmt.Fprintf(r.Output, "Control: %s during %s\n", text, annotation.Phase)
+	}
+	if annotator, ok := r.Observer.(Annotator); ok {
+		annotator.Annotate(annotation)
+	}
+	return annotation
+}
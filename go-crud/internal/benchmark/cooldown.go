@@ -0,0 +1,64 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// loadPollInterval is how often the host load average is checked while waiting
+const loadPollInterval = 5 * time.Second
+
+// Cooldown waits between consecutive benchmark runs so that they do not
+// contaminate each other through page cache pressure or thermal throttling.
+// It sleeps for the given duration and then, when maxLoad is positive, waits
+// until the host 1-minute load average has dropped below maxLoad, printing
+// what it waits for to w.
+func Cooldown(ctx context.Context, w io.Writer, duration time.Duration, maxLoad float64) error {
+	if duration > 0 {
+		fmt.Fprintf(w, "Cooling down for %v...\n", duration)
+		select {
+		case <-time.After(duration):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if maxLoad <= 0 {
+		return nil
+	}
+
+	for {
+		load, err := LoadAverage()
+		if err != nil {
+			return fmt.Errorf("failed to read host load average: %w", err)
+		}
+		if load < maxLoad {
+			return nil
+		}
+
+		fmt.Fprintf(w, "Waiting for load average %.2f to drop below %.2f...\n", load, maxLoad)
+		select {
+		case <-time.After(loadPollInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// LoadAverage returns the 1-minute host load average
+func LoadAverage() (float64, error) {
+	data, err := os.ReadFile("/proc/loadavg")
+	if err != nil {
+		return 0, err
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("unexpected /proc/loadavg format")
+	}
+	return strconv.ParseFloat(fields[0], 64)
+}
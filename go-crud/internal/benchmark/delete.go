@@ -0,0 +1,168 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DeleteMode selects how the delete phase removes the records
+type DeleteMode string
+
+const (
+	// DeleteModeRow deletes the records one key at a time
+	DeleteModeRow DeleteMode = "row"
+	// DeleteModeBatch deletes the records in batches of keys per request
+	DeleteModeBatch DeleteMode = "batch"
+	// DeleteModeTruncate removes every record with a single request
+	DeleteModeTruncate DeleteMode = "truncate"
+)
+
+// BatchDeleter is implemented by adapters that can delete several records in
+// a single request (SQL IN lists, Redis DEL, MongoDB $in)
+type BatchDeleter interface {
+	// DeleteBatch removes the records with the given keys
+	DeleteBatch(ctx context.Context, keys []string) error
+}
+
+// Truncater is implemented by adapters that can remove every record of the
+// benchmark table at once (TRUNCATE or an equivalent)
+type Truncater interface {
+	// Truncate removes every record, keeping the table usable
+	Truncate(ctx context.Context) error
+}
+
+// runDeleteBatched deletes every record in batches of the configured size,
+// reporting records per second and the latency per batch
+func (r *Runner) runDeleteBatched(ctx context.Context) error {
+	size := r.Config.DeleteBatch
+
+	deleter, ok := r.Adapter.(BatchDeleter)
+	if !ok {
+		return fmt.Errorf("database %s does not support batched deletes", r.Adapter.Name())
+	}
+	if err := r.requireStringKeys("batched deletes"); err != nil {
+		return err
+	}
+
+	if len(r.keys) == 0 {
+		return fmt.Errorf("no records available for batched deletes")
+	}
+	keys, err := r.deleteKeys(r.keys)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(r.Output, "Running DELETE benchmark with %d samples in batches of %d...\n", len(keys), size)
+
+	// Start timer
+	startTime := time.Now()
+	rec := r.newRecorder()
+
+	var wg sync.WaitGroup
+	workers := r.Config.Clients * r.threads
+	errCh := make(chan error, workers)
+	batches := (len(keys) + size - 1) / size
+	steps := &steps{total: batches}
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			ctx := r.clientContext(ctx, w)
+
+			for {
+				r.admit(ctx, w, steps)
+				b, ok := steps.take()
+				if !ok {
+					return
+				}
+				if ctx.Err() != nil {
+					errCh <- ctx.Err()
+					return
+				}
+
+				start := b * size
+				end := start + size
+				if end > len(keys) {
+					end = len(keys)
+				}
+
+				opStart := r.pace(ctx)
+				err := r.invoke(ctx, &Call{Operation: OperationDelete, Keys: keys[start:end]}, func(ctx context.Context, call *Call) error {
+					return deleter.DeleteBatch(ctx, call.Keys)
+				})
+				rec.observe(time.Since(opStart), err)
+				if err != nil {
+					errCh <- fmt.Errorf("failed to delete batch %d: %w", b, err)
+					return
+				}
+			}
+		}()
+	}
+
+	// Wait for all goroutines to finish
+	wg.Wait()
+
+	// Check for errors
+	close(errCh)
+	for err := range errCh {
+		if err != nil {
+			return err
+		}
+	}
+
+	// Record result, counting records rather than batches
+	duration := time.Since(startTime)
+	result := Result{
+		Operation: OperationDelete,
+		Name:      "delete_all",
+		Duration:  duration,
+		Count:     len(keys),
+		Metrics: map[string]float64{
+			"batch_size": float64(size),
+			"batches":    float64(batches),
+		},
+	}
+	rec.apply(&result)
+	r.Results = append(r.Results, result)
+
+	fmt.Fprintf(r.Output, "DELETE completed in %v\n", duration)
+	return nil
+}
+
+// runTruncate removes every record with a single truncate request
+func (r *Runner) runTruncate(ctx context.Context) error {
+	truncater, ok := r.Adapter.(Truncater)
+	if !ok {
+		return fmt.Errorf("database %s does not support truncating", r.Adapter.Name())
+	}
+
+	fmt.Fprintf(r.Output, "Running DELETE benchmark truncating %d samples...\n", r.Config.Samples)
+
+	// Start timer
+	startTime := time.Now()
+	rec := r.newRecorder()
+
+	err := truncater.Truncate(ctx)
+	rec.observe(time.Since(startTime), err)
+	if err != nil {
+		return fmt.Errorf("failed to truncate: %w", err)
+	}
+
+	// Record result, counting the records removed
+	duration := time.Since(startTime)
+	result := Result{
+		Operation: OperationDelete,
+		Name:      "delete_all",
+		Duration:  duration,
+		Count:     r.Config.Samples,
+	}
+	rec.apply(&result)
+	r.Results = append(r.Results, result)
+
+	fmt.Fprintf(r.Output, "DELETE completed in %v\n", duration)
+	return nil
+}
@@ -0,0 +1,104 @@
+package benchmark
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DataDirProvider is implemented by adapters whose managed container stores
+// the database data in a known directory
+type DataDirProvider interface {
+	// DataDir returns the directory holding the database data inside the
+	// container, or an empty string when the data is held in memory
+	DataDir() string
+}
+
+// measureDiskUsage adds the disk space used by the database once the create
+// phase has loaded it to the metrics of the result, together with the space
+// per record and the storage amplification over the logical dataset size.
+// Databases holding their data in memory or behind an endpoint are skipped.
+func (r *Runner) measureDiskUsage(ctx context.Context, result *Result) {
+	size, ok, err := r.diskUsage(ctx)
+	if err != nil {
+		fmt.Fprintf(r.Output, "Warning: failed to measure disk usage: %v\n", err)
+		return
+	}
+	if !ok {
+		return
+	}
+
+	if result.Metrics == nil {
+		result.Metrics = make(map[string]float64)
+	}
+	result.Metrics["disk_mb"] = float64(size) / (1 << 20)
+	if r.Config.Samples > 0 {
+		result.Metrics["disk_bytes_per_record"] = float64(size) / float64(r.Config.Samples)
+	}
+	line := fmt.Sprintf("Disk usage after load: %s", formatBytes(uint64(size)))
+	if p := r.Profile; p != nil && p.LogicalBytes > 0 {
+		amplification := float64(size) / float64(p.LogicalBytes)
+		result.Metrics["storage_amplification"] = amplification
+		line += fmt.Sprintf(", %.2fx the logical dataset size", amplification)
+	}
+	fmt.Fprintln(r.Output, line)
+}
+
+// diskUsage returns the bytes the database occupies on disk: the size of the
+// data files of embedded adapters, or the usage of the data directory of the
+// managed container. It reports false when the usage cannot be measured.
+func (r *Runner) diskUsage(ctx context.Context) (int64, bool, error) {
+	if provider, ok := r.Adapter.(DataFileProvider); ok {
+		paths := provider.DataFiles()
+		if len(paths) == 0 {
+			return 0, false, nil
+		}
+		var total int64
+		for _, path := range paths {
+			if err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+				// Files such as write-ahead logs only exist once written to
+				if errors.Is(err, fs.ErrNotExist) {
+					return nil
+				}
+				if err != nil || d.IsDir() {
+					return err
+				}
+				info, err := d.Info()
+				if err != nil {
+					return err
+				}
+				total += allocatedSize(info)
+				return nil
+			}); err != nil {
+				return 0, false, fmt.Errorf("failed to measure %s: %w", path, err)
+			}
+		}
+		return total, true, nil
+	}
+
+	provider, ok := r.Adapter.(ContainerProvider)
+	if !ok || provider.Container() == nil {
+		return 0, false, nil
+	}
+	dirs, ok := r.Adapter.(DataDirProvider)
+	if !ok || dirs.DataDir() == "" {
+		return 0, false, nil
+	}
+	output, err := provider.Container().Exec(ctx, []string{"du", "-sk", dirs.DataDir()})
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to run du in the container: %w", err)
+	}
+	fields := strings.Fields(output)
+	if len(fields) == 0 {
+		return 0, false, fmt.Errorf("unexpected du output %q", output)
+	}
+	kb, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("unexpected du output %q", output)
+	}
+	return kb << 10, true, nil
+}
@@ -0,0 +1,11 @@
+//go:build !unix
+
+package benchmark
+
+import "io/fs"
+
+// allocatedSize returns the apparent size of a file, as the allocated disk
+// space is not reported on this platform
+func allocatedSize(info fs.FileInfo) int64 {
+	return info.Size()
+}
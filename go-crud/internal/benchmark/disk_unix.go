@@ -0,0 +1,17 @@
+//go:build unix
+
+package benchmark
+
+import (
+	"io/fs"
+	"syscall"
+)
+
+// allocatedSize returns the disk space allocated to a file like du, rather
+// than its apparent size, as engines preallocate sparse log files
+func allocatedSize(info fs.FileInfo) int64 {
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		return stat.Blocks * 512
+	}
+	return info.Size()
+}
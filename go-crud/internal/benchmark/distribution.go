@@ -0,0 +1,49 @@
+package benchmark
+
+import (
+	"fmt"
+
+	"github.com/surrealdb/go-crud-bench/internal/generators"
+)
+
+// accessKeys returns the keys accessed in turn by the operations of a read or
+// update phase. With the uniform distribution every key is accessed once,
+// and otherwise as many keys are picked following the configured
+// distribution, so that popular keys are accessed repeatedly.
+func (r *Runner) accessKeys(keys []string) ([]string, error) {
+	if r.Config.Distribution == "" || r.Config.Distribution == "uniform" {
+		return keys, nil
+	}
+	chooser, err := generators.NewKeyChooser(r.Config.Distribution, len(keys))
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintf(r.Output, "Accessing keys following the %s distribution\n", r.Config.Distribution)
+	accessed := make([]string, len(keys))
+	for i := range accessed {
+		accessed[i] = keys[chooser.Next()]
+	}
+	return accessed, nil
+}
+
+// deleteKeys returns the keys in the order a delete phase removes them. With
+// the uniform distribution they are removed in the order they were created,
+// and otherwise in the order the configured distribution first picks them,
+// so that the popular keys are removed first while every key is removed once.
+func (r *Runner) deleteKeys(keys []string) ([]string, error) {
+	if r.Config.Distribution == "" || r.Config.Distribution == "uniform" {
+		return keys, nil
+	}
+	chooser, err := generators.NewKeyChooser(r.Config.Distribution, len(keys))
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintf(r.Output, "Deleting keys in the order of the %s distribution\n", r.Config.Distribution)
+	ordered := make([]string, len(keys))
+	for i, j := range generators.AccessOrder(chooser, len(keys)) {
+		ordered[i] = keys[j]
+	}
+	return ordered, nil
+}
@@ -0,0 +1,190 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/surrealdb/go-crud-bench/internal/generators"
+)
+
+// steps hands out the steps of a phase to its workers: a fixed number of
+// steps, or as many as the workers take until the configured duration elapses
+type steps struct {
+	total    int
+	deadline time.Time
+	next     atomic.Int64
+	taken    atomic.Int64
+}
+
+// newSteps creates the steps of a phase, running for the configured duration
+// if there is one and otherwise the given number of steps
+func (r *Runner) newSteps(total int) *steps {
+	s := &steps{total: total}
+	if r.Config.Duration > 0 {
+		s.deadline = time.Now().Add(r.Config.Duration)
+	}
+	return s
+}
+
+// take returns the next step, and false once the phase is over
+func (s *steps) take() (int, bool) {
+	if !s.deadline.IsZero() && !time.Now().Before(s.deadline) {
+		return 0, false
+	}
+	i := int(s.next.Add(1)) - 1
+	if s.deadline.IsZero() && i >= s.total {
+		return 0, false
+	}
+	s.taken.Add(1)
+	return i, true
+}
+
+// over reports whether the phase is over, the duration having elapsed or
+// every step having been taken
+func (s *steps) over() bool {
+	if !s.deadline.IsZero() {
+		return !time.Now().Before(s.deadline)
+	}
+	return int(s.next.Load()) >= s.total
+}
+
+// count returns the number of steps taken
+func (s *steps) count() int {
+	return int(s.taken.Load())
+}
+
+// runReadTimed reads the records until the configured duration elapses
+func (r *Runner) runReadTimed(ctx context.Context) error {
+	ks, err := r.timedKeySet()
+	if err != nil {
+		return err
+	}
+
+	return r.runTimed(ctx, ks, OperationRead, "read_all", func(ctx context.Context, i int, rec *recorder) error {
+		opStart := r.pace(ctx)
+		_, err := ks.read(ctx, i)
+		rec.observe(time.Since(opStart), err)
+		return err
+	})
+}
+
+// runUpdateTimed updates the records until the configured duration elapses
+func (r *Runner) runUpdateTimed(ctx context.Context) error {
+	ks, err := r.timedKeySet()
+	if err != nil {
+		return err
+	}
+
+	// Parse the value template
+	valueTemplate, err := generators.ParseTemplate(r.Config.Value)
+	if err != nil {
+		return fmt.Errorf("failed to process value template: %w", err)
+	}
+
+	return r.runTimed(ctx, ks, OperationUpdate, "update_all", func(ctx context.Context, i int, rec *recorder) error {
+		// Generate a unique value for this record
+		value := generators.NewValue(generators.RecordRandom("update", i), valueTemplate)
+		payload, err := ks.encode(value)
+		if err != nil {
+			return err
+		}
+
+		opStart := r.pace(ctx)
+		err = ks.update(ctx, i, value, payload)
+		rec.observe(time.Since(opStart), err)
+		return err
+	})
+}
+
+// timedKeySet prepares the keys of the records created by the create phase,
+// accessed following the configured distribution
+func (r *Runner) timedKeySet() (*keySet, error) {
+	// Generate keys (same order as create)
+	keys, err := generators.GenerateKeys(r.Config.KeyType, r.Config.Samples, r.Config.Random)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate keys: %w", err)
+	}
+	keys, err = r.accessKeys(keys)
+	if err != nil {
+		return nil, err
+	}
+	return r.newKeySet(keys)
+}
+
+// runTimed runs an operation on the records of a phase until the configured
+// duration elapses, cycling through the keys, and records the number of
+// operations completed. The operation is issued with the context of the worker
+// and observes its own latency, so that the generation of values is not
+// measured.
+func (r *Runner) runTimed(ctx context.Context, ks *keySet, operation Operation, name string, op func(ctx context.Context, i int, rec *recorder) error) error {
+	fmt.Fprintf(r.Output, "Running %s benchmark for %v on %d samples...\n", operation, r.Config.Duration, len(ks.keys))
+
+	// Start timer
+	startTime := time.Now()
+	rec := r.newRecorder()
+	steps := r.newSteps(0)
+
+	var wg sync.WaitGroup
+	workers := r.Config.Clients * r.threads
+	errCh := make(chan error, workers)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			ctx := r.clientContext(ctx, w)
+
+			for {
+				r.admit(ctx, w, steps)
+				step, ok := steps.take()
+				if !ok {
+					return
+				}
+				if ctx.Err() != nil {
+					errCh <- ctx.Err()
+					return
+				}
+
+				i := step % len(ks.keys)
+				if err := op(ctx, i, rec); err != nil {
+					errCh <- fmt.Errorf("failed to %s record %d: %w", strings.ToLower(string(operation)), i, err)
+					return
+				}
+			}
+		}()
+	}
+
+	// Wait for all goroutines to finish
+	wg.Wait()
+
+	// Check for errors
+	close(errCh)
+	for err := range errCh {
+		if err != nil {
+			return err
+		}
+	}
+
+	// Record result
+	duration := time.Since(startTime)
+	count := steps.count()
+	result := Result{
+		Operation: operation,
+		Name:      name,
+		Duration:  duration,
+		Count:     count,
+		Metrics: map[string]float64{
+			"cycles": float64(count) / float64(len(ks.keys)),
+		},
+	}
+	rec.apply(&result)
+	r.Results = append(r.Results, result)
+
+	fmt.Fprintf(r.Output, "%s completed in %v: %d operations\n", operation, duration, count)
+	return nil
+}
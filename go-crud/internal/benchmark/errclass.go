@@ -0,0 +1,100 @@
+package benchmark
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"syscall"
+)
+
+// ErrorClass is the class of a failed operation, reported per phase so that
+// error counts show what went wrong
+type ErrorClass string
+
+const (
+	// ErrorClassTimeout is an operation which timed out, on the client or the server
+	ErrorClassTimeout ErrorClass = "timeout"
+	// ErrorClassConnection is a refused, reset or lost connection, or a server
+	// refusing new connections
+	ErrorClassConnection ErrorClass = "connection"
+	// ErrorClassConstraint is a violated unique, foreign key or other constraint
+	ErrorClassConstraint ErrorClass = "constraint"
+	// ErrorClassConflict is a serialization conflict or deadlock, which can be retried
+	ErrorClassConflict ErrorClass = "conflict"
+	// ErrorClassUnsupported is an operation the database does not support
+	ErrorClassUnsupported ErrorClass = "unsupported"
+	// ErrorClassCrashed is any error once the managed database container stopped
+	ErrorClassCrashed ErrorClass = "crashed"
+	// ErrorClassUnknown is any other error
+	ErrorClassUnknown ErrorClass = "unknown"
+)
+
+// ErrorClasses lists the error classes in the order they are reported
+var ErrorClasses = []ErrorClass{
+	ErrorClassTimeout,
+	ErrorClassConnection,
+	ErrorClassConstraint,
+	ErrorClassConflict,
+	ErrorClassUnsupported,
+	ErrorClassCrashed,
+	ErrorClassUnknown,
+}
+
+// ErrorClassifier is implemented by adapters which can map the errors of
+// their driver to an error class
+type ErrorClassifier interface {
+	// ClassifyError returns the class of an error, or an empty class to fall
+	// back to the classification of network and context errors
+	ClassifyError(err error) ErrorClass
+}
+
+// classifyError returns the class of an error, asking the adapter first
+// unless the managed database container crashed
+func (r *Runner) classifyError(err error) ErrorClass {
+	if r.crashed.Load() {
+		return ErrorClassCrashed
+	}
+	if classifier, ok := r.Adapter.(ErrorClassifier); ok {
+		if class := classifier.ClassifyError(err); class != "" {
+			return class
+		}
+	}
+	return classifyCommonError(err)
+}
+
+// classifyCommonError classifies the context, network and driver errors
+// common to every database
+func classifyCommonError(err error) ErrorClass {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, os.ErrDeadlineExceeded):
+		return ErrorClassTimeout
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return ErrorClassTimeout
+	case errors.Is(err, errors.ErrUnsupported):
+		return ErrorClassUnsupported
+	case errors.Is(err, syscall.ECONNREFUSED), errors.Is(err, syscall.ECONNRESET), errors.Is(err, syscall.EPIPE),
+		errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, driver.ErrBadConn):
+		return ErrorClassConnection
+	case errors.As(err, new(*net.OpError)):
+		return ErrorClassConnection
+	default:
+		return ErrorClassUnknown
+	}
+}
+
+// formatErrorClasses formats the error counts per class in report order
+func formatErrorClasses(classes map[ErrorClass]int64) string {
+	parts := make([]string, 0, len(classes))
+	for _, class := range ErrorClasses {
+		if count := classes[class]; count > 0 {
+			parts = append(parts, fmt.Sprintf("%s %d", class, count))
+		}
+	}
+	return strings.Join(parts, " | ")
+}
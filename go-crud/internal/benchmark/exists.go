@@ -0,0 +1,124 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// OperationExists represents a key existence check
+const OperationExists Operation = "EXISTS"
+
+// absentKeySuffix is appended to the written keys to derive keys which do not exist
+const absentKeySuffix = "-absent"
+
+// ExistenceChecker is implemented by adapters that can check whether a key
+// exists without fetching its value (SELECT 1, Redis EXISTS, HEAD requests)
+type ExistenceChecker interface {
+	// Exists reports whether a record with the given key exists
+	Exists(ctx context.Context, key string) (bool, error)
+}
+
+// runExists checks the existence of every written key, and of as many keys
+// which were never written, as membership checks commonly miss
+func (r *Runner) runExists(ctx context.Context) error {
+	checker, ok := r.Adapter.(ExistenceChecker)
+	if !ok {
+		return fmt.Errorf("database %s does not support existence checks", r.Adapter.Name())
+	}
+	if err := r.requireStringKeys("existence checks"); err != nil {
+		return err
+	}
+
+	keys := r.keys
+	if len(keys) == 0 {
+		return fmt.Errorf("no records available for existence checks")
+	}
+
+	fmt.Fprintf(r.Output, "Running EXISTS benchmark with %d samples...\n", len(keys))
+
+	if err := r.runExistsPass(ctx, checker, "exists_present", keys, true); err != nil {
+		return err
+	}
+
+	absent := make([]string, len(keys))
+	for i, key := range keys {
+		absent[i] = key + absentKeySuffix
+	}
+
+	return r.runExistsPass(ctx, checker, "exists_absent", absent, false)
+}
+
+// runExistsPass checks the given keys and verifies that each matches the expected existence
+func (r *Runner) runExistsPass(ctx context.Context, checker ExistenceChecker, name string, keys []string, want bool) error {
+	// Start timer
+	startTime := time.Now()
+	rec := r.newRecorder()
+
+	var wg sync.WaitGroup
+	workers := r.Config.Clients * r.threads
+	errCh := make(chan error, workers)
+	steps := &steps{total: len(keys)}
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			ctx := r.clientContext(ctx, w)
+
+			for {
+				r.admit(ctx, w, steps)
+				i, ok := steps.take()
+				if !ok {
+					return
+				}
+				if ctx.Err() != nil {
+					errCh <- ctx.Err()
+					return
+				}
+
+				opStart := r.pace(ctx)
+				var exists bool
+				err := r.invoke(ctx, &Call{Operation: OperationExists, Key: keys[i]}, func(ctx context.Context, call *Call) (err error) {
+					exists, err = checker.Exists(ctx, call.Key)
+					return err
+				})
+				if err == nil && exists != want {
+					err = fmt.Errorf("existence check returned %t, expected %t", exists, want)
+				}
+				rec.observe(time.Since(opStart), err)
+				if err != nil {
+					errCh <- fmt.Errorf("failed to check record %d: %w", i, err)
+					return
+				}
+			}
+		}()
+	}
+
+	// Wait for all goroutines to finish
+	wg.Wait()
+
+	// Check for errors
+	close(errCh)
+	for err := range errCh {
+		if err != nil {
+			return err
+		}
+	}
+
+	// Record result
+	duration := time.Since(startTime)
+	result := Result{
+		Operation: OperationExists,
+		Name:      name,
+		Duration:  duration,
+		Count:     len(keys),
+	}
+	rec.apply(&result)
+	r.Results = append(r.Results, result)
+
+	fmt.Fprintf(r.Output, "EXISTS '%s' completed in %v\n", name, duration)
+	return nil
+}
@@ -0,0 +1,58 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// GeoIndexer is implemented by adapters which need a spatial index before
+// running GEO_WITHIN scans (PostGIS GiST, MongoDB 2dsphere, Redis GEO)
+type GeoIndexer interface {
+	// CreateGeoIndex builds a spatial index on the GeoJSON points of a value field
+	CreateGeoIndex(ctx context.Context, field string) error
+
+	// DropGeoIndex removes the spatial index on a value field
+	DropGeoIndex(ctx context.Context, field string) error
+}
+
+// prepareGeo builds a spatial index on every field queried by the configured
+// geo scans, and returns a function dropping them again. Index builds are
+// reported but not timed as part of the scans.
+func (r *Runner) prepareGeo(ctx context.Context) (func(), error) {
+	indexer, ok := r.Adapter.(GeoIndexer)
+	if !ok {
+		return func() {}, nil
+	}
+
+	var fields []string
+	seen := make(map[string]bool)
+	for _, scan := range r.Config.Scans {
+		field, _, _, _, ok := scan.GeoWithin()
+		if !ok || seen[field] {
+			continue
+		}
+		seen[field] = true
+		fields = append(fields, field)
+	}
+
+	drop := func() {
+		for _, field := range fields {
+			if err := indexer.DropGeoIndex(ctx, field); err != nil {
+				fmt.Fprintf(r.Output, "Warning: failed to drop geo index on field '%s': %v\n", field, err)
+			}
+		}
+	}
+
+	for i, field := range fields {
+		start := time.Now()
+		if err := indexer.CreateGeoIndex(ctx, field); err != nil {
+			fields = fields[:i]
+			drop()
+			return nil, fmt.Errorf("failed to build geo index on field '%s': %w", field, err)
+		}
+		fmt.Fprintf(r.Output, "Geo index on field '%s' built in %v\n", field, time.Since(start))
+	}
+
+	return drop, nil
+}
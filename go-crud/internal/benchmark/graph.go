@@ -0,0 +1,195 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync/atomic"
+	"time"
+)
+
+// OperationTraverse represents a k-hop graph traversal from a record
+const OperationTraverse Operation = "TRAVERSE"
+
+// graphSeed seeds the choice of edge targets, so that every run and every
+// database traverses the same graph
+const graphSeed = 1
+
+// GraphAdapter is implemented by adapters that can store edges between
+// records and traverse them natively (SurrealDB record links, Neo4j
+// relationships, ArangoDB edge collections)
+type GraphAdapter interface {
+	// CreateEdges creates an edge from a record to each of the given records
+	CreateEdges(ctx context.Context, from string, to []string) error
+
+	// Traverse follows the edges from a record for the given number of hops,
+	// returning the number of distinct records reached by the last hop
+	Traverse(ctx context.Context, start string, hops int) (int, error)
+
+	// DropEdges removes every edge
+	DropEdges(ctx context.Context) error
+}
+
+// runGraph links every record to the configured number of other records,
+// picked at random with a fixed seed, then traverses the configured number
+// of hops from every record and verifies the records reached. Databases
+// without graph support skip the phase.
+func (r *Runner) runGraph(ctx context.Context) (err error) {
+	graph, ok := r.Adapter.(GraphAdapter)
+	if !ok {
+		fmt.Fprintf(r.Output, "Skipping graph traversals: database %s does not support graphs\n", r.Adapter.Name())
+		return nil
+	}
+	if err := r.requireStringKeys("graph traversals"); err != nil {
+		return err
+	}
+
+	keys := r.keys
+	if len(keys) == 0 {
+		return fmt.Errorf("no records available for graph traversals")
+	}
+	edges, hops := r.Config.GraphEdges, r.Config.GraphHops
+	if edges >= len(keys) {
+		return fmt.Errorf("cannot link every record to %d others with %d records", edges, len(keys))
+	}
+
+	// Pick the distinct targets of every record, never linking a record to itself
+	rng := rand.New(rand.NewSource(graphSeed))
+	adjacency := make([][]int, len(keys))
+	for i := range adjacency {
+		picked := make(map[int]bool, edges)
+		for len(adjacency[i]) < edges {
+			j := rng.Intn(len(keys))
+			if j == i || picked[j] {
+				continue
+			}
+			picked[j] = true
+			adjacency[i] = append(adjacency[i], j)
+		}
+	}
+
+	defer func() {
+		if dropErr := graph.DropEdges(ctx); dropErr != nil && err == nil {
+			err = fmt.Errorf("failed to drop edges: %w", dropErr)
+		}
+	}()
+
+	fmt.Fprintf(r.Output, "Running GRAPH benchmark with %d samples, %d edges each and %d hops...\n", len(keys), edges, hops)
+
+	if err := r.runCreateEdges(ctx, graph, keys, adjacency); err != nil {
+		return err
+	}
+
+	return r.runTraverse(ctx, graph, keys, adjacency, hops)
+}
+
+// runCreateEdges creates the outgoing edges of every record, one request per record
+func (r *Runner) runCreateEdges(ctx context.Context, graph GraphAdapter, keys []string, adjacency [][]int) error {
+	// Start timer
+	startTime := time.Now()
+	rec := r.newRecorder()
+
+	err := r.forEachKey(ctx, keys, func(ctx context.Context, i int) error {
+		to := make([]string, len(adjacency[i]))
+		for j, target := range adjacency[i] {
+			to[j] = keys[target]
+		}
+
+		opStart := r.pace(ctx)
+		err := r.invoke(ctx, &Call{Operation: OperationCreate, Key: keys[i], Keys: to}, func(ctx context.Context, call *Call) error {
+			return graph.CreateEdges(ctx, call.Key, call.Keys)
+		})
+		rec.observe(time.Since(opStart), err)
+		if err != nil {
+			return fmt.Errorf("failed to create edges of record %d: %w", i, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Record result, counting records rather than edges
+	duration := time.Since(startTime)
+	result := Result{
+		Operation: OperationCreate,
+		Name:      "create_edges",
+		Duration:  duration,
+		Count:     len(keys),
+		Metrics: map[string]float64{
+			"edges": float64(len(keys) * r.Config.GraphEdges),
+		},
+	}
+	rec.apply(&result)
+	r.Results = append(r.Results, result)
+
+	fmt.Fprintf(r.Output, "CREATE 'create_edges' completed in %v\n", duration)
+	return nil
+}
+
+// runTraverse traverses the graph from every record, verifying the number of
+// records reached against a traversal of the adjacency lists
+func (r *Runner) runTraverse(ctx context.Context, graph GraphAdapter, keys []string, adjacency [][]int, hops int) error {
+	// Start timer
+	startTime := time.Now()
+	rec := r.newRecorder()
+
+	var reached atomic.Int64
+	err := r.forEachKey(ctx, keys, func(ctx context.Context, i int) error {
+		want := reachable(adjacency, i, hops)
+
+		opStart := r.pace(ctx)
+		call := &Call{Operation: OperationTraverse, Key: keys[i]}
+		err := r.invoke(ctx, call, func(ctx context.Context, call *Call) (err error) {
+			call.Count, err = graph.Traverse(ctx, call.Key, hops)
+			return err
+		})
+		count := call.Count
+		if err == nil && count != want {
+			err = fmt.Errorf("reached %d records, expected %d", count, want)
+		}
+		rec.observe(time.Since(opStart), err)
+		if err != nil {
+			return fmt.Errorf("failed to traverse from record %d: %w", i, err)
+		}
+		reached.Add(int64(count))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Record result
+	name := fmt.Sprintf("traverse_%dhop", hops)
+	duration := time.Since(startTime)
+	result := Result{
+		Operation: OperationTraverse,
+		Name:      name,
+		Duration:  duration,
+		Count:     len(keys),
+		Metrics: map[string]float64{
+			"reached": float64(reached.Load()) / float64(len(keys)),
+		},
+	}
+	rec.apply(&result)
+	r.Results = append(r.Results, result)
+
+	fmt.Fprintf(r.Output, "TRAVERSE '%s' completed in %v\n", name, duration)
+	return nil
+}
+
+// reachable returns the number of distinct records reached from a record by
+// following exactly the given number of edges
+func reachable(adjacency [][]int, start, hops int) int {
+	frontier := map[int]bool{start: true}
+	for h := 0; h < hops; h++ {
+		next := make(map[int]bool)
+		for node := range frontier {
+			for _, target := range adjacency[node] {
+				next[target] = true
+			}
+		}
+		frontier = next
+	}
+	return len(frontier)
+}
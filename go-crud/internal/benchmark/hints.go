@@ -0,0 +1,190 @@
+package benchmark
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Thresholds of the bottleneck hints
+const (
+	// hintClientCPU is the share of the client CPU above which the client is
+	// likely the bottleneck
+	hintClientCPU = 90
+	// hintMinPhase is the duration below which a phase is too short to be reliable
+	hintMinPhase = 2 * time.Second
+	// cpuMinPhase is the duration below which the client CPU usage of a phase
+	// is not recorded, as it is counted in ticks of 10ms
+	cpuMinPhase = time.Second
+)
+
+// clockTicks is the unit of the CPU times in /proc, USER_HZ on Linux
+const clockTicks = 100
+
+// Hint is a likely bottleneck or source of unreliable numbers spotted in the
+// metrics of a result, as guidance for reading the results
+type Hint struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// hintRules derive hints from a result, returning an empty message when the
+// rule does not apply
+var hintRules = []struct {
+	rule  string
+	check func(result *Result) string
+}{
+	{"client_cpu", func(result *Result) string {
+		if cpu := result.Metrics["client_cpu_pct"]; cpu > hintClientCPU {
+			return fmt.Sprintf("client CPU >%d%% (%.0f%%) during %s, the benchmark client may be the bottleneck", hintClientCPU, cpu, result.Operation)
+		}
+		return ""
+	}},
+	{"pool_exhausted", func(result *Result) string {
+		if waits := result.Metrics["pool_wait_count"]; waits > 0 {
+			return fmt.Sprintf("connection pool exhausted (%.0f waits observed, %.0fms waiting), raise the pool size or lower the concurrency", waits, result.Metrics["pool_wait_ms"])
+		}
+		return ""
+	}},
+	{"container_memory", func(result *Result) string {
+		if hits := result.Metrics["container_mem_limit_hits"]; hits > 0 {
+			return fmt.Sprintf("container hit memory limit (%.0f times), the database may be reclaiming memory or swapping", hits)
+		}
+		return ""
+	}},
+	{"client_memory", func(result *Result) string {
+		if pauses := result.Metrics["client_mem_pauses"]; pauses > 0 {
+			return fmt.Sprintf("workers paused %.0f times for %.0fms by the client memory ceiling", pauses, result.Metrics["client_mem_paused_ms"])
+		}
+		return ""
+	}},
+	{"cpu_throttled", func(result *Result) string {
+		if result.Metrics["cpu_throttled"] > 0 {
+			return "CPU frequency scaling or throttling detected, results may not be reproducible"
+		}
+		return ""
+	}},
+	{"rate_not_sustained", func(result *Result) string {
+		target, achieved := result.Metrics["target_rate"], result.Metrics["achieved_rate"]
+		if target > 0 && achieved < target*rateSustainedRatio {
+			return fmt.Sprintf("target rate not sustained (%.0f of %.0f ops/sec), latencies include the backlog behind the schedule", achieved, target)
+		}
+		return ""
+	}},
+	{"timeouts", func(result *Result) string {
+		if timeouts := result.ErrorClasses[ErrorClassTimeout]; timeouts > 0 {
+			return fmt.Sprintf("%d operations timed out, the database may be saturated", timeouts)
+		}
+		return ""
+	}},
+	{"short_phase", func(result *Result) string {
+		// Scans time a single query, which is expected to be short
+		if result.Operation != OperationScan && result.Duration > 0 && result.Duration < hintMinPhase {
+			return fmt.Sprintf("phase shorter than %v: unreliable, raise the samples or use --duration", hintMinPhase)
+		}
+		return ""
+	}},
+}
+
+// phaseUsage is a snapshot of the resources used by the client and the
+// database container at the start of a phase
+type phaseUsage struct {
+	time      time.Time
+	cpu       time.Duration
+	memEvents float64
+}
+
+// startUsage takes a snapshot of the resources used so far
+func (r *Runner) startUsage(ctx context.Context) phaseUsage {
+	return phaseUsage{time: time.Now(), cpu: clientCPUTime(), memEvents: r.containerMemoryEvents(ctx)}
+}
+
+// recordUsage attaches the client CPU usage and container memory limit hits
+// since the snapshot to the results recorded from index onwards
+func (r *Runner) recordUsage(ctx context.Context, usage phaseUsage, index int) {
+	wall := time.Since(usage.time)
+	cpu := clientCPUTime() - usage.cpu
+	memHits := r.containerMemoryEvents(ctx) - usage.memEvents
+
+	for i := index; i < len(r.Results); i++ {
+		result := &r.Results[i]
+		if result.Metrics == nil {
+			result.Metrics = map[string]float64{}
+		}
+		if usage.cpu > 0 && wall >= cpuMinPhase {
+			// The CPU time is counted in ticks, which can overshoot the wall time
+			result.Metrics["client_cpu_pct"] = min(100, float64(cpu)/float64(wall)/float64(runtime.NumCPU())*100)
+		}
+		if memHits > 0 {
+			result.Metrics["container_mem_limit_hits"] = memHits
+		}
+	}
+}
+
+// recordHints annotates the results recorded from index onwards with the
+// hints of every rule which applies
+func (r *Runner) recordHints(index int) {
+	for i := index; i < len(r.Results); i++ {
+		result := &r.Results[i]
+		result.Hints = nil
+		for _, rule := range hintRules {
+			if message := rule.check(result); message != "" {
+				result.Hints = append(result.Hints, Hint{Rule: rule.rule, Message: message})
+			}
+		}
+	}
+}
+
+// clientCPUTime returns the user and system CPU time used by the process so
+// far, read from /proc on Linux and 0 elsewhere
+func clientCPUTime() time.Duration {
+	data, err := os.ReadFile("/proc/self/stat")
+	if err != nil {
+		return 0
+	}
+
+	// The command name may contain spaces, the fields follow its closing parenthesis
+	if i := bytes.LastIndexByte(data, ')'); i >= 0 {
+		data = data[i+1:]
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) < 13 {
+		return 0
+	}
+	utime, err := strconv.ParseInt(fields[11], 10, 64)
+	if err != nil {
+		return 0
+	}
+	stime, err := strconv.ParseInt(fields[12], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return time.Duration(utime+stime) * time.Second / clockTicks
+}
+
+// containerMemoryEvents returns the number of times the database container
+// reached its cgroup memory limit, or 0 without a managed container
+func (r *Runner) containerMemoryEvents(ctx context.Context) float64 {
+	provider, ok := r.Adapter.(ContainerProvider)
+	if !ok || provider.Container() == nil {
+		return 0
+	}
+	out, err := provider.Container().Exec(ctx, []string{"cat", "/sys/fs/cgroup/memory.events"})
+	if err != nil {
+		return 0
+	}
+
+	// memory.events holds one counter per line, max counting the limit being reached
+	for _, line := range strings.Split(out, "\n") {
+		if name, value, ok := strings.Cut(strings.TrimSpace(line), " "); ok && name == "max" {
+			n, _ := strconv.ParseFloat(value, 64)
+			return n
+		}
+	}
+	return 0
+}
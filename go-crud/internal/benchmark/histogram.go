@@ -0,0 +1,139 @@
+package benchmark
+
+import (
+	"math"
+	"math/bits"
+	"sync/atomic"
+	"time"
+)
+
+// histogramSubBits is the number of bits used for the linear sub-buckets within
+// each power of two, giving a worst-case relative error of 1/64 (about 1.6%)
+const histogramSubBits = 6
+
+// histogramSubBuckets is the number of linear sub-buckets per power of two
+const histogramSubBuckets = 1 << histogramSubBits
+
+// histogram is a lock-free log-linear latency histogram in the style of HDR histograms
+type histogram struct {
+	counts [64 * histogramSubBuckets]atomic.Int64
+	total  atomic.Int64
+	sum    atomic.Int64
+	min    atomic.Int64
+	max    atomic.Int64
+}
+
+// newHistogram creates an empty histogram
+func newHistogram() *histogram {
+	h := &histogram{}
+	h.min.Store(math.MaxInt64)
+	return h
+}
+
+// bucketIndex returns the bucket holding the given value
+func bucketIndex(v uint64) int {
+	if v < histogramSubBuckets {
+		return int(v)
+	}
+	exp := bits.Len64(v) - histogramSubBits
+	sub := v >> uint(exp-1) & (histogramSubBuckets - 1)
+	return exp*histogramSubBuckets + int(sub)
+}
+
+// bucketUpperBound returns the largest value stored in the given bucket
+func bucketUpperBound(index int) uint64 {
+	if index < histogramSubBuckets {
+		return uint64(index)
+	}
+	exp := index / histogramSubBuckets
+	sub := uint64(index % histogramSubBuckets)
+	base := uint64(1) << uint(exp+histogramSubBits-1)
+	width := uint64(1) << uint(exp-1)
+	return base + (sub+1)*width - 1
+}
+
+// record adds a latency to the histogram
+func (h *histogram) record(d time.Duration) {
+	v := int64(d)
+	if v < 0 {
+		v = 0
+	}
+	h.counts[bucketIndex(uint64(v))].Add(1)
+	h.total.Add(1)
+	h.sum.Add(v)
+	for {
+		cur := h.min.Load()
+		if v >= cur || h.min.CompareAndSwap(cur, v) {
+			break
+		}
+	}
+	for {
+		cur := h.max.Load()
+		if v <= cur || h.max.CompareAndSwap(cur, v) {
+			break
+		}
+	}
+}
+
+// count returns the number of recorded values
+func (h *histogram) count() int64 {
+	return h.total.Load()
+}
+
+// quantile returns the latency below which the given fraction of values fall
+func (h *histogram) quantile(q float64) time.Duration {
+	total := h.total.Load()
+	if total == 0 {
+		return 0
+	}
+	target := int64(math.Ceil(q * float64(total)))
+	if target < 1 {
+		target = 1
+	}
+	var seen int64
+	for i := range h.counts {
+		seen += h.counts[i].Load()
+		if seen >= target {
+			bound := time.Duration(bucketUpperBound(i))
+			if max := time.Duration(h.max.Load()); bound > max {
+				return max
+			}
+			return bound
+		}
+	}
+	return time.Duration(h.max.Load())
+}
+
+// HistogramBucket counts the operations of a phase whose latency is at most
+// Le and above the bound of the previous bucket
+type HistogramBucket struct {
+	Le    time.Duration `json:"le"`
+	Count int64         `json:"count"`
+}
+
+// buckets returns the recorded values in buckets doubling in width, from the
+// first to the last non-empty one
+func (h *histogram) buckets() []HistogramBucket {
+	var buckets []HistogramBucket
+	first, last := -1, -1
+	for group := 0; group < len(h.counts)/histogramSubBuckets; group++ {
+		var count int64
+		for i := group * histogramSubBuckets; i < (group+1)*histogramSubBuckets; i++ {
+			count += h.counts[i].Load()
+		}
+		buckets = append(buckets, HistogramBucket{
+			Le:    time.Duration(bucketUpperBound((group+1)*histogramSubBuckets - 1)),
+			Count: count,
+		})
+		if count > 0 {
+			if first < 0 {
+				first = group
+			}
+			last = group
+		}
+	}
+	if first < 0 {
+		return nil
+	}
+	return buckets[first : last+1]
+}
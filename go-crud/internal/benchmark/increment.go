@@ -0,0 +1,142 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// OperationIncrement represents an atomic counter increment
+const OperationIncrement Operation = "INCREMENT"
+
+// Incrementer is implemented by adapters that support atomic counters
+// (SQL UPDATE n = n + 1, Redis INCR, MongoDB $inc, CQL counter columns)
+type Incrementer interface {
+	// CreateCounters creates the given counters, starting at zero
+	CreateCounters(ctx context.Context, counters []string) error
+
+	// Increment atomically adds one to a counter
+	Increment(ctx context.Context, counter string) error
+
+	// Counter returns the current value of a counter
+	Counter(ctx context.Context, counter string) (int64, error)
+
+	// DropCounters removes the counters created with CreateCounters
+	DropCounters(ctx context.Context, counters []string) error
+}
+
+// runIncrement spreads the configured number of increments over a small set of
+// counters under concurrency, then verifies that no increment was lost
+func (r *Runner) runIncrement(ctx context.Context) error {
+	total := r.Config.Increments
+
+	incrementer, ok := r.Adapter.(Incrementer)
+	if !ok {
+		return fmt.Errorf("database %s does not support atomic increments", r.Adapter.Name())
+	}
+
+	counters := make([]string, r.Config.Counters)
+	for i := range counters {
+		counters[i] = fmt.Sprintf("counter_%d", i)
+	}
+
+	fmt.Fprintf(r.Output, "Running INCREMENT benchmark with %d increments on %d counters...\n", total, len(counters))
+
+	if err := incrementer.CreateCounters(ctx, counters); err != nil {
+		return fmt.Errorf("failed to create counters: %w", err)
+	}
+	defer func() {
+		if err := incrementer.DropCounters(ctx, counters); err != nil {
+			fmt.Fprintf(r.Output, "Warning: failed to drop counters: %v\n", err)
+		}
+	}()
+
+	// Start timer
+	startTime := time.Now()
+	rec := r.newRecorder()
+	var next atomic.Int64
+
+	var wg sync.WaitGroup
+	workers := r.Config.Clients * r.threads
+	errCh := make(chan error, workers)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			ctx := r.clientContext(ctx, w)
+
+			for {
+				i := int(next.Add(1)) - 1
+				if i >= total {
+					return
+				}
+				if ctx.Err() != nil {
+					errCh <- ctx.Err()
+					return
+				}
+
+				counter := counters[i%len(counters)]
+				opStart := r.pace(ctx)
+				err := r.invoke(ctx, &Call{Operation: OperationIncrement, Key: counter}, func(ctx context.Context, call *Call) error {
+					return incrementer.Increment(ctx, call.Key)
+				})
+				rec.observe(time.Since(opStart), err)
+				if err != nil {
+					errCh <- fmt.Errorf("failed to increment %s: %w", counter, err)
+					return
+				}
+			}
+		}()
+	}
+
+	// Wait for all goroutines to finish
+	wg.Wait()
+
+	// Check for errors
+	close(errCh)
+	for err := range errCh {
+		if err != nil {
+			return err
+		}
+	}
+	duration := time.Since(startTime)
+
+	// Verify that every increment was applied exactly once
+	var counted int64
+	for _, counter := range counters {
+		var n int64
+		err := r.invoke(ctx, &Call{Operation: OperationRead, Key: counter}, func(ctx context.Context, call *Call) (err error) {
+			n, err = incrementer.Counter(ctx, call.Key)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", counter, err)
+		}
+		counted += n
+	}
+
+	// Record result
+	result := Result{
+		Operation: OperationIncrement,
+		Name:      "increment",
+		Duration:  duration,
+		Count:     total,
+		Metrics: map[string]float64{
+			"counters": float64(len(counters)),
+			"counted":  float64(counted),
+		},
+	}
+	rec.apply(&result)
+	r.Results = append(r.Results, result)
+
+	if counted != int64(total) {
+		return fmt.Errorf("counters add up to %d after %d increments", counted, total)
+	}
+
+	fmt.Fprintf(r.Output, "INCREMENT completed in %v\n", duration)
+	return nil
+}
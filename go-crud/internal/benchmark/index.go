@@ -0,0 +1,190 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/surrealdb/go-crud-bench/internal/generators"
+)
+
+// OperationIndex represents a secondary index build operation
+const OperationIndex Operation = "INDEX"
+
+// indexBaselineWindow is how long the foreground workload runs before the index build starts
+const indexBaselineWindow = 2 * time.Second
+
+// indexName is the name of the secondary index created by the index build scenario
+const indexName = "bench_index"
+
+// Indexer is implemented by adapters that can build secondary indexes
+type Indexer interface {
+	// CreateIndex builds a secondary index on the given value field
+	CreateIndex(ctx context.Context, name string, field string) error
+
+	// DropIndex removes a secondary index previously created with CreateIndex
+	DropIndex(ctx context.Context, name string) error
+}
+
+// foregroundLoad tracks the latency of operations issued by a background workload
+type foregroundLoad struct {
+	ops    atomic.Int64
+	nanos  atomic.Int64
+	errors atomic.Int64
+}
+
+// reset clears the counters and returns their values before the reset
+func (f *foregroundLoad) reset() (ops, nanos, errors int64) {
+	return f.ops.Swap(0), f.nanos.Swap(0), f.errors.Swap(0)
+}
+
+// runIndexBuild creates a secondary index on the populated table while a
+// read/write workload runs, and reports the build time and latency impact
+func (r *Runner) runIndexBuild(ctx context.Context) error {
+	field := r.Config.IndexBuild
+
+	indexer, ok := r.Adapter.(Indexer)
+	if !ok {
+		return fmt.Errorf("database %s does not support building secondary indexes", r.Adapter.Name())
+	}
+
+	fmt.Fprintf(r.Output, "Running INDEX build benchmark on field '%s'...\n", field)
+
+	// Parse the value template
+	valueTemplate, err := generators.ParseTemplate(r.Config.Value)
+	if err != nil {
+		return fmt.Errorf("failed to process value template: %w", err)
+	}
+
+	keys := r.keys
+	if len(keys) == 0 {
+		return fmt.Errorf("no records available for the index build workload")
+	}
+	ks, err := r.newKeySet(keys)
+	if err != nil {
+		return err
+	}
+
+	// Start the foreground read/write workload
+	loadCtx, stopLoad := context.WithCancel(ctx)
+	defer stopLoad()
+
+	load := &foregroundLoad{}
+	var wg sync.WaitGroup
+	workers := r.Config.Clients * r.threads
+
+	// The load runs until it is stopped, so its steps never run out
+	steps := &steps{total: math.MaxInt}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func(workerID int) {
+			defer wg.Done()
+			loadCtx := r.clientContext(loadCtx, workerID)
+
+			for {
+				i, ok := steps.take()
+				if !ok {
+					return
+				}
+				if loadCtx.Err() != nil {
+					return
+				}
+
+				k := i % len(keys)
+				start := time.Now()
+
+				// Alternate between reads and updates
+				var err error
+				if i%2 == 0 {
+					_, err = ks.read(loadCtx, k)
+				} else {
+					value := generators.NewValue(generators.RecordRandom("index", i), valueTemplate)
+					var payload *Payload
+					if payload, err = ks.encode(value); err == nil {
+						err = ks.update(loadCtx, k, value, payload)
+					}
+				}
+
+				if err != nil {
+					if loadCtx.Err() != nil {
+						return
+					}
+					load.errors.Add(1)
+					continue
+				}
+
+				load.ops.Add(1)
+				load.nanos.Add(int64(time.Since(start)))
+			}
+		}(w)
+	}
+
+	// Measure the baseline latency before building the index
+	select {
+	case <-time.After(indexBaselineWindow):
+	case <-ctx.Done():
+		stopLoad()
+		wg.Wait()
+		return ctx.Err()
+	}
+	baselineOps, baselineNanos, baselineErrors := load.reset()
+
+	// Build the index while the workload continues
+	startTime := time.Now()
+	buildErr := indexer.CreateIndex(ctx, indexName, field)
+	duration := time.Since(startTime)
+	duringOps, duringNanos, duringErrors := load.reset()
+
+	// Stop the foreground workload
+	stopLoad()
+	wg.Wait()
+
+	if buildErr != nil {
+		return fmt.Errorf("failed to build index on field '%s': %w", field, buildErr)
+	}
+
+	// Drop the index so that later phases are not affected by it
+	if err := indexer.DropIndex(ctx, indexName); err != nil {
+		return fmt.Errorf("failed to drop index on field '%s': %w", field, err)
+	}
+
+	baselineMean := meanMicros(baselineNanos, baselineOps)
+	duringMean := meanMicros(duringNanos, duringOps)
+	impact := 0.0
+	if baselineMean > 0 {
+		impact = (duringMean - baselineMean) / baselineMean * 100
+	}
+
+	// Record result
+	r.Results = append(r.Results, Result{
+		Operation: OperationIndex,
+		Name:      "index_build",
+		Duration:  duration,
+		Count:     int(duringOps),
+		Metrics: map[string]float64{
+			"baseline_ops":     float64(baselineOps),
+			"baseline_errors":  float64(baselineErrors),
+			"baseline_mean_us": baselineMean,
+			"during_ops":       float64(duringOps),
+			"during_errors":    float64(duringErrors),
+			"during_mean_us":   duringMean,
+			"latency_impact":   impact,
+		},
+	})
+
+	fmt.Fprintf(r.Output, "INDEX build completed in %v (foreground mean latency %.1fµs -> %.1fµs, %+.1f%%)\n",
+		duration, baselineMean, duringMean, impact)
+	return nil
+}
+
+// meanMicros returns the mean duration in microseconds for the given totals
+func meanMicros(nanos, ops int64) float64 {
+	if ops == 0 {
+		return 0
+	}
+	return float64(nanos) / float64(ops) / float64(time.Microsecond)
+}
@@ -0,0 +1,62 @@
+package benchmark
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// phaseResult names the result of a phase
+type phaseResult struct {
+	operation Operation
+	name      string
+}
+
+// phaseResults names the results of the phases whose result is not named
+// after them
+var phaseResults = map[string]phaseResult{
+	"create": {OperationCreate, "create_all"},
+	"read":   {OperationRead, "read_all"},
+	"update": {OperationUpdate, "update_all"},
+	"delete": {OperationDelete, "delete_all"},
+	"probe":  {OperationProbe, "read_after_write"},
+	"check":  {OperationCheck, "linearizability"},
+	"cas":    {OperationCAS, "cas_contended"},
+	"index":  {OperationIndex, "index_build"},
+	"graph":  {OperationTraverse, "graph"},
+}
+
+// interrupted reports whether the run was cancelled from outside, by a
+// signal, rather than by a failure of its own
+func interrupted(ctx context.Context) bool {
+	return ctx.Err() != nil && errors.Is(context.Cause(ctx), context.Canceled)
+}
+
+// recordPartial records the operations completed and the errors counted by a
+// phase which failed or was interrupted before it recorded its result, marked
+// as partial
+func (r *Runner) recordPartial(name string, index int) {
+	rec := r.recorder.Load()
+	if rec == nil || len(r.Results) > index || name == "warmup" {
+		return
+	}
+	count := rec.hist.count() + rec.failed.Load()
+	if count == 0 {
+		return
+	}
+
+	named, ok := phaseResults[name]
+	if !ok {
+		named = phaseResult{Operation(strings.ToUpper(name)), name}
+	}
+	result := Result{
+		Operation: named.operation,
+		Name:      named.name,
+		Duration:  time.Since(rec.start),
+		Count:     int(count),
+		Partial:   true,
+	}
+	rec.apply(&result)
+	r.Results = append(r.Results, result)
+}
@@ -0,0 +1,230 @@
+package benchmark
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+)
+
+// KeyEncoding selects how keys are handed to adapters which support native keys
+type KeyEncoding string
+
+const (
+	// KeyEncodingString passes keys as Go strings through the Adapter interface
+	KeyEncodingString KeyEncoding = "string"
+	// KeyEncodingBytes passes keys as raw byte slices
+	KeyEncodingBytes KeyEncoding = "bytes"
+	// KeyEncodingInt64 passes integer keys as 8-byte big-endian values, preserving their order
+	KeyEncodingInt64 KeyEncoding = "int64"
+)
+
+// NativeKeyAdapter is implemented by key-value adapters which can operate on
+// binary or integer keys directly, avoiding an unfair string conversion penalty
+type NativeKeyAdapter interface {
+	// SupportsKeyEncoding reports whether the adapter accepts keys in the given encoding
+	SupportsKeyEncoding(encoding KeyEncoding) bool
+
+	// CreateKey inserts a new record with a natively encoded key
+	CreateKey(ctx context.Context, key []byte, value map[string]interface{}) error
+
+	// ReadKey retrieves a record with a natively encoded key
+	ReadKey(ctx context.Context, key []byte) (map[string]interface{}, error)
+
+	// UpdateKey updates a record with a natively encoded key
+	UpdateKey(ctx context.Context, key []byte, value map[string]interface{}) error
+
+	// DeleteKey removes a record with a natively encoded key
+	DeleteKey(ctx context.Context, key []byte) error
+}
+
+// EncodeKey converts a generated key into the given native encoding
+func EncodeKey(key string, encoding KeyEncoding) ([]byte, error) {
+	switch encoding {
+	case KeyEncodingString, KeyEncodingBytes:
+		return []byte(key), nil
+	case KeyEncodingInt64:
+		n, err := strconv.ParseInt(key, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("key %q is not an integer: %w", key, err)
+		}
+		buf := make([]byte, 8)
+		// Flip the sign bit so that negative keys sort before positive ones
+		binary.BigEndian.PutUint64(buf, uint64(n)^(1<<63))
+		return buf, nil
+	default:
+		return nil, fmt.Errorf("unsupported key encoding: %s", encoding)
+	}
+}
+
+// keySet holds the keys used by a phase together with their native encoding
+type keySet struct {
+	adapter Adapter
+	keys    []string
+	native  NativeKeyAdapter
+	encoded [][]byte
+
+	// payloads is set on the payload path, where encoded holds the keys in
+	// their native encoding or as raw bytes
+	payloads PayloadAdapter
+
+	// handler calls the adapter through the middleware chain, nil without middleware
+	handler Handler
+}
+
+// newKeySet prepares the keys of a phase, encoding them up front so that the
+// conversion cost is not included in the measured operations
+func (r *Runner) newKeySet(keys []string) (*keySet, error) {
+	ks := &keySet{adapter: r.Adapter, keys: keys}
+	ks.handler = r.chain(ks.execute)
+
+	if RecordFormat(r.Config.RecordFormat) == RecordFormatPayload {
+		payloads, ok := r.Adapter.(PayloadAdapter)
+		if !ok {
+			return nil, fmt.Errorf("database %s does not support the payload record format", r.Adapter.Name())
+		}
+		ks.payloads = payloads
+	}
+
+	encoding := KeyEncoding(r.Config.KeyEncoding)
+	if encoding == "" {
+		encoding = KeyEncodingString
+	}
+	if encoding != KeyEncodingString {
+		native, ok := r.Adapter.(NativeKeyAdapter)
+		if !ok || !native.SupportsKeyEncoding(encoding) {
+			return nil, fmt.Errorf("database %s does not support the %s key encoding", r.Adapter.Name(), encoding)
+		}
+		ks.native = native
+	} else if ks.payloads == nil {
+		return ks, nil
+	}
+
+	ks.encoded = make([][]byte, len(keys))
+	for i, key := range keys {
+		encoded, err := EncodeKey(key, encoding)
+		if err != nil {
+			return nil, err
+		}
+		ks.encoded[i] = encoded
+	}
+
+	return ks, nil
+}
+
+// requireStringKeys fails the phases which address records by their string
+// keys alone when another key encoding is configured
+func (r *Runner) requireStringKeys(feature string) error {
+	if encoding := KeyEncoding(r.Config.KeyEncoding); encoding != "" && encoding != KeyEncodingString {
+		return fmt.Errorf("%s require the %s key encoding", feature, KeyEncodingString)
+	}
+	return nil
+}
+
+// encode pre-encodes a value on the payload path, so that the encoding is not
+// included in the measured operation, and returns nil on the map path
+func (ks *keySet) encode(value map[string]interface{}) (*Payload, error) {
+	if ks.payloads == nil {
+		return nil, nil
+	}
+	return NewPayload(value)
+}
+
+// create inserts the record with the key at index i, with its payload on the
+// payload path
+func (ks *keySet) create(ctx context.Context, i int, value map[string]interface{}, payload *Payload) error {
+	if ks.handler != nil {
+		return ks.handler(ctx, &Call{Operation: OperationCreate, Key: ks.keys[i], Value: value, Payload: payload, Index: i})
+	}
+	if ks.payloads != nil {
+		return ks.payloads.CreatePayload(ctx, ks.encoded[i], payload)
+	}
+	if ks.native != nil {
+		return ks.native.CreateKey(ctx, ks.encoded[i], value)
+	}
+	return ks.adapter.Create(ctx, ks.keys[i], value)
+}
+
+// read retrieves the record with the key at index i. On the payload path the
+// record is not decoded and nil is returned.
+func (ks *keySet) read(ctx context.Context, i int) (map[string]interface{}, error) {
+	if ks.handler != nil {
+		call := &Call{Operation: OperationRead, Key: ks.keys[i], Index: i}
+		err := ks.handler(ctx, call)
+		return call.Record, err
+	}
+	if ks.payloads != nil {
+		_, err := ks.payloads.ReadPayload(ctx, ks.encoded[i])
+		return nil, err
+	}
+	if ks.native != nil {
+		return ks.native.ReadKey(ctx, ks.encoded[i])
+	}
+	return ks.adapter.Read(ctx, ks.keys[i])
+}
+
+// update updates the record with the key at index i, with its payload on the
+// payload path
+func (ks *keySet) update(ctx context.Context, i int, value map[string]interface{}, payload *Payload) error {
+	if ks.handler != nil {
+		return ks.handler(ctx, &Call{Operation: OperationUpdate, Key: ks.keys[i], Value: value, Payload: payload, Index: i})
+	}
+	if ks.payloads != nil {
+		return ks.payloads.UpdatePayload(ctx, ks.encoded[i], payload)
+	}
+	if ks.native != nil {
+		return ks.native.UpdateKey(ctx, ks.encoded[i], value)
+	}
+	return ks.adapter.Update(ctx, ks.keys[i], value)
+}
+
+// delete removes the record with the key at index i
+func (ks *keySet) delete(ctx context.Context, i int) error {
+	if ks.handler != nil {
+		return ks.handler(ctx, &Call{Operation: OperationDelete, Key: ks.keys[i], Index: i})
+	}
+	if ks.native != nil {
+		return ks.native.DeleteKey(ctx, ks.encoded[i])
+	}
+	return ks.adapter.Delete(ctx, ks.keys[i])
+}
+
+// execute calls the adapter for a call which has passed through the middleware chain
+func (ks *keySet) execute(ctx context.Context, call *Call) (err error) {
+	switch call.Operation {
+	case OperationCreate:
+		if ks.payloads != nil {
+			return ks.payloads.CreatePayload(ctx, ks.encoded[call.Index], call.Payload)
+		}
+		if ks.native != nil {
+			return ks.native.CreateKey(ctx, ks.encoded[call.Index], call.Value)
+		}
+		return ks.adapter.Create(ctx, call.Key, call.Value)
+	case OperationRead:
+		if ks.payloads != nil {
+			call.Payload, err = ks.payloads.ReadPayload(ctx, ks.encoded[call.Index])
+			return err
+		}
+		if ks.native != nil {
+			call.Record, err = ks.native.ReadKey(ctx, ks.encoded[call.Index])
+			return err
+		}
+		call.Record, err = ks.adapter.Read(ctx, call.Key)
+		return err
+	case OperationUpdate:
+		if ks.payloads != nil {
+			return ks.payloads.UpdatePayload(ctx, ks.encoded[call.Index], call.Payload)
+		}
+		if ks.native != nil {
+			return ks.native.UpdateKey(ctx, ks.encoded[call.Index], call.Value)
+		}
+		return ks.adapter.Update(ctx, call.Key, call.Value)
+	case OperationDelete:
+		if ks.native != nil {
+			return ks.native.DeleteKey(ctx, ks.encoded[call.Index])
+		}
+		return ks.adapter.Delete(ctx, call.Key)
+	default:
+		return fmt.Errorf("unsupported operation: %s", call.Operation)
+	}
+}
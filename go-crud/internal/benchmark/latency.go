@@ -0,0 +1,173 @@
+package benchmark
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SLOBucket reports the share of operations in a phase falling within a latency class
+type SLOBucket struct {
+	Label    string  `json:"label"`
+	Count    int64   `json:"count"`
+	Fraction float64 `json:"fraction"`
+}
+
+// LatencyStats summarises the per-operation latency distribution of a phase
+type LatencyStats struct {
+	Min  time.Duration `json:"min"`
+	Mean time.Duration `json:"mean"`
+	P50  time.Duration `json:"p50"`
+	P90  time.Duration `json:"p90"`
+	P95  time.Duration `json:"p95"`
+	P99  time.Duration `json:"p99"`
+	P999 time.Duration `json:"p999"`
+	Max  time.Duration `json:"max"`
+}
+
+// recorder collects per-operation measurements during a benchmark phase
+type recorder struct {
+	done   *atomic.Int64
+	fails  *atomic.Int64
+	sum    *atomic.Int64
+	hist   *histogram
+	bounds []time.Duration
+	counts []atomic.Int64
+	failed atomic.Int64
+	memory *memoryGuard
+	out    io.Writer
+	start  time.Time
+
+	// classify maps failed operations to the error classes counted in classes
+	classify func(error) ErrorClass
+	classMu  sync.Mutex
+	classes  map[ErrorClass]int64
+}
+
+// newRecorder creates a recorder for a single benchmark phase, which becomes
+// the recorder of the running phase
+func (r *Runner) newRecorder() *recorder {
+	bounds := r.Config.SLO
+	rec := &recorder{
+		done:   &r.completed,
+		fails:  &r.failed,
+		sum:    &r.latencySum,
+		hist:   newHistogram(),
+		bounds: bounds,
+		counts: make([]atomic.Int64, len(bounds)+1),
+		memory: r.memory,
+		out:    r.Output,
+
+		classify: r.classifyError,
+		start:    time.Now(),
+	}
+	r.recorder.Store(rec)
+	return rec
+}
+
+// observe records the outcome of a single operation, blocking while the
+// workers are paused by the client memory ceiling
+func (rec *recorder) observe(latency time.Duration, err error) {
+	rec.memory.wait()
+	rec.done.Add(1)
+	if err != nil {
+		rec.failed.Add(1)
+		rec.fails.Add(1)
+		rec.countError(err)
+		return
+	}
+	rec.hist.record(latency)
+	rec.sum.Add(int64(latency))
+	for i, bound := range rec.bounds {
+		if latency < bound {
+			rec.counts[i].Add(1)
+			return
+		}
+	}
+	rec.counts[len(rec.bounds)].Add(1)
+}
+
+// apply attaches the recorded measurements to a result
+func (rec *recorder) apply(result *Result) {
+	if count := rec.hist.count(); count > 0 {
+		result.Latency = &LatencyStats{
+			Min:  time.Duration(rec.hist.min.Load()),
+			Mean: time.Duration(rec.hist.sum.Load() / count),
+			P50:  rec.hist.quantile(0.50),
+			P90:  rec.hist.quantile(0.90),
+			P95:  rec.hist.quantile(0.95),
+			P99:  rec.hist.quantile(0.99),
+			P999: rec.hist.quantile(0.999),
+			Max:  time.Duration(rec.hist.max.Load()),
+		}
+		result.Histogram = rec.hist.buckets()
+	}
+	result.Errors = rec.failed.Load()
+	rec.applyErrorClasses(result)
+	rec.applySLO(result)
+}
+
+// countError counts a failed operation in its error class
+func (rec *recorder) countError(err error) {
+	class := rec.classify(err)
+
+	rec.classMu.Lock()
+	defer rec.classMu.Unlock()
+	if rec.classes == nil {
+		rec.classes = make(map[ErrorClass]int64)
+	}
+	rec.classes[class]++
+}
+
+// applyErrorClasses attaches the error counts per class to a result
+func (rec *recorder) applyErrorClasses(result *Result) {
+	rec.classMu.Lock()
+	defer rec.classMu.Unlock()
+	if len(rec.classes) == 0 {
+		return
+	}
+
+	result.ErrorClasses = make(map[ErrorClass]int64, len(rec.classes))
+	for class, count := range rec.classes {
+		result.ErrorClasses[class] = count
+	}
+	fmt.Fprintf(rec.out, "%s errors: %s\n", result.Name, formatErrorClasses(result.ErrorClasses))
+}
+
+// applySLO attaches the SLO class breakdown to a result
+func (rec *recorder) applySLO(result *Result) {
+	if len(rec.bounds) == 0 {
+		return
+	}
+
+	total := rec.failed.Load()
+	for i := range rec.counts {
+		total += rec.counts[i].Load()
+	}
+	if total == 0 {
+		return
+	}
+
+	buckets := make([]SLOBucket, 0, len(rec.counts)+1)
+	for i := range rec.counts {
+		label := fmt.Sprintf(">=%v", rec.bounds[len(rec.bounds)-1])
+		if i < len(rec.bounds) {
+			label = fmt.Sprintf("<%v", rec.bounds[i])
+		}
+		count := rec.counts[i].Load()
+		buckets = append(buckets, SLOBucket{Label: label, Count: count, Fraction: float64(count) / float64(total)})
+	}
+	failed := rec.failed.Load()
+	buckets = append(buckets, SLOBucket{Label: "fail", Count: failed, Fraction: float64(failed) / float64(total)})
+
+	result.SLO = buckets
+
+	parts := make([]string, len(buckets))
+	for i, b := range buckets {
+		parts[i] = fmt.Sprintf("%s %.1f%%", b.Label, b.Fraction*100)
+	}
+	fmt.Fprintf(rec.out, "%s SLO: %s\n", result.Name, strings.Join(parts, " | "))
+}
@@ -0,0 +1,241 @@
+package benchmark
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// memoryInterval is how often the resident memory of the client is sampled
+const memoryInterval = 100 * time.Millisecond
+
+// memoryPauseRatio is the fraction of the memory ceiling at which the workers
+// are paused, and memoryResumeRatio the fraction below which they resume
+const (
+	memoryPauseRatio  = 0.9
+	memoryResumeRatio = 0.8
+)
+
+// memoryPauseTimeout is how long the workers stay paused waiting for the
+// memory to be reclaimed before the run is aborted
+const memoryPauseTimeout = 30 * time.Second
+
+// MemoryEvent records a pause of the workers caused by the client memory
+// approaching the ceiling
+type MemoryEvent struct {
+	Time     time.Time `json:"time"`
+	Phase    string    `json:"phase"`
+	RSS      uint64    `json:"rss_bytes"`
+	PausedMs float64   `json:"paused_ms"`
+	Resumed  bool      `json:"resumed"`
+
+	// released is closed when the workers resume
+	released chan struct{}
+}
+
+// memoryGuard watches the resident memory of the client, pausing the workers
+// of the running phase while it is close to the ceiling
+type memoryGuard struct {
+	ceiling uint64
+	phase   atomic.Value
+	peak    atomic.Uint64
+	cancel  context.CancelCauseFunc
+	out     io.Writer
+
+	mu     sync.Mutex
+	paused *MemoryEvent
+	events []MemoryEvent
+}
+
+// startMemoryGuard starts watching the client memory against the configured
+// ceiling, returning a context which is cancelled if the ceiling cannot be
+// kept, and a function stopping the guard
+func (r *Runner) startMemoryGuard(ctx context.Context) (context.Context, func()) {
+	if r.Config.MaxClientMem <= 0 {
+		return ctx, func() {}
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	g := &memoryGuard{ceiling: uint64(r.Config.MaxClientMem), cancel: cancel, out: r.Output}
+	g.phase.Store("")
+	r.memory = g
+
+	// Make the garbage collector work harder before the workers are paused
+	previous := debug.SetMemoryLimit(int64(float64(g.ceiling) * memoryPauseRatio))
+
+	watchCtx, stopWatch := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		g.watch(watchCtx)
+	}()
+
+	return ctx, func() {
+		stopWatch()
+		<-done
+		g.resume(true)
+		debug.SetMemoryLimit(previous)
+		cancel(nil)
+
+		g.mu.Lock()
+		r.MemoryEvents = g.events
+		g.mu.Unlock()
+	}
+}
+
+// watch samples the resident memory until the context is cancelled
+func (g *memoryGuard) watch(ctx context.Context) {
+	ticker := time.NewTicker(memoryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			g.check(residentMemory())
+		}
+	}
+}
+
+// check pauses or resumes the workers for a resident memory sample, and
+// aborts the run if the memory stays above the pause threshold
+func (g *memoryGuard) check(rss uint64) {
+	if rss > g.peak.Load() {
+		g.peak.Store(rss)
+	}
+
+	g.mu.Lock()
+	paused := g.paused
+	g.mu.Unlock()
+
+	switch {
+	case paused == nil && rss >= uint64(float64(g.ceiling)*memoryPauseRatio):
+		g.mu.Lock()
+		g.paused = &MemoryEvent{Time: time.Now(), Phase: g.phase.Load().(string), RSS: rss, released: make(chan struct{})}
+		g.mu.Unlock()
+		fmt.Fprintf(g.out, "Client memory at %s of the %s ceiling, pausing workers...\n", formatBytes(rss), formatBytes(g.ceiling))
+
+		// Return the freed memory to the operating system
+		debug.FreeOSMemory()
+	case paused != nil && rss < uint64(float64(g.ceiling)*memoryResumeRatio):
+		g.resume(true)
+		fmt.Fprintf(g.out, "Client memory down to %s, resuming workers\n", formatBytes(rss))
+	case paused != nil && (rss >= g.ceiling || time.Since(paused.Time) > memoryPauseTimeout):
+		g.cancel(fmt.Errorf("client memory at %s could not be kept below the %s ceiling", formatBytes(rss), formatBytes(g.ceiling)))
+		g.resume(false)
+	case paused != nil:
+		debug.FreeOSMemory()
+	}
+}
+
+// resume releases the paused workers, recording the pause
+func (g *memoryGuard) resume(resumed bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.paused == nil {
+		return
+	}
+	event := g.paused
+	event.PausedMs = float64(time.Since(event.Time)) / float64(time.Millisecond)
+	event.Resumed = resumed
+	close(event.released)
+	g.events = append(g.events, *event)
+	g.paused = nil
+}
+
+// wait blocks while the workers are paused
+func (g *memoryGuard) wait() {
+	if g == nil {
+		return
+	}
+	g.mu.Lock()
+	paused := g.paused
+	g.mu.Unlock()
+
+	if paused != nil {
+		<-paused.released
+	}
+}
+
+// startPhase resets the peak memory and returns the number of pauses so far
+func (g *memoryGuard) startPhase(phase string) int {
+	if g == nil {
+		return 0
+	}
+	g.phase.Store(phase)
+	g.peak.Store(residentMemory())
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return len(g.events)
+}
+
+// recordMemory attaches the peak client memory and the pauses of a phase to
+// the results recorded from index onwards
+func (r *Runner) recordMemory(pausesBefore, index int) {
+	g := r.memory
+	if g == nil {
+		return
+	}
+
+	g.mu.Lock()
+	events := append([]MemoryEvent(nil), g.events[pausesBefore:]...)
+	g.mu.Unlock()
+
+	var paused float64
+	for _, event := range events {
+		paused += event.PausedMs
+	}
+
+	for i := index; i < len(r.Results); i++ {
+		result := &r.Results[i]
+		if result.Metrics == nil {
+			result.Metrics = map[string]float64{}
+		}
+		result.Metrics["client_rss_peak_mb"] = float64(g.peak.Load()) / (1 << 20)
+		if len(events) > 0 {
+			result.Metrics["client_mem_pauses"] = float64(len(events))
+			result.Metrics["client_mem_paused_ms"] = paused
+		}
+	}
+}
+
+// residentMemory returns the resident set size of the process, read from
+// /proc on Linux and approximated by the memory obtained by the Go runtime
+// elsewhere
+func residentMemory() uint64 {
+	if data, err := os.ReadFile("/proc/self/statm"); err == nil {
+		fields := bytes.Fields(data)
+		if len(fields) > 1 {
+			if pages, err := strconv.ParseUint(string(fields[1]), 10, 64); err == nil {
+				return pages * uint64(os.Getpagesize())
+			}
+		}
+	}
+
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats.Sys
+}
+
+// formatBytes formats a number of bytes in the largest binary unit
+func formatBytes(n uint64) string {
+	switch {
+	case n >= 1<<30:
+		return fmt.Sprintf("%.2fGB", float64(n)/(1<<30))
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1fMB", float64(n)/(1<<20))
+	default:
+		return fmt.Sprintf("%dKB", n>>10)
+	}
+}
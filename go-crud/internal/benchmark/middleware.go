@@ -0,0 +1,58 @@
+package benchmark
+
+import (
+	"context"
+
+	"github.com/surrealdb/go-crud-bench/internal/config"
+	"github.com/surrealdb/go-crud-bench/middleware"
+)
+
+// Call is an adapter operation passing through the middleware chain
+type Call = middleware.Call
+
+// Handler executes an adapter call
+type Handler = middleware.Handler
+
+// Middleware wraps the handler of every adapter call
+type Middleware = middleware.Middleware
+
+// chain wraps a handler in the middleware of the runner, the first middleware
+// being the outermost, and returns nil without middleware so that the adapter
+// is called directly. The limit on the calls in flight is outermost, so that
+// the operation timeout does not run while a call waits for a slot.
+func (r *Runner) chain(h Handler) Handler {
+	chain := r.Middleware
+	if r.Config.OpTimeout > 0 {
+		chain = append([]Middleware{middleware.Timeout(r.Config.OpTimeout)}, chain...)
+	}
+	if r.limiter != nil {
+		chain = append([]Middleware{r.limiter}, chain...)
+	}
+	if len(chain) == 0 {
+		return nil
+	}
+
+	for i := len(chain) - 1; i >= 0; i-- {
+		h = chain[i](h)
+	}
+	return h
+}
+
+// invoke issues an adapter call through the middleware chain, calling the
+// handler directly without middleware
+func (r *Runner) invoke(ctx context.Context, call *Call, h Handler) error {
+	if handler := r.chain(h); handler != nil {
+		return handler(ctx, call)
+	}
+	return h(ctx, call)
+}
+
+// scan executes a scan through the middleware chain
+func (r *Runner) scan(ctx context.Context, scanConfig config.ScanConfig) (int, error) {
+	call := &Call{Operation: OperationScan, Scan: &scanConfig}
+	err := r.invoke(ctx, call, func(ctx context.Context, call *Call) (err error) {
+		call.Count, err = r.Adapter.Scan(ctx, *call.Scan)
+		return err
+	})
+	return call.Count, err
+}
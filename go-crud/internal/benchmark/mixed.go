@@ -0,0 +1,349 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/surrealdb/go-crud-bench/internal/config"
+	"github.com/surrealdb/go-crud-bench/internal/generators"
+)
+
+// mixAttempts is how many keys an operation of the mixed workload picks
+// before giving up on finding a record which has not been deleted
+const mixAttempts = 1000
+
+// mixOperations maps the operations of mixed workloads to the operation
+// recorded in the results
+var mixOperations = map[string]Operation{
+	"read":   OperationRead,
+	"update": OperationUpdate,
+	"insert": OperationCreate,
+	"delete": OperationDelete,
+}
+
+// mixSlot is a record of the mixed workload key space. Reads and updates
+// hold the slot shared while inserts and deletes hold it exclusively, so that
+// a record is never read while it is being deleted.
+type mixSlot struct {
+	mu   sync.RWMutex
+	live bool
+}
+
+// mixKeys is the key space of the mixed workload: the records loaded by the
+// create phase followed by the keys of the inserts, which become live as the
+// inserts complete
+type mixKeys struct {
+	ks      *keySet
+	slots   []mixSlot
+	chooser generators.KeyChooser
+	next    atomic.Int64
+
+	// latest shifts the picks so that the latest keys are the last inserted
+	latest bool
+}
+
+// runMixed interleaves reads, updates, inserts and deletes on the records
+// loaded by the create phase, in the configured ratios and with keys picked
+// from the configured distribution. The operations are drawn up front so
+// that the keys of the inserts can be generated and encoded before the timer
+// starts.
+func (r *Runner) runMixed(ctx context.Context) error {
+	loaded := r.keys
+	if len(loaded) == 0 {
+		return fmt.Errorf("no records available for the mixed workload")
+	}
+	total := r.Config.Samples
+
+	// Draw the operation of every step from the weights
+	weights := 0
+	for _, entry := range r.Config.Mix {
+		weights += entry.Weight
+	}
+	schedule := make([]uint8, total)
+	inserts := 0
+	for i := range schedule {
+		n := rand.Intn(weights)
+		for j, entry := range r.Config.Mix {
+			if n < entry.Weight {
+				schedule[i] = uint8(j)
+				if entry.Operation == "insert" {
+					inserts++
+				}
+				break
+			}
+			n -= entry.Weight
+		}
+	}
+
+	// Generate the keys of the inserts after the loaded keys
+	generator, err := generators.NewKeyGenerator(r.Config.KeyType)
+	if err != nil {
+		return fmt.Errorf("failed to generate keys: %w", err)
+	}
+	keys := make([]string, len(loaded), len(loaded)+inserts)
+	copy(keys, loaded)
+	for i := 0; i < inserts; i++ {
+		keys = append(keys, generator.Generate(len(loaded)+i))
+	}
+	ks, err := r.newKeySet(keys)
+	if err != nil {
+		return err
+	}
+
+	// Keys are picked from the whole key space, as in YCSB, and picked again
+	// while they have not been inserted yet
+	chooser, err := generators.NewKeyChooser(r.Config.Distribution, len(keys))
+	if err != nil {
+		return err
+	}
+	m := &mixKeys{ks: ks, slots: make([]mixSlot, len(keys)), chooser: chooser, latest: r.Config.Distribution == "latest"}
+	for i := range loaded {
+		m.slots[i].live = true
+	}
+	m.next.Store(int64(len(loaded)))
+	r.mix = m
+
+	// Parse the value template
+	valueTemplate, err := generators.ParseTemplate(r.Config.Value)
+	if err != nil {
+		return fmt.Errorf("failed to process value template: %w", err)
+	}
+
+	if r.Config.Duration > 0 {
+		fmt.Fprintf(r.Output, "Running MIXED benchmark for %v (%s) and %s key distribution...\n", r.Config.Duration, formatMix(r.Config.Mix), r.Config.Distribution)
+	} else {
+		fmt.Fprintf(r.Output, "Running MIXED benchmark with %d operations (%s) and %s key distribution...\n", total, formatMix(r.Config.Mix), r.Config.Distribution)
+	}
+
+	// Start timer
+	startTime := time.Now()
+	recs := make([]*recorder, len(r.Config.Mix))
+	counts := make([]atomic.Int64, len(r.Config.Mix))
+	for j := range recs {
+		recs[j] = r.newRecorder()
+	}
+	steps := r.newSteps(total)
+
+	var wg sync.WaitGroup
+	workers := r.Config.Clients * r.threads
+	errCh := make(chan error, workers)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			ctx := r.clientContext(ctx, w)
+
+			for {
+				r.admit(ctx, w, steps)
+				i, ok := steps.take()
+				if !ok {
+					return
+				}
+				if ctx.Err() != nil {
+					errCh <- ctx.Err()
+					return
+				}
+
+				j := schedule[i%total]
+				operation := r.Config.Mix[j].Operation
+				var err error
+				switch operation {
+				case "read":
+					err = m.access(false, func(k int) error {
+						opStart := r.pace(ctx)
+						_, err := ks.read(ctx, k)
+						recs[j].observe(time.Since(opStart), err)
+						return err
+					})
+				case "update":
+					value := generators.NewValue(generators.RecordRandom("mixed:update", i), valueTemplate)
+					var payload *Payload
+					if payload, err = ks.encode(value); err != nil {
+						break
+					}
+					err = m.access(false, func(k int) error {
+						opStart := r.pace(ctx)
+						err := ks.update(ctx, k, value, payload)
+						recs[j].observe(time.Since(opStart), err)
+						return err
+					})
+				case "insert":
+					value := generators.NewValue(generators.RecordRandom("mixed:insert", i), valueTemplate)
+					var payload *Payload
+					if payload, err = ks.encode(value); err != nil {
+						break
+					}
+					err = m.insert(func(k int) error {
+						opStart := r.pace(ctx)
+						err := ks.create(ctx, k, value, payload)
+						recs[j].observe(time.Since(opStart), err)
+						return err
+					})
+				case "delete":
+					err = m.access(true, func(k int) error {
+						opStart := r.pace(ctx)
+						err := ks.delete(ctx, k)
+						recs[j].observe(time.Since(opStart), err)
+						return err
+					})
+				}
+				if err != nil {
+					errCh <- fmt.Errorf("failed to %s record in step %d: %w", operation, i, err)
+					return
+				}
+				counts[j].Add(1)
+			}
+		}()
+	}
+
+	// Wait for all goroutines to finish
+	wg.Wait()
+
+	// Check for errors
+	close(errCh)
+	for err := range errCh {
+		if err != nil {
+			return err
+		}
+	}
+
+	// Record a result per operation, sharing the duration of the phase
+	duration := time.Since(startTime)
+	for j, entry := range r.Config.Mix {
+		count := counts[j].Load()
+		if count == 0 {
+			continue
+		}
+		result := Result{
+			Operation: mixOperations[entry.Operation],
+			Name:      "mixed_" + entry.Operation,
+			Duration:  duration,
+			Count:     int(count),
+			Metrics: map[string]float64{
+				"share": float64(count) / float64(steps.count()),
+			},
+		}
+		recs[j].apply(&result)
+		r.Results = append(r.Results, result)
+	}
+
+	fmt.Fprintf(r.Output, "MIXED completed in %v (%.0f ops/sec)\n", duration, float64(steps.count())/duration.Seconds())
+	return nil
+}
+
+// access runs fn on a live record picked from the key space, holding the
+// record exclusively when it is deleted
+func (m *mixKeys) access(remove bool, fn func(i int) error) error {
+	for attempt := 0; attempt < mixAttempts; attempt++ {
+		i, next := m.chooser.Next(), int(m.next.Load())
+		if m.latest {
+			i -= len(m.slots) - next
+		}
+		if i < 0 || i >= next {
+			continue
+		}
+
+		slot := &m.slots[i]
+		if remove {
+			slot.mu.Lock()
+		} else {
+			slot.mu.RLock()
+		}
+		live := slot.live
+		var err error
+		if live {
+			err = fn(i)
+			if remove && err == nil {
+				slot.live = false
+			}
+		}
+		if remove {
+			slot.mu.Unlock()
+		} else {
+			slot.mu.RUnlock()
+		}
+
+		if live {
+			return err
+		}
+	}
+	return fmt.Errorf("no live record found in %d attempts", mixAttempts)
+}
+
+// insert runs fn on the next key of the inserts, making the record live once
+// it has been created
+func (m *mixKeys) insert(fn func(i int) error) error {
+	i := int(m.next.Add(1)) - 1
+	slot := &m.slots[i]
+	slot.mu.Lock()
+	defer slot.mu.Unlock()
+
+	if err := fn(i); err != nil {
+		return err
+	}
+	slot.live = true
+	return nil
+}
+
+// formatMix formats the operation ratios of a mixed workload
+func formatMix(mix []config.MixEntry) string {
+	parts := make([]string, len(mix))
+	for i, entry := range mix {
+		parts[i] = fmt.Sprintf("%s=%d", entry.Operation, entry.Weight)
+	}
+	return strings.Join(parts, ",")
+}
+
+// runMixedDelete deletes the records left by the mixed workload, both loaded
+// and inserted
+func (r *Runner) runMixedDelete(ctx context.Context) error {
+	m := r.mix
+	var live []int
+	var keys []string
+	for i := range m.slots {
+		if m.slots[i].live {
+			live = append(live, i)
+			keys = append(keys, m.ks.keys[i])
+		}
+	}
+
+	fmt.Fprintf(r.Output, "Running DELETE benchmark with %d samples...\n", len(live))
+
+	// Start timer
+	startTime := time.Now()
+	rec := r.newRecorder()
+
+	err := r.forEachKey(ctx, keys, func(ctx context.Context, i int) error {
+		opStart := r.pace(ctx)
+		err := m.ks.delete(ctx, live[i])
+		rec.observe(time.Since(opStart), err)
+		if err != nil {
+			return fmt.Errorf("failed to delete record %d: %w", live[i], err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Record result
+	duration := time.Since(startTime)
+	result := Result{
+		Operation: OperationDelete,
+		Name:      "delete_all",
+		Duration:  duration,
+		Count:     len(live),
+	}
+	rec.apply(&result)
+	r.Results = append(r.Results, result)
+
+	fmt.Fprintf(r.Output, "DELETE completed in %v\n", duration)
+	return nil
+}
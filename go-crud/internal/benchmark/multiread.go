@@ -0,0 +1,125 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// OperationReadMulti represents a batched point read of several keys
+const OperationReadMulti Operation = "READ_MULTI"
+
+// MultiReader is implemented by adapters that can fetch several records in a
+// single request (SQL IN lists, Redis MGET, MongoDB $in)
+type MultiReader interface {
+	// ReadMulti retrieves the records with the given keys, in any order.
+	// Missing keys are omitted from the result.
+	ReadMulti(ctx context.Context, keys []string) ([]map[string]interface{}, error)
+}
+
+// runReadMulti reads every record in batches of the configured size, and
+// reports the per-record throughput together with the per-batch latency
+func (r *Runner) runReadMulti(ctx context.Context) error {
+	size := r.Config.ReadMulti
+
+	reader, ok := r.Adapter.(MultiReader)
+	if !ok {
+		return fmt.Errorf("database %s does not support multi-key reads", r.Adapter.Name())
+	}
+	if err := r.requireStringKeys("multi-key reads"); err != nil {
+		return err
+	}
+
+	keys := r.keys
+	if len(keys) == 0 {
+		return fmt.Errorf("no records available for multi-key reads")
+	}
+
+	fmt.Fprintf(r.Output, "Running READ_MULTI benchmark with %d samples in batches of %d...\n", len(keys), size)
+
+	// Split the keys into batches, which are shared out between the workers
+	var batches [][]string
+	for start := 0; start < len(keys); start += size {
+		end := start + size
+		if end > len(keys) {
+			end = len(keys)
+		}
+		batches = append(batches, keys[start:end])
+	}
+
+	// Start timer
+	startTime := time.Now()
+	rec := r.newRecorder()
+
+	var wg sync.WaitGroup
+	workers := r.Config.Clients * r.threads
+	errCh := make(chan error, workers)
+
+	steps := &steps{total: len(batches)}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func(workerID int) {
+			defer wg.Done()
+			ctx := r.clientContext(ctx, workerID)
+
+			for {
+				i, ok := steps.take()
+				if !ok {
+					return
+				}
+				if ctx.Err() != nil {
+					errCh <- ctx.Err()
+					return
+				}
+
+				batch := batches[i]
+				opStart := r.pace(ctx)
+				call := &Call{Operation: OperationReadMulti, Keys: batch}
+				err := r.invoke(ctx, call, func(ctx context.Context, call *Call) error {
+					records, err := reader.ReadMulti(ctx, call.Keys)
+					call.Count = len(records)
+					return err
+				})
+				if err == nil && call.Count != len(batch) {
+					err = fmt.Errorf("read %d of %d records", call.Count, len(batch))
+				}
+				rec.observe(time.Since(opStart), err)
+				if err != nil {
+					errCh <- fmt.Errorf("failed to read batch %d: %w", i, err)
+					return
+				}
+			}
+		}(w)
+	}
+
+	// Wait for all goroutines to finish
+	wg.Wait()
+
+	// Check for errors
+	close(errCh)
+	for err := range errCh {
+		if err != nil {
+			return err
+		}
+	}
+
+	// Record result, counting records rather than batches
+	duration := time.Since(startTime)
+	result := Result{
+		Operation: OperationReadMulti,
+		Name:      "read_multi",
+		Duration:  duration,
+		Count:     len(keys),
+		Metrics: map[string]float64{
+			"batch_size": float64(size),
+			"batches":    float64(len(batches)),
+		},
+	}
+	rec.apply(&result)
+	r.Results = append(r.Results, result)
+
+	fmt.Fprintf(r.Output, "READ_MULTI completed in %v\n", duration)
+	return nil
+}
@@ -0,0 +1,18 @@
+package benchmark
+
+import "context"
+
+// Namespaced is implemented by adapters which can isolate a benchmark run in its
+// own table or collection, so that several runs can share one endpoint
+type Namespaced interface {
+	// SetNamespace sets the unique suffix of the table or collection used by the run
+	SetNamespace(namespace string)
+}
+
+// Janitor is implemented by adapters which can remove namespaced tables or
+// collections left behind on a shared endpoint by interrupted runs
+type Janitor interface {
+	// DropNamespace drops the benchmark table of the given namespace and the
+	// tables named after it, and returns their names
+	DropNamespace(ctx context.Context, namespace string) ([]string, error)
+}
@@ -0,0 +1,35 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/surrealdb/go-crud-bench/internal/docker"
+)
+
+// ContainerProvider is implemented by adapters that manage a Docker container
+type ContainerProvider interface {
+	// Container returns the managed container, or nil when connecting to an external endpoint
+	Container() *docker.Container
+}
+
+// applyNetworkProfile shapes the traffic of the managed database container
+// using tc netem according to the configured network profile
+func (r *Runner) applyNetworkProfile(ctx context.Context) error {
+	profile := r.Config.Network
+
+	provider, ok := r.Adapter.(ContainerProvider)
+	if !ok || provider.Container() == nil {
+		return fmt.Errorf("database %s does not run in a managed container", r.Adapter.Name())
+	}
+
+	fmt.Fprintf(r.Output, "Applying network profile '%s' (delay %v ± %v, loss %g%%)...\n",
+		profile.Name, profile.Delay, profile.Jitter, profile.Loss)
+
+	cmd := append([]string{"tc", "qdisc", "add", "dev", "eth0", "root", "netem"}, profile.NetemArgs()...)
+	if _, err := provider.Container().Exec(ctx, cmd); err != nil {
+		return fmt.Errorf("failed to apply network profile (is tc installed in the image?): %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package benchmark
+
+import (
+	"context"
+	"time"
+
+	"github.com/surrealdb/go-crud-bench/internal/dbutils"
+)
+
+// progressInterval is how often progress events are reported during a phase
+const progressInterval = time.Second
+
+// Observer receives benchmark lifecycle events as the runner progresses
+type Observer interface {
+	// PhaseStart is called before a phase starts
+	PhaseStart(phase string)
+
+	// Progress is called periodically with the number of operations completed
+	// in the phase and how many of them failed
+	Progress(phase string, completed, failed int64, elapsed time.Duration)
+
+	// PhaseEnd is called once a phase has finished with the results it recorded
+	PhaseEnd(phase string, results []Result, err error)
+}
+
+// Annotator is implemented by observers which record the changes made to the
+// running benchmark through the control socket. Observers forwarding events
+// to another observer forward the annotations too.
+type Annotator interface {
+	// Annotate is called for every change made to the running benchmark
+	Annotate(annotation Annotation)
+}
+
+// reportProgress emits progress events for the running phase until the context is cancelled
+func (r *Runner) reportProgress(ctx context.Context, phase string, start time.Time) {
+	ticker := time.NewTicker(progressInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.Observer.Progress(phase, r.completed.Load(), r.failed.Load(), time.Since(start))
+		}
+	}
+}
+
+// QueryLogger is implemented by adapters which can log the statements they issue
+type QueryLogger interface {
+	// SetQueryLog sets the log receiving every statement executed by the adapter
+	SetQueryLog(log *dbutils.QueryLog)
+}
@@ -0,0 +1,37 @@
+package benchmark
+
+import "fmt"
+
+// DataFileProvider is implemented by embedded adapters which store their data in local files
+type DataFileProvider interface {
+	// DataFiles returns the files and directories holding the database data
+	DataFiles() []string
+}
+
+// PageCacheDrop records a page cache eviction performed before a phase
+type PageCacheDrop struct {
+	Phase  string `json:"phase"`
+	Method string `json:"method"`
+}
+
+// dropPageCache evicts the database data from the OS page cache before a read
+// phase, so that cold reads are actually served from storage. Embedded adapters
+// have their data files evicted with fadvise, otherwise the whole host page
+// cache is dropped, which requires root privileges.
+func (r *Runner) dropPageCache(phase string) error {
+	method := "drop_caches"
+	if provider, ok := r.Adapter.(DataFileProvider); ok {
+		method = "fadvise"
+		for _, path := range provider.DataFiles() {
+			if err := fadviseTree(path); err != nil {
+				return fmt.Errorf("failed to evict %s from the page cache: %w", path, err)
+			}
+		}
+	} else if err := dropHostPageCache(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(r.Output, "Dropped page cache before %s using %s\n", phase, method)
+	r.PageCacheDrops = append(r.PageCacheDrops, PageCacheDrop{Phase: phase, Method: method})
+	return nil
+}
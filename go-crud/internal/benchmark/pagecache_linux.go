@@ -0,0 +1,42 @@
+package benchmark
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/sys/unix"
+)
+
+// dropHostPageCache drops the whole host page cache, which requires root
+// privileges
+func dropHostPageCache() error {
+	// Flush dirty pages first, as only clean pages can be dropped
+	unix.Sync()
+	if err := os.WriteFile("/proc/sys/vm/drop_caches", []byte("3\n"), 0200); err != nil {
+		return fmt.Errorf("failed to drop the page cache (requires root): %w", err)
+	}
+	return nil
+}
+
+// fadviseTree advises the kernel that the files below path will not be needed
+func fadviseTree(path string) error {
+	return filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		// Flush dirty pages first, as only clean pages can be evicted
+		if err := f.Sync(); err != nil {
+			return err
+		}
+		return unix.Fadvise(int(f.Fd()), 0, 0, unix.FADV_DONTNEED)
+	})
+}
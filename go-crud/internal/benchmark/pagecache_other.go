@@ -0,0 +1,21 @@
+//go:build !linux
+
+package benchmark
+
+import "errors"
+
+// errPageCacheUnsupported reports that the page cache cannot be dropped on
+// this platform
+var errPageCacheUnsupported = errors.New("dropping the page cache is unsupported on this platform")
+
+// dropHostPageCache reports that the host page cache cannot be dropped on
+// this platform
+func dropHostPageCache() error {
+	return errPageCacheUnsupported
+}
+
+// fadviseTree reports that files cannot be evicted from the page cache on
+// this platform
+func fadviseTree(path string) error {
+	return errPageCacheUnsupported
+}
@@ -0,0 +1,48 @@
+package benchmark
+
+import (
+	"context"
+
+	"github.com/surrealdb/go-crud-bench/middleware"
+)
+
+// RecordFormat selects how records are handed to adapters
+type RecordFormat string
+
+const (
+	// RecordFormatMap passes records as maps through the Adapter interface,
+	// which adapters marshal themselves
+	RecordFormatMap RecordFormat = "map"
+	// RecordFormatPayload passes records as payloads encoded before the
+	// measured operation to adapters which support them
+	RecordFormatPayload RecordFormat = "payload"
+)
+
+// PayloadAdapter is implemented by adapters which can store records as
+// pre-encoded payloads, so that fast key-value engines do not pay for a map
+// and the reflection-heavy JSON marshaling within the measured operations.
+// Keys are passed in their native encoding, as raw bytes with the string
+// encoding.
+type PayloadAdapter interface {
+	// CreatePayload inserts a new record with the given payload
+	CreatePayload(ctx context.Context, key []byte, payload *Payload) error
+
+	// ReadPayload retrieves the payload of a record
+	ReadPayload(ctx context.Context, key []byte) (*Payload, error)
+
+	// UpdatePayload replaces the payload of a record
+	UpdatePayload(ctx context.Context, key []byte, payload *Payload) error
+}
+
+// Payload is a record encoded as a JSON document
+type Payload = middleware.Payload
+
+// NewPayload encodes a record with the selected JSON encoder
+func NewPayload(value map[string]interface{}) (*Payload, error) {
+	return middleware.NewPayload(value)
+}
+
+// PayloadOf wraps the JSON encoding of a record, as stored by an adapter
+func PayloadOf(data []byte) *Payload {
+	return middleware.PayloadOf(data)
+}
@@ -0,0 +1,81 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/surrealdb/go-crud-bench/internal/dbutils"
+)
+
+// StepRecorder is implemented by adapters that report the duration of their
+// provisioning steps (image pull, container start, readiness wait, schema creation)
+type StepRecorder interface {
+	// SetSteps sets the recorder receiving the durations of the provisioning steps
+	SetSteps(steps *dbutils.Steps)
+}
+
+// Printer is implemented by adapters printing messages while they provision
+// and tear down the database, such as the progress of image pulls
+type Printer interface {
+	// SetOutput sets the writer receiving the messages of the adapter
+	SetOutput(w io.Writer)
+}
+
+// PoolWarmer is implemented by adapters holding a pool of connections, which
+// can be established before the measured phases
+type PoolWarmer interface {
+	// WarmPool establishes the given number of connections concurrently
+	WarmPool(ctx context.Context, connections int) error
+}
+
+// Provisioning contains the time spent setting up and tearing down the database
+type Provisioning struct {
+	Initialize ProvisionTiming `json:"initialize"`
+	PoolWarmup *PoolWarmup     `json:"pool_warmup,omitempty"`
+	Cleanup    ProvisionTiming `json:"cleanup"`
+}
+
+// PoolWarmup is the time spent establishing the connection pool
+type PoolWarmup struct {
+	Connections int           `json:"connections"`
+	Duration    time.Duration `json:"duration"`
+}
+
+// ProvisionTiming is the total duration of an adapter lifecycle call and its steps
+type ProvisionTiming struct {
+	Duration time.Duration  `json:"duration"`
+	Steps    []dbutils.Step `json:"steps,omitempty"`
+}
+
+// String formats the timing with its steps for the console
+func (t ProvisionTiming) String() string {
+	if len(t.Steps) == 0 {
+		return t.Duration.Round(time.Millisecond).String()
+	}
+	parts := make([]string, len(t.Steps))
+	for i, step := range t.Steps {
+		parts[i] = fmt.Sprintf("%s %v", step.Name, step.Duration.Round(time.Millisecond))
+	}
+	return fmt.Sprintf("%v (%s)", t.Duration.Round(time.Millisecond), strings.Join(parts, ", "))
+}
+
+// warmPool establishes a connection for every worker before the measured
+// phases, so that the first operations do not pay for the connection setup
+func (r *Runner) warmPool(ctx context.Context) error {
+	warmer, ok := r.Adapter.(PoolWarmer)
+	if !ok {
+		return nil
+	}
+
+	connections := r.Config.Clients * r.Config.Threads
+	start := time.Now()
+	if err := warmer.WarmPool(ctx, connections); err != nil {
+		return fmt.Errorf("failed to warm the connection pool: %w", err)
+	}
+	r.Provisioning.PoolWarmup = &PoolWarmup{Connections: connections, Duration: time.Since(start)}
+	fmt.Fprintf(r.Output, "Warmed the connection pool with %d connections in %v\n", connections, r.Provisioning.PoolWarmup.Duration.Round(time.Millisecond))
+	return nil
+}
@@ -0,0 +1,103 @@
+package benchmark
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateSustainedRatio is the fraction of the target rate below which a phase
+// is reported as not sustaining the rate
+const rateSustainedRatio = 0.95
+
+// pacer spaces the operations of a phase at the target rate. It acts as a
+// token bucket shared by every worker, holding a single token, and hands out
+// the time at which every operation was meant to start. The rate can change
+// while the phase runs, restarting the schedule at the new rate.
+type pacer struct {
+	mu        sync.Mutex
+	start     time.Time
+	rate      int
+	interval  time.Duration
+	scheduled int64
+}
+
+// startPacer starts the schedule of a phase at the given rate. With a
+// control socket every phase is paced, unpaced until a rate is set.
+func (r *Runner) startPacer(rate int) {
+	if rate <= 0 && r.control == nil {
+		return
+	}
+	p := &pacer{}
+	p.setRate(rate)
+	r.pacer.Store(p)
+}
+
+// setRate restarts the schedule at the given rate, 0 running unpaced
+func (p *pacer) setRate(rate int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.start = time.Now()
+	p.rate = rate
+	p.interval = 0
+	if rate > 0 {
+		p.interval = time.Duration(float64(time.Second) / float64(rate))
+	}
+	p.scheduled = 0
+}
+
+// pace waits until the next operation of the schedule is due and returns the
+// time at which it was meant to start. Latencies measured from that time
+// include the time an operation waited behind slower operations, correcting
+// for coordinated omission. Without a target rate it returns the current time.
+func (r *Runner) pace(ctx context.Context) time.Time {
+	p := r.pacer.Load()
+	if p == nil {
+		return time.Now()
+	}
+
+	p.mu.Lock()
+	if p.interval == 0 {
+		p.mu.Unlock()
+		return time.Now()
+	}
+	intended := p.start.Add(time.Duration(p.scheduled) * p.interval)
+	p.scheduled++
+	p.mu.Unlock()
+
+	if wait := time.Until(intended); wait > 0 {
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+	return intended
+}
+
+// recordRate attaches the target and achieved rates of the phase to the
+// results recorded from index onwards, for paced phases which issued
+// operations. When the rate changed during the phase, the rates are those
+// since the last change.
+func (r *Runner) recordRate(index int) {
+	p := r.pacer.Swap(nil)
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.rate <= 0 || p.scheduled == 0 {
+		return
+	}
+
+	achieved := float64(p.scheduled) / time.Since(p.start).Seconds()
+	for i := index; i < len(r.Results); i++ {
+		result := &r.Results[i]
+		if result.Metrics == nil {
+			result.Metrics = map[string]float64{}
+		}
+		result.Metrics["target_rate"] = float64(p.rate)
+		result.Metrics["achieved_rate"] = achieved
+	}
+}
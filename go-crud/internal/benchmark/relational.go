@@ -0,0 +1,169 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/surrealdb/go-crud-bench/internal/generators"
+)
+
+// RelationalAdapter is implemented by SQL adapters that can store every record
+// as a parent row with child rows in a second table, referencing the parent
+// through a foreign key which cascades deletes
+type RelationalAdapter interface {
+	// CreateChildTable creates the empty child table
+	CreateChildTable(ctx context.Context) error
+
+	// CreateWithChildren inserts a parent row and its child rows in a single
+	// transaction
+	CreateWithChildren(ctx context.Context, key string, value map[string]interface{}, children []map[string]interface{}) error
+
+	// ReadWithChildren retrieves a parent row together with its child rows,
+	// joining the two tables
+	ReadWithChildren(ctx context.Context, key string) (map[string]interface{}, []map[string]interface{}, error)
+
+	// DropChildTable removes the child table
+	DropChildTable(ctx context.Context) error
+}
+
+// relational returns the adapter as a RelationalAdapter, checking that the
+// relational workload can run
+func (r *Runner) relational() (RelationalAdapter, error) {
+	adapter, ok := r.Adapter.(RelationalAdapter)
+	if !ok {
+		return nil, fmt.Errorf("database %s does not support the relational workload", r.Adapter.Name())
+	}
+	if err := r.requireStringKeys("relational workloads"); err != nil {
+		return nil, err
+	}
+	return adapter, nil
+}
+
+// runCreateRelational inserts every record as a parent row with the
+// configured number of child rows, each generated from the value template
+func (r *Runner) runCreateRelational(ctx context.Context) error {
+	adapter, err := r.relational()
+	if err != nil {
+		return err
+	}
+	children := r.Config.Children
+
+	fmt.Fprintf(r.Output, "Running CREATE benchmark with %d samples and %d children each...\n", r.Config.Samples, children)
+
+	// Generate keys
+	keys, err := generators.GenerateKeys(r.Config.KeyType, r.Config.Samples, r.Config.Random)
+	if err != nil {
+		return fmt.Errorf("failed to generate keys: %w", err)
+	}
+	r.keys = keys
+
+	// Parse the value template
+	valueTemplate, err := generators.ParseTemplate(r.Config.Value)
+	if err != nil {
+		return fmt.Errorf("failed to process value template: %w", err)
+	}
+
+	// Start timer
+	startTime := time.Now()
+	rec := r.newRecorder()
+
+	err = r.forEachKey(ctx, keys, func(ctx context.Context, i int) error {
+		// The parent and its child rows are generated from the same source
+		rnd := generators.RecordRandom("create", i)
+		value := generators.NewValue(rnd, valueTemplate)
+		r.profiler.Observe(i, value)
+		rows := make([]map[string]interface{}, children)
+		for j := range rows {
+			rows[j] = generators.NewValue(rnd, valueTemplate)
+		}
+
+		opStart := r.pace(ctx)
+		err := r.invoke(ctx, &Call{Operation: OperationCreate, Key: keys[i], Value: value}, func(ctx context.Context, call *Call) error {
+			return adapter.CreateWithChildren(ctx, call.Key, call.Value, rows)
+		})
+		rec.observe(time.Since(opStart), err)
+		if err != nil {
+			return fmt.Errorf("failed to create record %d: %w", i, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Record result
+	duration := time.Since(startTime)
+	result := Result{
+		Operation: OperationCreate,
+		Name:      "create_all",
+		Duration:  duration,
+		Count:     len(keys),
+		Metrics: map[string]float64{
+			"children": float64(children),
+		},
+	}
+	rec.apply(&result)
+	r.Results = append(r.Results, result)
+
+	fmt.Fprintf(r.Output, "CREATE completed in %v\n", duration)
+	return nil
+}
+
+// runReadRelational reads every record joined with its child rows, verifying
+// that every child row is returned
+func (r *Runner) runReadRelational(ctx context.Context) error {
+	adapter, err := r.relational()
+	if err != nil {
+		return err
+	}
+	children := r.Config.Children
+
+	keys := r.keys
+	if len(keys) == 0 {
+		return fmt.Errorf("no records available for relational reads")
+	}
+
+	fmt.Fprintf(r.Output, "Running READ benchmark with %d samples and %d children each...\n", len(keys), children)
+
+	// Start timer
+	startTime := time.Now()
+	rec := r.newRecorder()
+
+	err = r.forEachKey(ctx, keys, func(ctx context.Context, i int) error {
+		opStart := r.pace(ctx)
+		var rows []map[string]interface{}
+		err := r.invoke(ctx, &Call{Operation: OperationRead, Key: keys[i]}, func(ctx context.Context, call *Call) (err error) {
+			call.Record, rows, err = adapter.ReadWithChildren(ctx, call.Key)
+			return err
+		})
+		if err == nil && len(rows) != children {
+			err = fmt.Errorf("read %d of %d child rows", len(rows), children)
+		}
+		rec.observe(time.Since(opStart), err)
+		if err != nil {
+			return fmt.Errorf("failed to read record %d: %w", i, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Record result
+	duration := time.Since(startTime)
+	result := Result{
+		Operation: OperationRead,
+		Name:      "read_all",
+		Duration:  duration,
+		Count:     len(keys),
+		Metrics: map[string]float64{
+			"children": float64(children),
+		},
+	}
+	rec.apply(&result)
+	r.Results = append(r.Results, result)
+
+	fmt.Fprintf(r.Output, "READ completed in %v\n", duration)
+	return nil
+}
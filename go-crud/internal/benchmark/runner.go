@@ -2,82 +2,90 @@ package benchmark
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
 
+	"github.com/surrealdb/go-crud-bench/internal/config"
 	"github.com/surrealdb/go-crud-bench/internal/generators"
 )
 
 // runCreate executes the create benchmark
 func (r *Runner) runCreate(ctx context.Context) error {
-	fmt.Printf("Running CREATE benchmark with %d samples...\n", r.Config.Samples)
+	if r.Config.BatchSize > 1 {
+		return r.runCreateBatched(ctx)
+	}
+
+	fmt.Fprintf(r.Output, "Running CREATE benchmark with %d samples...\n", r.Config.Samples)
 	
 	// Generate keys
 	keys, err := generators.GenerateKeys(r.Config.KeyType, r.Config.Samples, r.Config.Random)
 	if err != nil {
 		return fmt.Errorf("failed to generate keys: %w", err)
 	}
+	ks, err := r.newKeySet(keys)
+	if err != nil {
+		return err
+	}
+	r.keys = keys
 	
-	// Generate sample value template
-	valueTemplate, err := generators.ProcessTemplate(r.Config.Value)
+	// Parse the value template
+	valueTemplate, err := generators.ParseTemplate(r.Config.Value)
 	if err != nil {
 		return fmt.Errorf("failed to process value template: %w", err)
 	}
 	
 	// Start timer
 	startTime := time.Now()
+	rec := r.newRecorder()
 	
 	// Create records
 	var wg sync.WaitGroup
-	errCh := make(chan error, r.Config.Clients*r.Config.Threads)
+	workers := r.Config.Clients * r.threads
+	errCh := make(chan error, workers)
 	
-	// Process in batches based on client and thread count
-	batchSize := r.Config.Samples / (r.Config.Clients * r.Config.Threads)
-	if batchSize == 0 {
-		batchSize = 1
-	}
+	// Hand the records out one by one, so that no worker idles while
+	// another still has records left
+	steps := &steps{total: r.Config.Samples}
 	
-	for c := 0; c < r.Config.Clients; c++ {
-		for t := 0; t < r.Config.Threads; t++ {
-			wg.Add(1)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		
+		go func() {
+			defer wg.Done()
+			ctx := r.clientContext(ctx, w)
 			
-			go func(clientID, threadID int) {
-				defer wg.Done()
-				
-				// Calculate start and end indices for this worker
-				start := (clientID*r.Config.Threads + threadID) * batchSize
-				end := start + batchSize
-				
-				if end > r.Config.Samples {
-					end = r.Config.Samples
+			for {
+				r.admit(ctx, w, steps)
+				i, ok := steps.take()
+				if !ok {
+					return
+				}
+				if ctx.Err() != nil {
+					errCh <- ctx.Err()
+					return
 				}
 				
-				if start >= r.Config.Samples {
+				// Generate a unique value for this record
+				value := generators.NewValue(generators.RecordRandom("create", i), valueTemplate)
+				r.profiler.Observe(i, value)
+				payload, err := ks.encode(value)
+				if err != nil {
+					errCh <- fmt.Errorf("failed to encode record %d: %w", i, err)
 					return
 				}
 				
-				// Process assigned keys
-				for i := start; i < end; i++ {
-					select {
-					case <-ctx.Done():
-						errCh <- ctx.Err()
-						return
-					default:
-						// Generate a unique value for this record
-						value := make(map[string]interface{})
-						for k, v := range valueTemplate {
-							value[k] = generators.ProcessValue(v)
-						}
-						
-						if err := r.Adapter.Create(ctx, keys[i], value); err != nil {
-							errCh <- fmt.Errorf("failed to create record %d: %w", i, err)
-							return
-						}
-					}
+				opStart := r.pace(ctx)
+				r.feed.send(ks.keys[i], opStart)
+				err = ks.create(ctx, i, value, payload)
+				rec.observe(time.Since(opStart), err)
+				if err != nil {
+					errCh <- fmt.Errorf("failed to create record %d: %w", i, err)
+					return
 				}
-			}(c, t)
-		}
+			}
+		}()
 	}
 	
 	// Wait for all goroutines to finish
@@ -93,74 +101,81 @@ func (r *Runner) runCreate(ctx context.Context) error {
 	
 	// Record result
 	duration := time.Since(startTime)
-	r.Results = append(r.Results, Result{
+	result := Result{
 		Operation: OperationCreate,
 		Name:      "create_all",
 		Duration:  duration,
 		Count:     r.Config.Samples,
-	})
+	}
+	rec.apply(&result)
+	r.Results = append(r.Results, result)
 	
-	fmt.Printf("CREATE completed in %v\n", duration)
+	fmt.Fprintf(r.Output, "CREATE completed in %v\n", duration)
 	return nil
 }
 
 // runRead executes the read benchmark
 func (r *Runner) runRead(ctx context.Context) error {
-	fmt.Printf("Running READ benchmark with %d samples...\n", r.Config.Samples)
+	if r.Config.Duration > 0 {
+		return r.runReadTimed(ctx)
+	}
+
+	fmt.Fprintf(r.Output, "Running READ benchmark with %d samples...\n", r.Config.Samples)
 	
 	// Generate keys (same order as create)
 	keys, err := generators.GenerateKeys(r.Config.KeyType, r.Config.Samples, r.Config.Random)
 	if err != nil {
 		return fmt.Errorf("failed to generate keys: %w", err)
 	}
+	keys, err = r.accessKeys(keys)
+	if err != nil {
+		return err
+	}
+	ks, err := r.newKeySet(keys)
+	if err != nil {
+		return err
+	}
 	
 	// Start timer
 	startTime := time.Now()
+	rec := r.newRecorder()
 	
 	// Read records
 	var wg sync.WaitGroup
-	errCh := make(chan error, r.Config.Clients*r.Config.Threads)
+	workers := r.Config.Clients * r.threads
+	errCh := make(chan error, workers)
 	
-	// Process in batches based on client and thread count
-	batchSize := r.Config.Samples / (r.Config.Clients * r.Config.Threads)
-	if batchSize == 0 {
-		batchSize = 1
-	}
+	// Hand the records out one by one, so that no worker idles while
+	// another still has records left
+	steps := &steps{total: r.Config.Samples}
 	
-	for c := 0; c < r.Config.Clients; c++ {
-		for t := 0; t < r.Config.Threads; t++ {
-			wg.Add(1)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		
+		go func() {
+			defer wg.Done()
+			ctx := r.clientContext(ctx, w)
 			
-			go func(clientID, threadID int) {
-				defer wg.Done()
-				
-				// Calculate start and end indices for this worker
-				start := (clientID*r.Config.Threads + threadID) * batchSize
-				end := start + batchSize
-				
-				if end > r.Config.Samples {
-					end = r.Config.Samples
+			for {
+				r.admit(ctx, w, steps)
+				i, ok := steps.take()
+				if !ok {
+					return
 				}
-				
-				if start >= r.Config.Samples {
+				if ctx.Err() != nil {
+					errCh <- ctx.Err()
 					return
 				}
 				
-				// Process assigned keys
-				for i := start; i < end; i++ {
-					select {
-					case <-ctx.Done():
-						errCh <- ctx.Err()
-						return
-					default:
-						if _, err := r.Adapter.Read(ctx, keys[i]); err != nil {
-							errCh <- fmt.Errorf("failed to read record %d: %w", i, err)
-							return
-						}
-					}
+				opStart := r.pace(ctx)
+				_, err := ks.read(ctx, i)
+				rec.observe(time.Since(opStart), err)
+				if err != nil {
+					errCh <- fmt.Errorf("failed to read record %d: %w", i, err)
+					return
 				}
-			}(c, t)
-		}
+			}
+		}()
 	}
 	
 	// Wait for all goroutines to finish
@@ -176,86 +191,98 @@ func (r *Runner) runRead(ctx context.Context) error {
 	
 	// Record result
 	duration := time.Since(startTime)
-	r.Results = append(r.Results, Result{
+	result := Result{
 		Operation: OperationRead,
 		Name:      "read_all",
 		Duration:  duration,
 		Count:     r.Config.Samples,
-	})
+	}
+	rec.apply(&result)
+	r.Results = append(r.Results, result)
 	
-	fmt.Printf("READ completed in %v\n", duration)
+	fmt.Fprintf(r.Output, "READ completed in %v\n", duration)
 	return nil
 }
 
 // runUpdate executes the update benchmark
 func (r *Runner) runUpdate(ctx context.Context) error {
-	fmt.Printf("Running UPDATE benchmark with %d samples...\n", r.Config.Samples)
+	if UpdateMode(r.Config.UpdateMode) == UpdateModeVersioned {
+		return r.runUpdateVersioned(ctx)
+	}
+	if r.Config.Duration > 0 {
+		return r.runUpdateTimed(ctx)
+	}
+
+	fmt.Fprintf(r.Output, "Running UPDATE benchmark with %d samples...\n", r.Config.Samples)
 	
 	// Generate keys (same order as create)
 	keys, err := generators.GenerateKeys(r.Config.KeyType, r.Config.Samples, r.Config.Random)
 	if err != nil {
 		return fmt.Errorf("failed to generate keys: %w", err)
 	}
+	keys, err = r.accessKeys(keys)
+	if err != nil {
+		return err
+	}
+	ks, err := r.newKeySet(keys)
+	if err != nil {
+		return err
+	}
 	
-	// Generate sample value template
-	valueTemplate, err := generators.ProcessTemplate(r.Config.Value)
+	// Parse the value template
+	valueTemplate, err := generators.ParseTemplate(r.Config.Value)
 	if err != nil {
 		return fmt.Errorf("failed to process value template: %w", err)
 	}
 	
 	// Start timer
 	startTime := time.Now()
+	rec := r.newRecorder()
 	
 	// Update records
 	var wg sync.WaitGroup
-	errCh := make(chan error, r.Config.Clients*r.Config.Threads)
+	workers := r.Config.Clients * r.threads
+	errCh := make(chan error, workers)
 	
-	// Process in batches based on client and thread count
-	batchSize := r.Config.Samples / (r.Config.Clients * r.Config.Threads)
-	if batchSize == 0 {
-		batchSize = 1
-	}
+	// Hand the records out one by one, so that no worker idles while
+	// another still has records left
+	steps := &steps{total: r.Config.Samples}
 	
-	for c := 0; c < r.Config.Clients; c++ {
-		for t := 0; t < r.Config.Threads; t++ {
-			wg.Add(1)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		
+		go func() {
+			defer wg.Done()
+			ctx := r.clientContext(ctx, w)
 			
-			go func(clientID, threadID int) {
-				defer wg.Done()
-				
-				// Calculate start and end indices for this worker
-				start := (clientID*r.Config.Threads + threadID) * batchSize
-				end := start + batchSize
-				
-				if end > r.Config.Samples {
-					end = r.Config.Samples
+			for {
+				r.admit(ctx, w, steps)
+				i, ok := steps.take()
+				if !ok {
+					return
+				}
+				if ctx.Err() != nil {
+					errCh <- ctx.Err()
+					return
 				}
 				
-				if start >= r.Config.Samples {
+				// Generate a unique value for this record
+				value := generators.NewValue(generators.RecordRandom("update", i), valueTemplate)
+				payload, err := ks.encode(value)
+				if err != nil {
+					errCh <- fmt.Errorf("failed to encode record %d: %w", i, err)
 					return
 				}
 				
-				// Process assigned keys
-				for i := start; i < end; i++ {
-					select {
-					case <-ctx.Done():
-						errCh <- ctx.Err()
-						return
-					default:
-						// Generate a unique value for this record
-						value := make(map[string]interface{})
-						for k, v := range valueTemplate {
-							value[k] = generators.ProcessValue(v)
-						}
-						
-						if err := r.Adapter.Update(ctx, keys[i], value); err != nil {
-							errCh <- fmt.Errorf("failed to update record %d: %w", i, err)
-							return
-						}
-					}
+				opStart := r.pace(ctx)
+				err = ks.update(ctx, i, value, payload)
+				rec.observe(time.Since(opStart), err)
+				if err != nil {
+					errCh <- fmt.Errorf("failed to update record %d: %w", i, err)
+					return
 				}
-			}(c, t)
-		}
+			}
+		}()
 	}
 	
 	// Wait for all goroutines to finish
@@ -271,29 +298,53 @@ func (r *Runner) runUpdate(ctx context.Context) error {
 	
 	// Record result
 	duration := time.Since(startTime)
-	r.Results = append(r.Results, Result{
+	result := Result{
 		Operation: OperationUpdate,
 		Name:      "update_all",
 		Duration:  duration,
 		Count:     r.Config.Samples,
-	})
+	}
+	rec.apply(&result)
+	r.Results = append(r.Results, result)
 	
-	fmt.Printf("UPDATE completed in %v\n", duration)
+	fmt.Fprintf(r.Output, "UPDATE completed in %v\n", duration)
 	return nil
 }
 
 // runScans executes the scan benchmarks
 func (r *Runner) runScans(ctx context.Context) error {
-	fmt.Printf("Running SCAN benchmarks...\n")
+	fmt.Fprintf(r.Output, "Running SCAN benchmarks...\n")
 	
+	// Build the full-text indexes needed by search scans
+	dropSearch, err := r.prepareSearch(ctx)
+	if err != nil {
+		return err
+	}
+	defer dropSearch()
+	
+	// Build the spatial indexes needed by geo scans
+	dropGeo, err := r.prepareGeo(ctx)
+	if err != nil {
+		return err
+	}
+	defer dropGeo()
+	
+	var sequential time.Duration
+	var supported []config.ScanConfig
 	for _, scanConfig := range r.Config.Scans {
-		fmt.Printf("Running scan '%s'...\n", scanConfig.Name)
+		fmt.Fprintf(r.Output, "Running scan '%s'...\n", scanConfig.Name)
 		
 		// Start timer
 		startTime := time.Now()
+		rec := r.newRecorder()
 		
-		// Execute scan
-		count, err := r.Adapter.Scan(ctx, scanConfig)
+		// Execute scan, skipping scans the adapter cannot express
+		count, err := r.scan(ctx, scanConfig)
+		if errors.Is(err, ErrUnsupported) {
+			r.skipScan(scanConfig, err)
+			continue
+		}
+		rec.observe(time.Since(startTime), err)
 		if err != nil {
 			return fmt.Errorf("failed to execute scan '%s': %w", scanConfig.Name, err)
 		}
@@ -305,14 +356,23 @@ func (r *Runner) runScans(ctx context.Context) error {
 		
 		// Record result
 		duration := time.Since(startTime)
-		r.Results = append(r.Results, Result{
+		result := Result{
 			Operation: OperationScan,
 			Name:      scanConfig.Name,
 			Duration:  duration,
 			Count:     count,
-		})
+		}
+		rec.apply(&result)
+		r.Results = append(r.Results, result)
 		
-		fmt.Printf("Scan '%s' completed in %v with %d rows\n", scanConfig.Name, duration, count)
+		fmt.Fprintf(r.Output, "Scan '%s' completed in %v with %d rows\n", scanConfig.Name, duration, count)
+		sequential += duration
+		supported = append(supported, scanConfig)
+	}
+	
+	// Optionally run the supported scans again at the same time
+	if r.Config.ScanConcurrency > 1 && len(supported) > 1 {
+		return r.runConcurrentScans(ctx, supported, sequential)
 	}
 	
 	return nil
@@ -320,61 +380,69 @@ func (r *Runner) runScans(ctx context.Context) error {
 
 // runDelete executes the delete benchmark
 func (r *Runner) runDelete(ctx context.Context) error {
-	fmt.Printf("Running DELETE benchmark with %d samples...\n", r.Config.Samples)
+	switch DeleteMode(r.Config.DeleteMode) {
+	case DeleteModeBatch:
+		return r.runDeleteBatched(ctx)
+	case DeleteModeTruncate:
+		return r.runTruncate(ctx)
+	}
+
+	fmt.Fprintf(r.Output, "Running DELETE benchmark with %d samples...\n", r.Config.Samples)
 	
 	// Generate keys (same order as create)
 	keys, err := generators.GenerateKeys(r.Config.KeyType, r.Config.Samples, r.Config.Random)
 	if err != nil {
 		return fmt.Errorf("failed to generate keys: %w", err)
 	}
+	keys, err = r.deleteKeys(keys)
+	if err != nil {
+		return err
+	}
+	ks, err := r.newKeySet(keys)
+	if err != nil {
+		return err
+	}
 	
 	// Start timer
 	startTime := time.Now()
+	rec := r.newRecorder()
 	
 	// Delete records
 	var wg sync.WaitGroup
-	errCh := make(chan error, r.Config.Clients*r.Config.Threads)
+	workers := r.Config.Clients * r.threads
+	errCh := make(chan error, workers)
 	
-	// Process in batches based on client and thread count
-	batchSize := r.Config.Samples / (r.Config.Clients * r.Config.Threads)
-	if batchSize == 0 {
-		batchSize = 1
-	}
+	// Hand the records out one by one, so that no worker idles while
+	// another still has records left
+	steps := &steps{total: r.Config.Samples}
 	
-	for c := 0; c < r.Config.Clients; c++ {
-		for t := 0; t < r.Config.Threads; t++ {
-			wg.Add(1)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		
+		go func() {
+			defer wg.Done()
+			ctx := r.clientContext(ctx, w)
 			
-			go func(clientID, threadID int) {
-				defer wg.Done()
-				
-				// Calculate start and end indices for this worker
-				start := (clientID*r.Config.Threads + threadID) * batchSize
-				end := start + batchSize
-				
-				if end > r.Config.Samples {
-					end = r.Config.Samples
+			for {
+				r.admit(ctx, w, steps)
+				i, ok := steps.take()
+				if !ok {
+					return
 				}
-				
-				if start >= r.Config.Samples {
+				if ctx.Err() != nil {
+					errCh <- ctx.Err()
 					return
 				}
 				
-				// Process assigned keys
-				for i := start; i < end; i++ {
-					select {
-					case <-ctx.Done():
-						errCh <- ctx.Err()
-						return
-					default:
-						if err := r.Adapter.Delete(ctx, keys[i]); err != nil {
-							errCh <- fmt.Errorf("failed to delete record %d: %w", i, err)
-							return
-						}
-					}
+				opStart := r.pace(ctx)
+				err := ks.delete(ctx, i)
+				rec.observe(time.Since(opStart), err)
+				if err != nil {
+					errCh <- fmt.Errorf("failed to delete record %d: %w", i, err)
+					return
 				}
-			}(c, t)
-		}
+			}
+		}()
 	}
 	
 	// Wait for all goroutines to finish
@@ -390,13 +458,15 @@ func (r *Runner) runDelete(ctx context.Context) error {
 	
 	// Record result
 	duration := time.Since(startTime)
-	r.Results = append(r.Results, Result{
+	result := Result{
 		Operation: OperationDelete,
 		Name:      "delete_all",
 		Duration:  duration,
 		Count:     r.Config.Samples,
-	})
+	}
+	rec.apply(&result)
+	r.Results = append(r.Results, result)
 	
-	fmt.Printf("DELETE completed in %v\n", duration)
+	fmt.Fprintf(r.Output, "DELETE completed in %v\n", duration)
 	return nil
 } 
\ No newline at end of file
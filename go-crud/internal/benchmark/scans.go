@@ -0,0 +1,100 @@
+package benchmark
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/surrealdb/go-crud-bench/internal/config"
+)
+
+// ErrUnsupported is wrapped by the errors of adapters which cannot express a
+// scan, such as a filter on a plain key-value store, so that the scan is
+// recorded as skipped instead of failing the run. A single scan file can then
+// be used with every adapter.
+var ErrUnsupported = errors.New("unsupported")
+
+// skipScan records a scan the adapter cannot express as skipped, with the
+// error of the adapter as the reason
+func (r *Runner) skipScan(scanConfig config.ScanConfig, err error) {
+	r.Results = append(r.Results, Result{
+		Operation: OperationScan,
+		Name:      scanConfig.Name,
+		Skipped:   err.Error(),
+	})
+	fmt.Fprintf(r.Output, "Scan '%s' skipped: %v\n", scanConfig.Name, err)
+}
+
+// runConcurrentScans runs the given scan specs at the same time, with at most
+// ScanConcurrency scans in flight, simulating dashboard-style query load. It
+// records one result per scan and a combined result comparing the wall time
+// with the given total sequential scan time.
+func (r *Runner) runConcurrentScans(ctx context.Context, scans []config.ScanConfig, sequential time.Duration) error {
+	fmt.Fprintf(r.Output, "Running %d scans concurrently (up to %d at a time)...\n", len(scans), r.Config.ScanConcurrency)
+
+	results := make([]Result, len(scans))
+	errs := make([]error, len(scans))
+	slots := make(chan struct{}, r.Config.ScanConcurrency)
+	var wg sync.WaitGroup
+	startTime := time.Now()
+
+	for i, scanConfig := range scans {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			ctx := r.clientContext(ctx, i)
+
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			rec := r.newRecorder()
+			scanStart := time.Now()
+			count, err := r.scan(ctx, scanConfig)
+			rec.observe(time.Since(scanStart), err)
+			if err != nil {
+				errs[i] = fmt.Errorf("failed to execute concurrent scan '%s': %w", scanConfig.Name, err)
+				return
+			}
+
+			results[i] = Result{
+				Operation: OperationScan,
+				Name:      scanConfig.Name + "_concurrent",
+				Duration:  time.Since(scanStart),
+				Count:     count,
+			}
+			rec.apply(&results[i])
+		}()
+	}
+	wg.Wait()
+	wall := time.Since(startTime)
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	// Record the per-scan results followed by the combined result
+	rows := 0
+	for _, result := range results {
+		rows += result.Count
+	}
+	r.Results = append(r.Results, results...)
+	r.Results = append(r.Results, Result{
+		Operation: OperationScan,
+		Name:      "all_concurrent",
+		Duration:  wall,
+		Count:     rows,
+		Metrics: map[string]float64{
+			"sequential_ms": float64(sequential) / float64(time.Millisecond),
+			"speedup":       float64(sequential) / float64(wall),
+		},
+	})
+
+	fmt.Fprintf(r.Output, "Concurrent scans completed in %v (sequential %v, %.2fx)\n",
+		wall, sequential, float64(sequential)/float64(wall))
+	return nil
+}
@@ -0,0 +1,58 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// SearchIndexer is implemented by adapters which need a full-text index
+// before running SEARCH scans (PostgreSQL GIN, MySQL FULLTEXT, MongoDB text)
+type SearchIndexer interface {
+	// CreateSearchIndex builds a full-text index on the given value field
+	CreateSearchIndex(ctx context.Context, field string) error
+
+	// DropSearchIndex removes the full-text index on the given value field
+	DropSearchIndex(ctx context.Context, field string) error
+}
+
+// prepareSearch builds a full-text index on every field searched by the
+// configured scans, and returns a function dropping them again. Index builds
+// are reported but not timed as part of the scans.
+func (r *Runner) prepareSearch(ctx context.Context) (func(), error) {
+	indexer, ok := r.Adapter.(SearchIndexer)
+	if !ok {
+		return func() {}, nil
+	}
+
+	var fields []string
+	seen := make(map[string]bool)
+	for _, scan := range r.Config.Scans {
+		field, _, ok := scan.Search()
+		if !ok || seen[field] {
+			continue
+		}
+		seen[field] = true
+		fields = append(fields, field)
+	}
+
+	drop := func() {
+		for _, field := range fields {
+			if err := indexer.DropSearchIndex(ctx, field); err != nil {
+				fmt.Fprintf(r.Output, "Warning: failed to drop search index on field '%s': %v\n", field, err)
+			}
+		}
+	}
+
+	for i, field := range fields {
+		start := time.Now()
+		if err := indexer.CreateSearchIndex(ctx, field); err != nil {
+			fields = fields[:i]
+			drop()
+			return nil, fmt.Errorf("failed to build search index on field '%s': %w", field, err)
+		}
+		fmt.Fprintf(r.Output, "Search index on field '%s' built in %v\n", field, time.Since(start))
+	}
+
+	return drop, nil
+}
@@ -0,0 +1,88 @@
+package benchmark
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/surrealdb/go-crud-bench/internal/codec"
+	"github.com/surrealdb/go-crud-bench/internal/generators"
+)
+
+// selfTestWindow is how long each self-test measurement runs
+const selfTestWindow = 200 * time.Millisecond
+
+// SelfTest reports the single-threaded throughput of the client side work done
+// for every operation, so readers can judge whether the client could have been
+// the bottleneck of a run
+type SelfTest struct {
+	KeysPerSec    float64 `json:"keys_per_sec"`
+	ValuesPerSec  float64 `json:"values_per_sec"`
+	MarshalPerSec float64 `json:"marshal_per_sec"`
+	ValueBytes    int     `json:"value_bytes"`
+}
+
+// RunSelfTest measures key generation, value generation and JSON encoding
+// throughput for the given key type and value template on this machine
+func RunSelfTest(keyType, valueTemplate string) (*SelfTest, error) {
+	keyGen, err := generators.NewKeyGenerator(keyType)
+	if err != nil {
+		return nil, err
+	}
+	template, err := generators.ParseTemplate(valueTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process value template: %w", err)
+	}
+	newValue := func(i int) map[string]interface{} {
+		return generators.NewValue(generators.RecordRandom("selftest", i), template)
+	}
+
+	result := &SelfTest{}
+
+	// Key generation
+	result.KeysPerSec = measureRate(func(i int) error {
+		keyGen.Generate(i)
+		return nil
+	})
+
+	// Value generation
+	result.ValuesPerSec = measureRate(func(i int) error {
+		newValue(i)
+		return nil
+	})
+
+	// JSON encoding of generated values
+	value := newValue(0)
+	data, err := codec.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal value: %w", err)
+	}
+	result.ValueBytes = len(data)
+	result.MarshalPerSec = measureRate(func(int) error {
+		_, err := codec.Marshal(value)
+		return err
+	})
+
+	return result, nil
+}
+
+// String formats the self-test results for the console
+func (s *SelfTest) String() string {
+	return fmt.Sprintf("keys %.0f/s, values %.0f/s, JSON marshal %.0f/s (%d bytes)",
+		s.KeysPerSec, s.ValuesPerSec, s.MarshalPerSec, s.ValueBytes)
+}
+
+// measureRate calls fn repeatedly for the self-test window and returns the calls per second
+func measureRate(fn func(i int) error) float64 {
+	start := time.Now()
+	calls := 0
+	for time.Since(start) < selfTestWindow {
+		// Check the clock only every batch of calls to keep its cost out of the measurement
+		for j := 0; j < 100; j++ {
+			if fn(calls) != nil {
+				return 0
+			}
+			calls++
+		}
+	}
+	return float64(calls) / time.Since(start).Seconds()
+}
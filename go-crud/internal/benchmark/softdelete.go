@@ -0,0 +1,264 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// OperationSoftDelete represents marking a record as deleted by setting its
+// deleted_at field instead of removing it
+const OperationSoftDelete Operation = "SOFT_DELETE"
+
+// SoftDeleteField is the field which marks a record as soft deleted
+const SoftDeleteField = "deleted_at"
+
+// SoftDeleter is implemented by adapters that can soft delete records, and
+// filter the soft deleted records out of reads the way applications do
+type SoftDeleter interface {
+	// SoftDelete sets the deleted_at field of the record with the given key
+	SoftDelete(ctx context.Context, key string) error
+
+	// ReadLive retrieves a record unless it was soft deleted, reporting
+	// whether a live record was found
+	ReadLive(ctx context.Context, key string) (map[string]interface{}, bool, error)
+
+	// CountLive counts the records which were not soft deleted
+	CountLive(ctx context.Context) (int, error)
+}
+
+// runSoftDelete soft deletes the configured fraction of the records, spread
+// evenly over the key space, then reads every record and counts the table
+// again with the deleted_at filter, to measure what the accumulated soft
+// deleted rows cost the reads of the live ones
+func (r *Runner) runSoftDelete(ctx context.Context) error {
+	deleter, ok := r.Adapter.(SoftDeleter)
+	if !ok {
+		return fmt.Errorf("database %s does not support soft deletes", r.Adapter.Name())
+	}
+	if err := r.requireStringKeys("soft deletes"); err != nil {
+		return err
+	}
+
+	keys := r.keys
+	if len(keys) == 0 {
+		return fmt.Errorf("no records available for soft deletes")
+	}
+
+	// Pick the records to soft delete
+	count := int(float64(len(keys))*r.Config.SoftDelete + 0.5)
+	if count == 0 {
+		count = 1
+	}
+	deleted := make([]bool, len(keys))
+	victims := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		index := i * len(keys) / count
+		deleted[index] = true
+		victims = append(victims, keys[index])
+	}
+
+	fmt.Fprintf(r.Output, "Running SOFT_DELETE benchmark with %d of %d samples...\n", count, len(keys))
+
+	if err := r.runSoftDeletePass(ctx, deleter, victims); err != nil {
+		return err
+	}
+	if err := r.runReadLive(ctx, deleter, keys, deleted); err != nil {
+		return err
+	}
+
+	return r.runCountLive(ctx, deleter, len(keys)-count)
+}
+
+// runSoftDeletePass soft deletes the given keys
+func (r *Runner) runSoftDeletePass(ctx context.Context, deleter SoftDeleter, keys []string) error {
+	// Start timer
+	startTime := time.Now()
+	rec := r.newRecorder()
+
+	var wg sync.WaitGroup
+	workers := r.Config.Clients * r.threads
+	errCh := make(chan error, workers)
+
+	steps := &steps{total: len(keys)}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func(workerID int) {
+			defer wg.Done()
+			ctx := r.clientContext(ctx, workerID)
+
+			for {
+				i, ok := steps.take()
+				if !ok {
+					return
+				}
+				if ctx.Err() != nil {
+					errCh <- ctx.Err()
+					return
+				}
+
+				opStart := r.pace(ctx)
+				err := r.invoke(ctx, &Call{Operation: OperationSoftDelete, Key: keys[i]}, func(ctx context.Context, call *Call) error {
+					return deleter.SoftDelete(ctx, call.Key)
+				})
+				rec.observe(time.Since(opStart), err)
+				if err != nil {
+					errCh <- fmt.Errorf("failed to soft delete record %d: %w", i, err)
+					return
+				}
+			}
+		}(w)
+	}
+
+	// Wait for all goroutines to finish
+	wg.Wait()
+
+	// Check for errors
+	close(errCh)
+	for err := range errCh {
+		if err != nil {
+			return err
+		}
+	}
+
+	// Record result
+	duration := time.Since(startTime)
+	result := Result{
+		Operation: OperationSoftDelete,
+		Name:      "soft_delete",
+		Duration:  duration,
+		Count:     len(keys),
+	}
+	rec.apply(&result)
+	r.Results = append(r.Results, result)
+
+	fmt.Fprintf(r.Output, "SOFT_DELETE completed in %v\n", duration)
+	return nil
+}
+
+// runReadLive reads every record with the deleted_at filter, verifying that
+// exactly the soft deleted records are filtered out. The slowdown metric
+// compares the mean latency with the one of the earlier read phase.
+func (r *Runner) runReadLive(ctx context.Context, deleter SoftDeleter, keys []string, deleted []bool) error {
+	// Start timer
+	startTime := time.Now()
+	rec := r.newRecorder()
+
+	var wg sync.WaitGroup
+	var hidden atomic.Int64
+	workers := r.Config.Clients * r.threads
+	errCh := make(chan error, workers)
+
+	steps := &steps{total: len(keys)}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func(workerID int) {
+			defer wg.Done()
+			ctx := r.clientContext(ctx, workerID)
+
+			for {
+				i, ok := steps.take()
+				if !ok {
+					return
+				}
+				if ctx.Err() != nil {
+					errCh <- ctx.Err()
+					return
+				}
+
+				opStart := r.pace(ctx)
+				var found bool
+				err := r.invoke(ctx, &Call{Operation: OperationRead, Key: keys[i]}, func(ctx context.Context, call *Call) (err error) {
+					call.Record, found, err = deleter.ReadLive(ctx, call.Key)
+					return err
+				})
+				if err == nil && found == deleted[i] {
+					err = fmt.Errorf("live read returned found=%t for a record with soft deleted=%t", found, deleted[i])
+				}
+				rec.observe(time.Since(opStart), err)
+				if err != nil {
+					errCh <- fmt.Errorf("failed to read live record %d: %w", i, err)
+					return
+				}
+				if !found {
+					hidden.Add(1)
+				}
+			}
+		}(w)
+	}
+
+	// Wait for all goroutines to finish
+	wg.Wait()
+
+	// Check for errors
+	close(errCh)
+	for err := range errCh {
+		if err != nil {
+			return err
+		}
+	}
+
+	// Record result
+	duration := time.Since(startTime)
+	result := Result{
+		Operation: OperationRead,
+		Name:      "read_live",
+		Duration:  duration,
+		Count:     len(keys),
+		Metrics: map[string]float64{
+			"soft_deleted": float64(hidden.Load()) / float64(len(keys)),
+		},
+	}
+	rec.apply(&result)
+	if baseline := r.resultByName("read_all"); baseline != nil && baseline.Latency != nil && baseline.Latency.Mean > 0 && result.Latency != nil {
+		result.Metrics["slowdown"] = float64(result.Latency.Mean) / float64(baseline.Latency.Mean)
+	}
+	r.Results = append(r.Results, result)
+
+	fmt.Fprintf(r.Output, "READ 'read_live' completed in %v\n", duration)
+	return nil
+}
+
+// runCountLive counts the live records with a single filtered query
+func (r *Runner) runCountLive(ctx context.Context, deleter SoftDeleter, want int) error {
+	// Start timer
+	startTime := time.Now()
+	rec := r.newRecorder()
+
+	count, err := deleter.CountLive(ctx)
+	if err == nil && count != want {
+		err = fmt.Errorf("counted %d live records, expected %d", count, want)
+	}
+	rec.observe(time.Since(startTime), err)
+	if err != nil {
+		return fmt.Errorf("failed to count live records: %w", err)
+	}
+
+	// Record result
+	duration := time.Since(startTime)
+	result := Result{
+		Operation: OperationScan,
+		Name:      "count_live",
+		Duration:  duration,
+		Count:     1,
+	}
+	rec.apply(&result)
+	r.Results = append(r.Results, result)
+
+	fmt.Fprintf(r.Output, "SCAN 'count_live' completed in %v\n", duration)
+	return nil
+}
+
+// resultByName returns the recorded result with the given name, if any
+func (r *Runner) resultByName(name string) *Result {
+	for i := range r.Results {
+		if r.Results[i].Name == name {
+			return &r.Results[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/surrealdb/go-crud-bench/internal/generators"
+)
+
+// BatchSweepSizes are the batch sizes measured by the batch size sweep
+var BatchSweepSizes = []int{1, 10, 100, 1000}
+
+// runBatchSweep inserts the records again once per sweep batch size, removing
+// them after every size, and reports the throughput and batch latency of every
+// size together with the size giving the highest throughput. It runs once the
+// delete phase has emptied the table, reusing the keys of the workload.
+// Databases without batched creates skip the sweep.
+func (r *Runner) runBatchSweep(ctx context.Context) error {
+	creator, ok := r.Adapter.(BatchCreator)
+	if !ok {
+		fmt.Fprintf(r.Output, "Skipping batch size sweep: database %s does not support batched creates\n", r.Adapter.Name())
+		return nil
+	}
+	if err := r.requireStringKeys("batch size sweeps"); err != nil {
+		return err
+	}
+
+	keys := r.keys
+	if len(keys) == 0 {
+		return fmt.Errorf("no records available for the batch size sweep")
+	}
+
+	// Parse the value template
+	valueTemplate, err := generators.ParseTemplate(r.Config.Value)
+	if err != nil {
+		return fmt.Errorf("failed to process value template: %w", err)
+	}
+
+	fmt.Fprintf(r.Output, "Running batch size sweep with %d samples and batch sizes %v...\n", len(keys), BatchSweepSizes)
+
+	first := len(r.Results)
+	for _, size := range BatchSweepSizes {
+		// Start timer
+		startTime := time.Now()
+		rec := r.newRecorder()
+
+		if err := r.createBatches(ctx, creator, keys, size, valueTemplate, rec); err != nil {
+			return fmt.Errorf("failed to create records in batches of %d: %w", size, err)
+		}
+
+		// Record result, counting records rather than batches
+		duration := time.Since(startTime)
+		result := Result{
+			Operation: OperationCreate,
+			Name:      fmt.Sprintf("batch_sweep_%d", size),
+			Duration:  duration,
+			Count:     len(keys),
+			Metrics: map[string]float64{
+				"batch_size": float64(size),
+				"batches":    float64((len(keys) + size - 1) / size),
+			},
+		}
+		rec.apply(&result)
+		r.Results = append(r.Results, result)
+
+		if err := r.removeKeys(ctx, keys); err != nil {
+			return fmt.Errorf("failed to remove records after batches of %d: %w", size, err)
+		}
+
+		// Larger batches would only repeat a single batch of every record
+		if size >= len(keys) {
+			break
+		}
+	}
+
+	// Mark the batch size with the highest throughput
+	sweep := r.Results[first:]
+	best := 0
+	for i := range sweep {
+		if sweep[i].Duration < sweep[best].Duration {
+			best = i
+		}
+	}
+	sweep[best].Metrics["optimal"] = 1
+
+	printSweep(r.Output, sweep, best)
+	return nil
+}
+
+// removeKeys deletes the records with the given keys, with batched deletes
+// when the adapter supports them
+func (r *Runner) removeKeys(ctx context.Context, keys []string) error {
+	if deleter, ok := r.Adapter.(BatchDeleter); ok {
+		size := BatchSweepSizes[len(BatchSweepSizes)-1]
+		for start := 0; start < len(keys); start += size {
+			end := start + size
+			if end > len(keys) {
+				end = len(keys)
+			}
+			err := r.invoke(ctx, &Call{Operation: OperationDelete, Keys: keys[start:end]}, func(ctx context.Context, call *Call) error {
+				return deleter.DeleteBatch(ctx, call.Keys)
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return r.forEachKey(ctx, keys, func(ctx context.Context, i int) error {
+		return r.invoke(ctx, &Call{Operation: OperationDelete, Key: keys[i]}, func(ctx context.Context, call *Call) error {
+			return r.Adapter.Delete(ctx, call.Key)
+		})
+	})
+}
+
+// printSweep prints the throughput and latency trade-off of every batch size to out
+func printSweep(out io.Writer, sweep []Result, best int) {
+	fmt.Fprintln(out)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "BATCH SIZE\tRECORDS/SEC\tBATCH P50 (ms)\tBATCH P99 (ms)\tPER RECORD (ms)\t")
+	for i, result := range sweep {
+		size := result.Metrics["batch_size"]
+		marker := ""
+		if i == best {
+			marker = "optimal"
+		}
+		var p50, p99, perRecord float64
+		if result.Latency != nil {
+			p50 = milliseconds(result.Latency.P50)
+			p99 = milliseconds(result.Latency.P99)
+			perRecord = milliseconds(result.Latency.Mean) / size
+		}
+		fmt.Fprintf(w, "%.0f\t%.0f\t%.3f\t%.3f\t%.4f\t%s\n", size, float64(result.Count)/result.Duration.Seconds(), p50, p99, perRecord, marker)
+	}
+	w.Flush()
+	fmt.Fprintf(out, "\nOptimal batch size: %.0f\n", sweep[best].Metrics["batch_size"])
+}
+
+// milliseconds converts a duration to fractional milliseconds
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
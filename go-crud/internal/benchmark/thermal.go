@@ -0,0 +1,157 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// thermalInterval is how often CPU frequency and temperature are sampled
+const thermalInterval = time.Second
+
+// throttleRatio is the fraction of the maximum CPU frequency below which a
+// phase is flagged as frequency scaled
+const throttleRatio = 0.8
+
+// HostSample is a single CPU frequency and temperature reading taken during a phase
+type HostSample struct {
+	Time        time.Time `json:"time"`
+	Phase       string    `json:"phase"`
+	FreqMHz     float64   `json:"freq_mhz,omitempty"`
+	TempCelsius float64   `json:"temp_c,omitempty"`
+}
+
+// sampleThermal records host samples for the running phase until the context is cancelled
+func (r *Runner) sampleThermal(ctx context.Context, phase string, done chan<- []HostSample) {
+	ticker := time.NewTicker(thermalInterval)
+	defer ticker.Stop()
+
+	var samples []HostSample
+	for {
+		select {
+		case <-ctx.Done():
+			done <- samples
+			return
+		case <-ticker.C:
+			samples = append(samples, HostSample{
+				Time:        time.Now(),
+				Phase:       phase,
+				FreqMHz:     cpuFrequency(),
+				TempCelsius: cpuTemperature(),
+			})
+		}
+	}
+}
+
+// recordThermal attaches the host samples of a phase to the results recorded
+// from index onwards, flagging phases in which the CPU was throttled
+func (r *Runner) recordThermal(samples []HostSample, throttlesBefore int64, index int) {
+	r.HostSamples = append(r.HostSamples, samples...)
+	if len(samples) == 0 {
+		return
+	}
+
+	var minFreq, sumFreq, maxTemp float64
+	for i, s := range samples {
+		if i == 0 || s.FreqMHz < minFreq {
+			minFreq = s.FreqMHz
+		}
+		sumFreq += s.FreqMHz
+		if s.TempCelsius > maxTemp {
+			maxTemp = s.TempCelsius
+		}
+	}
+	meanFreq := sumFreq / float64(len(samples))
+
+	// A phase is throttled if the kernel counted throttling events or the
+	// frequency dropped well below the maximum the CPU supports
+	throttled := 0.0
+	if throttleCount()-throttlesBefore > 0 {
+		throttled = 1
+	}
+	if maxFreq := cpuMaxFrequency(); maxFreq > 0 && minFreq > 0 && minFreq < maxFreq*throttleRatio {
+		throttled = 1
+	}
+
+	for i := index; i < len(r.Results); i++ {
+		result := &r.Results[i]
+		if result.Metrics == nil {
+			result.Metrics = map[string]float64{}
+		}
+		result.Metrics["cpu_freq_min_mhz"] = minFreq
+		result.Metrics["cpu_freq_mean_mhz"] = meanFreq
+		result.Metrics["cpu_temp_max_c"] = maxTemp
+		result.Metrics["cpu_throttled"] = throttled
+		if throttled > 0 {
+			fmt.Fprintf(r.Output, "Warning: CPU frequency scaling or throttling detected during %s, results may not be reproducible\n", result.Name)
+		}
+	}
+}
+
+// cpuFrequency returns the mean current frequency across all CPUs in MHz
+func cpuFrequency() float64 {
+	return meanSysfsValue("/sys/devices/system/cpu/cpu[0-9]*/cpufreq/scaling_cur_freq") / 1000
+}
+
+// cpuMaxFrequency returns the mean maximum frequency across all CPUs in MHz
+func cpuMaxFrequency() float64 {
+	return meanSysfsValue("/sys/devices/system/cpu/cpu[0-9]*/cpufreq/cpuinfo_max_freq") / 1000
+}
+
+// cpuTemperature returns the highest thermal zone temperature in degrees Celsius
+func cpuTemperature() float64 {
+	paths, _ := filepath.Glob("/sys/class/thermal/thermal_zone*/temp")
+	max := 0.0
+	for _, path := range paths {
+		if v, ok := readSysfsValue(path); ok && v/1000 > max {
+			max = v / 1000
+		}
+	}
+	return max
+}
+
+// throttleCount returns the total number of thermal throttling events counted by the kernel
+func throttleCount() int64 {
+	paths, _ := filepath.Glob("/sys/devices/system/cpu/cpu[0-9]*/thermal_throttle/core_throttle_count")
+	var total int64
+	for _, path := range paths {
+		if v, ok := readSysfsValue(path); ok {
+			total += int64(v)
+		}
+	}
+	return total
+}
+
+// meanSysfsValue returns the mean of the numeric values stored in the files matching pattern
+func meanSysfsValue(pattern string) float64 {
+	paths, _ := filepath.Glob(pattern)
+	var sum float64
+	var count int
+	for _, path := range paths {
+		if v, ok := readSysfsValue(path); ok {
+			sum += v
+			count++
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+	return sum / float64(count)
+}
+
+// readSysfsValue reads a single numeric value from a sysfs file
+func readSysfsValue(path string) (float64, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(strings.TrimSpace(string(data)), 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
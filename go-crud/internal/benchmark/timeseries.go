@@ -0,0 +1,62 @@
+package benchmark
+
+import (
+	"context"
+	"time"
+)
+
+// TimeSample holds the operations completed during one interval of a phase,
+// showing ramp-up, compaction stalls and throttling which the aggregate
+// results of the phase hide
+type TimeSample struct {
+	Time        time.Time     `json:"time"`
+	Phase       string        `json:"phase"`
+	Elapsed     time.Duration `json:"elapsed"`
+	Ops         int64         `json:"ops"`
+	Errors      int64         `json:"errors,omitempty"`
+	Throughput  float64       `json:"ops_per_sec"`
+	MeanLatency time.Duration `json:"mean_latency,omitempty"`
+}
+
+// sampleTimeSeries records the operations completed in every interval of the
+// running phase until the context is cancelled, followed by the partial last
+// interval
+func (r *Runner) sampleTimeSeries(ctx context.Context, phase string, start time.Time, done chan<- []TimeSample) {
+	ticker := time.NewTicker(r.Config.TimeSeriesInterval)
+	defer ticker.Stop()
+
+	var samples []TimeSample
+	var lastCompleted, lastFailed, lastLatency int64
+	last := start
+	sample := func(now time.Time) {
+		completed, failed, latency := r.completed.Load(), r.failed.Load(), r.latencySum.Load()
+		s := TimeSample{
+			Time:    now,
+			Phase:   phase,
+			Elapsed: now.Sub(start),
+			Ops:     completed - lastCompleted,
+			Errors:  failed - lastFailed,
+		}
+		if interval := now.Sub(last); interval > 0 {
+			s.Throughput = float64(s.Ops) / interval.Seconds()
+		}
+		if succeeded := s.Ops - s.Errors; succeeded > 0 {
+			s.MeanLatency = time.Duration((latency - lastLatency) / succeeded)
+		}
+		samples = append(samples, s)
+		lastCompleted, lastFailed, lastLatency, last = completed, failed, latency, now
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			if r.completed.Load() > lastCompleted {
+				sample(time.Now())
+			}
+			done <- samples
+			return
+		case now := <-ticker.C:
+			sample(now)
+		}
+	}
+}
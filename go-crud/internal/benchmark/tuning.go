@@ -0,0 +1,9 @@
+package benchmark
+
+// Tunable is implemented by adapters which apply curated default settings
+// (pool sizes, consistency levels) that can be overridden by the user
+type Tunable interface {
+	// Tune applies the overrides on top of the adapter preset and returns the
+	// effective settings, which are disclosed in the results
+	Tune(overrides map[string]string) (map[string]string, error)
+}
@@ -0,0 +1,206 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/surrealdb/go-crud-bench/internal/generators"
+)
+
+// UpdateMode selects how the update phase writes the new values
+type UpdateMode string
+
+const (
+	// UpdateModeOverwrite replaces each record in place
+	UpdateModeOverwrite UpdateMode = "overwrite"
+	// UpdateModeVersioned appends a new version row per update, keeping the
+	// history of every record
+	UpdateModeVersioned UpdateMode = "versioned"
+)
+
+// VersionedWriter is implemented by adapters that can keep the history of the
+// records in a separate insert-only table, where every update appends a row
+// keyed by the record key and its version
+type VersionedWriter interface {
+	// CreateVersions creates the empty history table
+	CreateVersions(ctx context.Context) error
+
+	// AppendVersion inserts a version of a record, leaving the earlier
+	// versions in place
+	AppendVersion(ctx context.Context, key string, version int, value map[string]interface{}) error
+
+	// ReadLatest retrieves the most recent version of a record together
+	// with its version number
+	ReadLatest(ctx context.Context, key string) (map[string]interface{}, int, error)
+
+	// DropVersions removes the history table
+	DropVersions(ctx context.Context) error
+}
+
+// runUpdateVersioned appends the configured number of versions to every
+// record, one round over all the records per version, then reads the latest
+// version of every record back. The history table is dropped afterwards.
+func (r *Runner) runUpdateVersioned(ctx context.Context) (err error) {
+	writer, ok := r.Adapter.(VersionedWriter)
+	if !ok {
+		return fmt.Errorf("database %s does not support versioned writes", r.Adapter.Name())
+	}
+	if err := r.requireStringKeys("versioned writes"); err != nil {
+		return err
+	}
+
+	keys := r.keys
+	if len(keys) == 0 {
+		return fmt.Errorf("no records available for versioned writes")
+	}
+	versions := r.Config.Versions
+
+	// Parse the value template
+	valueTemplate, err := generators.ParseTemplate(r.Config.Value)
+	if err != nil {
+		return fmt.Errorf("failed to process value template: %w", err)
+	}
+
+	if err := writer.CreateVersions(ctx); err != nil {
+		return fmt.Errorf("failed to create history table: %w", err)
+	}
+	defer func() {
+		if dropErr := writer.DropVersions(ctx); dropErr != nil && err == nil {
+			err = fmt.Errorf("failed to drop history table: %w", dropErr)
+		}
+	}()
+
+	fmt.Fprintf(r.Output, "Running UPDATE benchmark appending %d versions to %d samples...\n", versions, len(keys))
+
+	// Start timer
+	startTime := time.Now()
+	rec := r.newRecorder()
+
+	for version := 1; version <= versions; version++ {
+		err := r.forEachKey(ctx, keys, func(ctx context.Context, i int) error {
+			// Generate a unique value for this version
+			value := generators.NewValue(generators.RecordRandom(fmt.Sprintf("version:%d", version), i), valueTemplate)
+
+			opStart := r.pace(ctx)
+			err := r.invoke(ctx, &Call{Operation: OperationCreate, Key: keys[i], Value: value}, func(ctx context.Context, call *Call) error {
+				return writer.AppendVersion(ctx, call.Key, version, call.Value)
+			})
+			rec.observe(time.Since(opStart), err)
+			if err != nil {
+				return fmt.Errorf("failed to append version %d of record %d: %w", version, i, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Record result, counting every appended version
+	duration := time.Since(startTime)
+	result := Result{
+		Operation: OperationUpdate,
+		Name:      "update_versioned",
+		Duration:  duration,
+		Count:     len(keys) * versions,
+		Metrics: map[string]float64{
+			"versions": float64(versions),
+		},
+	}
+	rec.apply(&result)
+	r.Results = append(r.Results, result)
+
+	fmt.Fprintf(r.Output, "UPDATE completed in %v\n", duration)
+
+	return r.runReadLatest(ctx, writer, keys, versions)
+}
+
+// runReadLatest reads the latest version of every record, verifying that it
+// is the last version appended
+func (r *Runner) runReadLatest(ctx context.Context, writer VersionedWriter, keys []string, want int) error {
+	// Start timer
+	startTime := time.Now()
+	rec := r.newRecorder()
+
+	err := r.forEachKey(ctx, keys, func(ctx context.Context, i int) error {
+		opStart := r.pace(ctx)
+		var version int
+		err := r.invoke(ctx, &Call{Operation: OperationRead, Key: keys[i]}, func(ctx context.Context, call *Call) (err error) {
+			call.Record, version, err = writer.ReadLatest(ctx, call.Key)
+			return err
+		})
+		if err == nil && version != want {
+			err = fmt.Errorf("latest version is %d, expected %d", version, want)
+		}
+		rec.observe(time.Since(opStart), err)
+		if err != nil {
+			return fmt.Errorf("failed to read latest version of record %d: %w", i, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Record result
+	duration := time.Since(startTime)
+	result := Result{
+		Operation: OperationRead,
+		Name:      "read_latest",
+		Duration:  duration,
+		Count:     len(keys),
+	}
+	rec.apply(&result)
+	r.Results = append(r.Results, result)
+
+	fmt.Fprintf(r.Output, "READ 'read_latest' completed in %v\n", duration)
+	return nil
+}
+
+// forEachKey calls fn with the index of every key and the context of the
+// worker's client, sharing the keys out between the workers, and returns the
+// first error
+func (r *Runner) forEachKey(ctx context.Context, keys []string, fn func(ctx context.Context, i int) error) error {
+	var wg sync.WaitGroup
+	workers := r.Config.Clients * r.threads
+	errCh := make(chan error, workers)
+
+	steps := &steps{total: len(keys)}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func(workerID int) {
+			defer wg.Done()
+			ctx := r.clientContext(ctx, workerID)
+
+			for {
+				i, ok := steps.take()
+				if !ok {
+					return
+				}
+				if ctx.Err() != nil {
+					errCh <- ctx.Err()
+					return
+				}
+				if err := fn(ctx, i); err != nil {
+					errCh <- err
+					return
+				}
+			}
+		}(w)
+	}
+
+	// Wait for all goroutines to finish
+	wg.Wait()
+
+	// Check for errors
+	close(errCh)
+	for err := range errCh {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// runWarmup reads the records created by the create phase, cycling through
+// the keys, for the configured number of operations or duration, so that
+// caches, connection pools and JIT compilers settle before the measured
+// phases. The reads are reported separately in Warmup rather than in the
+// results.
+func (r *Runner) runWarmup(ctx context.Context) error {
+	if len(r.keys) == 0 {
+		return fmt.Errorf("no records available for the warmup")
+	}
+	ks, err := r.newKeySet(r.keys)
+	if err != nil {
+		return err
+	}
+
+	steps := &steps{total: r.Config.WarmupOps}
+	if r.Config.WarmupDuration > 0 {
+		fmt.Fprintf(r.Output, "Warming up for %v...\n", r.Config.WarmupDuration)
+		steps.deadline = time.Now().Add(r.Config.WarmupDuration)
+	} else {
+		fmt.Fprintf(r.Output, "Warming up with %d reads...\n", r.Config.WarmupOps)
+	}
+
+	// Start timer
+	startTime := time.Now()
+	rec := r.newRecorder()
+
+	var wg sync.WaitGroup
+	workers := r.Config.Clients * r.threads
+	errCh := make(chan error, workers)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			ctx := r.clientContext(ctx, w)
+
+			for {
+				r.admit(ctx, w, steps)
+				step, ok := steps.take()
+				if !ok {
+					return
+				}
+				if ctx.Err() != nil {
+					errCh <- ctx.Err()
+					return
+				}
+
+				// The warmup runs unpaced, ahead of the schedule of the measured phases
+				i := step % len(ks.keys)
+				opStart := time.Now()
+				_, err := ks.read(ctx, i)
+				rec.observe(time.Since(opStart), err)
+				if err != nil {
+					errCh <- fmt.Errorf("failed to read record %d during warmup: %w", i, err)
+					return
+				}
+			}
+		}()
+	}
+
+	// Wait for all goroutines to finish
+	wg.Wait()
+
+	// Check for errors
+	close(errCh)
+	for err := range errCh {
+		if err != nil {
+			return err
+		}
+	}
+
+	// Record the warmup apart from the measured results
+	duration := time.Since(startTime)
+	result := Result{
+		Operation: OperationRead,
+		Name:      "warmup",
+		Duration:  duration,
+		Count:     steps.count(),
+	}
+	rec.apply(&result)
+	r.Warmup = &result
+
+	fmt.Fprintf(r.Output, "Warmup completed in %v: %d reads (%.0f ops/sec)\n", duration, result.Count, result.Throughput())
+	return nil
+}
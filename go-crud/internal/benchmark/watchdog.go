@@ -0,0 +1,111 @@
+package benchmark
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/surrealdb/go-crud-bench/internal/docker"
+)
+
+// crashLogLines is how many of the last log lines of a crashed database
+// container are captured
+const crashLogLines = 50
+
+// ErrDatabaseCrashed is reported when the managed database container stopped
+// during a phase
+var ErrDatabaseCrashed = errors.New("database crashed")
+
+// Crash records the managed database container stopping during a phase, with
+// its exit code and the last lines it logged
+type Crash struct {
+	Time      time.Time `json:"time"`
+	Phase     string    `json:"phase"`
+	ExitCode  int       `json:"exit_code"`
+	OOMKilled bool      `json:"oom_killed,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	Logs      []string  `json:"logs,omitempty"`
+}
+
+// Err returns the error the crashed phase fails with
+func (c *Crash) Err() error {
+	reason := fmt.Sprintf("container exited with code %d", c.ExitCode)
+	if c.OOMKilled {
+		reason += ", killed out of memory"
+	}
+	if c.Error != "" {
+		reason += ": " + c.Error
+	}
+	return fmt.Errorf("%w during the %s phase (%s)", ErrDatabaseCrashed, c.Phase, reason)
+}
+
+// startWatchdog checks the managed database container periodically while a
+// phase runs, returning a context which is cancelled as soon as the container
+// stopped, and a function stopping the watchdog. The function reports the
+// crash, checking the container once more when the phase failed, as the
+// phase usually fails on refused connections before the next check.
+func (r *Runner) startWatchdog(ctx context.Context, phase string) (context.Context, func(failed bool) *Crash) {
+	provider, ok := r.Adapter.(ContainerProvider)
+	if !ok || provider.Container() == nil || r.Config.WatchdogInterval <= 0 {
+		return ctx, func(bool) *Crash { return nil }
+	}
+	container := provider.Container()
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(r.Config.WatchdogInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if crash := r.checkContainer(ctx, container, phase); crash != nil {
+					cancel(crash.Err())
+					return
+				}
+			}
+		}
+	}()
+
+	return ctx, func(failed bool) *Crash {
+		cancel(nil)
+		<-done
+		if r.Crash == nil && failed {
+			// The phase context is cancelled, so check with a fresh one
+			checkCtx, stop := context.WithTimeout(context.Background(), 10*time.Second)
+			defer stop()
+			r.checkContainer(checkCtx, container, phase)
+		}
+		return r.Crash
+	}
+}
+
+// checkContainer records the crash of the container if it is no longer
+// running. Failing to inspect the container is not taken as a crash, as the
+// Docker daemon may be slow to answer under load.
+func (r *Runner) checkContainer(ctx context.Context, container *docker.Container, phase string) *Crash {
+	state, err := container.State(ctx)
+	if err != nil || state.Running {
+		return nil
+	}
+
+	crash := &Crash{
+		Time:      time.Now(),
+		Phase:     phase,
+		ExitCode:  state.ExitCode,
+		OOMKilled: state.OOMKilled,
+		Error:     state.Error,
+	}
+	r.crashed.Store(true)
+	logs, err := container.Logs(ctx, crashLogLines)
+	if err != nil {
+		fmt.Fprintf(r.Output, "Warning: failed to capture the logs of the crashed container: %v\n", err)
+	}
+	crash.Logs = logs
+	r.Crash = crash
+	return crash
+}
@@ -0,0 +1,70 @@
+package benchmark
+
+import "context"
+
+// BlockingAdapter is implemented by embedded adapters whose calls run the
+// database engine on the calling goroutine, holding its thread until the
+// engine returns, rather than waiting on the network
+type BlockingAdapter interface {
+	// Blocking reports whether the calls of the adapter block
+	Blocking() bool
+}
+
+// callLimit bounds the number of calls in flight at once
+type callLimit chan struct{}
+
+// acquire waits for a free slot, or for the context to be done
+func (l callLimit) acquire(ctx context.Context) error {
+	select {
+	case l <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release frees a slot taken by acquire
+func (l callLimit) release() {
+	<-l
+}
+
+// newLimits creates the limits of the calls in flight: every call is bounded
+// by the workers, and the calls of blocking adapters also by the blocking
+// threads. The limits are shared by every phase.
+func (r *Runner) newLimits() []callLimit {
+	var limits []callLimit
+	if r.Config.Workers > 0 {
+		limits = append(limits, make(callLimit, r.Config.Workers))
+	}
+	if blocking, ok := r.Adapter.(BlockingAdapter); ok && blocking.Blocking() && r.Config.Blocking > 0 {
+		limits = append(limits, make(callLimit, r.Config.Blocking))
+	}
+	return limits
+}
+
+// newLimiter creates the outermost middleware of the chain, bounding every
+// adapter call by the limits of the calls in flight, taking the slots in the
+// same order for every call. It returns nil when the calls are not bounded.
+func (r *Runner) newLimiter() Middleware {
+	if len(r.limits) == 0 {
+		return nil
+	}
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) error {
+			for i, limit := range r.limits {
+				if err := limit.acquire(ctx); err != nil {
+					for _, taken := range r.limits[:i] {
+						taken.release()
+					}
+					return err
+				}
+			}
+			defer func() {
+				for _, limit := range r.limits {
+					limit.release()
+				}
+			}()
+			return next(ctx, call)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+// Package codec holds the JSON encoder the adapters marshal and unmarshal
+// records with, selected with --json-encoder, as the client side encoding
+// cost is a measurable part of the latency of large documents
+package codec
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/bytedance/sonic"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// encoder marshals and unmarshals JSON
+type encoder struct {
+	marshal   func(v interface{}) ([]byte, error)
+	unmarshal func(data []byte, v interface{}) error
+}
+
+// encoders contains the supported JSON encoders. jsoniter runs in its
+// standard library compatible mode and sonic in its default mode, as they
+// are used as drop-in replacements by applications.
+var encoders = map[string]encoder{
+	"std": {json.Marshal, json.Unmarshal},
+	"jsoniter": {
+		jsoniter.ConfigCompatibleWithStandardLibrary.Marshal,
+		jsoniter.ConfigCompatibleWithStandardLibrary.Unmarshal,
+	},
+	"sonic": {sonic.ConfigDefault.Marshal, sonic.ConfigDefault.Unmarshal},
+}
+
+// current is the encoder in use, set before the benchmark runs
+var current = encoders["std"]
+
+// Use selects the JSON encoder used from now on
+func Use(name string) error {
+	e, ok := encoders[name]
+	if !ok {
+		return fmt.Errorf("unsupported JSON encoder: %s", name)
+	}
+	current = e
+	return nil
+}
+
+// Marshal returns the JSON encoding of v with the selected encoder
+func Marshal(v interface{}) ([]byte, error) {
+	return current.marshal(v)
+}
+
+// Unmarshal parses the JSON encoded data into v with the selected encoder
+func Unmarshal(data []byte, v interface{}) error {
+	return current.unmarshal(data, v)
+}
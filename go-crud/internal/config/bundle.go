@@ -0,0 +1,258 @@
+package config
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// BundleVersion is the version of the workload bundle format
+const BundleVersion = 1
+
+// Files of a workload bundle
+const (
+	bundleManifest = "bundle.json"
+	bundleValue    = "value.json"
+	bundleScans    = "scans.json"
+)
+
+// bundleExcluded lists the flags which describe the host, the output or the
+// bundle itself rather than the workload, and are left out of bundles. The
+// value template and scans are stored in files of their own, and the image
+// apart so that it can be pinned to its digest.
+var bundleExcluded = map[string]bool{
+	"name":                true,
+	"config":              true,
+	"image":               true,
+	"endpoint":            true,
+	"username":            true,
+	"password":            true,
+	"db-name":             true,
+	"port":                true,
+	"tls":                 true,
+	"tls-ca":              true,
+	"tls-cert":            true,
+	"tls-key":             true,
+	"tls-skip-verify":     true,
+	"probe-endpoint":      true,
+	"namespace":           true,
+	"pid":                 true,
+	"show-sample":         true,
+	"color":               true,
+	"output":              true,
+	"output-format":       true,
+	"charts":              true,
+	"report-html":         true,
+	"upload":              true,
+	"history":             true,
+	"reference":           true,
+	"checksum":            true,
+	"sign-key":            true,
+	"progress":            true,
+	"progress-file":       true,
+	"timeseries-interval": true,
+	"watchdog-interval":   true,
+	"bind-address":        true,
+	"control-socket":      true,
+	"rerun":               true,
+	"calibration":         true,
+	"workload-bundle":     true,
+	"value":               true,
+	"scans":               true,
+}
+
+// Bundle is a shareable description of a benchmark, holding every flag of
+// the run including the defaults, so that a later version of the tool with
+// different defaults runs the same workload. It is stored as a gzipped tar
+// file holding bundle.json, value.json and scans.json.
+type Bundle struct {
+	Version      int               `json:"version"`
+	Created      time.Time         `json:"created"`
+	Database     string            `json:"database"`
+	Seed         int64             `json:"seed"`
+	Image        string            `json:"image,omitempty"`
+	ImageDigest  string            `json:"image_digest,omitempty"`
+	WorkloadHash string            `json:"workload_hash"`
+	Flags        map[string]string `json:"flags"`
+	Value        string            `json:"-"`
+	Scans        string            `json:"-"`
+}
+
+// NewBundle creates the bundle of the benchmark configured by the flags of
+// the command
+func NewBundle(cmd *cobra.Command, cfg *Config) (*Bundle, error) {
+	workload, err := cfg.Workload()
+	if err != nil {
+		return nil, err
+	}
+	hash, err := workload.Hash()
+	if err != nil {
+		return nil, err
+	}
+	scans, _ := cmd.Flags().GetString("scans")
+
+	bundle := &Bundle{
+		Version:      BundleVersion,
+		Created:      time.Now().UTC(),
+		Database:     cfg.Database,
+		Seed:         cfg.Seed,
+		Image:        cfg.Image,
+		WorkloadHash: hash,
+		Flags:        make(map[string]string),
+		Value:        cfg.Value,
+		Scans:        scans,
+	}
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if bundleExcluded[flag.Name] {
+			return
+		}
+		value := flag.Value.String()
+		if flag.Value.Type() == "stringToString" {
+			// Maps are printed in brackets but set without them
+			value = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+		}
+		bundle.Flags[flag.Name] = value
+	})
+	return bundle, nil
+}
+
+// LoadBundle reads a workload bundle
+func LoadBundle(path string) (*Bundle, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open workload bundle: %w", err)
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workload bundle %s: %w", path, err)
+	}
+	files := make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read workload bundle %s: %w", path, err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s from workload bundle %s: %w", header.Name, path, err)
+		}
+		files[header.Name] = data
+	}
+
+	manifest, ok := files[bundleManifest]
+	if !ok {
+		return nil, fmt.Errorf("workload bundle %s has no %s", path, bundleManifest)
+	}
+	var bundle Bundle
+	if err := json.Unmarshal(manifest, &bundle); err != nil {
+		return nil, fmt.Errorf("failed to parse %s of workload bundle %s: %w", bundleManifest, path, err)
+	}
+	if bundle.Version > BundleVersion {
+		return nil, fmt.Errorf("workload bundle %s has version %d, this version of crud-bench reads up to version %d", path, bundle.Version, BundleVersion)
+	}
+	bundle.Value = string(files[bundleValue])
+	bundle.Scans = string(files[bundleScans])
+	return &bundle, nil
+}
+
+// Write stores the bundle as a gzipped tar file
+func (b *Bundle) Write(path string) error {
+	manifest, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode workload bundle: %w", err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create workload bundle: %w", err)
+	}
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for _, file := range []struct {
+		name string
+		data []byte
+	}{
+		{bundleManifest, manifest},
+		{bundleValue, []byte(b.Value)},
+		{bundleScans, []byte(b.Scans)},
+	} {
+		header := &tar.Header{Name: file.name, Mode: 0644, Size: int64(len(file.data)), ModTime: b.Created}
+		if err := tw.WriteHeader(header); err != nil {
+			f.Close()
+			return fmt.Errorf("failed to write workload bundle: %w", err)
+		}
+		if _, err := tw.Write(file.data); err != nil {
+			f.Close()
+			return fmt.Errorf("failed to write workload bundle: %w", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write workload bundle: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write workload bundle: %w", err)
+	}
+	return f.Close()
+}
+
+// Apply sets the flags of the command to the values of the bundle, except
+// the flags given on the command line, which take precedence. The image is
+// pinned to its digest when the bundle recorded one.
+func (b *Bundle) Apply(cmd *cobra.Command) error {
+	values := make(map[string]string, len(b.Flags)+3)
+	for name, value := range b.Flags {
+		values[name] = value
+	}
+	if b.Value != "" {
+		values["value"] = b.Value
+	}
+	if b.Scans != "" {
+		values["scans"] = b.Scans
+	}
+	switch {
+	case b.ImageDigest != "":
+		values["image"] = b.ImageDigest
+	case b.Image != "":
+		values["image"] = b.Image
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil {
+			return fmt.Errorf("workload bundle sets unknown flag --%s, it may require a newer version of crud-bench", name)
+		}
+		if flag.Changed {
+			continue
+		}
+		// Empty maps cannot be set, and are the default
+		if values[name] == "" && flag.Value.Type() == "stringToString" {
+			continue
+		}
+		if err := cmd.Flags().Set(name, values[name]); err != nil {
+			return fmt.Errorf("invalid value %q for --%s in workload bundle: %w", values[name], name, err)
+		}
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -18,6 +19,7 @@ func FromCommand(cmd *cobra.Command) (*Config, error) {
 	workers, _ := cmd.Flags().GetInt("workers")
 	clients, _ := cmd.Flags().GetInt("clients")
 	threads, _ := cmd.Flags().GetInt("threads")
+	sharedPool, _ := cmd.Flags().GetBool("shared-pool")
 	samples, _ := cmd.Flags().GetInt("samples")
 	random, _ := cmd.Flags().GetBool("random")
 	keyType, _ := cmd.Flags().GetString("key")
@@ -25,6 +27,87 @@ func FromCommand(cmd *cobra.Command) (*Config, error) {
 	showSample, _ := cmd.Flags().GetBool("show-sample")
 	pid, _ := cmd.Flags().GetInt("pid")
 	scansJSON, _ := cmd.Flags().GetString("scans")
+	indexBuild, _ := cmd.Flags().GetString("index-build")
+	compaction, _ := cmd.Flags().GetBool("compaction")
+	networkProfile, _ := cmd.Flags().GetString("network-profile")
+	sloBounds, _ := cmd.Flags().GetString("slo")
+	timeUnit, _ := cmd.Flags().GetString("time-unit")
+	color, _ := cmd.Flags().GetString("color")
+	output, _ := cmd.Flags().GetString("output")
+	tuning, _ := cmd.Flags().GetStringToString("tune")
+	checksum, _ := cmd.Flags().GetBool("checksum")
+	signKey, _ := cmd.Flags().GetString("sign-key")
+	repeat, _ := cmd.Flags().GetInt("repeat")
+	cooldown, _ := cmd.Flags().GetDuration("cooldown")
+	maxLoad, _ := cmd.Flags().GetFloat64("max-load")
+	thermal, _ := cmd.Flags().GetBool("thermal")
+	dropCaches, _ := cmd.Flags().GetBool("drop-caches")
+	debugQueries, _ := cmd.Flags().GetInt("debug-queries")
+	debugQueryParams, _ := cmd.Flags().GetBool("debug-query-params")
+	keyEncoding, _ := cmd.Flags().GetString("key-encoding")
+	namespace, _ := cmd.Flags().GetString("namespace")
+	consistencyProbes, _ := cmd.Flags().GetInt("consistency-probes")
+	probeEndpoint, _ := cmd.Flags().GetString("probe-endpoint")
+	anomalyCheck, _ := cmd.Flags().GetInt("anomaly-check")
+	cleanupPolicy, _ := cmd.Flags().GetString("cleanup-policy")
+	scanConcurrency, _ := cmd.Flags().GetInt("scan-concurrency")
+	selfTest, _ := cmd.Flags().GetBool("selftest")
+	readMulti, _ := cmd.Flags().GetInt("read-multi")
+	existsCheck, _ := cmd.Flags().GetBool("exists")
+	casWrites, _ := cmd.Flags().GetInt("cas")
+	casKeys, _ := cmd.Flags().GetInt("cas-keys")
+	increments, _ := cmd.Flags().GetInt("increments")
+	counters, _ := cmd.Flags().GetInt("counters")
+	changeFeed, _ := cmd.Flags().GetBool("change-feed")
+	batchSize, _ := cmd.Flags().GetInt("batch-size")
+	charts, _ := cmd.Flags().GetString("charts")
+	uploadTarget, _ := cmd.Flags().GetString("upload")
+	deleteMode, _ := cmd.Flags().GetString("delete-mode")
+	deleteBatch, _ := cmd.Flags().GetInt("delete-batch")
+	softDelete, _ := cmd.Flags().GetFloat64("soft-delete")
+	updateMode, _ := cmd.Flags().GetString("update-mode")
+	versions, _ := cmd.Flags().GetInt("versions")
+	children, _ := cmd.Flags().GetInt("children")
+	graphEdges, _ := cmd.Flags().GetInt("graph-edges")
+	graphHops, _ := cmd.Flags().GetInt("graph-hops")
+	batchSweep, _ := cmd.Flags().GetBool("batch-sweep")
+	maxClientMemSize, _ := cmd.Flags().GetString("max-client-mem")
+	yugabyteAPI, _ := cmd.Flags().GetString("yugabyte-api")
+	opTimeout, _ := cmd.Flags().GetDuration("op-timeout")
+	mixSpec, _ := cmd.Flags().GetString("workload")
+	distribution, _ := cmd.Flags().GetString("distribution")
+	progressFile, _ := cmd.Flags().GetString("progress-file")
+	runDuration, _ := cmd.Flags().GetDuration("duration")
+	rate, _ := cmd.Flags().GetInt("rate")
+	warmupSpec, _ := cmd.Flags().GetString("warmup")
+	seed, _ := cmd.Flags().GetInt64("seed")
+	workloadBundle, _ := cmd.Flags().GetString("workload-bundle")
+	controlSocket, _ := cmd.Flags().GetString("control-socket")
+	serial, _ := cmd.Flags().GetBool("serial")
+	rerun, _ := cmd.Flags().GetString("rerun")
+	calibration, _ := cmd.Flags().GetString("calibration")
+	outputFormats, _ := cmd.Flags().GetStringSlice("output-format")
+	reportHTML, _ := cmd.Flags().GetString("report-html")
+	jsonEncoderFlag, _ := cmd.Flags().GetString("json-encoder")
+	recordFormatFlag, _ := cmd.Flags().GetString("record-format")
+	progressFlag, _ := cmd.Flags().GetString("progress")
+	timeSeriesIntervalFlag, _ := cmd.Flags().GetDuration("timeseries-interval")
+	watchdogIntervalFlag, _ := cmd.Flags().GetDuration("watchdog-interval")
+	historyFlag, _ := cmd.Flags().GetString("history")
+	bindAddressesFlag, _ := cmd.Flags().GetStringSlice("bind-address")
+	referenceFlag, _ := cmd.Flags().GetString("reference")
+	username, _ := cmd.Flags().GetString("username")
+	password, _ := cmd.Flags().GetString("password")
+	dbName, _ := cmd.Flags().GetString("db-name")
+	port, _ := cmd.Flags().GetInt("port")
+	tls, _ := cmd.Flags().GetBool("tls")
+	tlsCA, _ := cmd.Flags().GetString("tls-ca")
+	tlsCert, _ := cmd.Flags().GetString("tls-cert")
+	tlsKey, _ := cmd.Flags().GetString("tls-key")
+	tlsSkipVerify, _ := cmd.Flags().GetBool("tls-skip-verify")
+
+	// Any TLS setting enables TLS
+	tls = tls || tlsCA != "" || tlsCert != "" || tlsSkipVerify
 
 	// Parse scans from JSON
 	scans, err := ParseScans(scansJSON)
@@ -32,10 +115,76 @@ func FromCommand(cmd *cobra.Command) (*Config, error) {
 		return nil, fmt.Errorf("invalid scans configuration: %w", err)
 	}
 
+	// Parse SLO latency classes
+	slo, err := ParseSLO(sloBounds)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SLO configuration: %w", err)
+	}
+
+	// Parse the client memory ceiling
+	maxClientMem, err := ParseByteSize(maxClientMemSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid client memory ceiling: %w", err)
+	}
+
+	// Parse the mixed workload ratios
+	mix, err := ParseMix(mixSpec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mixed workload: %w", err)
+	}
+
+	// Parse the warmup
+	warmupOps, warmupDuration, err := ParseWarmup(warmupSpec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid warmup: %w", err)
+	}
+
+	// Resolve the network profile
+	var network *NetworkProfile
+	if networkProfile != "" {
+		network, err = LookupNetworkProfile(networkProfile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Parse the upload destination
+	var upload *UploadTarget
+	if uploadTarget != "" {
+		upload, err = ParseUploadTarget(uploadTarget)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Run several databases one after the other when a list is given
+	var databases []string
+	if strings.Contains(database, ",") {
+		for _, db := range strings.Split(database, ",") {
+			databases = append(databases, strings.TrimSpace(db))
+		}
+		database = databases[0]
+	}
+
+	// Parse the history store
+	var history *HistoryStore
+	if historyFlag != "" {
+		history, err = ParseHistoryStore(historyFlag)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Isolate runs against shared endpoints in their own namespace
+	if namespace == "" && endpoint != "" {
+		namespace = GenerateNamespace()
+	}
+
 	// Create config
 	config := &Config{
 		Name:       name,
 		Database:   database,
+		Databases:  databases,
 		Image:      image,
 		Privileged: privileged,
 		Endpoint:   endpoint,
@@ -43,6 +192,7 @@ func FromCommand(cmd *cobra.Command) (*Config, error) {
 		Workers:    workers,
 		Clients:    clients,
 		Threads:    threads,
+		SharedPool: sharedPool,
 		Samples:    samples,
 		Random:     random,
 		KeyType:    keyType,
@@ -50,6 +200,88 @@ func FromCommand(cmd *cobra.Command) (*Config, error) {
 		ShowSample: showSample,
 		PID:        pid,
 		Scans:      scans,
+		IndexBuild: indexBuild,
+		Compaction: compaction,
+		Network:    network,
+		SLO:        slo,
+		TimeUnit:   timeUnit,
+		Color:      color,
+		Output:     output,
+		Tuning:     tuning,
+		Checksum:   checksum,
+		SignKey:    signKey,
+		Repeat:     repeat,
+		Cooldown:   cooldown,
+		MaxLoad:    maxLoad,
+		Thermal:    thermal,
+		DropCaches: dropCaches,
+
+		DebugQueries:       debugQueries,
+		DebugQueryParams:   debugQueryParams,
+		KeyEncoding:        keyEncoding,
+		Namespace:          namespace,
+		ConsistencyProbes:  consistencyProbes,
+		ProbeEndpoint:      probeEndpoint,
+		AnomalyCheck:       anomalyCheck,
+		CleanupPolicy:      cleanupPolicy,
+		ScanConcurrency:    scanConcurrency,
+		SelfTest:           selfTest,
+		ReadMulti:          readMulti,
+		Exists:             existsCheck,
+		CAS:                casWrites,
+		CASKeys:            casKeys,
+		Increments:         increments,
+		Counters:           counters,
+		ChangeFeed:         changeFeed,
+		BatchSize:          batchSize,
+		Charts:             charts,
+		Upload:             upload,
+		DeleteMode:         deleteMode,
+		DeleteBatch:        deleteBatch,
+		SoftDelete:         softDelete,
+		UpdateMode:         updateMode,
+		Versions:           versions,
+		Children:           children,
+		GraphEdges:         graphEdges,
+		GraphHops:          graphHops,
+		BatchSweep:         batchSweep,
+		MaxClientMem:       maxClientMem,
+		YugabyteAPI:        yugabyteAPI,
+		OpTimeout:          opTimeout,
+		Mix:                mix,
+		Distribution:       distribution,
+		ProgressFile:       progressFile,
+		Duration:           runDuration,
+		Rate:               rate,
+		WarmupOps:          warmupOps,
+		WarmupDuration:     warmupDuration,
+		Seed:               seed,
+		WorkloadBundle:     workloadBundle,
+		ControlSocket:      controlSocket,
+		Serial:             serial,
+		Rerun:              rerun,
+		Calibration:        calibration,
+		OutputFormats:      outputFormats,
+		ReportHTML:         reportHTML,
+		JSONEncoder:        jsonEncoderFlag,
+		RecordFormat:       recordFormatFlag,
+		Progress:           progressFlag,
+		TimeSeriesInterval: timeSeriesIntervalFlag,
+		WatchdogInterval:   watchdogIntervalFlag,
+		History:            history,
+		BindAddresses:      bindAddressesFlag,
+		Reference:          referenceFlag,
+		Connection: ConnectionOptions{
+			Username:      username,
+			Password:      password,
+			Database:      dbName,
+			Port:          port,
+			TLS:           tls,
+			TLSCA:         tlsCA,
+			TLSCert:       tlsCert,
+			TLSKey:        tlsKey,
+			TLSSkipVerify: tlsSkipVerify,
+		},
 	}
 
 	// Validate config
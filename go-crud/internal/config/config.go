@@ -1,14 +1,24 @@
 package config
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"net"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // Config represents the main configuration for the benchmark
 type Config struct {
 	Name       string
 	Database   string
+	Databases  []string
 	Image      string
 	Privileged bool
 	Endpoint   string
@@ -16,6 +26,7 @@ type Config struct {
 	Workers    int
 	Clients    int
 	Threads    int
+	SharedPool bool
 	Samples    int
 	Random     bool
 	KeyType    string
@@ -23,27 +34,243 @@ type Config struct {
 	ShowSample bool
 	PID        int
 	Scans      []ScanConfig
+	IndexBuild string
+	Compaction bool
+	Network    *NetworkProfile
+	SLO        []time.Duration
+	TimeUnit   string
+	Color      string
+	Output     string
+	Tuning     map[string]string
+	Checksum   bool
+	SignKey    string
+	Repeat     int
+	Cooldown   time.Duration
+	MaxLoad    float64
+	Thermal    bool
+	DropCaches bool
+
+	DebugQueries       int
+	DebugQueryParams   bool
+	KeyEncoding        string
+	Namespace          string
+	ConsistencyProbes  int
+	ProbeEndpoint      string
+	AnomalyCheck       int
+	CleanupPolicy      string
+	ScanConcurrency    int
+	SelfTest           bool
+	ReadMulti          int
+	Exists             bool
+	CAS                int
+	CASKeys            int
+	Increments         int
+	Counters           int
+	ChangeFeed         bool
+	BatchSize          int
+	Charts             string
+	Upload             *UploadTarget
+	DeleteMode         string
+	DeleteBatch        int
+	SoftDelete         float64
+	UpdateMode         string
+	Versions           int
+	Children           int
+	GraphEdges         int
+	GraphHops          int
+	BatchSweep         bool
+	MaxClientMem       int64
+	YugabyteAPI        string
+	OpTimeout          time.Duration
+	Mix                []MixEntry
+	Distribution       string
+	ProgressFile       string
+	Duration           time.Duration
+	Rate               int
+	WarmupOps          int
+	WarmupDuration     time.Duration
+	Seed               int64
+	WorkloadBundle     string
+	ControlSocket      string
+	Serial             bool
+	Rerun              string
+	Calibration        string
+	OutputFormats      []string
+	ReportHTML         string
+	JSONEncoder        string
+	RecordFormat       string
+	Progress           string
+	TimeSeriesInterval time.Duration
+	WatchdogInterval   time.Duration
+	History            *HistoryStore
+	BindAddresses      []string
+	Reference          string
+	Connection         ConnectionOptions
 }
 
 // ScanConfig represents a scan operation configuration
 type ScanConfig struct {
 	Name       string `json:"name"`
 	Samples    int    `json:"samples"`
-	Projection string `json:"projection"` // ID, FULL, COUNT
+	Projection string `json:"projection"` // ID, FULL, COUNT, SEARCH:field:term, GEO_WITHIN:field:lon,lat:km, JSON_PATH:path:value, JOIN:fanout
 	Start      int    `json:"start,omitempty"`
 	Limit      int    `json:"limit,omitempty"`
 	Expect     int    `json:"expect,omitempty"`
 }
 
+// searchPrefix starts the projection of full-text search scans
+const searchPrefix = "SEARCH:"
+
+// geoPrefix starts the projection of geo radius scans
+const geoPrefix = "GEO_WITHIN:"
+
+// jsonPathPrefix starts the projection of JSON path scans
+const jsonPathPrefix = "JSON_PATH:"
+
+// joinPrefix starts the projection of parent-child join scans
+const joinPrefix = "JOIN:"
+
+// fieldRegex matches value field names which can be embedded in queries
+var fieldRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Search returns the field and term of a full-text search scan, whose
+// projection has the form SEARCH:field:term
+func (s ScanConfig) Search() (field, term string, ok bool) {
+	if !strings.HasPrefix(s.Projection, searchPrefix) {
+		return "", "", false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(s.Projection, searchPrefix), ":", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// GeoWithin returns the field, centre and radius in kilometres of a geo radius
+// scan, whose projection has the form GEO_WITHIN:field:lon,lat:km
+func (s ScanConfig) GeoWithin() (field string, lon, lat, radiusKm float64, ok bool) {
+	if !strings.HasPrefix(s.Projection, geoPrefix) {
+		return "", 0, 0, 0, false
+	}
+	parts := strings.Split(strings.TrimPrefix(s.Projection, geoPrefix), ":")
+	if len(parts) != 3 {
+		return "", 0, 0, 0, false
+	}
+	lonText, latText, found := strings.Cut(parts[1], ",")
+	if !found {
+		return "", 0, 0, 0, false
+	}
+
+	var err error
+	if lon, err = strconv.ParseFloat(lonText, 64); err != nil {
+		return "", 0, 0, 0, false
+	}
+	if lat, err = strconv.ParseFloat(latText, 64); err != nil {
+		return "", 0, 0, 0, false
+	}
+	if radiusKm, err = strconv.ParseFloat(parts[2], 64); err != nil {
+		return "", 0, 0, 0, false
+	}
+	return parts[0], lon, lat, radiusKm, true
+}
+
+// JSONPath returns the field names of the path and the expected value of a
+// JSON path scan, whose projection has the form JSON_PATH:a.b.c:value. The
+// value is parsed as JSON, falling back to a plain string.
+func (s ScanConfig) JSONPath() (path []string, value interface{}, ok bool) {
+	if !strings.HasPrefix(s.Projection, jsonPathPrefix) {
+		return nil, nil, false
+	}
+	pathText, valueText, found := strings.Cut(strings.TrimPrefix(s.Projection, jsonPathPrefix), ":")
+	if !found {
+		return nil, nil, false
+	}
+	if err := json.Unmarshal([]byte(valueText), &value); err != nil {
+		value = valueText
+	}
+	return strings.Split(pathText, "."), value, true
+}
+
+// Join returns the fan-out of a parent-child join scan, whose projection has
+// the form JOIN:fanout: every scanned parent row is joined with at most
+// fanout of its child rows
+func (s ScanConfig) Join() (fanout int, ok bool) {
+	if !strings.HasPrefix(s.Projection, joinPrefix) {
+		return 0, false
+	}
+	fanout, err := strconv.Atoi(strings.TrimPrefix(s.Projection, joinPrefix))
+	if err != nil {
+		return 0, false
+	}
+	return fanout, true
+}
+
 // ValidKeyTypes contains all supported key types
 var ValidKeyTypes = []string{"integer", "string26", "string90", "string250", "string506", "uuid"}
 
+// ValidTimeUnits contains all supported console time units
+var ValidTimeUnits = []string{"s", "ms", "us"}
+
+// ValidColorModes contains all supported console colour modes
+var ValidColorModes = []string{"auto", "always", "never"}
+
+// ValidOutputModes contains all supported stdout modes
+var ValidOutputModes = []string{"text", "json-stream"}
+
+// ValidOutputFormats contains the formats of the results files
+var ValidOutputFormats = []string{"json", "csv", "markdown"}
+
+// ValidJSONEncoders contains the JSON encoders the adapters can marshal records with
+var ValidJSONEncoders = []string{"std", "jsoniter", "sonic"}
+
+// ValidProgressModes contains the modes of the console progress display
+var ValidProgressModes = []string{"auto", "line", "plain", "off"}
+
+// ValidDeleteModes contains all supported delete phase modes
+var ValidDeleteModes = []string{"row", "batch", "truncate"}
+
+// ValidUpdateModes contains all supported update phase modes
+var ValidUpdateModes = []string{"overwrite", "versioned"}
+
+// ValidChartFormats contains all supported chart image formats
+var ValidChartFormats = []string{"svg", "png"}
+
+// ValidCleanupPolicies contains all supported cleanup failure policies
+var ValidCleanupPolicies = []string{"fail", "warn", "janitor"}
+
+// ValidYugabyteAPIs contains the supported YugabyteDB API layers
+var ValidYugabyteAPIs = []string{"ysql", "ycql"}
+
+// ValidMixOperations contains the operations of mixed workloads
+var ValidMixOperations = []string{"read", "update", "insert", "delete"}
+
+// ValidDistributions contains the supported key access distributions
+var ValidDistributions = []string{"uniform", "zipfian", "latest", "hotspot"}
+
 // ValidDatabases contains all supported database types
 var ValidDatabases = []string{
-	"dry", "map", "arangodb", "dragonfly", "fjall", "keydb", "lmdb", 
-	"mongodb", "mysql", "neo4j", "postgres", "redb", "redis", "rocksdb", 
+	"dry", "map", "arangodb", "badger", "cassandra", "cockroachdb", "dragonfly", "fjall", "keydb", "leveldb", "lmdb",
+	"mongodb", "mssql", "mysql", "neo4j", "postgres", "redb", "redis", "rocksdb",
 	"scylladb", "sqlite", "surrealkv", "surrealdb", "surrealdb-memory", 
-	"surrealdb-rocksdb", "surrealdb-surrealkv",
+	"surrealdb-rocksdb", "surrealdb-surrealkv", "yugabyte",
+}
+
+// namespaceRegex matches valid namespace suffixes for table and collection names
+var namespaceRegex = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// GenerateNamespace returns a random namespace used to isolate runs on shared endpoints
+func GenerateNamespace() string {
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// ValidateNamespace checks that a namespace can suffix table and collection names
+func ValidateNamespace(namespace string) error {
+	if !namespaceRegex.MatchString(namespace) {
+		return fmt.Errorf("invalid namespace %q: only lowercase letters, digits and underscores are allowed", namespace)
+	}
+	return nil
 }
 
 // ParseScans parses the JSON string into a slice of ScanConfig
@@ -56,6 +283,129 @@ func ParseScans(scansJSON string) ([]ScanConfig, error) {
 	return scans, nil
 }
 
+// ParseSLO parses a comma-separated list of latency bounds into ascending order
+func ParseSLO(slo string) ([]time.Duration, error) {
+	if slo == "" {
+		return nil, nil
+	}
+	var bounds []time.Duration
+	for _, part := range strings.Split(slo, ",") {
+		bound, err := time.ParseDuration(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse SLO bound %q: %w", part, err)
+		}
+		if bound <= 0 {
+			return nil, fmt.Errorf("SLO bound must be greater than 0: %s", part)
+		}
+		bounds = append(bounds, bound)
+	}
+	sort.Slice(bounds, func(i, j int) bool { return bounds[i] < bounds[j] })
+	return bounds, nil
+}
+
+// MixEntry is the share of an operation in a mixed workload
+type MixEntry struct {
+	Operation string `json:"operation"`
+	Weight    int    `json:"weight"`
+}
+
+// ParseMix parses mixed workload ratios such as read=95,update=5 into entries
+// in the order given. The weights are relative and need not add up to 100.
+func ParseMix(spec string) ([]MixEntry, error) {
+	if spec == "" {
+		return nil, nil
+	}
+	var mix []MixEntry
+	total := 0
+	for _, part := range strings.Split(spec, ",") {
+		operation, weight, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return nil, fmt.Errorf("expected operation=weight, got %q", part)
+		}
+		valid := false
+		for _, op := range ValidMixOperations {
+			if operation == op {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("invalid operation %q. Must be one of: %s", operation, strings.Join(ValidMixOperations, ", "))
+		}
+		for _, entry := range mix {
+			if entry.Operation == operation {
+				return nil, fmt.Errorf("operation %s is given more than once", operation)
+			}
+		}
+		n, err := strconv.Atoi(weight)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid weight for operation %s: %s", operation, weight)
+		}
+		mix = append(mix, MixEntry{Operation: operation, Weight: n})
+		total += n
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("at least one operation must have a weight greater than 0")
+	}
+	return mix, nil
+}
+
+// ParseWarmup parses a warmup given as a number of operations or a duration.
+// An empty warmup disables it.
+func ParseWarmup(warmup string) (int, time.Duration, error) {
+	warmup = strings.TrimSpace(warmup)
+	if warmup == "" {
+		return 0, 0, nil
+	}
+	if ops, err := strconv.Atoi(warmup); err == nil {
+		if ops < 0 {
+			return 0, 0, fmt.Errorf("warmup operations must not be negative: %d", ops)
+		}
+		return ops, 0, nil
+	}
+	d, err := time.ParseDuration(warmup)
+	if err != nil {
+		return 0, 0, fmt.Errorf("expected a number of operations or a duration, got %q", warmup)
+	}
+	if d < 0 {
+		return 0, 0, fmt.Errorf("warmup duration must not be negative: %s", warmup)
+	}
+	return 0, d, nil
+}
+
+// byteUnits are the suffixes accepted by ParseByteSize, as powers of 1024
+var byteUnits = []struct {
+	suffix string
+	size   float64
+}{
+	{"TB", 1 << 40},
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"B", 1},
+}
+
+// ParseByteSize parses a size such as 2GB or 512MB into bytes. The units are
+// powers of 1024 and a size without a unit is in bytes. An empty size is 0.
+func ParseByteSize(size string) (int64, error) {
+	size = strings.ToUpper(strings.TrimSpace(size))
+	if size == "" {
+		return 0, nil
+	}
+	number, multiplier := size, 1.0
+	for _, unit := range byteUnits {
+		if strings.HasSuffix(size, unit.suffix) {
+			number, multiplier = strings.TrimSpace(strings.TrimSuffix(size, unit.suffix)), unit.size
+			break
+		}
+	}
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil || value <= 0 {
+		return 0, fmt.Errorf("invalid size %q, expected a positive number with an optional KB, MB, GB or TB unit", size)
+	}
+	return int64(value * multiplier), nil
+}
+
 // Validate checks if the configuration is valid
 func (c *Config) Validate() error {
 	if c.Database == "" {
@@ -66,6 +416,218 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("samples must be greater than 0")
 	}
 
+	if c.Repeat <= 0 {
+		return fmt.Errorf("repeat must be greater than 0")
+	}
+
+	if c.Workers < 0 {
+		return fmt.Errorf("workers must not be negative")
+	}
+	if c.Blocking < 0 {
+		return fmt.Errorf("blocking threads must not be negative")
+	}
+
+	if c.ConsistencyProbes < 0 {
+		return fmt.Errorf("consistency probes must not be negative")
+	}
+	if c.BatchSize <= 0 {
+		return fmt.Errorf("batch size must be greater than 0")
+	}
+	if c.DeleteBatch <= 0 {
+		return fmt.Errorf("delete batch size must be greater than 0")
+	}
+	if c.Versions <= 0 {
+		return fmt.Errorf("versions must be greater than 0")
+	}
+	if c.Children < 0 {
+		return fmt.Errorf("children must not be negative")
+	}
+	if c.Children > 0 && (c.BatchSize > 1 || c.ChangeFeed || c.DeleteMode == "truncate") {
+		return fmt.Errorf("the relational workload cannot be combined with batched creates, change feeds or truncation")
+	}
+	if c.Duration < 0 {
+		return fmt.Errorf("duration must not be negative")
+	}
+	if c.Duration > 0 && (c.Children > 0 || c.UpdateMode == "versioned") {
+		return fmt.Errorf("the duration cannot be combined with the relational workload or versioned updates")
+	}
+	for _, entry := range c.Mix {
+		if c.Duration > 0 && entry.Operation == "insert" && entry.Weight > 0 {
+			return fmt.Errorf("the duration cannot be combined with inserts in the mixed workload")
+		}
+	}
+	if c.Rate < 0 {
+		return fmt.Errorf("rate must not be negative")
+	}
+	if c.Distribution != "uniform" && (c.Children > 0 || c.UpdateMode == "versioned") {
+		return fmt.Errorf("the key distribution cannot be combined with the relational workload or versioned updates")
+	}
+	if len(c.Databases) > 1 && (c.Endpoint != "" || c.Image != "" || c.Rerun != "") {
+		return fmt.Errorf("an endpoint, image or rerun applies to a single database and cannot be combined with several databases")
+	}
+	if c.Rerun != "" && c.Endpoint != "" {
+		return fmt.Errorf("a rerun provisions a container and cannot be combined with an endpoint")
+	}
+	if !c.Connection.IsZero() {
+		if c.Endpoint == "" {
+			return fmt.Errorf("connection options such as --username and --tls require --endpoint, managed containers use their own credentials")
+		}
+		networked := false
+		for _, db := range NetworkedDatabases {
+			if c.Database == db {
+				networked = true
+				break
+			}
+		}
+		if !networked {
+			return fmt.Errorf("database %s is not served over the network and takes no connection options", c.Database)
+		}
+		if c.Connection.Port < 0 || c.Connection.Port > 65535 {
+			return fmt.Errorf("port must be between 1 and 65535")
+		}
+		if (c.Connection.TLSCert == "") != (c.Connection.TLSKey == "") {
+			return fmt.Errorf("--tls-cert and --tls-key must be given together")
+		}
+	}
+	if c.Serial && (c.Clients > 1 || c.Threads > 1) {
+		return fmt.Errorf("serial mode runs a single client and thread")
+	}
+	if c.Serial && (c.ChangeFeed || c.IndexBuild != "" || c.AnomalyCheck > 0 || c.ScanConcurrency > 0) {
+		return fmt.Errorf("serial mode cannot be combined with change feeds, index builds, anomaly checks or concurrent scans, which run operations concurrently")
+	}
+	if c.OpTimeout < 0 {
+		return fmt.Errorf("operation timeout must not be negative")
+	}
+	if c.GraphEdges < 0 {
+		return fmt.Errorf("graph edges must not be negative")
+	}
+	if c.GraphHops <= 0 {
+		return fmt.Errorf("graph hops must be greater than 0")
+	}
+	if c.SoftDelete < 0 || c.SoftDelete > 1 {
+		return fmt.Errorf("soft delete fraction must be between 0 and 1")
+	}
+	if c.ReadMulti < 0 {
+		return fmt.Errorf("read multi batch size must not be negative")
+	}
+	if c.CAS < 0 {
+		return fmt.Errorf("compare-and-set writes must not be negative")
+	}
+	if c.CAS > 0 && c.CASKeys <= 0 {
+		return fmt.Errorf("cas keys must be greater than 0")
+	}
+	if c.Increments < 0 {
+		return fmt.Errorf("increments must not be negative")
+	}
+	if c.Increments > 0 && c.Counters <= 0 {
+		return fmt.Errorf("counters must be greater than 0")
+	}
+	if c.ScanConcurrency < 0 {
+		return fmt.Errorf("scan concurrency must not be negative")
+	}
+	if c.AnomalyCheck < 0 {
+		return fmt.Errorf("anomaly check operations must not be negative")
+	}
+	if c.ProbeEndpoint != "" && c.ConsistencyProbes == 0 {
+		return fmt.Errorf("--probe-endpoint requires --consistency-probes")
+	}
+
+	// Validate full-text search scans
+	for _, scan := range c.Scans {
+		if !strings.HasPrefix(scan.Projection, searchPrefix) {
+			continue
+		}
+		field, term, ok := scan.Search()
+		if !ok || term == "" {
+			return fmt.Errorf("invalid search projection %q for scan '%s': expected SEARCH:field:term", scan.Projection, scan.Name)
+		}
+		if !fieldRegex.MatchString(field) {
+			return fmt.Errorf("invalid search field %q for scan '%s'", field, scan.Name)
+		}
+	}
+
+	// The indexed field is embedded in the index definition
+	if c.IndexBuild != "" && !fieldRegex.MatchString(c.IndexBuild) {
+		return fmt.Errorf("invalid index build field %q", c.IndexBuild)
+	}
+
+	// Validate JSON path scans
+	for _, scan := range c.Scans {
+		if !strings.HasPrefix(scan.Projection, jsonPathPrefix) {
+			continue
+		}
+		path, value, ok := scan.JSONPath()
+		if !ok {
+			return fmt.Errorf("invalid JSON path projection %q for scan '%s': expected JSON_PATH:path:value", scan.Projection, scan.Name)
+		}
+		for _, field := range path {
+			if !fieldRegex.MatchString(field) {
+				return fmt.Errorf("invalid JSON path field %q for scan '%s'", field, scan.Name)
+			}
+		}
+		switch value.(type) {
+		case string, float64, bool:
+		default:
+			return fmt.Errorf("JSON path value for scan '%s' must be a string, number or boolean", scan.Name)
+		}
+	}
+
+	// Validate join scans, which need the child table of the relational workload
+	for _, scan := range c.Scans {
+		if !strings.HasPrefix(scan.Projection, joinPrefix) {
+			continue
+		}
+		fanout, ok := scan.Join()
+		if !ok || fanout <= 0 {
+			return fmt.Errorf("invalid join projection %q for scan '%s': expected JOIN:fanout with a fan-out greater than 0", scan.Projection, scan.Name)
+		}
+		if c.Children == 0 {
+			return fmt.Errorf("join scan '%s' requires --children", scan.Name)
+		}
+	}
+
+	// Validate geo radius scans
+	for _, scan := range c.Scans {
+		if !strings.HasPrefix(scan.Projection, geoPrefix) {
+			continue
+		}
+		field, lon, lat, radius, ok := scan.GeoWithin()
+		if !ok {
+			return fmt.Errorf("invalid geo projection %q for scan '%s': expected GEO_WITHIN:field:lon,lat:km", scan.Projection, scan.Name)
+		}
+		if !fieldRegex.MatchString(field) {
+			return fmt.Errorf("invalid geo field %q for scan '%s'", field, scan.Name)
+		}
+		if lon < -180 || lon > 180 || lat < -90 || lat > 90 {
+			return fmt.Errorf("invalid geo centre %v,%v for scan '%s'", lon, lat, scan.Name)
+		}
+		if radius <= 0 {
+			return fmt.Errorf("geo radius must be greater than 0 for scan '%s'", scan.Name)
+		}
+	}
+
+	if c.Namespace != "" {
+		if err := ValidateNamespace(c.Namespace); err != nil {
+			return err
+		}
+	}
+
+	// Validate key encoding
+	switch c.KeyEncoding {
+	case "string", "bytes":
+	case "int64":
+		if c.KeyType != "integer" {
+			return fmt.Errorf("the int64 key encoding requires the integer key type")
+		}
+	default:
+		return fmt.Errorf("invalid key encoding: %s", c.KeyEncoding)
+	}
+
+	// Validate record format
+	if c.RecordFormat != "map" && c.RecordFormat != "payload" {
+		return fmt.Errorf("invalid record format: %s", c.RecordFormat)
+	}
+
 	// Validate key type
 	validKey := false
 	for _, k := range ValidKeyTypes {
@@ -78,16 +640,196 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid key type: %s", c.KeyType)
 	}
 
-	// Validate database
-	validDB := false
-	for _, db := range ValidDatabases {
-		if c.Database == db {
-			validDB = true
+	// Validate databases
+	for _, database := range append([]string{c.Database}, c.Databases...) {
+		validDB := false
+		for _, db := range ValidDatabases {
+			if database == db {
+				validDB = true
+				break
+			}
+		}
+		if !validDB {
+			return fmt.Errorf("invalid database: %s", database)
+		}
+	}
+
+	// Validate time unit
+	validUnit := false
+	for _, u := range ValidTimeUnits {
+		if c.TimeUnit == u {
+			validUnit = true
+			break
+		}
+	}
+	if !validUnit {
+		return fmt.Errorf("invalid time unit: %s", c.TimeUnit)
+	}
+
+	// Validate colour mode
+	validColor := false
+	for _, m := range ValidColorModes {
+		if c.Color == m {
+			validColor = true
 			break
 		}
 	}
-	if !validDB {
-		return fmt.Errorf("invalid database: %s", c.Database)
+	if !validColor {
+		return fmt.Errorf("invalid color mode: %s", c.Color)
+	}
+
+	// Validate output mode
+	validOutput := false
+	for _, o := range ValidOutputModes {
+		if c.Output == o {
+			validOutput = true
+			break
+		}
+	}
+	if !validOutput {
+		return fmt.Errorf("invalid output mode: %s", c.Output)
+	}
+
+	// Validate results file formats
+	if len(c.OutputFormats) == 0 {
+		return fmt.Errorf("at least one output format is required")
+	}
+	for _, format := range c.OutputFormats {
+		validFormat := false
+		for _, f := range ValidOutputFormats {
+			if format == f {
+				validFormat = true
+				break
+			}
+		}
+		if !validFormat {
+			return fmt.Errorf("invalid output format: %s", format)
+		}
+	}
+	// Uploaded objects are stored below the checksum of the JSON results file
+	if c.Upload != nil && !slices.Contains(c.OutputFormats, "json") {
+		return fmt.Errorf("--upload requires the json output format")
+	}
+
+	// Validate JSON encoder
+	validEncoder := false
+	for _, e := range ValidJSONEncoders {
+		if c.JSONEncoder == e {
+			validEncoder = true
+			break
+		}
+	}
+	if !validEncoder {
+		return fmt.Errorf("invalid JSON encoder: %s", c.JSONEncoder)
+	}
+
+	if c.TimeSeriesInterval < 0 {
+		return fmt.Errorf("timeseries interval must not be negative")
+	}
+
+	if c.WatchdogInterval < 0 {
+		return fmt.Errorf("watchdog interval must not be negative")
+	}
+
+	// Validate bind addresses
+	for _, address := range c.BindAddresses {
+		if net.ParseIP(address) == nil {
+			return fmt.Errorf("invalid bind address: %s", address)
+		}
+	}
+
+	// Validate progress mode
+	validProgress := false
+	for _, m := range ValidProgressModes {
+		if c.Progress == m {
+			validProgress = true
+			break
+		}
+	}
+	if !validProgress {
+		return fmt.Errorf("invalid progress mode: %s", c.Progress)
+	}
+
+	// Validate delete mode
+	validDelete := false
+	for _, m := range ValidDeleteModes {
+		if c.DeleteMode == m {
+			validDelete = true
+			break
+		}
+	}
+	if !validDelete {
+		return fmt.Errorf("invalid delete mode: %s", c.DeleteMode)
+	}
+
+	// Validate update mode
+	validUpdate := false
+	for _, m := range ValidUpdateModes {
+		if c.UpdateMode == m {
+			validUpdate = true
+			break
+		}
+	}
+	if !validUpdate {
+		return fmt.Errorf("invalid update mode: %s", c.UpdateMode)
+	}
+
+	// Validate YugabyteDB API
+	validAPI := false
+	for _, api := range ValidYugabyteAPIs {
+		if c.YugabyteAPI == api {
+			validAPI = true
+			break
+		}
+	}
+	if !validAPI {
+		return fmt.Errorf("invalid YugabyteDB API: %s", c.YugabyteAPI)
+	}
+
+	// Validate key access distribution
+	validDistribution := false
+	for _, distribution := range ValidDistributions {
+		if c.Distribution == distribution {
+			validDistribution = true
+			break
+		}
+	}
+	if !validDistribution {
+		return fmt.Errorf("invalid key distribution: %s", c.Distribution)
+	}
+	if len(c.Mix) > 0 && (c.Children > 0 || c.ChangeFeed || c.BatchSweep) {
+		return fmt.Errorf("the mixed workload cannot be combined with the relational workload, change feeds or the batch size sweep")
+	}
+
+	// Validate chart format
+	if c.Charts != "" {
+		validCharts := false
+		for _, f := range ValidChartFormats {
+			if c.Charts == f {
+				validCharts = true
+				break
+			}
+		}
+		if !validCharts {
+			return fmt.Errorf("invalid chart format: %s", c.Charts)
+		}
+	}
+
+	// Validate cleanup policy
+	validPolicy := false
+	for _, p := range ValidCleanupPolicies {
+		if c.CleanupPolicy == p {
+			validPolicy = true
+			break
+		}
+	}
+	if !validPolicy {
+		return fmt.Errorf("invalid cleanup policy: %s", c.CleanupPolicy)
+	}
+
+	// Network profiles are applied inside a managed container
+	if c.Network != nil && (c.Endpoint != "" || !c.Privileged) {
+		return fmt.Errorf("network profiles require a managed container started with --privileged")
 	}
 
 	return nil
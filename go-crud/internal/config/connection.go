@@ -0,0 +1,91 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ConnectionOptions hold the credentials and connection settings of a
+// networked database given apart from its endpoint. Adapters merge them into
+// the endpoint in the form their driver expects, taking precedence over the
+// values of the endpoint. Zero values leave the endpoint unchanged.
+type ConnectionOptions struct {
+	Username string
+	Password string
+	// Database is the database holding the benchmark table: the keyspace of
+	// CQL databases and the logical database number of Redis
+	Database string
+	Port     int
+	TLS      bool
+	// TLSCA is a PEM file of the certificate authorities verifying the
+	// server, which otherwise is verified against the system roots
+	TLSCA string
+	// TLSCert and TLSKey are PEM files of the client certificate and key
+	TLSCert       string
+	TLSKey        string
+	TLSSkipVerify bool
+}
+
+// IsZero reports whether no connection option is set
+func (o ConnectionOptions) IsZero() bool {
+	return o == ConnectionOptions{}
+}
+
+// HostPort returns the address of a host[:port] endpoint with the port
+// replaced by the port option when it is set. An endpoint without a port
+// gets the given default port, unless that is empty.
+func (o ConnectionOptions) HostPort(endpoint, defaultPort string) string {
+	host, port, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		host, port = strings.Trim(endpoint, "[]"), defaultPort
+	}
+	if o.Port > 0 {
+		port = strconv.Itoa(o.Port)
+	}
+	if port == "" {
+		return host
+	}
+	return net.JoinHostPort(host, port)
+}
+
+// TLSConfig returns the TLS configuration of the connection to the given
+// server name, or nil when TLS is not enabled
+func (o ConnectionOptions) TLSConfig(serverName string) (*tls.Config, error) {
+	if !o.TLS {
+		return nil, nil
+	}
+	config := &tls.Config{
+		ServerName:         serverName,
+		InsecureSkipVerify: o.TLSSkipVerify,
+	}
+	if o.TLSCA != "" {
+		pem, err := os.ReadFile(o.TLSCA)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read TLS CA: %w", err)
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM certificates found in TLS CA %s", o.TLSCA)
+		}
+	}
+	if o.TLSCert != "" {
+		cert, err := tls.LoadX509KeyPair(o.TLSCert, o.TLSKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+// NetworkedDatabases contains the databases served over the network, which
+// accept connection options
+var NetworkedDatabases = []string{
+	"cassandra", "cockroachdb", "dragonfly", "keydb", "mongodb", "mssql", "mysql", "postgres", "redis",
+	"scylladb", "surrealdb", "surrealdb-memory", "surrealdb-rocksdb", "surrealdb-surrealkv", "yugabyte",
+}
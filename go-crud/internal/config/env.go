@@ -0,0 +1,39 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// EnvPrefix is the prefix of the environment variables which set flags
+const EnvPrefix = "CRUD_BENCH_"
+
+// EnvName returns the environment variable which sets a flag: the flag name
+// in upper case with dashes replaced by underscores, after the prefix
+func EnvName(flag string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(flag, "-", "_"))
+}
+
+// ApplyEnv sets the flags of the command not given on the command line from
+// their CRUD_BENCH_* environment variables. Flags taking several values or
+// key=value pairs are set from comma separated lists, as on the command line.
+func ApplyEnv(cmd *cobra.Command) error {
+	var err error
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if err != nil || flag.Changed || flag.Name == "help" {
+			return
+		}
+		value, ok := os.LookupEnv(EnvName(flag.Name))
+		if !ok {
+			return
+		}
+		if setErr := cmd.Flags().Set(flag.Name, value); setErr != nil {
+			err = fmt.Errorf("invalid value for %s in %s: %w", flag.Name, EnvName(flag.Name), setErr)
+		}
+	})
+	return err
+}
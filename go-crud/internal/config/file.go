@@ -0,0 +1,115 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadFile reads a benchmark definition from a YAML or TOML file, selected by
+// its extension. Its keys are the names of the run flags.
+func LoadFile(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	values := make(map[string]interface{})
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &values)
+	case ".toml":
+		err = toml.Unmarshal(data, &values)
+	default:
+		return nil, fmt.Errorf("unsupported config file %s, expected a .yaml, .yml or .toml file", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return values, nil
+}
+
+// ApplyFile sets the flags of the command to the values of a benchmark
+// definition file, except the flags given on the command line, which take
+// precedence. Flags taking JSON, such as the value template and the scans,
+// may be written as structured values, lists set flags taking several
+// values, and maps set flags taking key=value pairs.
+func ApplyFile(cmd *cobra.Command, path string) error {
+	values, err := LoadFile(path)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil || name == "config" {
+			return fmt.Errorf("config file %s sets unknown flag %s", path, name)
+		}
+		if flag.Changed {
+			continue
+		}
+		value, err := flagValue(flag, values[name])
+		if err != nil {
+			return fmt.Errorf("invalid value for %s in config file %s: %w", name, path, err)
+		}
+		if err := cmd.Flags().Set(name, value); err != nil {
+			return fmt.Errorf("invalid value %q for %s in config file %s: %w", value, name, path, err)
+		}
+	}
+	return nil
+}
+
+// flagValue formats a value of a definition file as the command line value
+// of a flag
+func flagValue(flag *pflag.Flag, value interface{}) (string, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if flag.Value.Type() == "stringToString" {
+			pairs := make([]string, 0, len(v))
+			for key, item := range v {
+				pairs = append(pairs, fmt.Sprintf("%s=%v", key, item))
+			}
+			sort.Strings(pairs)
+			return strings.Join(pairs, ","), nil
+		}
+		return jsonValue(flag, v)
+	case []interface{}:
+		if strings.HasSuffix(flag.Value.Type(), "Slice") {
+			items := make([]string, len(v))
+			for i, item := range v {
+				items[i] = fmt.Sprint(item)
+			}
+			return strings.Join(items, ","), nil
+		}
+		return jsonValue(flag, v)
+	case nil:
+		return "", nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
+// jsonValue encodes a structured value for a flag taking JSON
+func jsonValue(flag *pflag.Flag, value interface{}) (string, error) {
+	if flag.Value.Type() != "string" {
+		return "", fmt.Errorf("a structured value cannot set a flag of type %s", flag.Value.Type())
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
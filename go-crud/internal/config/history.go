@@ -0,0 +1,45 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidHistorySchemes contains the stores supported by --history
+var ValidHistorySchemes = []string{"sqlite"}
+
+// HistoryStore is a database every run is appended to, for trending results
+// over time
+type HistoryStore struct {
+	Scheme string
+	Path   string
+}
+
+// ParseHistoryStore parses a sqlite:path history store
+func ParseHistoryStore(store string) (*HistoryStore, error) {
+	scheme, path, ok := strings.Cut(store, ":")
+	if !ok {
+		return nil, fmt.Errorf("invalid history store %q: expected <scheme>:<path>, e.g. sqlite:history.db", store)
+	}
+
+	valid := false
+	for _, s := range ValidHistorySchemes {
+		if scheme == s {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return nil, fmt.Errorf("invalid history store %q: scheme must be one of %v", store, ValidHistorySchemes)
+	}
+	if path == "" {
+		return nil, fmt.Errorf("invalid history store %q: missing path", store)
+	}
+
+	return &HistoryStore{Scheme: scheme, Path: path}, nil
+}
+
+// String returns the store in the form it is parsed from
+func (s *HistoryStore) String() string {
+	return s.Scheme + ":" + s.Path
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// NetworkProfile describes a simulated network path between the client and the database
+type NetworkProfile struct {
+	Name   string        `json:"name"`
+	Delay  time.Duration `json:"delay"`
+	Jitter time.Duration `json:"jitter"`
+	Loss   float64       `json:"loss"` // Packet loss in percent
+}
+
+// NetworkProfiles contains the named netem profiles selectable with --network-profile.
+// The delay is applied to traffic leaving the database container.
+var NetworkProfiles = map[string]NetworkProfile{
+	"same-az":      {Name: "same-az", Delay: 250 * time.Microsecond, Jitter: 50 * time.Microsecond},
+	"cross-az":     {Name: "cross-az", Delay: 1 * time.Millisecond, Jitter: 250 * time.Microsecond},
+	"cross-region": {Name: "cross-region", Delay: 35 * time.Millisecond, Jitter: 5 * time.Millisecond, Loss: 0.01},
+	"mobile":       {Name: "mobile", Delay: 50 * time.Millisecond, Jitter: 15 * time.Millisecond, Loss: 1},
+}
+
+// NetworkProfileNames returns the sorted names of the network profiles
+func NetworkProfileNames() []string {
+	names := make([]string, 0, len(NetworkProfiles))
+	for n := range NetworkProfiles {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupNetworkProfile returns the named network profile
+func LookupNetworkProfile(name string) (*NetworkProfile, error) {
+	profile, ok := NetworkProfiles[name]
+	if !ok {
+		return nil, fmt.Errorf("invalid network profile: %s (valid profiles: %v)", name, NetworkProfileNames())
+	}
+	return &profile, nil
+}
+
+// NetemArgs returns the tc netem arguments implementing the profile
+func (p *NetworkProfile) NetemArgs() []string {
+	args := []string{"delay", fmt.Sprintf("%dus", p.Delay.Microseconds())}
+	if p.Jitter > 0 {
+		args = append(args, fmt.Sprintf("%dus", p.Jitter.Microseconds()), "distribution", "normal")
+	}
+	if p.Loss > 0 {
+		args = append(args, "loss", fmt.Sprintf("%g%%", p.Loss))
+	}
+	return args
+}
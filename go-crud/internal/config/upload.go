@@ -0,0 +1,56 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// ValidUploadSchemes contains the object storage schemes supported by --upload
+var ValidUploadSchemes = []string{"s3", "gs"}
+
+// UploadTarget is an object storage location the results are uploaded to
+type UploadTarget struct {
+	Scheme string
+	Bucket string
+	Prefix string
+}
+
+// ParseUploadTarget parses an s3://bucket/prefix or gs://bucket/prefix URL
+func ParseUploadTarget(target string) (*UploadTarget, error) {
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid upload target %q: %w", target, err)
+	}
+
+	valid := false
+	for _, s := range ValidUploadSchemes {
+		if u.Scheme == s {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return nil, fmt.Errorf("invalid upload target %q: scheme must be one of %v", target, ValidUploadSchemes)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid upload target %q: missing bucket", target)
+	}
+
+	return &UploadTarget{
+		Scheme: u.Scheme,
+		Bucket: u.Host,
+		Prefix: strings.Trim(u.Path, "/"),
+	}, nil
+}
+
+// Key returns the object key of a file name below the prefix
+func (t *UploadTarget) Key(name string) string {
+	return path.Join(t.Prefix, name)
+}
+
+// URL returns the URL of an object key in the bucket
+func (t *UploadTarget) URL(key string) string {
+	return fmt.Sprintf("%s://%s/%s", t.Scheme, t.Bucket, key)
+}
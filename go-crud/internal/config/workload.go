@@ -0,0 +1,181 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Workload is the canonical description of the work a benchmark performs. It
+// excludes the database and environment settings, so that runs of the same
+// workload against different databases or hosts share the same hash.
+type Workload struct {
+	Samples      int             `json:"samples"`
+	Duration     string          `json:"duration,omitempty"`
+	Rate         int             `json:"rate,omitempty"`
+	Clients      int             `json:"clients"`
+	Threads      int             `json:"threads"`
+	Workers      int             `json:"workers,omitempty"`
+	Blocking     int             `json:"blocking,omitempty"`
+	SharedPool   bool            `json:"shared_pool,omitempty"`
+	KeyType      string          `json:"key_type"`
+	KeyEncoding  string          `json:"key_encoding"`
+	RecordFormat string          `json:"record_format,omitempty"`
+	Random       bool            `json:"random"`
+	Distribution string          `json:"distribution,omitempty"`
+	Seed         int64           `json:"seed,omitempty"`
+	Value        json.RawMessage `json:"value"`
+	Scans        []ScanConfig    `json:"scans"`
+	ScanThreads  int             `json:"scan_concurrency,omitempty"`
+	Phases       []string        `json:"phases"`
+}
+
+// Workload returns the canonical workload description of the configuration
+func (c *Config) Workload() (*Workload, error) {
+	// Re-encode the value template so that formatting and key order do not matter
+	var template interface{}
+	if err := json.Unmarshal([]byte(c.Value), &template); err != nil {
+		return nil, fmt.Errorf("invalid value template: %w", err)
+	}
+	value, err := json.Marshal(template)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value template: %w", err)
+	}
+
+	// List the phases in the order the runner executes them
+	phases := []string{"create"}
+	if c.BatchSize > 1 {
+		phases[0] = fmt.Sprintf("create:%d", c.BatchSize)
+	}
+	if c.Children > 0 {
+		phases[0] = fmt.Sprintf("create:children:%d", c.Children)
+	}
+	if c.ChangeFeed {
+		phases = append(phases, "change_feed")
+	}
+	switch {
+	case c.WarmupOps > 0:
+		phases = append(phases, fmt.Sprintf("warmup:%d", c.WarmupOps))
+	case c.WarmupDuration > 0:
+		phases = append(phases, fmt.Sprintf("warmup:%s", c.WarmupDuration))
+	}
+	if len(c.Mix) > 0 {
+		// The mixed workload replaces the phases between create and delete
+		parts := make([]string, len(c.Mix))
+		for i, entry := range c.Mix {
+			parts[i] = fmt.Sprintf("%s=%d", entry.Operation, entry.Weight)
+		}
+		phases = append(phases, fmt.Sprintf("mixed:%s:%s", strings.Join(parts, ","), c.Distribution), "delete")
+		return c.workload(value, phases), nil
+	}
+	if c.Children > 0 {
+		phases = append(phases, fmt.Sprintf("read:children:%d", c.Children))
+	} else {
+		phases = append(phases, "read")
+	}
+	if c.ReadMulti > 0 {
+		phases = append(phases, fmt.Sprintf("read_multi:%d", c.ReadMulti))
+	}
+	if c.Exists {
+		phases = append(phases, "exists")
+	}
+	if c.UpdateMode == "versioned" {
+		phases = append(phases, fmt.Sprintf("update:versioned:%d", c.Versions))
+	} else {
+		phases = append(phases, "update")
+	}
+	if c.CAS > 0 {
+		phases = append(phases, fmt.Sprintf("cas:%d:%d", c.CAS, c.CASKeys))
+	}
+	if c.Increments > 0 {
+		phases = append(phases, fmt.Sprintf("increment:%d:%d", c.Increments, c.Counters))
+	}
+	if c.ConsistencyProbes > 0 {
+		phases = append(phases, fmt.Sprintf("probe:%d", c.ConsistencyProbes))
+	}
+	if c.AnomalyCheck > 0 {
+		phases = append(phases, fmt.Sprintf("check:%d", c.AnomalyCheck))
+	}
+	if c.IndexBuild != "" {
+		phases = append(phases, fmt.Sprintf("index:%s", c.IndexBuild))
+	}
+	if c.Compaction {
+		phases = append(phases, "compact")
+	}
+	if c.GraphEdges > 0 {
+		phases = append(phases, fmt.Sprintf("graph:%d:%d", c.GraphEdges, c.GraphHops))
+	}
+	phases = append(phases, "scan")
+	if c.SoftDelete > 0 {
+		phases = append(phases, fmt.Sprintf("soft_delete:%g", c.SoftDelete))
+	}
+	switch c.DeleteMode {
+	case "batch":
+		phases = append(phases, fmt.Sprintf("delete:batch:%d", c.DeleteBatch))
+	case "truncate":
+		phases = append(phases, "delete:truncate")
+	default:
+		phases = append(phases, "delete")
+	}
+	if c.BatchSweep {
+		phases = append(phases, "batch_sweep")
+	}
+
+	return c.workload(value, phases), nil
+}
+
+// workload returns the workload description with the given phases
+func (c *Config) workload(value json.RawMessage, phases []string) *Workload {
+	var duration string
+	if c.Duration > 0 {
+		duration = c.Duration.String()
+	}
+
+	// The mixed phase names its distribution, and uniform is left out so
+	// that the hashes of runs predating the distributions do not change
+	var distribution string
+	if len(c.Mix) == 0 && c.Distribution != "uniform" {
+		distribution = c.Distribution
+	}
+
+	// The map record format is left out so that the hashes of runs predating
+	// the payload record format do not change
+	var recordFormat string
+	if c.RecordFormat != "map" {
+		recordFormat = c.RecordFormat
+	}
+	// The call limits, the concurrent scans and the shared pool are left out
+	// when unset, so that the hashes of runs predating them do not change
+	return &Workload{
+		Samples:      c.Samples,
+		Duration:     duration,
+		Rate:         c.Rate,
+		Clients:      c.Clients,
+		Threads:      c.Threads,
+		Workers:      c.Workers,
+		Blocking:     c.Blocking,
+		SharedPool:   c.SharedPool,
+		KeyType:      c.KeyType,
+		KeyEncoding:  c.KeyEncoding,
+		RecordFormat: recordFormat,
+		Random:       c.Random,
+		Distribution: distribution,
+		Seed:         c.Seed,
+		Value:        value,
+		Scans:        c.Scans,
+		ScanThreads:  c.ScanConcurrency,
+		Phases:       phases,
+	}
+}
+
+// Hash returns the hex encoded SHA-256 hash of the canonical workload encoding
+func (w *Workload) Hash() (string, error) {
+	data, err := json.Marshal(w)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode workload: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,596 @@
+package badger
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	badgerdb "github.com/dgraph-io/badger/v4"
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+	"github.com/surrealdb/go-crud-bench/internal/codec"
+	"github.com/surrealdb/go-crud-bench/internal/config"
+	"github.com/surrealdb/go-crud-bench/internal/dbutils"
+)
+
+// memoryEndpoint opens the database in memory instead of on disk
+const memoryEndpoint = ":memory:"
+
+// versionPrefix starts the keys of the version history, which sort before
+// the record keys and are followed by the record key, a zero byte and the
+// big-endian version
+const versionPrefix = "\x00versions\x00"
+
+// preset contains the default tuning settings for BadgerDB
+var preset = map[string]string{
+	"block_cache_mb": "256",
+	"index_cache_mb": "0",
+	"num_compactors": "4",
+	"sync_writes":    "false",
+}
+
+// Adapter implements the benchmark.Adapter interface for BadgerDB, running in-process
+type Adapter struct {
+	db       *badgerdb.DB
+	endpoint string
+	path     string
+	tempDir  string
+	tuning   dbutils.Tuning
+	queryLog *dbutils.QueryLog
+	steps    *dbutils.Steps
+}
+
+// NewAdapter creates a new BadgerDB adapter. The endpoint is the path of the
+// database directory, or :memory: for an in-memory database. Without an
+// endpoint the database is created in a temporary directory.
+func NewAdapter(endpoint string) *Adapter {
+	return &Adapter{
+		endpoint: endpoint,
+		tuning:   preset,
+	}
+}
+
+// Tune applies tuning overrides on top of the BadgerDB preset
+func (a *Adapter) Tune(overrides map[string]string) (map[string]string, error) {
+	tuning, err := dbutils.NewTuning(preset, overrides)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := strconv.ParseBool(tuning["sync_writes"]); err != nil {
+		return nil, fmt.Errorf("invalid value for tuning setting sync_writes: %w", err)
+	}
+	a.tuning = tuning
+	return tuning, nil
+}
+
+// Initialize opens the BadgerDB database
+func (a *Adapter) Initialize(ctx context.Context) error {
+	// Create a temporary database directory if no endpoint is provided
+	a.path = a.endpoint
+	if a.path == "" {
+		dir, err := os.MkdirTemp("", "crud-bench-badger-")
+		if err != nil {
+			return fmt.Errorf("failed to create temporary directory: %w", err)
+		}
+		a.tempDir = dir
+		a.path = dir
+	}
+
+	openStart := time.Now()
+	if err := a.open(); err != nil {
+		return err
+	}
+	a.steps.Record("connect", openStart)
+
+	return nil
+}
+
+// Cleanup closes the database and removes the temporary database directory
+func (a *Adapter) Cleanup(ctx context.Context) error {
+	if a.db != nil {
+		if err := a.db.Close(); err != nil {
+			return fmt.Errorf("failed to close database: %w", err)
+		}
+	}
+
+	// Remove the temporary database directory
+	if a.tempDir != "" {
+		removeStart := time.Now()
+		if err := os.RemoveAll(a.tempDir); err != nil {
+			return fmt.Errorf("failed to remove temporary database: %w", err)
+		}
+		a.steps.Record("file_remove", removeStart)
+	}
+
+	return nil
+}
+
+// Create inserts a new record
+func (a *Adapter) Create(ctx context.Context, key string, value map[string]interface{}) error {
+	return a.CreateKey(ctx, []byte(key), value)
+}
+
+// CreateBatch inserts several records with a single write batch
+func (a *Adapter) CreateBatch(ctx context.Context, keys []string, values []map[string]interface{}) error {
+	a.queryLog.Log(a.Name(), "WriteBatch", len(keys))
+	wb := a.db.NewWriteBatch()
+	defer wb.Cancel()
+
+	for i, key := range keys {
+		jsonData, err := codec.Marshal(values[i])
+		if err != nil {
+			return fmt.Errorf("failed to marshal value to JSON: %w", err)
+		}
+		if err := wb.Set([]byte(key), jsonData); err != nil {
+			return fmt.Errorf("failed to insert record: %w", err)
+		}
+	}
+	if err := wb.Flush(); err != nil {
+		return fmt.Errorf("failed to flush write batch: %w", err)
+	}
+
+	return nil
+}
+
+// Read retrieves a record by key
+func (a *Adapter) Read(ctx context.Context, key string) (map[string]interface{}, error) {
+	return a.ReadKey(ctx, []byte(key))
+}
+
+// ReadMulti retrieves several records within a single read transaction
+func (a *Adapter) ReadMulti(ctx context.Context, keys []string) ([]map[string]interface{}, error) {
+	a.queryLog.Log(a.Name(), "Get", keys)
+	results := make([]map[string]interface{}, 0, len(keys))
+	err := a.db.View(func(txn *badgerdb.Txn) error {
+		for _, key := range keys {
+			item, err := txn.Get([]byte(key))
+			if errors.Is(err, badgerdb.ErrKeyNotFound) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			result, err := decode(item)
+			if err != nil {
+				return err
+			}
+			results = append(results, result)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read records: %w", err)
+	}
+
+	return results, nil
+}
+
+// Exists checks whether a record exists without reading its value
+func (a *Adapter) Exists(ctx context.Context, key string) (bool, error) {
+	a.queryLog.Log(a.Name(), "Get", key)
+	err := a.db.View(func(txn *badgerdb.Txn) error {
+		_, err := txn.Get([]byte(key))
+		return err
+	})
+	if errors.Is(err, badgerdb.ErrKeyNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check record: %w", err)
+	}
+
+	return true, nil
+}
+
+// Update modifies an existing record
+func (a *Adapter) Update(ctx context.Context, key string, value map[string]interface{}) error {
+	return a.UpdateKey(ctx, []byte(key), value)
+}
+
+// CompareAndSet replaces a record only if its stored version still matches,
+// relying on the conflict detection of read-write transactions
+func (a *Adapter) CompareAndSet(ctx context.Context, key string, expected int64, value map[string]interface{}) (bool, error) {
+	jsonData, err := codec.Marshal(value)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal value to JSON: %w", err)
+	}
+
+	a.queryLog.Log(a.Name(), "CompareAndSet", key, expected, string(jsonData))
+	applied := false
+	err = a.db.Update(func(txn *badgerdb.Txn) error {
+		item, err := txn.Get([]byte(key))
+		if err != nil {
+			return err
+		}
+		current, err := decode(item)
+		if err != nil {
+			return err
+		}
+		if benchmark.Version(current) != expected {
+			return nil
+		}
+		applied = true
+		return txn.Set([]byte(key), jsonData)
+	})
+	if errors.Is(err, badgerdb.ErrConflict) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to update record: %w", err)
+	}
+
+	return applied, nil
+}
+
+// Delete removes a record by key
+func (a *Adapter) Delete(ctx context.Context, key string) error {
+	return a.DeleteKey(ctx, []byte(key))
+}
+
+// Scan performs a scan operation based on the scan configuration, iterating
+// the keys in order from the first and honouring the start and limit. Values
+// are only fetched for full projections.
+func (a *Adapter) Scan(ctx context.Context, scanConfig config.ScanConfig) (int, error) {
+	switch scanConfig.Projection {
+	case "ID", "FULL", "COUNT":
+	default:
+		return 0, fmt.Errorf("%w projection type: %s", benchmark.ErrUnsupported, scanConfig.Projection)
+	}
+
+	a.queryLog.Log(a.Name(), "Iterator", scanConfig.Start, scanConfig.Limit)
+	count := 0
+	err := a.db.View(func(txn *badgerdb.Txn) error {
+		opts := badgerdb.DefaultIteratorOptions
+		opts.PrefetchValues = scanConfig.Projection == "FULL"
+		iter := txn.NewIterator(opts)
+		defer iter.Close()
+
+		skipped := 0
+		for iter.Rewind(); iter.Valid(); iter.Next() {
+			// Skip the keys before the start of the window
+			if skipped < scanConfig.Start {
+				skipped++
+				continue
+			}
+
+			// Fetch the key, and the value for full projections
+			item := iter.Item()
+			_ = item.Key()
+			if scanConfig.Projection == "FULL" {
+				if _, err := item.ValueCopy(nil); err != nil {
+					return err
+				}
+			}
+
+			count++
+			if scanConfig.Limit > 0 && count >= scanConfig.Limit {
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute scan: %w", err)
+	}
+
+	return count, nil
+}
+
+// Name returns the name of the database
+func (a *Adapter) Name() string {
+	return "badger"
+}
+
+// SupportsKeyEncoding reports whether the adapter accepts keys in the given encoding
+func (a *Adapter) SupportsKeyEncoding(encoding benchmark.KeyEncoding) bool {
+	switch encoding {
+	case benchmark.KeyEncodingString, benchmark.KeyEncodingBytes, benchmark.KeyEncodingInt64:
+		return true
+	default:
+		return false
+	}
+}
+
+// CreateKey inserts a new record with a natively encoded key
+func (a *Adapter) CreateKey(ctx context.Context, key []byte, value map[string]interface{}) error {
+	payload, err := benchmark.NewPayload(value)
+	if err != nil {
+		return err
+	}
+	return a.CreatePayload(ctx, key, payload)
+}
+
+// ReadKey retrieves a record with a natively encoded key
+func (a *Adapter) ReadKey(ctx context.Context, key []byte) (map[string]interface{}, error) {
+	a.queryLog.Log(a.Name(), "Get", key)
+	var result map[string]interface{}
+	err := a.db.View(func(txn *badgerdb.Txn) error {
+		item, err := txn.Get(key)
+		if err != nil {
+			return err
+		}
+		result, err = decode(item)
+		return err
+	})
+	if errors.Is(err, badgerdb.ErrKeyNotFound) {
+		return nil, fmt.Errorf("record not found: %x", key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read record: %w", err)
+	}
+
+	return result, nil
+}
+
+// UpdateKey updates a record with a natively encoded key
+func (a *Adapter) UpdateKey(ctx context.Context, key []byte, value map[string]interface{}) error {
+	payload, err := benchmark.NewPayload(value)
+	if err != nil {
+		return err
+	}
+	return a.UpdatePayload(ctx, key, payload)
+}
+
+// CreatePayload inserts a new record with a pre-encoded payload
+func (a *Adapter) CreatePayload(ctx context.Context, key []byte, payload *benchmark.Payload) error {
+	a.queryLog.Log(a.Name(), "Set", key, payload)
+	err := a.db.Update(func(txn *badgerdb.Txn) error {
+		return txn.Set(key, payload.Bytes())
+	})
+	if err != nil {
+		return fmt.Errorf("failed to insert record: %w", err)
+	}
+
+	return nil
+}
+
+// ReadPayload retrieves the stored payload of a record, copied out of the
+// read transaction
+func (a *Adapter) ReadPayload(ctx context.Context, key []byte) (*benchmark.Payload, error) {
+	a.queryLog.Log(a.Name(), "Get", key)
+	var data []byte
+	err := a.db.View(func(txn *badgerdb.Txn) error {
+		item, err := txn.Get(key)
+		if err != nil {
+			return err
+		}
+		data, err = item.ValueCopy(nil)
+		return err
+	})
+	if errors.Is(err, badgerdb.ErrKeyNotFound) {
+		return nil, fmt.Errorf("record not found: %x", key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read record: %w", err)
+	}
+
+	return benchmark.PayloadOf(data), nil
+}
+
+// UpdatePayload replaces a record with a pre-encoded payload
+func (a *Adapter) UpdatePayload(ctx context.Context, key []byte, payload *benchmark.Payload) error {
+	a.queryLog.Log(a.Name(), "Set", key, payload)
+	err := a.db.Update(func(txn *badgerdb.Txn) error {
+		return txn.Set(key, payload.Bytes())
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update record: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteKey removes a record with a natively encoded key
+func (a *Adapter) DeleteKey(ctx context.Context, key []byte) error {
+	a.queryLog.Log(a.Name(), "Delete", key)
+	err := a.db.Update(func(txn *badgerdb.Txn) error {
+		return txn.Delete(key)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete record: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteBatch removes several records with a single write batch
+func (a *Adapter) DeleteBatch(ctx context.Context, keys []string) error {
+	a.queryLog.Log(a.Name(), "WriteBatch", len(keys))
+	wb := a.db.NewWriteBatch()
+	defer wb.Cancel()
+
+	for _, key := range keys {
+		if err := wb.Delete([]byte(key)); err != nil {
+			return fmt.Errorf("failed to delete record: %w", err)
+		}
+	}
+	if err := wb.Flush(); err != nil {
+		return fmt.Errorf("failed to flush write batch: %w", err)
+	}
+
+	return nil
+}
+
+// Truncate removes every record with DropAll, which discards the tables and
+// value logs instead of writing tombstones
+func (a *Adapter) Truncate(ctx context.Context) error {
+	a.queryLog.Log(a.Name(), "DropAll")
+	if err := a.db.DropAll(); err != nil {
+		return fmt.Errorf("failed to drop records: %w", err)
+	}
+
+	return nil
+}
+
+// CreateVersions removes any version history left behind by an earlier run
+func (a *Adapter) CreateVersions(ctx context.Context) error {
+	return a.DropVersions(ctx)
+}
+
+// AppendVersion stores a new version of a record under its own key, so that
+// the versions of a record are adjacent and ordered
+func (a *Adapter) AppendVersion(ctx context.Context, key string, version int, value map[string]interface{}) error {
+	jsonData, err := codec.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal value to JSON: %w", err)
+	}
+
+	a.queryLog.Log(a.Name(), "Set", versionKey(key, version))
+	err = a.db.Update(func(txn *badgerdb.Txn) error {
+		return txn.Set(versionKey(key, version), jsonData)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to append version: %w", err)
+	}
+
+	return nil
+}
+
+// ReadLatest retrieves the latest version of a record with a reverse
+// iterator seeking to the end of the versions of the record
+func (a *Adapter) ReadLatest(ctx context.Context, key string) (map[string]interface{}, int, error) {
+	prefix := append([]byte(versionPrefix+key), 0)
+
+	a.queryLog.Log(a.Name(), "ReverseIterator", string(prefix))
+	var result map[string]interface{}
+	version := 0
+	err := a.db.View(func(txn *badgerdb.Txn) error {
+		opts := badgerdb.DefaultIteratorOptions
+		opts.Reverse = true
+		opts.PrefetchValues = false
+		opts.Prefix = prefix
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		it.Seek(append(append([]byte{}, prefix...), 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff))
+		if !it.ValidForPrefix(prefix) {
+			return fmt.Errorf("record not found: %s", key)
+		}
+		item := it.Item()
+		version = int(binary.BigEndian.Uint64(item.Key()[len(prefix):]))
+		var err error
+		result, err = decode(item)
+		return err
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read latest version: %w", err)
+	}
+
+	return result, version, nil
+}
+
+// DropVersions removes the version history with DropPrefix
+func (a *Adapter) DropVersions(ctx context.Context) error {
+	a.queryLog.Log(a.Name(), "DropPrefix", versionPrefix)
+	if err := a.db.DropPrefix([]byte(versionPrefix)); err != nil {
+		return fmt.Errorf("failed to drop version history: %w", err)
+	}
+
+	return nil
+}
+
+// versionKey returns the key of a version of a record
+func versionKey(key string, version int) []byte {
+	buf := append([]byte(versionPrefix+key), 0)
+	return binary.BigEndian.AppendUint64(buf, uint64(version))
+}
+
+// Compact flattens the LSM tree and garbage collects the value log
+func (a *Adapter) Compact(ctx context.Context) error {
+	compactors, err := a.tuning.Int("num_compactors")
+	if err != nil {
+		return err
+	}
+	if err := a.db.Flatten(max(compactors, 1)); err != nil {
+		return fmt.Errorf("failed to flatten database: %w", err)
+	}
+
+	// Rewrite value log files until no more space can be reclaimed
+	for {
+		err := a.db.RunValueLogGC(0.5)
+		if errors.Is(err, badgerdb.ErrNoRewrite) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to garbage collect value log: %w", err)
+		}
+	}
+}
+
+// DataFiles returns the database directory
+func (a *Adapter) DataFiles() []string {
+	if a.path == "" || a.path == memoryEndpoint {
+		return nil
+	}
+	return []string{a.path}
+}
+
+// Blocking reports that the calls of the adapter block, as the engine runs on the calling goroutine
+func (a *Adapter) Blocking() bool {
+	return true
+}
+
+// SetSteps sets the recorder receiving the durations of the provisioning steps
+func (a *Adapter) SetSteps(steps *dbutils.Steps) {
+	a.steps = steps
+}
+
+// SetQueryLog sets the log receiving the operations issued by the adapter
+func (a *Adapter) SetQueryLog(log *dbutils.QueryLog) {
+	a.queryLog = log
+}
+
+// open opens the database directory with the tuning settings applied
+func (a *Adapter) open() error {
+	blockCache, err := a.tuning.Int("block_cache_mb")
+	if err != nil {
+		return err
+	}
+	indexCache, err := a.tuning.Int("index_cache_mb")
+	if err != nil {
+		return err
+	}
+	compactors, err := a.tuning.Int("num_compactors")
+	if err != nil {
+		return err
+	}
+	syncWrites, err := strconv.ParseBool(a.tuning["sync_writes"])
+	if err != nil {
+		return fmt.Errorf("invalid value for tuning setting sync_writes: %w", err)
+	}
+
+	opts := badgerdb.DefaultOptions(a.path)
+	if a.path == memoryEndpoint {
+		opts = badgerdb.DefaultOptions("").WithInMemory(true)
+	}
+	opts = opts.
+		WithLogger(nil).
+		WithSyncWrites(syncWrites).
+		WithBlockCacheSize(int64(blockCache) << 20).
+		WithIndexCacheSize(int64(indexCache) << 20).
+		WithNumCompactors(compactors)
+
+	db, err := badgerdb.Open(opts)
+	if err != nil {
+		return fmt.Errorf("failed to open BadgerDB database: %w", err)
+	}
+	a.db = db
+
+	return nil
+}
+
+// decode unmarshals the JSON value of an item
+func decode(item *badgerdb.Item) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	err := item.Value(func(val []byte) error {
+		return codec.Unmarshal(val, &result)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+	}
+	return result, nil
+}
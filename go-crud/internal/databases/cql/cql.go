@@ -0,0 +1,674 @@
+package cql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+	"github.com/surrealdb/go-crud-bench/internal/codec"
+	"github.com/surrealdb/go-crud-bench/internal/config"
+	"github.com/surrealdb/go-crud-bench/internal/dbutils"
+	"github.com/surrealdb/go-crud-bench/internal/docker"
+)
+
+const (
+	// Default CQL port
+	defaultPort = "9042"
+
+	// Keyspace and table names
+	defaultKeyspace = "bench"
+	tableName       = "bench_table"
+
+	// Time allowed for the node to accept CQL connections
+	readinessTimeout = 180 * time.Second
+)
+
+// variant describes a database speaking CQL
+type variant struct {
+	name  string
+	label string
+	image string
+	env   []string
+	cmd   []string
+
+	// ports overrides the published ports, which default to the CQL port
+	ports map[string]string
+
+	// dataDir is the directory of the container holding the data
+	dataDir string
+
+	// compaction reports support for major compactions with nodetool
+	compaction bool
+}
+
+// variants contains the supported CQL databases
+var variants = map[string]variant{
+	"scylladb": {
+		name:  "scylladb",
+		label: "ScyllaDB",
+		image: "scylladb/scylla:5.4",
+		cmd:   []string{"--smp", "1", "--memory", "1G", "--overprovisioned", "1", "--developer-mode", "1"},
+
+		dataDir: "/var/lib/scylla",
+
+		compaction: true,
+	},
+	"cassandra": {
+		name:  "cassandra",
+		label: "Cassandra",
+		image: "cassandra:4.1",
+		env:   []string{"MAX_HEAP_SIZE=1G", "HEAP_NEWSIZE=256M"},
+
+		dataDir: "/var/lib/cassandra",
+
+		compaction: true,
+	},
+	"yugabyte-ycql": {
+		name:  "yugabyte-ycql",
+		label: "YugabyteDB (YCQL)",
+		image: dbutils.YugabyteImage,
+		cmd:   dbutils.YugabyteCommand,
+		ports: dbutils.YugabytePorts,
+
+		dataDir: dbutils.YugabyteDataDir,
+	},
+}
+
+// preset contains the default tuning settings for CQL databases
+var preset = map[string]string{
+	"consistency": "LOCAL_ONE",
+	"num_conns":   "2",
+	"timeout":     "10s",
+}
+
+// Adapter implements the benchmark.Adapter interface for ScyllaDB, Cassandra and
+// the YCQL API of YugabyteDB
+type Adapter struct {
+	session     *gocql.Session
+	container   *docker.Container
+	variant     variant
+	endpoint    string
+	conn        config.ConnectionOptions
+	image       string
+	privileged  bool
+	containerID string
+	tracker     *dbutils.ConnTracker
+	keyspace    string
+	table       string
+	namespaced  bool
+	tuning      dbutils.Tuning
+	queryLog    *dbutils.QueryLog
+	steps       *dbutils.Steps
+	out         io.Writer
+}
+
+// NewAdapter creates a new adapter for the named CQL database (scylladb,
+// cassandra or yugabyte-ycql)
+func NewAdapter(name, endpoint, image string, privileged bool, conn config.ConnectionOptions) *Adapter {
+	v := variants[name]
+	if image == "" {
+		image = v.image
+	}
+	keyspace := defaultKeyspace
+	if conn.Database != "" {
+		keyspace = conn.Database
+	}
+
+	return &Adapter{
+		variant:    v,
+		endpoint:   endpoint,
+		conn:       conn,
+		image:      image,
+		privileged: privileged,
+		tracker:    dbutils.NewConnTracker(),
+		out:        os.Stdout,
+		keyspace:   keyspace,
+		table:      tableName,
+		tuning:     preset,
+	}
+}
+
+// SetNamespace isolates the benchmark in its own table on a shared cluster
+func (a *Adapter) SetNamespace(namespace string) {
+	a.table = fmt.Sprintf("%s_%s", tableName, namespace)
+	a.namespaced = true
+}
+
+// Tune applies tuning overrides on top of the CQL preset
+func (a *Adapter) Tune(overrides map[string]string) (map[string]string, error) {
+	tuning, err := dbutils.NewTuning(preset, overrides)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := gocql.ParseConsistencyWrapper(tuning["consistency"]); err != nil {
+		return nil, fmt.Errorf("invalid value for tuning setting consistency: %w", err)
+	}
+	a.tuning = tuning
+	return tuning, nil
+}
+
+// Initialize sets up the keyspace and table
+func (a *Adapter) Initialize(ctx context.Context) error {
+	hosts := []string{dbutils.HostAddress(defaultPort)}
+
+	// If no endpoint is provided, start a Docker container
+	if a.endpoint == "" {
+		container, err := a.startContainer(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to start %s container: %w", a.variant.label, err)
+		}
+
+		a.container = container
+		a.containerID = container.ID
+	} else {
+		// Use provided endpoint, a comma separated list of contact points
+		hosts = strings.Split(a.endpoint, ",")
+	}
+
+	// Connect with token-aware routing, so requests go straight to a replica
+	connectStart := time.Now()
+	cluster, err := a.cluster(hosts)
+	if err != nil {
+		return err
+	}
+	session, err := cluster.CreateSession()
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", a.variant.label, err)
+	}
+	a.session = session
+	a.steps.Record("connect", connectStart)
+
+	// Create keyspace and table
+	schemaStart := time.Now()
+	if err := a.createTable(ctx); err != nil {
+		return fmt.Errorf("failed to create table: %w", err)
+	}
+	a.steps.Record("schema_create", schemaStart)
+
+	return nil
+}
+
+// Cleanup performs cleanup operations
+func (a *Adapter) Cleanup(ctx context.Context) error {
+	// Drop the namespaced table so that shared clusters are left clean
+	if a.session != nil && a.namespaced {
+		dropStart := time.Now()
+		query := fmt.Sprintf("DROP TABLE IF EXISTS %s.%s", a.keyspace, a.table)
+		if err := a.session.Query(query).WithContext(ctx).Exec(); err != nil {
+			return fmt.Errorf("failed to drop table %s: %w", a.table, err)
+		}
+		a.steps.Record("schema_drop", dropStart)
+	}
+
+	// Close database session
+	if a.session != nil {
+		a.session.Close()
+	}
+
+	// Stop and remove container if it was started
+	if a.container != nil {
+		fmt.Fprintf(a.out, "Cleaning up %s container %s...\n", a.variant.label, a.containerID)
+		stopStart := time.Now()
+		if err := a.container.Stop(ctx); err != nil {
+			return fmt.Errorf("failed to stop %s container: %w", a.variant.label, err)
+		}
+		a.steps.Record("container_stop", stopStart)
+	}
+
+	return nil
+}
+
+// Create inserts a new record
+func (a *Adapter) Create(ctx context.Context, key string, value map[string]interface{}) error {
+	jsonData, err := codec.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal value to JSON: %w", err)
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s.%s (id, data) VALUES (?, ?)", a.keyspace, a.table)
+	a.queryLog.Log(a.Name(), query, key, string(jsonData))
+	if err := a.session.Query(query, key, string(jsonData)).WithContext(ctx).Exec(); err != nil {
+		return fmt.Errorf("failed to insert record: %w", err)
+	}
+
+	return nil
+}
+
+// Read retrieves a record by key
+func (a *Adapter) Read(ctx context.Context, key string) (map[string]interface{}, error) {
+	query := fmt.Sprintf("SELECT data FROM %s.%s WHERE id = ?", a.keyspace, a.table)
+	a.queryLog.Log(a.Name(), query, key)
+
+	var jsonData string
+	if err := a.session.Query(query, key).WithContext(ctx).Scan(&jsonData); err != nil {
+		if err == gocql.ErrNotFound {
+			return nil, fmt.Errorf("record not found: %s", key)
+		}
+		return nil, fmt.Errorf("failed to read record: %w", err)
+	}
+
+	// Parse JSON data
+	var result map[string]interface{}
+	if err := codec.Unmarshal([]byte(jsonData), &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+	}
+
+	return result, nil
+}
+
+// ReadMulti retrieves several records with a single IN query on the partition key
+func (a *Adapter) ReadMulti(ctx context.Context, keys []string) ([]map[string]interface{}, error) {
+	query := fmt.Sprintf("SELECT data FROM %s.%s WHERE id IN ?", a.keyspace, a.table)
+	a.queryLog.Log(a.Name(), query, keys)
+
+	iter := a.session.Query(query, keys).WithContext(ctx).Iter()
+	var results []map[string]interface{}
+	var jsonData string
+	for iter.Scan(&jsonData) {
+		var result map[string]interface{}
+		if err := codec.Unmarshal([]byte(jsonData), &result); err != nil {
+			iter.Close()
+			return nil, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+		}
+		results = append(results, result)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, fmt.Errorf("failed to read records: %w", err)
+	}
+
+	return results, nil
+}
+
+// Exists checks whether a record exists by selecting only its key
+func (a *Adapter) Exists(ctx context.Context, key string) (bool, error) {
+	query := fmt.Sprintf("SELECT id FROM %s.%s WHERE id = ?", a.keyspace, a.table)
+	a.queryLog.Log(a.Name(), query, key)
+
+	var id string
+	if err := a.session.Query(query, key).WithContext(ctx).Scan(&id); err != nil {
+		if err == gocql.ErrNotFound {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check record: %w", err)
+	}
+
+	return true, nil
+}
+
+// CreateCounters recreates the counter table. CQL counters cannot be reset,
+// so the table is dropped and created empty, a missing counter reading as zero.
+func (a *Adapter) CreateCounters(ctx context.Context, counters []string) error {
+	queries := []string{
+		fmt.Sprintf("DROP TABLE IF EXISTS %s.%s_counters", a.keyspace, a.table),
+		fmt.Sprintf("CREATE TABLE %s.%s_counters (id text PRIMARY KEY, n counter)", a.keyspace, a.table),
+	}
+
+	for _, query := range queries {
+		a.queryLog.Log(a.Name(), query)
+		if err := a.session.Query(query).WithContext(ctx).Exec(); err != nil {
+			return fmt.Errorf("failed to create counter table: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Increment atomically adds one to a counter column
+func (a *Adapter) Increment(ctx context.Context, counter string) error {
+	query := fmt.Sprintf("UPDATE %s.%s_counters SET n = n + 1 WHERE id = ?", a.keyspace, a.table)
+	a.queryLog.Log(a.Name(), query, counter)
+	if err := a.session.Query(query, counter).WithContext(ctx).Exec(); err != nil {
+		return fmt.Errorf("failed to increment counter: %w", err)
+	}
+
+	return nil
+}
+
+// Counter returns the current value of a counter
+func (a *Adapter) Counter(ctx context.Context, counter string) (int64, error) {
+	query := fmt.Sprintf("SELECT n FROM %s.%s_counters WHERE id = ?", a.keyspace, a.table)
+	a.queryLog.Log(a.Name(), query, counter)
+
+	var n int64
+	if err := a.session.Query(query, counter).WithContext(ctx).Scan(&n); err != nil {
+		if err == gocql.ErrNotFound {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to read counter: %w", err)
+	}
+
+	return n, nil
+}
+
+// DropCounters drops the counter table
+func (a *Adapter) DropCounters(ctx context.Context, counters []string) error {
+	query := fmt.Sprintf("DROP TABLE IF EXISTS %s.%s_counters", a.keyspace, a.table)
+	a.queryLog.Log(a.Name(), query)
+	if err := a.session.Query(query).WithContext(ctx).Exec(); err != nil {
+		return fmt.Errorf("failed to drop counter table: %w", err)
+	}
+
+	return nil
+}
+
+// Update modifies an existing record
+func (a *Adapter) Update(ctx context.Context, key string, value map[string]interface{}) error {
+	jsonData, err := codec.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal value to JSON: %w", err)
+	}
+
+	query := fmt.Sprintf("UPDATE %s.%s SET data = ? WHERE id = ?", a.keyspace, a.table)
+	a.queryLog.Log(a.Name(), query, string(jsonData), key)
+	if err := a.session.Query(query, string(jsonData), key).WithContext(ctx).Exec(); err != nil {
+		return fmt.Errorf("failed to update record: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a record by key
+func (a *Adapter) Delete(ctx context.Context, key string) error {
+	query := fmt.Sprintf("DELETE FROM %s.%s WHERE id = ?", a.keyspace, a.table)
+	a.queryLog.Log(a.Name(), query, key)
+	if err := a.session.Query(query, key).WithContext(ctx).Exec(); err != nil {
+		return fmt.Errorf("failed to delete record: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteBatch removes several records with a single IN query on the partition key
+func (a *Adapter) DeleteBatch(ctx context.Context, keys []string) error {
+	query := fmt.Sprintf("DELETE FROM %s.%s WHERE id IN ?", a.keyspace, a.table)
+	a.queryLog.Log(a.Name(), query, keys)
+	if err := a.session.Query(query, keys).WithContext(ctx).Exec(); err != nil {
+		return fmt.Errorf("failed to delete records: %w", err)
+	}
+
+	return nil
+}
+
+// Truncate removes every record with TRUNCATE
+func (a *Adapter) Truncate(ctx context.Context) error {
+	query := fmt.Sprintf("TRUNCATE %s.%s", a.keyspace, a.table)
+	a.queryLog.Log(a.Name(), query)
+	if err := a.session.Query(query).WithContext(ctx).Exec(); err != nil {
+		return fmt.Errorf("failed to truncate table: %w", err)
+	}
+
+	return nil
+}
+
+// Scan performs a scan operation based on the scan configuration. CQL has no
+// OFFSET clause, so skipped rows are read and discarded by the client.
+func (a *Adapter) Scan(ctx context.Context, scanConfig config.ScanConfig) (int, error) {
+	var window string
+	if scanConfig.Limit > 0 {
+		window = fmt.Sprintf(" LIMIT %d", scanConfig.Start+scanConfig.Limit)
+	}
+
+	// Build query based on projection type
+	var query string
+	switch scanConfig.Projection {
+	case "ID":
+		query = fmt.Sprintf("SELECT id FROM %s.%s%s", a.keyspace, a.table, window)
+	case "FULL":
+		query = fmt.Sprintf("SELECT id, data FROM %s.%s%s", a.keyspace, a.table, window)
+	case "COUNT":
+		if window == "" {
+			query = fmt.Sprintf("SELECT COUNT(*) FROM %s.%s", a.keyspace, a.table)
+			a.queryLog.Log(a.Name(), query)
+			var count int64
+			if err := a.session.Query(query).WithContext(ctx).Scan(&count); err != nil {
+				return 0, fmt.Errorf("failed to execute count scan: %w", err)
+			}
+			return int(count), nil
+		}
+		// Count a window by reading the ids within it
+		query = fmt.Sprintf("SELECT id FROM %s.%s%s", a.keyspace, a.table, window)
+	default:
+		return 0, fmt.Errorf("%w projection type: %s", benchmark.ErrUnsupported, scanConfig.Projection)
+	}
+
+	// Execute query and count rows
+	a.queryLog.Log(a.Name(), query)
+	iter := a.session.Query(query).WithContext(ctx).Iter()
+	count := 0
+	for iter.Scanner().Next() {
+		count++
+	}
+	if err := iter.Close(); err != nil {
+		return 0, fmt.Errorf("failed to execute scan: %w", err)
+	}
+
+	// Discard the rows before the start of the window
+	count -= scanConfig.Start
+	if count < 0 {
+		count = 0
+	}
+
+	return count, nil
+}
+
+// Name returns the name of the database
+func (a *Adapter) Name() string {
+	return a.variant.name
+}
+
+// ClassifyError maps CQL request errors to error classes
+func (a *Adapter) ClassifyError(err error) benchmark.ErrorClass {
+	var writeTimeout *gocql.RequestErrWriteTimeout
+	var readTimeout *gocql.RequestErrReadTimeout
+	var unavailable *gocql.RequestErrUnavailable
+	switch {
+	case errors.As(err, &writeTimeout), errors.As(err, &readTimeout), errors.Is(err, gocql.ErrTimeoutNoResponse):
+		return benchmark.ErrorClassTimeout
+	case errors.As(err, &unavailable), errors.Is(err, gocql.ErrNoConnections), errors.Is(err, gocql.ErrConnectionClosed):
+		// Not enough replicas are alive, or no connection to any of them
+		return benchmark.ErrorClassConnection
+	default:
+		return ""
+	}
+}
+
+// Compact runs a major compaction of the table with nodetool in the managed container
+func (a *Adapter) Compact(ctx context.Context) error {
+	if !a.variant.compaction {
+		return fmt.Errorf("%s does not support nodetool compactions", a.variant.label)
+	}
+	if a.container == nil {
+		return fmt.Errorf("compaction requires a managed container")
+	}
+
+	if _, err := a.container.Exec(ctx, []string{"nodetool", "compact", a.keyspace, a.table}); err != nil {
+		return fmt.Errorf("failed to compact table: %w", err)
+	}
+
+	return nil
+}
+
+// DropNamespace removes the tables of a namespaced run left on the cluster
+func (a *Adapter) DropNamespace(ctx context.Context, namespace string) ([]string, error) {
+	if a.endpoint == "" {
+		return nil, fmt.Errorf("an endpoint is required to clean up namespaced tables")
+	}
+
+	cluster, err := a.cluster(strings.Split(a.endpoint, ","))
+	if err != nil {
+		return nil, err
+	}
+	session, err := cluster.CreateSession()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", a.variant.label, err)
+	}
+	defer session.Close()
+
+	iter := session.Query("SELECT table_name FROM system_schema.tables WHERE keyspace_name = ?", a.keyspace).WithContext(ctx).Iter()
+	var tables []string
+	var table string
+	for iter.Scan(&table) {
+		if dbutils.InNamespace(table, fmt.Sprintf("%s_%s", tableName, namespace)) {
+			tables = append(tables, table)
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+
+	// Drop the tables one by one
+	for i, table := range tables {
+		if err := session.Query(fmt.Sprintf("DROP TABLE IF EXISTS %s.%s", a.keyspace, table)).WithContext(ctx).Exec(); err != nil {
+			return tables[:i], fmt.Errorf("failed to drop table %s: %w", table, err)
+		}
+	}
+
+	return tables, nil
+}
+
+// SetSteps sets the recorder receiving the durations of the provisioning steps
+func (a *Adapter) SetSteps(steps *dbutils.Steps) {
+	a.steps = steps
+}
+
+// SetOutput sets the writer receiving the container messages of the adapter
+func (a *Adapter) SetOutput(w io.Writer) {
+	a.out = w
+}
+
+// SetQueryLog sets the log receiving the statements issued by the adapter
+func (a *Adapter) SetQueryLog(log *dbutils.QueryLog) {
+	a.queryLog = log
+}
+
+// ConnectionStats returns the connection churn counters
+func (a *Adapter) ConnectionStats() map[string]float64 {
+	return a.tracker.Metrics(nil)
+}
+
+// DataDir returns the directory of the container holding the data
+func (a *Adapter) DataDir() string {
+	return a.variant.dataDir
+}
+
+// Container returns the managed Docker container, or nil for external endpoints
+func (a *Adapter) Container() *docker.Container {
+	return a.container
+}
+
+// cluster returns the cluster configuration for the given contact points
+func (a *Adapter) cluster(hosts []string) (*gocql.ClusterConfig, error) {
+	consistency, err := gocql.ParseConsistencyWrapper(a.tuning["consistency"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for tuning setting consistency: %w", err)
+	}
+	numConns, err := a.tuning.Int("num_conns")
+	if err != nil {
+		return nil, err
+	}
+	timeout, err := a.tuning.Duration("timeout")
+	if err != nil {
+		return nil, err
+	}
+
+	cluster := gocql.NewCluster(hosts...)
+	cluster.Consistency = consistency
+	cluster.NumConns = numConns
+	cluster.Timeout = timeout
+	cluster.ConnectTimeout = timeout
+	cluster.PoolConfig.HostSelectionPolicy = gocql.TokenAwareHostPolicy(gocql.RoundRobinHostPolicy())
+	cluster.Dialer = a.tracker
+
+	// The connection options take precedence over the contact points
+	if a.conn.Port > 0 {
+		for i, host := range cluster.Hosts {
+			cluster.Hosts[i] = a.conn.HostPort(host, "")
+		}
+	}
+	if a.conn.Username != "" || a.conn.Password != "" {
+		cluster.Authenticator = gocql.PasswordAuthenticator{Username: a.conn.Username, Password: a.conn.Password}
+	}
+	if a.conn.TLS {
+		tlsConfig, err := a.conn.TLSConfig("")
+		if err != nil {
+			return nil, err
+		}
+		cluster.SslOpts = &gocql.SslOptions{Config: tlsConfig, EnableHostVerification: !a.conn.TLSSkipVerify}
+	}
+	return cluster, nil
+}
+
+// createTable creates the benchmark keyspace and table
+func (a *Adapter) createTable(ctx context.Context) error {
+	queries := []string{
+		fmt.Sprintf("CREATE KEYSPACE IF NOT EXISTS %s WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1}", a.keyspace),
+		fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.%s (id text PRIMARY KEY, data text)", a.keyspace, a.table),
+	}
+
+	for _, query := range queries {
+		if err := a.session.Query(query).WithContext(ctx).Exec(); err != nil {
+			return fmt.Errorf("failed to create table: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// startContainer starts a single node container of the variant
+func (a *Adapter) startContainer(ctx context.Context) (*docker.Container, error) {
+	label := a.variant.label
+
+	// Generate unique container name with timestamp
+	containerName := fmt.Sprintf("crud-bench-%s-%d", a.variant.name, time.Now().Unix())
+
+	// Configure container
+	ports := map[string]string{
+		"9042/tcp": defaultPort,
+	}
+	if a.variant.ports != nil {
+		ports = a.variant.ports
+	}
+
+	fmt.Fprintf(a.out, "Starting %s container '%s' with image '%s'...\n", label, containerName, a.image)
+
+	// Create and start container with the common utility
+	container, err := dbutils.CreateContainerWithCommand(ctx, a.out, containerName, a.image, ports, a.privileged, a.variant.env, a.variant.cmd, a.steps)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start %s container: %w", label, err)
+	}
+
+	fmt.Fprintf(a.out, "%s container started, waiting for it to be ready...\n", label)
+
+	// Wait until the node accepts CQL sessions
+	checkFunc := func(ctx context.Context) error {
+		cluster := gocql.NewCluster(dbutils.HostAddress(defaultPort))
+		cluster.Timeout = 5 * time.Second
+		cluster.ConnectTimeout = 5 * time.Second
+		cluster.DisableInitialHostLookup = true
+
+		session, err := cluster.CreateSession()
+		if err != nil {
+			return err
+		}
+		session.Close()
+
+		fmt.Fprintf(a.out, "%s is ready!\n", label)
+		return nil
+	}
+
+	waitStart := time.Now()
+	if err := container.WaitForHealthy(ctx, readinessTimeout, checkFunc); err != nil {
+		// Clean up container if health check fails
+		_ = container.Stop(ctx)
+		return nil, fmt.Errorf("%s health check failed: %w", label, err)
+	}
+	a.steps.Record("readiness_wait", waitStart)
+
+	return container, nil
+}
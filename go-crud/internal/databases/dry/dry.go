@@ -0,0 +1,149 @@
+package dry
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+	"github.com/surrealdb/go-crud-bench/internal/config"
+)
+
+// emptyRecord is the JSON encoding of the empty record returned by reads
+var emptyRecord = []byte("{}")
+
+// Adapter implements the benchmark.Adapter interface without storing anything.
+// Every operation succeeds immediately, so that the results show the overhead
+// of the key and value generators, goroutine scheduling and the runner itself.
+type Adapter struct {
+	// records counts the records created and not yet deleted, so that scans
+	// return the counts a real database would
+	records atomic.Int64
+}
+
+// NewAdapter creates a new dry adapter
+func NewAdapter() *Adapter {
+	return &Adapter{}
+}
+
+// Initialize does nothing
+func (a *Adapter) Initialize(ctx context.Context) error {
+	a.records.Store(0)
+	return nil
+}
+
+// Cleanup does nothing
+func (a *Adapter) Cleanup(ctx context.Context) error {
+	return nil
+}
+
+// Create accepts a record without storing it
+func (a *Adapter) Create(ctx context.Context, key string, value map[string]interface{}) error {
+	if key == "" {
+		return fmt.Errorf("empty key")
+	}
+	a.records.Add(1)
+	return nil
+}
+
+// Read returns an empty record
+func (a *Adapter) Read(ctx context.Context, key string) (map[string]interface{}, error) {
+	if key == "" {
+		return nil, fmt.Errorf("empty key")
+	}
+	return map[string]interface{}{}, nil
+}
+
+// ReadMulti returns an empty record for every key
+func (a *Adapter) ReadMulti(ctx context.Context, keys []string) ([]map[string]interface{}, error) {
+	results := make([]map[string]interface{}, len(keys))
+	for i, key := range keys {
+		if key == "" {
+			return nil, fmt.Errorf("empty key")
+		}
+		results[i] = map[string]interface{}{}
+	}
+	return results, nil
+}
+
+// Update accepts a record without storing it
+func (a *Adapter) Update(ctx context.Context, key string, value map[string]interface{}) error {
+	if key == "" {
+		return fmt.Errorf("empty key")
+	}
+	return nil
+}
+
+// CreatePayload accepts a pre-encoded record without storing it
+func (a *Adapter) CreatePayload(ctx context.Context, key []byte, payload *benchmark.Payload) error {
+	if len(key) == 0 {
+		return fmt.Errorf("empty key")
+	}
+	a.records.Add(1)
+	return nil
+}
+
+// ReadPayload returns the payload of an empty record
+func (a *Adapter) ReadPayload(ctx context.Context, key []byte) (*benchmark.Payload, error) {
+	if len(key) == 0 {
+		return nil, fmt.Errorf("empty key")
+	}
+	return benchmark.PayloadOf(emptyRecord), nil
+}
+
+// UpdatePayload accepts a pre-encoded record without storing it
+func (a *Adapter) UpdatePayload(ctx context.Context, key []byte, payload *benchmark.Payload) error {
+	if len(key) == 0 {
+		return fmt.Errorf("empty key")
+	}
+	return nil
+}
+
+// Delete accepts a deletion without storing anything
+func (a *Adapter) Delete(ctx context.Context, key string) error {
+	if key == "" {
+		return fmt.Errorf("empty key")
+	}
+	a.records.Add(-1)
+	return nil
+}
+
+// DeleteBatch accepts a batch of deletions without storing anything
+func (a *Adapter) DeleteBatch(ctx context.Context, keys []string) error {
+	for _, key := range keys {
+		if key == "" {
+			return fmt.Errorf("empty key")
+		}
+	}
+	a.records.Add(-int64(len(keys)))
+	return nil
+}
+
+// Truncate forgets every record
+func (a *Adapter) Truncate(ctx context.Context) error {
+	a.records.Store(0)
+	return nil
+}
+
+// Scan returns the number of rows the scan would return from the created records
+func (a *Adapter) Scan(ctx context.Context, scanConfig config.ScanConfig) (int, error) {
+	switch scanConfig.Projection {
+	case "ID", "FULL", "COUNT":
+	default:
+		return 0, fmt.Errorf("%w projection type: %s", benchmark.ErrUnsupported, scanConfig.Projection)
+	}
+
+	count := int(a.records.Load()) - scanConfig.Start
+	if count < 0 {
+		count = 0
+	}
+	if scanConfig.Limit > 0 && count > scanConfig.Limit {
+		count = scanConfig.Limit
+	}
+	return count, nil
+}
+
+// Name returns the name of the database
+func (a *Adapter) Name() string {
+	return "dry"
+}
@@ -1,22 +1,76 @@
 package databases
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+	"github.com/surrealdb/go-crud-bench/internal/config"
+	"github.com/surrealdb/go-crud-bench/internal/databases/badger"
+	"github.com/surrealdb/go-crud-bench/internal/databases/cql"
+	"github.com/surrealdb/go-crud-bench/internal/databases/dry"
+	"github.com/surrealdb/go-crud-bench/internal/databases/leveldb"
+	"github.com/surrealdb/go-crud-bench/internal/databases/mapdb"
+	"github.com/surrealdb/go-crud-bench/internal/databases/mongodb"
+	"github.com/surrealdb/go-crud-bench/internal/databases/mssql"
 	"github.com/surrealdb/go-crud-bench/internal/databases/mysql"
 	"github.com/surrealdb/go-crud-bench/internal/databases/postgres"
+	"github.com/surrealdb/go-crud-bench/internal/databases/resp"
+	"github.com/surrealdb/go-crud-bench/internal/databases/sqlite"
+	"github.com/surrealdb/go-crud-bench/internal/databases/surrealdb"
 )
 
-// NewAdapter creates a new database adapter based on the database type
-func NewAdapter(dbType, endpoint, image string, privileged bool) (benchmark.Adapter, error) {
+// ErrNotBuilt is returned for databases whose adapter was excluded by build tags
+var ErrNotBuilt = errors.New("is not included in this build")
+
+// NewAdapter creates a new database adapter based on the database type. The
+// api selects the API layer of databases serving several (ysql or ycql for
+// yugabyte), an empty api selecting the default one. The connection options
+// complete the endpoint of networked databases.
+func NewAdapter(dbType, endpoint, image string, privileged bool, api string, conn config.ConnectionOptions) (benchmark.Adapter, error) {
 	switch dbType {
+	case "badger":
+		return badger.NewAdapter(endpoint), nil
+	case "scylladb", "cassandra":
+		return cql.NewAdapter(dbType, endpoint, image, privileged, conn), nil
+	case "dry":
+		return dry.NewAdapter(), nil
+	case "leveldb":
+		return leveldb.NewAdapter(endpoint), nil
+	case "map":
+		return mapdb.NewAdapter(), nil
+	case "mongodb":
+		return mongodb.NewAdapter(endpoint, image, privileged, conn), nil
+	case "mssql":
+		return mssql.NewAdapter(endpoint, image, privileged, conn), nil
 	case "mysql":
-		return mysql.NewAdapter(endpoint, image, privileged), nil
-	case "postgres":
-		return postgres.NewAdapter(endpoint, image, privileged), nil
+		return mysql.NewAdapter(endpoint, image, privileged, conn), nil
+	case "postgres", "cockroachdb":
+		return postgres.NewAdapter(dbType, endpoint, image, privileged, conn), nil
+	case "redis", "dragonfly", "keydb":
+		return resp.NewAdapter(dbType, endpoint, image, privileged, conn), nil
+	case "rocksdb":
+		return newRocksDBAdapter(endpoint)
+	case "sqlite":
+		return sqlite.NewAdapter(endpoint), nil
+	case "surrealdb", "surrealdb-memory", "surrealdb-rocksdb", "surrealdb-surrealkv":
+		return surrealdb.NewAdapter(dbType, endpoint, image, privileged, conn), nil
+	case "yugabyte":
+		return newYugabyteAdapter(api, endpoint, image, privileged, conn)
 	// Add more database types here as they are implemented
 	default:
 		return nil, fmt.Errorf("unsupported database type: %s", dbType)
 	}
-} 
\ No newline at end of file
+} 
+// newYugabyteAdapter creates the adapter for an API layer of YugabyteDB: the
+// PostgreSQL adapter for YSQL and the CQL adapter for YCQL
+func newYugabyteAdapter(api, endpoint, image string, privileged bool, conn config.ConnectionOptions) (benchmark.Adapter, error) {
+	switch api {
+	case "", "ysql":
+		return postgres.NewAdapter("yugabyte-ysql", endpoint, image, privileged, conn), nil
+	case "ycql":
+		return cql.NewAdapter("yugabyte-ycql", endpoint, image, privileged, conn), nil
+	default:
+		return nil, fmt.Errorf("unsupported YugabyteDB API: %s", api)
+	}
+}
@@ -0,0 +1,407 @@
+package leveldb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+	"github.com/surrealdb/go-crud-bench/internal/codec"
+	"github.com/surrealdb/go-crud-bench/internal/config"
+	"github.com/surrealdb/go-crud-bench/internal/dbutils"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/storage"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// memoryEndpoint opens the database in memory instead of on disk
+const memoryEndpoint = ":memory:"
+
+// preset contains the default tuning settings for LevelDB
+var preset = map[string]string{
+	"block_cache_mb":  "256",
+	"write_buffer_mb": "64",
+	"sync_writes":     "false",
+}
+
+// Adapter implements the benchmark.Adapter interface for LevelDB through the
+// pure Go goleveldb port, running in-process without cgo
+type Adapter struct {
+	db       *leveldb.DB
+	endpoint string
+	path     string
+	tempDir  string
+	sync     bool
+	tuning   dbutils.Tuning
+	queryLog *dbutils.QueryLog
+	steps    *dbutils.Steps
+}
+
+// NewAdapter creates a new LevelDB adapter. The endpoint is the path of the
+// database directory, or :memory: for an in-memory database. Without an
+// endpoint the database is created in a temporary directory.
+func NewAdapter(endpoint string) *Adapter {
+	return &Adapter{
+		endpoint: endpoint,
+		tuning:   preset,
+	}
+}
+
+// Tune applies tuning overrides on top of the LevelDB preset
+func (a *Adapter) Tune(overrides map[string]string) (map[string]string, error) {
+	tuning, err := dbutils.NewTuning(preset, overrides)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := strconv.ParseBool(tuning["sync_writes"]); err != nil {
+		return nil, fmt.Errorf("invalid value for tuning setting sync_writes: %w", err)
+	}
+	a.tuning = tuning
+	return tuning, nil
+}
+
+// Initialize opens the LevelDB database
+func (a *Adapter) Initialize(ctx context.Context) error {
+	// Create a temporary database directory if no endpoint is provided
+	a.path = a.endpoint
+	if a.path == "" {
+		dir, err := os.MkdirTemp("", "crud-bench-leveldb-")
+		if err != nil {
+			return fmt.Errorf("failed to create temporary directory: %w", err)
+		}
+		a.tempDir = dir
+		a.path = dir
+	}
+
+	openStart := time.Now()
+	if err := a.open(); err != nil {
+		return err
+	}
+	a.steps.Record("connect", openStart)
+
+	return nil
+}
+
+// Cleanup closes the database and removes the temporary database directory
+func (a *Adapter) Cleanup(ctx context.Context) error {
+	if a.db != nil {
+		if err := a.db.Close(); err != nil {
+			return fmt.Errorf("failed to close database: %w", err)
+		}
+	}
+
+	// Remove the temporary database directory
+	if a.tempDir != "" {
+		removeStart := time.Now()
+		if err := os.RemoveAll(a.tempDir); err != nil {
+			return fmt.Errorf("failed to remove temporary database: %w", err)
+		}
+		a.steps.Record("file_remove", removeStart)
+	}
+
+	return nil
+}
+
+// Create inserts a new record
+func (a *Adapter) Create(ctx context.Context, key string, value map[string]interface{}) error {
+	return a.CreateKey(ctx, []byte(key), value)
+}
+
+// CreateBatch inserts several records with a single write batch
+func (a *Adapter) CreateBatch(ctx context.Context, keys []string, values []map[string]interface{}) error {
+	a.queryLog.Log(a.Name(), "Batch", len(keys))
+	batch := new(leveldb.Batch)
+	for i, key := range keys {
+		jsonData, err := codec.Marshal(values[i])
+		if err != nil {
+			return fmt.Errorf("failed to marshal value to JSON: %w", err)
+		}
+		batch.Put([]byte(key), jsonData)
+	}
+	if err := a.db.Write(batch, a.writeOptions()); err != nil {
+		return fmt.Errorf("failed to write batch: %w", err)
+	}
+
+	return nil
+}
+
+// Read retrieves a record by key
+func (a *Adapter) Read(ctx context.Context, key string) (map[string]interface{}, error) {
+	return a.ReadKey(ctx, []byte(key))
+}
+
+// ReadMulti retrieves several records from a single snapshot
+func (a *Adapter) ReadMulti(ctx context.Context, keys []string) ([]map[string]interface{}, error) {
+	a.queryLog.Log(a.Name(), "Get", keys)
+	snapshot, err := a.db.GetSnapshot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read records: %w", err)
+	}
+	defer snapshot.Release()
+
+	results := make([]map[string]interface{}, 0, len(keys))
+	for _, key := range keys {
+		data, err := snapshot.Get([]byte(key), nil)
+		if errors.Is(err, leveldb.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read records: %w", err)
+		}
+
+		result, err := decode(data)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// Exists checks whether a record exists without decoding its value
+func (a *Adapter) Exists(ctx context.Context, key string) (bool, error) {
+	a.queryLog.Log(a.Name(), "Has", key)
+	exists, err := a.db.Has([]byte(key), nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to check record: %w", err)
+	}
+
+	return exists, nil
+}
+
+// Update modifies an existing record
+func (a *Adapter) Update(ctx context.Context, key string, value map[string]interface{}) error {
+	return a.UpdateKey(ctx, []byte(key), value)
+}
+
+// Delete removes a record by key
+func (a *Adapter) Delete(ctx context.Context, key string) error {
+	return a.DeleteKey(ctx, []byte(key))
+}
+
+// DeleteBatch removes several records with a single write batch
+func (a *Adapter) DeleteBatch(ctx context.Context, keys []string) error {
+	a.queryLog.Log(a.Name(), "Batch", len(keys))
+	batch := new(leveldb.Batch)
+	for _, key := range keys {
+		batch.Delete([]byte(key))
+	}
+	if err := a.db.Write(batch, a.writeOptions()); err != nil {
+		return fmt.Errorf("failed to delete records: %w", err)
+	}
+
+	return nil
+}
+
+// Scan performs a scan operation based on the scan configuration, iterating
+// the keys in order from the first and honouring the start and limit. Values
+// are only copied for full projections.
+func (a *Adapter) Scan(ctx context.Context, scanConfig config.ScanConfig) (int, error) {
+	switch scanConfig.Projection {
+	case "ID", "FULL", "COUNT":
+	default:
+		return 0, fmt.Errorf("%w projection type: %s", benchmark.ErrUnsupported, scanConfig.Projection)
+	}
+
+	a.queryLog.Log(a.Name(), "Iterator", scanConfig.Start, scanConfig.Limit)
+	iter := a.db.NewIterator(nil, &opt.ReadOptions{DontFillCache: true})
+	defer iter.Release()
+
+	count := 0
+	skipped := 0
+	for iter.Next() {
+		// Skip the keys before the start of the window
+		if skipped < scanConfig.Start {
+			skipped++
+			continue
+		}
+
+		// Fetch the key, and the value for full projections
+		_ = iter.Key()
+		if scanConfig.Projection == "FULL" {
+			_ = append([]byte(nil), iter.Value()...)
+		}
+
+		count++
+		if scanConfig.Limit > 0 && count >= scanConfig.Limit {
+			break
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return 0, fmt.Errorf("failed to execute scan: %w", err)
+	}
+
+	return count, nil
+}
+
+// Name returns the name of the database
+func (a *Adapter) Name() string {
+	return "leveldb"
+}
+
+// SupportsKeyEncoding reports whether the adapter accepts keys in the given encoding
+func (a *Adapter) SupportsKeyEncoding(encoding benchmark.KeyEncoding) bool {
+	switch encoding {
+	case benchmark.KeyEncodingString, benchmark.KeyEncodingBytes, benchmark.KeyEncodingInt64:
+		return true
+	default:
+		return false
+	}
+}
+
+// CreateKey inserts a new record with a natively encoded key
+func (a *Adapter) CreateKey(ctx context.Context, key []byte, value map[string]interface{}) error {
+	payload, err := benchmark.NewPayload(value)
+	if err != nil {
+		return err
+	}
+	return a.CreatePayload(ctx, key, payload)
+}
+
+// ReadKey retrieves a record with a natively encoded key
+func (a *Adapter) ReadKey(ctx context.Context, key []byte) (map[string]interface{}, error) {
+	payload, err := a.ReadPayload(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return decode(payload.Bytes())
+}
+
+// UpdateKey updates a record with a natively encoded key
+func (a *Adapter) UpdateKey(ctx context.Context, key []byte, value map[string]interface{}) error {
+	payload, err := benchmark.NewPayload(value)
+	if err != nil {
+		return err
+	}
+	return a.UpdatePayload(ctx, key, payload)
+}
+
+// CreatePayload inserts a new record with a pre-encoded payload
+func (a *Adapter) CreatePayload(ctx context.Context, key []byte, payload *benchmark.Payload) error {
+	a.queryLog.Log(a.Name(), "Put", key, payload)
+	if err := a.db.Put(key, payload.Bytes(), a.writeOptions()); err != nil {
+		return fmt.Errorf("failed to insert record: %w", err)
+	}
+
+	return nil
+}
+
+// ReadPayload retrieves the stored payload of a record
+func (a *Adapter) ReadPayload(ctx context.Context, key []byte) (*benchmark.Payload, error) {
+	a.queryLog.Log(a.Name(), "Get", key)
+	data, err := a.db.Get(key, nil)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return nil, fmt.Errorf("record not found: %x", key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read record: %w", err)
+	}
+
+	return benchmark.PayloadOf(data), nil
+}
+
+// UpdatePayload replaces a record with a pre-encoded payload
+func (a *Adapter) UpdatePayload(ctx context.Context, key []byte, payload *benchmark.Payload) error {
+	a.queryLog.Log(a.Name(), "Put", key, payload)
+	if err := a.db.Put(key, payload.Bytes(), a.writeOptions()); err != nil {
+		return fmt.Errorf("failed to update record: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteKey removes a record with a natively encoded key
+func (a *Adapter) DeleteKey(ctx context.Context, key []byte) error {
+	a.queryLog.Log(a.Name(), "Delete", key)
+	if err := a.db.Delete(key, a.writeOptions()); err != nil {
+		return fmt.Errorf("failed to delete record: %w", err)
+	}
+
+	return nil
+}
+
+// Compact compacts the whole key range down the LSM tree
+func (a *Adapter) Compact(ctx context.Context) error {
+	a.queryLog.Log(a.Name(), "CompactRange")
+	if err := a.db.CompactRange(util.Range{}); err != nil {
+		return fmt.Errorf("failed to compact database: %w", err)
+	}
+
+	return nil
+}
+
+// DataFiles returns the database directory
+func (a *Adapter) DataFiles() []string {
+	if a.path == "" || a.path == memoryEndpoint {
+		return nil
+	}
+	return []string{a.path}
+}
+
+// Blocking reports that the calls of the adapter block, as the engine runs on the calling goroutine
+func (a *Adapter) Blocking() bool {
+	return true
+}
+
+// SetSteps sets the recorder receiving the durations of the provisioning steps
+func (a *Adapter) SetSteps(steps *dbutils.Steps) {
+	a.steps = steps
+}
+
+// SetQueryLog sets the log receiving the operations issued by the adapter
+func (a *Adapter) SetQueryLog(log *dbutils.QueryLog) {
+	a.queryLog = log
+}
+
+// open opens the database directory with the tuning settings applied
+func (a *Adapter) open() error {
+	blockCache, err := a.tuning.Int("block_cache_mb")
+	if err != nil {
+		return err
+	}
+	writeBuffer, err := a.tuning.Int("write_buffer_mb")
+	if err != nil {
+		return err
+	}
+	a.sync, err = strconv.ParseBool(a.tuning["sync_writes"])
+	if err != nil {
+		return fmt.Errorf("invalid value for tuning setting sync_writes: %w", err)
+	}
+
+	opts := &opt.Options{
+		BlockCacheCapacity: blockCache * opt.MiB,
+		WriteBuffer:        writeBuffer * opt.MiB,
+	}
+
+	var db *leveldb.DB
+	if a.path == memoryEndpoint {
+		db, err = leveldb.Open(storage.NewMemStorage(), opts)
+	} else {
+		db, err = leveldb.OpenFile(a.path, opts)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open LevelDB database: %w", err)
+	}
+	a.db = db
+
+	return nil
+}
+
+// writeOptions returns the options of every write, syncing it if configured
+func (a *Adapter) writeOptions() *opt.WriteOptions {
+	return &opt.WriteOptions{Sync: a.sync}
+}
+
+// decode unmarshals a JSON value
+func decode(data []byte) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	if err := codec.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+	}
+	return result, nil
+}
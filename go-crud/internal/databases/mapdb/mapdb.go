@@ -0,0 +1,464 @@
+package mapdb
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"hash/maphash"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+	"github.com/surrealdb/go-crud-bench/internal/codec"
+	"github.com/surrealdb/go-crud-bench/internal/config"
+	"github.com/surrealdb/go-crud-bench/internal/generators"
+)
+
+// shardCount is the number of independently locked shards
+const shardCount = 64
+
+// shard is a section of the key space guarded by its own lock
+type shard struct {
+	mu      sync.RWMutex
+	records map[string]map[string]interface{}
+}
+
+// Adapter implements the benchmark.Adapter interface with an in-process
+// sharded map. It does no I/O or encoding, giving an upper bound which
+// isolates the cost of the runner and generators from database cost.
+type Adapter struct {
+	seed   maphash.Seed
+	shards [shardCount]*shard
+
+	// counters holds the atomic counters of the increment phase
+	counters sync.Map
+
+	// feed is the change feed handler notified of created records
+	feed atomic.Pointer[func(key string)]
+
+	// versions holds the version history of versioned writes, indexed by
+	// version - 1 per record
+	versionsMu sync.RWMutex
+	versions   map[string][]map[string]interface{}
+
+	// edges holds the adjacency lists of the graph phase
+	edgesMu sync.RWMutex
+	edges   map[string][]string
+}
+
+// NewAdapter creates a new in-memory map adapter
+func NewAdapter() *Adapter {
+	return &Adapter{seed: maphash.MakeSeed()}
+}
+
+// Initialize creates the empty shards
+func (a *Adapter) Initialize(ctx context.Context) error {
+	for i := range a.shards {
+		a.shards[i] = &shard{records: make(map[string]map[string]interface{})}
+	}
+	return nil
+}
+
+// Cleanup releases the stored records
+func (a *Adapter) Cleanup(ctx context.Context) error {
+	for i := range a.shards {
+		a.shards[i] = nil
+	}
+	return nil
+}
+
+// Create inserts a new record
+func (a *Adapter) Create(ctx context.Context, key string, value map[string]interface{}) error {
+	s := a.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.records[key]; ok {
+		return fmt.Errorf("record already exists: %s", key)
+	}
+	s.records[key] = value
+	if handler := a.feed.Load(); handler != nil {
+		(*handler)(key)
+	}
+	return nil
+}
+
+// Read retrieves a record by key
+func (a *Adapter) Read(ctx context.Context, key string) (map[string]interface{}, error) {
+	s := a.shard(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	value, ok := s.records[key]
+	if !ok {
+		return nil, fmt.Errorf("record not found: %s", key)
+	}
+	return value, nil
+}
+
+// ReadMulti retrieves several records
+func (a *Adapter) ReadMulti(ctx context.Context, keys []string) ([]map[string]interface{}, error) {
+	results := make([]map[string]interface{}, 0, len(keys))
+	for _, key := range keys {
+		s := a.shard(key)
+		s.mu.RLock()
+		value, ok := s.records[key]
+		s.mu.RUnlock()
+		if ok {
+			results = append(results, value)
+		}
+	}
+	return results, nil
+}
+
+// Exists checks whether a record exists
+func (a *Adapter) Exists(ctx context.Context, key string) (bool, error) {
+	s := a.shard(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.records[key]
+	return ok, nil
+}
+
+// Update replaces an existing record
+func (a *Adapter) Update(ctx context.Context, key string, value map[string]interface{}) error {
+	s := a.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.records[key]; !ok {
+		return fmt.Errorf("record not found: %s", key)
+	}
+	s.records[key] = value
+	return nil
+}
+
+// CompareAndSet replaces a record only if its stored version still matches
+func (a *Adapter) CompareAndSet(ctx context.Context, key string, expected int64, value map[string]interface{}) (bool, error) {
+	s := a.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, ok := s.records[key]
+	if !ok {
+		return false, fmt.Errorf("record not found: %s", key)
+	}
+	version, _ := current["_version"].(int64)
+	if version != expected {
+		return false, nil
+	}
+	s.records[key] = value
+	return true, nil
+}
+
+// CreateCounters creates the given counters at zero
+func (a *Adapter) CreateCounters(ctx context.Context, counters []string) error {
+	for _, counter := range counters {
+		a.counters.Store(counter, new(atomic.Int64))
+	}
+	return nil
+}
+
+// Increment atomically adds one to a counter
+func (a *Adapter) Increment(ctx context.Context, counter string) error {
+	n, ok := a.counters.Load(counter)
+	if !ok {
+		return fmt.Errorf("counter not found: %s", counter)
+	}
+	n.(*atomic.Int64).Add(1)
+	return nil
+}
+
+// Counter returns the current value of a counter
+func (a *Adapter) Counter(ctx context.Context, counter string) (int64, error) {
+	n, ok := a.counters.Load(counter)
+	if !ok {
+		return 0, fmt.Errorf("counter not found: %s", counter)
+	}
+	return n.(*atomic.Int64).Load(), nil
+}
+
+// DropCounters removes the given counters
+func (a *Adapter) DropCounters(ctx context.Context, counters []string) error {
+	for _, counter := range counters {
+		a.counters.Delete(counter)
+	}
+	return nil
+}
+
+// Subscribe calls the handler with the key of every created record, directly
+// from the writing goroutine
+func (a *Adapter) Subscribe(ctx context.Context, handler func(key string)) (func() error, error) {
+	a.feed.Store(&handler)
+	stop := func() error {
+		a.feed.Store(nil)
+		return nil
+	}
+	return stop, nil
+}
+
+// Delete removes a record by key
+func (a *Adapter) Delete(ctx context.Context, key string) error {
+	s := a.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+	return nil
+}
+
+// DeleteBatch removes several records
+func (a *Adapter) DeleteBatch(ctx context.Context, keys []string) error {
+	for _, key := range keys {
+		s := a.shard(key)
+		s.mu.Lock()
+		delete(s.records, key)
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+// Truncate removes every record by replacing the shard maps
+func (a *Adapter) Truncate(ctx context.Context) error {
+	for _, s := range a.shards {
+		s.mu.Lock()
+		s.records = make(map[string]map[string]interface{})
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+// SoftDelete marks a record as deleted by storing a copy with deleted_at set
+func (a *Adapter) SoftDelete(ctx context.Context, key string) error {
+	s := a.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	value, ok := s.records[key]
+	if !ok {
+		return fmt.Errorf("record not found: %s", key)
+	}
+	deleted := make(map[string]interface{}, len(value)+1)
+	for k, v := range value {
+		deleted[k] = v
+	}
+	deleted["deleted_at"] = time.Now().UTC().Format(time.RFC3339Nano)
+	s.records[key] = deleted
+	return nil
+}
+
+// ReadLive retrieves a record unless its deleted_at field is set
+func (a *Adapter) ReadLive(ctx context.Context, key string) (map[string]interface{}, bool, error) {
+	s := a.shard(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	value, ok := s.records[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if _, deleted := value["deleted_at"]; deleted {
+		return nil, false, nil
+	}
+	return value, true, nil
+}
+
+// CountLive counts the records whose deleted_at field is not set
+func (a *Adapter) CountLive(ctx context.Context) (int, error) {
+	count := 0
+	for _, s := range a.shards {
+		s.mu.RLock()
+		for _, record := range s.records {
+			if _, deleted := record["deleted_at"]; !deleted {
+				count++
+			}
+		}
+		s.mu.RUnlock()
+	}
+	return count, nil
+}
+
+// CreateVersions creates an empty version history
+func (a *Adapter) CreateVersions(ctx context.Context) error {
+	a.versionsMu.Lock()
+	defer a.versionsMu.Unlock()
+
+	a.versions = make(map[string][]map[string]interface{})
+	return nil
+}
+
+// AppendVersion appends a version to the history of a record
+func (a *Adapter) AppendVersion(ctx context.Context, key string, version int, value map[string]interface{}) error {
+	a.versionsMu.Lock()
+	defer a.versionsMu.Unlock()
+
+	if version != len(a.versions[key])+1 {
+		return fmt.Errorf("version %d of %s is out of order", version, key)
+	}
+	a.versions[key] = append(a.versions[key], value)
+	return nil
+}
+
+// ReadLatest retrieves the last version in the history of a record
+func (a *Adapter) ReadLatest(ctx context.Context, key string) (map[string]interface{}, int, error) {
+	a.versionsMu.RLock()
+	defer a.versionsMu.RUnlock()
+
+	history := a.versions[key]
+	if len(history) == 0 {
+		return nil, 0, fmt.Errorf("record not found: %s", key)
+	}
+	return history[len(history)-1], len(history), nil
+}
+
+// DropVersions releases the version history
+func (a *Adapter) DropVersions(ctx context.Context) error {
+	a.versionsMu.Lock()
+	defer a.versionsMu.Unlock()
+
+	a.versions = nil
+	return nil
+}
+
+// CreateEdges adds the given records to the adjacency list of a record
+func (a *Adapter) CreateEdges(ctx context.Context, from string, to []string) error {
+	a.edgesMu.Lock()
+	defer a.edgesMu.Unlock()
+
+	if a.edges == nil {
+		a.edges = make(map[string][]string)
+	}
+	a.edges[from] = append(a.edges[from], to...)
+	return nil
+}
+
+// Traverse follows the adjacency lists breadth first for the given number of
+// hops, counting the distinct records of the last frontier
+func (a *Adapter) Traverse(ctx context.Context, start string, hops int) (int, error) {
+	a.edgesMu.RLock()
+	defer a.edgesMu.RUnlock()
+
+	frontier := map[string]struct{}{start: {}}
+	for h := 0; h < hops; h++ {
+		next := make(map[string]struct{})
+		for key := range frontier {
+			for _, target := range a.edges[key] {
+				next[target] = struct{}{}
+			}
+		}
+		frontier = next
+	}
+	return len(frontier), nil
+}
+
+// DropEdges releases the adjacency lists
+func (a *Adapter) DropEdges(ctx context.Context) error {
+	a.edgesMu.Lock()
+	defer a.edgesMu.Unlock()
+
+	a.edges = nil
+	return nil
+}
+
+// Scan performs a scan operation based on the scan configuration. Records
+// are visited shard by shard in no particular order. Search scans match the
+// term against the whitespace separated words of the field, ignoring case,
+// geo scans compute the distance of every point and JSON path scans compare
+// the JSON encoding of the nested value, all without any index.
+func (a *Adapter) Scan(ctx context.Context, scanConfig config.ScanConfig) (int, error) {
+	var match func(record map[string]interface{}) bool
+	if field, term, ok := scanConfig.Search(); ok {
+		match = func(record map[string]interface{}) bool {
+			return containsWord(record[field], term)
+		}
+	} else if field, lon, lat, radius, ok := scanConfig.GeoWithin(); ok {
+		match = func(record map[string]interface{}) bool {
+			pointLon, pointLat, ok := generators.PointCoordinates(record[field])
+			return ok && generators.DistanceKm(lon, lat, pointLon, pointLat) <= radius
+		}
+	} else if path, value, ok := scanConfig.JSONPath(); ok {
+		expected, err := codec.Marshal(value)
+		if err != nil {
+			return 0, fmt.Errorf("failed to marshal JSON path value: %w", err)
+		}
+		match = func(record map[string]interface{}) bool {
+			return matchesPath(record, path, expected)
+		}
+	} else {
+		switch scanConfig.Projection {
+		case "ID", "FULL", "COUNT":
+		default:
+			return 0, fmt.Errorf("%w projection type: %s", benchmark.ErrUnsupported, scanConfig.Projection)
+		}
+	}
+
+	count := 0
+	skipped := 0
+	for _, s := range a.shards {
+		s.mu.RLock()
+		for _, record := range s.records {
+			if match != nil && !match(record) {
+				continue
+			}
+			if skipped < scanConfig.Start {
+				skipped++
+				continue
+			}
+			count++
+			if scanConfig.Limit > 0 && count >= scanConfig.Limit {
+				break
+			}
+		}
+		s.mu.RUnlock()
+
+		if scanConfig.Limit > 0 && count >= scanConfig.Limit {
+			break
+		}
+	}
+
+	return count, nil
+}
+
+// Name returns the name of the database
+func (a *Adapter) Name() string {
+	return "map"
+}
+
+// containsWord reports whether a string value contains the given word
+func containsWord(value interface{}, word string) bool {
+	text, ok := value.(string)
+	if !ok {
+		return false
+	}
+	for _, w := range strings.Fields(text) {
+		if strings.EqualFold(w, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesPath reports whether the value nested at the given path has the
+// expected JSON encoding, so that integers and floats compare equal
+func matchesPath(record map[string]interface{}, path []string, expected []byte) bool {
+	var value interface{} = record
+	for _, key := range path {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if value, ok = object[key]; !ok {
+			return false
+		}
+	}
+	encoded, err := codec.Marshal(value)
+	return err == nil && bytes.Equal(encoded, expected)
+}
+
+// shard returns the shard holding the given key
+func (a *Adapter) shard(key string) *shard {
+	return a.shards[maphash.String(a.seed, key)%shardCount]
+}
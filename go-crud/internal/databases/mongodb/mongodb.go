@@ -0,0 +1,933 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+	"github.com/surrealdb/go-crud-bench/internal/config"
+	"github.com/surrealdb/go-crud-bench/internal/dbutils"
+	"github.com/surrealdb/go-crud-bench/internal/docker"
+	"github.com/surrealdb/go-crud-bench/internal/generators"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// Default MongoDB Docker image
+	defaultImage = "mongo:7"
+
+	// Default MongoDB port
+	defaultPort = "27017"
+
+	// Directory of the container holding the MongoDB data
+	dataDir = "/data/db"
+
+	// Default MongoDB credentials
+	defaultUser     = "root"
+	defaultPassword = "root"
+	defaultDatabase = "bench"
+
+	// Collection name
+	collectionName = "bench_collection"
+
+	// Container name prefix
+	containerNamePrefix = "crud-bench-mongodb"
+)
+
+// Adapter implements the benchmark.Adapter interface for MongoDB
+type Adapter struct {
+	client      *mongo.Client
+	collection  *mongo.Collection
+	container   *docker.Container
+	endpoint    string
+	conn        config.ConnectionOptions
+	image       string
+	privileged  bool
+	containerID string
+	tracker     *dbutils.ConnTracker
+	database    string
+	name        string
+	namespaced  bool
+	queryLog    *dbutils.QueryLog
+	steps       *dbutils.Steps
+	out         io.Writer
+}
+
+// NewAdapter creates a new MongoDB adapter
+func NewAdapter(endpoint, image string, privileged bool, conn config.ConnectionOptions) *Adapter {
+	if image == "" {
+		image = defaultImage
+	}
+	database := defaultDatabase
+	if conn.Database != "" {
+		database = conn.Database
+	}
+
+	return &Adapter{
+		endpoint:   endpoint,
+		conn:       conn,
+		image:      image,
+		privileged: privileged,
+		tracker:    dbutils.NewConnTracker(),
+		out:        os.Stdout,
+		database:   database,
+		name:       collectionName,
+	}
+}
+
+// SetNamespace isolates the benchmark in its own collection on a shared endpoint
+func (a *Adapter) SetNamespace(namespace string) {
+	a.name = fmt.Sprintf("%s_%s", collectionName, namespace)
+	a.namespaced = true
+}
+
+// Initialize sets up the MongoDB database
+func (a *Adapter) Initialize(ctx context.Context) error {
+	var uri string
+
+	// If no endpoint is provided, start a Docker container
+	if a.endpoint == "" {
+		container, err := a.startContainer(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to start MongoDB container: %w", err)
+		}
+
+		a.container = container
+		a.containerID = container.ID
+		uri = localURI()
+	} else {
+		// Use provided endpoint
+		uri = a.endpoint
+	}
+
+	connectStart := time.Now()
+	client, err := a.connect(ctx, uri)
+	if err != nil {
+		return err
+	}
+	a.steps.Record("connect", connectStart)
+
+	a.client = client
+	a.collection = client.Database(a.database).Collection(a.name)
+
+	// Start from an empty collection
+	schemaStart := time.Now()
+	if err := a.collection.Drop(ctx); err != nil {
+		return fmt.Errorf("failed to drop collection: %w", err)
+	}
+	a.steps.Record("schema_create", schemaStart)
+
+	return nil
+}
+
+// Cleanup performs cleanup operations
+func (a *Adapter) Cleanup(ctx context.Context) error {
+	// Drop the namespaced collection so that shared endpoints are left clean
+	if a.collection != nil && a.namespaced {
+		dropStart := time.Now()
+		if err := a.collection.Drop(ctx); err != nil {
+			return fmt.Errorf("failed to drop collection %s: %w", a.name, err)
+		}
+		a.steps.Record("schema_drop", dropStart)
+	}
+
+	// Close database connection
+	if a.client != nil {
+		if err := a.client.Disconnect(ctx); err != nil {
+			return fmt.Errorf("failed to close MongoDB connection: %w", err)
+		}
+	}
+
+	// Stop and remove container if it was started
+	if a.container != nil {
+		fmt.Fprintf(a.out, "Cleaning up MongoDB container %s...\n", a.containerID)
+		stopStart := time.Now()
+		if err := a.container.Stop(ctx); err != nil {
+			return fmt.Errorf("failed to stop MongoDB container: %w", err)
+		}
+		a.steps.Record("container_stop", stopStart)
+	}
+
+	return nil
+}
+
+// Create inserts a new document
+func (a *Adapter) Create(ctx context.Context, key string, value map[string]interface{}) error {
+	a.queryLog.Log(a.Name(), fmt.Sprintf("%s.insertOne", a.name), key)
+	if _, err := a.collection.InsertOne(ctx, document(key, value)); err != nil {
+		return fmt.Errorf("failed to insert record: %w", err)
+	}
+
+	return nil
+}
+
+// Read retrieves a document by key
+func (a *Adapter) Read(ctx context.Context, key string) (map[string]interface{}, error) {
+	a.queryLog.Log(a.Name(), fmt.Sprintf("%s.findOne", a.name), key)
+	var result bson.M
+	err := a.collection.FindOne(ctx, bson.M{"_id": key}).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("record not found: %s", key)
+		}
+		return nil, fmt.Errorf("failed to read record: %w", err)
+	}
+
+	// Return only the stored value, as the other adapters do
+	delete(result, "_id")
+	return result, nil
+}
+
+// ReadMulti retrieves several documents with a single $in query
+func (a *Adapter) ReadMulti(ctx context.Context, keys []string) ([]map[string]interface{}, error) {
+	a.queryLog.Log(a.Name(), fmt.Sprintf("%s.find $in", a.name), keys)
+	cursor, err := a.collection.Find(ctx, bson.M{"_id": bson.M{"$in": keys}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read records: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var results []map[string]interface{}
+	for cursor.Next(ctx) {
+		var result bson.M
+		if err := cursor.Decode(&result); err != nil {
+			return nil, fmt.Errorf("failed to decode record: %w", err)
+		}
+
+		// Return only the stored value, as the other adapters do
+		delete(result, "_id")
+		results = append(results, result)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read records: %w", err)
+	}
+
+	return results, nil
+}
+
+// Exists checks whether a document exists without fetching its value
+func (a *Adapter) Exists(ctx context.Context, key string) (bool, error) {
+	a.queryLog.Log(a.Name(), fmt.Sprintf("%s.countDocuments", a.name), key)
+	count, err := a.collection.CountDocuments(ctx, bson.M{"_id": key}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, fmt.Errorf("failed to check record: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// Update replaces an existing document
+func (a *Adapter) Update(ctx context.Context, key string, value map[string]interface{}) error {
+	a.queryLog.Log(a.Name(), fmt.Sprintf("%s.replaceOne", a.name), key)
+	result, err := a.collection.ReplaceOne(ctx, bson.M{"_id": key}, document(key, value))
+	if err != nil {
+		return fmt.Errorf("failed to update record: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("record not found: %s", key)
+	}
+
+	return nil
+}
+
+// CompareAndSet replaces a document only if its stored version still matches,
+// reporting whether the replace was applied
+func (a *Adapter) CompareAndSet(ctx context.Context, key string, expected int64, value map[string]interface{}) (bool, error) {
+	// Documents without a version are at version 0
+	filter := bson.M{"_id": key, "_version": expected}
+	if expected == 0 {
+		filter["_version"] = bson.M{"$in": bson.A{int64(0), nil}}
+	}
+
+	a.queryLog.Log(a.Name(), fmt.Sprintf("%s.replaceOne", a.name), key, expected)
+	result, err := a.collection.ReplaceOne(ctx, filter, document(key, value))
+	if err != nil {
+		return false, fmt.Errorf("failed to update record: %w", err)
+	}
+
+	return result.MatchedCount > 0, nil
+}
+
+// CreateCounters resets the given counters to zero in the counter collection
+func (a *Adapter) CreateCounters(ctx context.Context, counters []string) error {
+	collection := a.counters()
+	for _, counter := range counters {
+		a.queryLog.Log(a.Name(), fmt.Sprintf("%s.replaceOne", collection.Name()), counter)
+		opts := options.Replace().SetUpsert(true)
+		if _, err := collection.ReplaceOne(ctx, bson.M{"_id": counter}, bson.M{"_id": counter, "n": int64(0)}, opts); err != nil {
+			return fmt.Errorf("failed to reset counter %s: %w", counter, err)
+		}
+	}
+
+	return nil
+}
+
+// Increment atomically adds one to a counter with $inc
+func (a *Adapter) Increment(ctx context.Context, counter string) error {
+	collection := a.counters()
+	a.queryLog.Log(a.Name(), fmt.Sprintf("%s.updateOne $inc", collection.Name()), counter)
+	if _, err := collection.UpdateOne(ctx, bson.M{"_id": counter}, bson.M{"$inc": bson.M{"n": 1}}); err != nil {
+		return fmt.Errorf("failed to increment counter: %w", err)
+	}
+
+	return nil
+}
+
+// Counter returns the current value of a counter
+func (a *Adapter) Counter(ctx context.Context, counter string) (int64, error) {
+	var result struct {
+		N int64 `bson:"n"`
+	}
+	if err := a.counters().FindOne(ctx, bson.M{"_id": counter}).Decode(&result); err != nil {
+		return 0, fmt.Errorf("failed to read counter: %w", err)
+	}
+
+	return result.N, nil
+}
+
+// DropCounters drops the counter collection
+func (a *Adapter) DropCounters(ctx context.Context, counters []string) error {
+	if err := a.counters().Drop(ctx); err != nil {
+		return fmt.Errorf("failed to drop counter collection: %w", err)
+	}
+
+	return nil
+}
+
+// Subscribe delivers the keys of new documents through a change stream, which
+// requires the server to be part of a replica set
+func (a *Adapter) Subscribe(ctx context.Context, handler func(key string)) (func() error, error) {
+	pipeline := mongo.Pipeline{bson.D{{Key: "$match", Value: bson.M{"operationType": "insert"}}}}
+	stream, err := a.collection.Watch(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open change stream: %w", err)
+	}
+
+	streamCtx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for stream.Next(streamCtx) {
+			var event struct {
+				DocumentKey struct {
+					ID string `bson:"_id"`
+				} `bson:"documentKey"`
+			}
+			if err := stream.Decode(&event); err == nil {
+				handler(event.DocumentKey.ID)
+			}
+		}
+	}()
+
+	stop := func() error {
+		cancel()
+		<-done
+		return stream.Close(context.Background())
+	}
+
+	return stop, nil
+}
+
+// Delete removes a document by key
+func (a *Adapter) Delete(ctx context.Context, key string) error {
+	a.queryLog.Log(a.Name(), fmt.Sprintf("%s.deleteOne", a.name), key)
+	if _, err := a.collection.DeleteOne(ctx, bson.M{"_id": key}); err != nil {
+		return fmt.Errorf("failed to delete record: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteBatch removes several documents with a single $in filter
+func (a *Adapter) DeleteBatch(ctx context.Context, keys []string) error {
+	a.queryLog.Log(a.Name(), fmt.Sprintf("%s.deleteMany $in", a.name), keys)
+	if _, err := a.collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": keys}}); err != nil {
+		return fmt.Errorf("failed to delete records: %w", err)
+	}
+
+	return nil
+}
+
+// Truncate removes every document by dropping the collection, which is
+// created again on the next insert
+func (a *Adapter) Truncate(ctx context.Context) error {
+	a.queryLog.Log(a.Name(), fmt.Sprintf("%s.drop", a.name))
+	if err := a.collection.Drop(ctx); err != nil {
+		return fmt.Errorf("failed to drop collection: %w", err)
+	}
+
+	return nil
+}
+
+// SoftDelete marks a document as deleted by setting deleted_at with $set
+func (a *Adapter) SoftDelete(ctx context.Context, key string) error {
+	a.queryLog.Log(a.Name(), fmt.Sprintf("%s.updateOne $set deleted_at", a.name), key)
+	result, err := a.collection.UpdateOne(ctx, bson.M{"_id": key}, bson.M{"$set": bson.M{"deleted_at": time.Now().UTC()}})
+	if err != nil {
+		return fmt.Errorf("failed to soft delete record: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("record not found: %s", key)
+	}
+
+	return nil
+}
+
+// ReadLive retrieves a document unless its deleted_at field is set
+func (a *Adapter) ReadLive(ctx context.Context, key string) (map[string]interface{}, bool, error) {
+	a.queryLog.Log(a.Name(), fmt.Sprintf("%s.findOne deleted_at $exists false", a.name), key)
+	var result bson.M
+	err := a.collection.FindOne(ctx, bson.M{"_id": key, "deleted_at": bson.M{"$exists": false}}).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to read record: %w", err)
+	}
+
+	// Return only the stored value, as the other adapters do
+	delete(result, "_id")
+	return result, true, nil
+}
+
+// CountLive counts the documents whose deleted_at field is not set
+func (a *Adapter) CountLive(ctx context.Context) (int, error) {
+	a.queryLog.Log(a.Name(), fmt.Sprintf("%s.countDocuments deleted_at $exists false", a.name))
+	count, err := a.collection.CountDocuments(ctx, bson.M{"deleted_at": bson.M{"$exists": false}})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count live records: %w", err)
+	}
+
+	return int(count), nil
+}
+
+// versions returns the history collection of versioned writes
+func (a *Adapter) versions() *mongo.Collection {
+	return a.collection.Database().Collection(a.name + "_versions")
+}
+
+// CreateVersions creates the empty history collection of versioned writes,
+// indexed on the key and version so that the latest version is an index seek
+func (a *Adapter) CreateVersions(ctx context.Context) error {
+	if err := a.DropVersions(ctx); err != nil {
+		return err
+	}
+
+	model := mongo.IndexModel{
+		Keys:    bson.D{{Key: "key", Value: 1}, {Key: "version", Value: -1}},
+		Options: options.Index().SetName("key_version"),
+	}
+	a.queryLog.Log(a.Name(), fmt.Sprintf("%s_versions.createIndex key_version", a.name))
+	if _, err := a.versions().Indexes().CreateOne(ctx, model); err != nil {
+		return fmt.Errorf("failed to create history index: %w", err)
+	}
+
+	return nil
+}
+
+// AppendVersion inserts a new version document of a record
+func (a *Adapter) AppendVersion(ctx context.Context, key string, version int, value map[string]interface{}) error {
+	a.queryLog.Log(a.Name(), fmt.Sprintf("%s_versions.insertOne", a.name), key, version)
+	doc := bson.M{"key": key, "version": version, "data": value}
+	if _, err := a.versions().InsertOne(ctx, doc); err != nil {
+		return fmt.Errorf("failed to append version: %w", err)
+	}
+
+	return nil
+}
+
+// ReadLatest retrieves the version document of a record with the highest version
+func (a *Adapter) ReadLatest(ctx context.Context, key string) (map[string]interface{}, int, error) {
+	a.queryLog.Log(a.Name(), fmt.Sprintf("%s_versions.findOne sort version -1", a.name), key)
+	var result struct {
+		Version int    `bson:"version"`
+		Data    bson.M `bson:"data"`
+	}
+	opts := options.FindOne().SetSort(bson.D{{Key: "version", Value: -1}})
+	err := a.versions().FindOne(ctx, bson.M{"key": key}, opts).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, 0, fmt.Errorf("record not found: %s", key)
+		}
+		return nil, 0, fmt.Errorf("failed to read latest version: %w", err)
+	}
+
+	return result.Data, result.Version, nil
+}
+
+// DropVersions drops the history collection
+func (a *Adapter) DropVersions(ctx context.Context) error {
+	a.queryLog.Log(a.Name(), fmt.Sprintf("%s_versions.drop", a.name))
+	if err := a.versions().Drop(ctx); err != nil {
+		return fmt.Errorf("failed to drop history collection: %w", err)
+	}
+
+	return nil
+}
+
+// Scan performs a scan operation based on the scan configuration
+func (a *Adapter) Scan(ctx context.Context, scanConfig config.ScanConfig) (int, error) {
+	// Full-text searches, geo radius and JSON path scans have their own query
+	if field, term, ok := scanConfig.Search(); ok {
+		return a.search(ctx, field, term, scanConfig)
+	}
+	if field, lon, lat, radius, ok := scanConfig.GeoWithin(); ok {
+		return a.geoWithin(ctx, field, lon, lat, radius, scanConfig)
+	}
+	if path, value, ok := scanConfig.JSONPath(); ok {
+		return a.jsonPath(ctx, path, value, scanConfig)
+	}
+
+	a.queryLog.Log(a.Name(), fmt.Sprintf("%s.scan %s", a.name, scanConfig.Projection))
+
+	// Count documents, honouring LIMIT and START if specified
+	if scanConfig.Projection == "COUNT" {
+		opts := options.Count()
+		if scanConfig.Limit > 0 {
+			opts.SetLimit(int64(scanConfig.Limit))
+			if scanConfig.Start > 0 {
+				opts.SetSkip(int64(scanConfig.Start))
+			}
+		}
+		count, err := a.collection.CountDocuments(ctx, bson.M{}, opts)
+		if err != nil {
+			return 0, fmt.Errorf("failed to execute count scan: %w", err)
+		}
+		return int(count), nil
+	}
+
+	// Build find options based on projection type
+	opts := options.Find()
+	switch scanConfig.Projection {
+	case "ID":
+		opts.SetProjection(bson.M{"_id": 1})
+	case "FULL":
+	default:
+		return 0, fmt.Errorf("%w projection type: %s", benchmark.ErrUnsupported, scanConfig.Projection)
+	}
+	if scanConfig.Limit > 0 {
+		opts.SetLimit(int64(scanConfig.Limit))
+		if scanConfig.Start > 0 {
+			opts.SetSkip(int64(scanConfig.Start))
+		}
+	}
+
+	cursor, err := a.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute scan: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	// Count documents
+	count := 0
+	for cursor.Next(ctx) {
+		count++
+	}
+
+	if err := cursor.Err(); err != nil {
+		return 0, fmt.Errorf("error while scanning documents: %w", err)
+	}
+
+	return count, nil
+}
+
+// search runs a full-text search scan with the $text operator, which uses the
+// text index built by CreateSearchIndex
+func (a *Adapter) search(ctx context.Context, field, term string, scanConfig config.ScanConfig) (int, error) {
+	a.queryLog.Log(a.Name(), fmt.Sprintf("%s.find $text", a.name), term)
+
+	opts := options.Find().SetProjection(bson.M{"_id": 1})
+	if scanConfig.Limit > 0 {
+		opts.SetLimit(int64(scanConfig.Limit))
+		if scanConfig.Start > 0 {
+			opts.SetSkip(int64(scanConfig.Start))
+		}
+	}
+
+	cursor, err := a.collection.Find(ctx, bson.M{"$text": bson.M{"$search": term}}, opts)
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute search: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	count := 0
+	for cursor.Next(ctx) {
+		count++
+	}
+	if err := cursor.Err(); err != nil {
+		return 0, fmt.Errorf("error while scanning documents: %w", err)
+	}
+
+	return count, nil
+}
+
+// geoWithin runs a geo radius scan with $geoWithin and $centerSphere, which
+// uses the 2dsphere index built by CreateGeoIndex
+func (a *Adapter) geoWithin(ctx context.Context, field string, lon, lat, radiusKm float64, scanConfig config.ScanConfig) (int, error) {
+	a.queryLog.Log(a.Name(), fmt.Sprintf("%s.find $geoWithin", a.name), field, lon, lat, radiusKm)
+
+	opts := options.Find().SetProjection(bson.M{"_id": 1})
+	if scanConfig.Limit > 0 {
+		opts.SetLimit(int64(scanConfig.Limit))
+		if scanConfig.Start > 0 {
+			opts.SetSkip(int64(scanConfig.Start))
+		}
+	}
+
+	// The radius of $centerSphere is expressed in radians
+	filter := bson.M{field: bson.M{"$geoWithin": bson.M{
+		"$centerSphere": bson.A{bson.A{lon, lat}, radiusKm / generators.EarthRadiusKm},
+	}}}
+	cursor, err := a.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute geo scan: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	count := 0
+	for cursor.Next(ctx) {
+		count++
+	}
+	if err := cursor.Err(); err != nil {
+		return 0, fmt.Errorf("error while scanning documents: %w", err)
+	}
+
+	return count, nil
+}
+
+// jsonPath runs a JSON path scan with an equality filter on the dotted path
+func (a *Adapter) jsonPath(ctx context.Context, path []string, value interface{}, scanConfig config.ScanConfig) (int, error) {
+	field := strings.Join(path, ".")
+	a.queryLog.Log(a.Name(), fmt.Sprintf("%s.find %s", a.name, field), value)
+
+	opts := options.Find().SetProjection(bson.M{"_id": 1})
+	if scanConfig.Limit > 0 {
+		opts.SetLimit(int64(scanConfig.Limit))
+		if scanConfig.Start > 0 {
+			opts.SetSkip(int64(scanConfig.Start))
+		}
+	}
+
+	cursor, err := a.collection.Find(ctx, bson.M{field: value}, opts)
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute JSON path scan: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	count := 0
+	for cursor.Next(ctx) {
+		count++
+	}
+	if err := cursor.Err(); err != nil {
+		return 0, fmt.Errorf("error while scanning documents: %w", err)
+	}
+
+	return count, nil
+}
+
+// Name returns the name of the database
+func (a *Adapter) Name() string {
+	return "mongodb"
+}
+
+// ClassifyError maps MongoDB driver and server errors to error classes
+func (a *Adapter) ClassifyError(err error) benchmark.ErrorClass {
+	var serverErr mongo.ServerError
+	switch {
+	case mongo.IsTimeout(err):
+		return benchmark.ErrorClassTimeout
+	case mongo.IsNetworkError(err):
+		return benchmark.ErrorClassConnection
+	case mongo.IsDuplicateKeyError(err):
+		return benchmark.ErrorClassConstraint
+	case errors.As(err, &serverErr) && (serverErr.HasErrorCode(112) || serverErr.HasErrorLabel("TransientTransactionError")):
+		// WriteConflict and transient transaction errors can be retried
+		return benchmark.ErrorClassConflict
+	default:
+		return ""
+	}
+}
+
+// CreateIndex builds a secondary index on the given value field
+func (a *Adapter) CreateIndex(ctx context.Context, name string, field string) error {
+	model := mongo.IndexModel{
+		Keys:    bson.D{{Key: field, Value: 1}},
+		Options: options.Index().SetName(name),
+	}
+
+	if _, err := a.collection.Indexes().CreateOne(ctx, model); err != nil {
+		return fmt.Errorf("failed to create index: %w", err)
+	}
+
+	return nil
+}
+
+// DropIndex removes a secondary index
+func (a *Adapter) DropIndex(ctx context.Context, name string) error {
+	if _, err := a.collection.Indexes().DropOne(ctx, name); err != nil {
+		return fmt.Errorf("failed to drop index: %w", err)
+	}
+
+	return nil
+}
+
+// CreateSearchIndex builds a text index on a value field. A collection can
+// only have one text index, so a single field can be searched per run.
+func (a *Adapter) CreateSearchIndex(ctx context.Context, field string) error {
+	model := mongo.IndexModel{
+		Keys:    bson.D{{Key: field, Value: "text"}},
+		Options: options.Index().SetName("search_" + field),
+	}
+
+	if _, err := a.collection.Indexes().CreateOne(ctx, model); err != nil {
+		return fmt.Errorf("failed to create search index: %w", err)
+	}
+
+	return nil
+}
+
+// DropSearchIndex removes the text index on a value field
+func (a *Adapter) DropSearchIndex(ctx context.Context, field string) error {
+	if _, err := a.collection.Indexes().DropOne(ctx, "search_"+field); err != nil {
+		return fmt.Errorf("failed to drop search index: %w", err)
+	}
+
+	return nil
+}
+
+// CreateGeoIndex builds a 2dsphere index on the GeoJSON points of a value field
+func (a *Adapter) CreateGeoIndex(ctx context.Context, field string) error {
+	model := mongo.IndexModel{
+		Keys:    bson.D{{Key: field, Value: "2dsphere"}},
+		Options: options.Index().SetName("geo_" + field),
+	}
+
+	if _, err := a.collection.Indexes().CreateOne(ctx, model); err != nil {
+		return fmt.Errorf("failed to create geo index: %w", err)
+	}
+
+	return nil
+}
+
+// DropGeoIndex removes the 2dsphere index on a value field
+func (a *Adapter) DropGeoIndex(ctx context.Context, field string) error {
+	if _, err := a.collection.Indexes().DropOne(ctx, "geo_"+field); err != nil {
+		return fmt.Errorf("failed to drop geo index: %w", err)
+	}
+
+	return nil
+}
+
+// Compact defragments the collection and releases unused disk space
+func (a *Adapter) Compact(ctx context.Context) error {
+	err := a.client.Database(a.database).RunCommand(ctx, bson.D{{Key: "compact", Value: a.name}}).Err()
+	if err != nil {
+		return fmt.Errorf("failed to compact collection: %w", err)
+	}
+
+	return nil
+}
+
+// DropNamespace removes the collections of a namespaced run left on the endpoint
+func (a *Adapter) DropNamespace(ctx context.Context, namespace string) ([]string, error) {
+	if a.endpoint == "" {
+		return nil, fmt.Errorf("an endpoint is required to clean up namespaced collections")
+	}
+
+	client, err := a.connect(ctx, a.endpoint)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Disconnect(ctx)
+
+	database := client.Database(a.database)
+	names, err := database.ListCollectionNames(ctx, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list collections: %w", err)
+	}
+
+	// Drop the collections one by one
+	var dropped []string
+	for _, name := range names {
+		if !dbutils.InNamespace(name, fmt.Sprintf("%s_%s", collectionName, namespace)) {
+			continue
+		}
+		if err := database.Collection(name).Drop(ctx); err != nil {
+			return dropped, fmt.Errorf("failed to drop collection %s: %w", name, err)
+		}
+		dropped = append(dropped, name)
+	}
+
+	return dropped, nil
+}
+
+// SetSteps sets the recorder receiving the durations of the provisioning steps
+func (a *Adapter) SetSteps(steps *dbutils.Steps) {
+	a.steps = steps
+}
+
+// SetOutput sets the writer receiving the container messages of the adapter
+func (a *Adapter) SetOutput(w io.Writer) {
+	a.out = w
+}
+
+// SetQueryLog sets the log receiving the operations issued by the adapter
+func (a *Adapter) SetQueryLog(log *dbutils.QueryLog) {
+	a.queryLog = log
+}
+
+// WarmPool establishes the connections of the pool before the measured
+// phases, pinging the server concurrently
+func (a *Adapter) WarmPool(ctx context.Context, connections int) error {
+	return dbutils.WarmPool(ctx, connections, func(ctx context.Context) error {
+		return a.client.Ping(ctx, nil)
+	})
+}
+
+// ConnectionStats returns the connection churn counters
+func (a *Adapter) ConnectionStats() map[string]float64 {
+	return a.tracker.Metrics(nil)
+}
+
+// DataDir returns the directory of the container holding the data
+func (a *Adapter) DataDir() string {
+	return dataDir
+}
+
+// Container returns the managed Docker container, or nil for external endpoints
+func (a *Adapter) Container() *docker.Container {
+	return a.container
+}
+
+// connect opens a client to the given URI, tracking the connections it opens
+func (a *Adapter) connect(ctx context.Context, uri string) (*mongo.Client, error) {
+	// Endpoints may be bare host[:port] addresses
+	if !strings.Contains(uri, "://") {
+		uri = "mongodb://" + uri
+	}
+	opts := options.Client().ApplyURI(uri).SetDialer(a.tracker)
+
+	// The connection options take precedence over the settings of the URI
+	if a.conn.Username != "" || a.conn.Password != "" {
+		credential := options.Credential{}
+		if opts.Auth != nil {
+			credential = *opts.Auth
+		}
+		if a.conn.Username != "" {
+			credential.Username = a.conn.Username
+		}
+		if a.conn.Password != "" {
+			credential.Password = a.conn.Password
+			credential.PasswordSet = true
+		}
+		opts.SetAuth(credential)
+	}
+	if a.conn.Port > 0 {
+		for i, host := range opts.Hosts {
+			opts.Hosts[i] = a.conn.HostPort(host, "")
+		}
+	}
+	if a.conn.TLS {
+		// The driver sets the server name of every host
+		tlsConfig, err := a.conn.TLSConfig("")
+		if err != nil {
+			return nil, err
+		}
+		opts.SetTLSConfig(tlsConfig)
+	}
+
+	client, err := mongo.Connect(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to MongoDB: %w", err)
+	}
+
+	// Test connection
+	if err := client.Ping(ctx, nil); err != nil {
+		_ = client.Disconnect(ctx)
+		return nil, fmt.Errorf("failed to ping MongoDB: %w", err)
+	}
+
+	return client, nil
+}
+
+// document builds the stored document for a key and value
+func document(key string, value map[string]interface{}) bson.M {
+	doc := make(bson.M, len(value)+1)
+	for k, v := range value {
+		doc[k] = v
+	}
+	doc["_id"] = key
+	return doc
+}
+
+// localURI returns the connection URI of the managed container
+func localURI() string {
+	return fmt.Sprintf("mongodb://%s:%s@%s/?directConnection=true", defaultUser, defaultPassword, dbutils.HostAddress(defaultPort))
+}
+
+// startContainer starts a MongoDB container
+func (a *Adapter) startContainer(ctx context.Context) (*docker.Container, error) {
+	// Generate unique container name with timestamp
+	containerName := fmt.Sprintf("%s-%d", containerNamePrefix, time.Now().Unix())
+
+	// Configure container
+	ports := map[string]string{
+		"27017/tcp": defaultPort,
+	}
+
+	env := []string{
+		fmt.Sprintf("MONGO_INITDB_ROOT_USERNAME=%s", defaultUser),
+		fmt.Sprintf("MONGO_INITDB_ROOT_PASSWORD=%s", defaultPassword),
+	}
+
+	fmt.Fprintf(a.out, "Starting MongoDB container '%s' with image '%s'...\n", containerName, a.image)
+
+	// Create and start container with the common utility
+	container, err := dbutils.CreateContainerWithRetry(ctx, a.out, containerName, a.image, ports, a.privileged, env, a.steps)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start MongoDB container: %w", err)
+	}
+
+	fmt.Fprintf(a.out, "MongoDB container started, waiting for it to be ready...\n")
+
+	// Wait for MongoDB to accept authenticated connections
+	checkFunc := func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+
+		client, err := mongo.Connect(ctx, options.Client().ApplyURI(localURI()))
+		if err != nil {
+			return err
+		}
+		defer client.Disconnect(ctx)
+
+		if err := client.Ping(ctx, nil); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(a.out, "MongoDB is ready!\n")
+		return nil
+	}
+
+	waitStart := time.Now()
+	if err := container.WaitForHealthy(ctx, 90*time.Second, checkFunc); err != nil {
+		// Clean up container if health check fails
+		_ = container.Stop(ctx)
+		return nil, fmt.Errorf("MongoDB health check failed: %w", err)
+	}
+	a.steps.Record("readiness_wait", waitStart)
+
+	return container, nil
+}
+
+// counters returns the collection holding the counters of the increment phase
+func (a *Adapter) counters() *mongo.Collection {
+	return a.collection.Database().Collection(a.name + "_counters")
+}
@@ -0,0 +1,897 @@
+package mssql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	mssqldriver "github.com/microsoft/go-mssqldb"
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+	"github.com/surrealdb/go-crud-bench/internal/codec"
+	"github.com/surrealdb/go-crud-bench/internal/config"
+	"github.com/surrealdb/go-crud-bench/internal/dbutils"
+	"github.com/surrealdb/go-crud-bench/internal/docker"
+)
+
+// Default SQL Server Docker image
+const (
+	defaultImage = "mcr.microsoft.com/mssql/server:2022-latest"
+
+	// Default SQL Server port
+	defaultPort = "1433"
+
+	// Directory of the container holding the SQL Server data
+	dataDir = "/var/opt/mssql/data"
+
+	// Default SQL Server credentials; the SA password must satisfy the
+	// server's complexity policy or the container refuses to start
+	defaultUser     = "sa"
+	defaultPassword = "Bench_Passw0rd"
+	defaultDatabase = "bench"
+
+	// Table name
+	tableName = "bench_table"
+
+	// Container name prefix
+	containerNamePrefix = "crud-bench-mssql"
+)
+
+// preset contains the default tuning settings for SQL Server
+var preset = map[string]string{
+	"max_open_conns":    "100",
+	"max_idle_conns":    "20",
+	"conn_max_lifetime": "1h",
+}
+
+// Adapter implements the benchmark.Adapter interface for Microsoft SQL Server
+type Adapter struct {
+	db          *sql.DB
+	container   *docker.Container
+	endpoint    string
+	conn        config.ConnectionOptions
+	image       string
+	privileged  bool
+	containerID string
+	tracker     *dbutils.ConnTracker
+	clients     *dbutils.ClientPools
+	dsn         string
+	table       string
+	namespaced  bool
+	tuning      dbutils.Tuning
+	queryLog    *dbutils.QueryLog
+	steps       *dbutils.Steps
+	out         io.Writer
+}
+
+// NewAdapter creates a new SQL Server adapter
+func NewAdapter(endpoint, image string, privileged bool, conn config.ConnectionOptions) *Adapter {
+	if image == "" {
+		image = defaultImage
+	}
+
+	return &Adapter{
+		endpoint:   endpoint,
+		conn:       conn,
+		image:      image,
+		privileged: privileged,
+		tracker:    dbutils.NewConnTracker(),
+		out:        os.Stdout,
+		table:      tableName,
+		tuning:     preset,
+	}
+}
+
+// SetNamespace isolates the benchmark in its own table on a shared endpoint
+func (a *Adapter) SetNamespace(namespace string) {
+	a.table = fmt.Sprintf("%s_%s", tableName, namespace)
+	a.namespaced = true
+}
+
+// Tune applies tuning overrides on top of the SQL Server preset
+func (a *Adapter) Tune(overrides map[string]string) (map[string]string, error) {
+	tuning, err := dbutils.NewTuning(preset, overrides)
+	if err != nil {
+		return nil, err
+	}
+	a.tuning = tuning
+	return tuning, nil
+}
+
+// Initialize sets up the SQL Server database
+func (a *Adapter) Initialize(ctx context.Context) error {
+	var dsn string
+
+	// If no endpoint is provided, start a Docker container
+	if a.endpoint == "" {
+		container, err := a.startContainer(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to start SQL Server container: %w", err)
+		}
+
+		a.container = container
+		a.containerID = container.ID
+		dsn = localDSN(defaultDatabase)
+	} else {
+		// Use provided endpoint, completed by the connection options
+		var err error
+		if dsn, err = a.endpointDSN(); err != nil {
+			return err
+		}
+	}
+
+	// Connect to SQL Server
+	connectStart := time.Now()
+	db, err := a.open(ctx, dsn)
+	if err != nil {
+		return err
+	}
+
+	a.db = db
+	a.dsn = dsn
+	a.steps.Record("connect", connectStart)
+
+	// Create table
+	schemaStart := time.Now()
+	if err := a.createTable(ctx); err != nil {
+		return fmt.Errorf("failed to create table: %w", err)
+	}
+	a.steps.Record("schema_create", schemaStart)
+
+	return nil
+}
+
+// Cleanup performs cleanup operations
+func (a *Adapter) Cleanup(ctx context.Context) error {
+	// Drop the namespaced table so that shared endpoints are left clean
+	if a.db != nil && a.namespaced {
+		dropStart := time.Now()
+		if _, err := a.db.ExecContext(ctx, fmt.Sprintf("DROP TABLE IF EXISTS %s", a.table)); err != nil {
+			return fmt.Errorf("failed to drop table %s: %w", a.table, err)
+		}
+		a.steps.Record("schema_drop", dropStart)
+	}
+
+	// Close database connections
+	if err := a.clients.Close(); err != nil {
+		return fmt.Errorf("failed to close SQL Server client connections: %w", err)
+	}
+	if a.db != nil {
+		if err := a.db.Close(); err != nil {
+			return fmt.Errorf("failed to close SQL Server connection: %w", err)
+		}
+	}
+
+	// Stop and remove container if it was started
+	if a.container != nil {
+		fmt.Fprintf(a.out, "Cleaning up SQL Server container %s...\n", a.containerID)
+		stopStart := time.Now()
+		if err := a.container.Stop(ctx); err != nil {
+			return fmt.Errorf("failed to stop SQL Server container: %w", err)
+		}
+		a.steps.Record("container_stop", stopStart)
+	}
+
+	return nil
+}
+
+// Create inserts a new record
+func (a *Adapter) Create(ctx context.Context, key string, value map[string]interface{}) error {
+	// Convert value to JSON
+	jsonData, err := codec.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal value to JSON: %w", err)
+	}
+
+	// Prepare SQL statement
+	query := fmt.Sprintf("INSERT INTO %s (id, data) VALUES (@p1, @p2)", a.table)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, key, string(jsonData))
+	_, err = a.pool(ctx).ExecContext(ctx, query, key, string(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to insert record: %w", err)
+	}
+
+	return nil
+}
+
+// Read retrieves a record
+func (a *Adapter) Read(ctx context.Context, key string) (map[string]interface{}, error) {
+	// Prepare SQL statement
+	query := fmt.Sprintf("SELECT data FROM %s WHERE id = @p1", a.table)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, key)
+	var jsonData string
+	err := a.pool(ctx).QueryRowContext(ctx, query, key).Scan(&jsonData)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("record not found: %s", key)
+		}
+		return nil, fmt.Errorf("failed to read record: %w", err)
+	}
+
+	// Parse JSON data
+	var result map[string]interface{}
+	if err := codec.Unmarshal([]byte(jsonData), &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+	}
+
+	return result, nil
+}
+
+// ReadMulti retrieves several records with a single IN list query
+func (a *Adapter) ReadMulti(ctx context.Context, keys []string) ([]map[string]interface{}, error) {
+	query := fmt.Sprintf("SELECT data FROM %s WHERE id IN (%s)", a.table, placeholders(len(keys)))
+	args := dbutils.KeyArgs(keys)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, args...)
+	records, err := dbutils.QueryJSON(ctx, a.pool(ctx), query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read records: %w", err)
+	}
+
+	return records, nil
+}
+
+// Exists checks whether a record exists without fetching its data
+func (a *Adapter) Exists(ctx context.Context, key string) (bool, error) {
+	query := fmt.Sprintf("SELECT 1 FROM %s WHERE id = @p1", a.table)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, key)
+	var found int
+	err := a.pool(ctx).QueryRowContext(ctx, query, key).Scan(&found)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check record: %w", err)
+	}
+
+	return true, nil
+}
+
+// Update updates a record
+func (a *Adapter) Update(ctx context.Context, key string, value map[string]interface{}) error {
+	// Convert value to JSON
+	jsonData, err := codec.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal value to JSON: %w", err)
+	}
+
+	// Prepare SQL statement
+	query := fmt.Sprintf("UPDATE %s SET data = @p1 WHERE id = @p2", a.table)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, string(jsonData), key)
+	_, err = a.pool(ctx).ExecContext(ctx, query, string(jsonData), key)
+	if err != nil {
+		return fmt.Errorf("failed to update record: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a record
+func (a *Adapter) Delete(ctx context.Context, key string) error {
+	// Prepare SQL statement
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = @p1", a.table)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, key)
+	_, err := a.pool(ctx).ExecContext(ctx, query, key)
+	if err != nil {
+		return fmt.Errorf("failed to delete record: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteBatch removes several records with a single IN list statement
+func (a *Adapter) DeleteBatch(ctx context.Context, keys []string) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id IN (%s)", a.table, placeholders(len(keys)))
+	args := dbutils.KeyArgs(keys)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, args...)
+	if _, err := a.pool(ctx).ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to delete records: %w", err)
+	}
+
+	return nil
+}
+
+// Truncate removes every record with TRUNCATE TABLE, which deallocates the data pages
+func (a *Adapter) Truncate(ctx context.Context) error {
+	query := fmt.Sprintf("TRUNCATE TABLE %s", a.table)
+
+	a.queryLog.Log(a.Name(), query)
+	if _, err := a.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to truncate table: %w", err)
+	}
+
+	return nil
+}
+
+// SoftDelete marks a record as deleted by setting deleted_at with JSON_MODIFY
+func (a *Adapter) SoftDelete(ctx context.Context, key string) error {
+	query := fmt.Sprintf("UPDATE %s SET data = JSON_MODIFY(data, '$.deleted_at', @p2) WHERE id = @p1", a.table)
+	deletedAt := time.Now().UTC().Format(time.RFC3339Nano)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, key, deletedAt)
+	if _, err := a.pool(ctx).ExecContext(ctx, query, key, deletedAt); err != nil {
+		return fmt.Errorf("failed to soft delete record: %w", err)
+	}
+
+	return nil
+}
+
+// ReadLive retrieves a record unless its deleted_at field is set
+func (a *Adapter) ReadLive(ctx context.Context, key string) (map[string]interface{}, bool, error) {
+	query := fmt.Sprintf("SELECT data FROM %s WHERE id = @p1 AND JSON_VALUE(data, '$.deleted_at') IS NULL", a.table)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, key)
+	var jsonData string
+	err := a.pool(ctx).QueryRowContext(ctx, query, key).Scan(&jsonData)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to read record: %w", err)
+	}
+
+	// Parse JSON data
+	var result map[string]interface{}
+	if err := codec.Unmarshal([]byte(jsonData), &result); err != nil {
+		return nil, false, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+	}
+
+	return result, true, nil
+}
+
+// CountLive counts the records whose deleted_at field is not set
+func (a *Adapter) CountLive(ctx context.Context) (int, error) {
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE JSON_VALUE(data, '$.deleted_at') IS NULL", a.table)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query)
+	var count int
+	if err := a.pool(ctx).QueryRowContext(ctx, query).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count live records: %w", err)
+	}
+
+	return count, nil
+}
+
+// CreateVersions creates the empty history table of versioned writes, keyed
+// by the record key and version so that the latest version is an index seek
+func (a *Adapter) CreateVersions(ctx context.Context) error {
+	if err := a.DropVersions(ctx); err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("CREATE TABLE %s_versions (id NVARCHAR(255) NOT NULL, version INTEGER NOT NULL, data NVARCHAR(MAX), PRIMARY KEY (id, version))", a.table)
+	a.queryLog.Log(a.Name(), query)
+	if _, err := a.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to create history table: %w", err)
+	}
+
+	return nil
+}
+
+// AppendVersion inserts a new version row of a record
+func (a *Adapter) AppendVersion(ctx context.Context, key string, version int, value map[string]interface{}) error {
+	jsonData, err := codec.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal value to JSON: %w", err)
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s_versions (id, version, data) VALUES (@p1, @p2, @p3)", a.table)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, key, version)
+	if _, err := a.pool(ctx).ExecContext(ctx, query, key, version, string(jsonData)); err != nil {
+		return fmt.Errorf("failed to append version: %w", err)
+	}
+
+	return nil
+}
+
+// ReadLatest retrieves the version row of a record with the highest version
+func (a *Adapter) ReadLatest(ctx context.Context, key string) (map[string]interface{}, int, error) {
+	query := fmt.Sprintf("SELECT TOP 1 version, data FROM %s_versions WHERE id = @p1 ORDER BY version DESC", a.table)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, key)
+	var version int
+	var jsonData string
+	err := a.pool(ctx).QueryRowContext(ctx, query, key).Scan(&version, &jsonData)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, 0, fmt.Errorf("record not found: %s", key)
+		}
+		return nil, 0, fmt.Errorf("failed to read latest version: %w", err)
+	}
+
+	// Parse JSON data
+	var result map[string]interface{}
+	if err := codec.Unmarshal([]byte(jsonData), &result); err != nil {
+		return nil, 0, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+	}
+
+	return result, version, nil
+}
+
+// DropVersions drops the history table
+func (a *Adapter) DropVersions(ctx context.Context) error {
+	query := fmt.Sprintf("DROP TABLE IF EXISTS %s_versions", a.table)
+	a.queryLog.Log(a.Name(), query)
+	if _, err := a.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to drop history table: %w", err)
+	}
+
+	return nil
+}
+
+// CreateChildTable creates the child table of the relational workload, whose
+// rows reference their parent record with a foreign key cascading deletes
+func (a *Adapter) CreateChildTable(ctx context.Context) error {
+	if err := a.DropChildTable(ctx); err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("CREATE TABLE %s_children (parent_id NVARCHAR(255) NOT NULL, seq INTEGER NOT NULL, data NVARCHAR(MAX), PRIMARY KEY (parent_id, seq), FOREIGN KEY (parent_id) REFERENCES %s (id) ON DELETE CASCADE)", a.table, a.table)
+	a.queryLog.Log(a.Name(), query)
+	if _, err := a.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to create child table: %w", err)
+	}
+
+	return nil
+}
+
+// CreateWithChildren inserts a record and its child rows in a transaction
+func (a *Adapter) CreateWithChildren(ctx context.Context, key string, value map[string]interface{}, children []map[string]interface{}) error {
+	jsonData, err := codec.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal value to JSON: %w", err)
+	}
+	query := fmt.Sprintf("INSERT INTO %s (id, data) VALUES (@p1, @p2)", a.table)
+	values := []interface{}{key, string(jsonData)}
+	childQuery := fmt.Sprintf("INSERT INTO %s_children (parent_id, seq, data) VALUES (@p1, @p2, @p3)", a.table)
+
+	// Execute queries
+	a.queryLog.Log(a.Name(), query, values...)
+	a.queryLog.Log(a.Name(), childQuery, key, len(children))
+	if err := dbutils.InsertWithChildren(ctx, a.pool(ctx), query, values, childQuery, key, children); err != nil {
+		return fmt.Errorf("failed to insert record: %w", err)
+	}
+
+	return nil
+}
+
+// ReadWithChildren retrieves a record left joined with its child rows
+func (a *Adapter) ReadWithChildren(ctx context.Context, key string) (map[string]interface{}, []map[string]interface{}, error) {
+	query := fmt.Sprintf("SELECT p.data, c.data FROM %s p LEFT JOIN %s_children c ON c.parent_id = p.id WHERE p.id = @p1 ORDER BY c.seq", a.table, a.table)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, key)
+	parent, children, err := dbutils.QueryWithChildren(ctx, a.pool(ctx), query, key)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, fmt.Errorf("record not found: %s", key)
+		}
+		return nil, nil, fmt.Errorf("failed to read record: %w", err)
+	}
+
+	return parent, children, nil
+}
+
+// DropChildTable drops the child table
+func (a *Adapter) DropChildTable(ctx context.Context) error {
+	query := fmt.Sprintf("DROP TABLE IF EXISTS %s_children", a.table)
+	a.queryLog.Log(a.Name(), query)
+	if _, err := a.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to drop child table: %w", err)
+	}
+
+	return nil
+}
+
+// Scan performs a scan operation. SQL Server has no LIMIT, so a limit alone
+// becomes TOP and a limit with a start becomes OFFSET ... FETCH, which needs
+// an ORDER BY on the primary key.
+func (a *Adapter) Scan(ctx context.Context, scanConfig config.ScanConfig) (int, error) {
+	// Join scans query the child table of the relational workload
+	if fanout, ok := scanConfig.Join(); ok {
+		return a.join(ctx, fanout, scanConfig)
+	}
+
+	var columns string
+
+	// Build query based on projection type
+	switch scanConfig.Projection {
+	case "ID", "COUNT":
+		columns = "id"
+	case "FULL":
+		columns = "*"
+	default:
+		return 0, fmt.Errorf("%w projection type: %s", benchmark.ErrUnsupported, scanConfig.Projection)
+	}
+
+	// Add TOP or OFFSET-FETCH if specified
+	var query string
+	switch {
+	case scanConfig.Limit > 0 && scanConfig.Start > 0:
+		query = fmt.Sprintf("SELECT %s FROM %s ORDER BY id OFFSET %d ROWS FETCH NEXT %d ROWS ONLY",
+			columns, a.table, scanConfig.Start, scanConfig.Limit)
+	case scanConfig.Limit > 0:
+		query = fmt.Sprintf("SELECT TOP (%d) %s FROM %s", scanConfig.Limit, columns, a.table)
+	default:
+		query = fmt.Sprintf("SELECT %s FROM %s", columns, a.table)
+	}
+
+	// Execute query
+	if scanConfig.Projection == "COUNT" {
+		// Count the rows of the window on the server
+		query = fmt.Sprintf("SELECT COUNT(*) FROM (%s) AS scan", query)
+
+		a.queryLog.Log(a.Name(), query)
+		var count int
+		err := a.pool(ctx).QueryRowContext(ctx, query).Scan(&count)
+		if err != nil {
+			return 0, fmt.Errorf("failed to execute count scan: %w", err)
+		}
+		return count, nil
+	}
+
+	// For ID and FULL projections, execute query and count rows
+	a.queryLog.Log(a.Name(), query)
+	count, err := dbutils.CountRows(ctx, a.pool(ctx), query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute scan: %w", err)
+	}
+
+	return count, nil
+}
+
+// join runs a parent-child join scan, joining every parent row of the scan
+// window with at most fanout of its child rows and fetching their data
+func (a *Adapter) join(ctx context.Context, fanout int, scanConfig config.ScanConfig) (int, error) {
+	// Restrict the parent rows with TOP or OFFSET-FETCH if specified
+	parents := a.table
+	switch {
+	case scanConfig.Limit > 0 && scanConfig.Start > 0:
+		parents = fmt.Sprintf("(SELECT id FROM %s ORDER BY id OFFSET %d ROWS FETCH NEXT %d ROWS ONLY)", a.table, scanConfig.Start, scanConfig.Limit)
+	case scanConfig.Limit > 0:
+		parents = fmt.Sprintf("(SELECT TOP (%d) id FROM %s)", scanConfig.Limit, a.table)
+	}
+
+	query := fmt.Sprintf("SELECT p.id, c.data FROM %s p JOIN %s_children c ON c.parent_id = p.id WHERE c.seq < %d", parents, a.table, fanout)
+
+	a.queryLog.Log(a.Name(), query)
+	count, err := dbutils.CountRows(ctx, a.pool(ctx), query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute join scan: %w", err)
+	}
+
+	return count, nil
+}
+
+// Name returns the adapter name
+func (a *Adapter) Name() string {
+	return "mssql"
+}
+
+// ClassifyError maps SQL Server error numbers to error classes
+func (a *Adapter) ClassifyError(err error) benchmark.ErrorClass {
+	var mssqlErr mssqldriver.Error
+	if !errors.As(err, &mssqlErr) {
+		return ""
+	}
+
+	switch mssqlErr.Number {
+	case 1222:
+		// Lock request timeout
+		return benchmark.ErrorClassTimeout
+	case 515, 547, 2601, 2627:
+		// NULL, foreign key, unique index and primary key violations
+		return benchmark.ErrorClassConstraint
+	case 1205, 3960:
+		// Deadlock victim and snapshot isolation update conflict
+		return benchmark.ErrorClassConflict
+	default:
+		return benchmark.ErrorClassUnknown
+	}
+}
+
+// DropNamespace removes the tables of a namespaced run left on the endpoint
+func (a *Adapter) DropNamespace(ctx context.Context, namespace string) ([]string, error) {
+	if a.endpoint == "" {
+		return nil, fmt.Errorf("an endpoint is required to clean up namespaced tables")
+	}
+
+	dsn, err := a.endpointDSN()
+	if err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("sqlserver", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to SQL Server: %w", err)
+	}
+	defer db.Close()
+
+	// Underscores are wildcards in LIKE patterns, and are escaped with brackets
+	return dbutils.DropTables(ctx, db, fmt.Sprintf("%s_%s", tableName, namespace),
+		"SELECT name FROM sys.tables WHERE name LIKE @p1",
+		tableName+`[_]%`)
+}
+
+// SetSteps sets the recorder receiving the durations of the provisioning steps
+func (a *Adapter) SetSteps(steps *dbutils.Steps) {
+	a.steps = steps
+}
+
+// SetOutput sets the writer receiving the container messages of the adapter
+func (a *Adapter) SetOutput(w io.Writer) {
+	a.out = w
+}
+
+// SetQueryLog sets the log receiving every statement executed by the adapter
+func (a *Adapter) SetQueryLog(log *dbutils.QueryLog) {
+	a.queryLog = log
+}
+
+// WarmPool establishes the connections of the pool before the measured
+// phases, or those of the pools of the clients when they are connected
+// separately
+func (a *Adapter) WarmPool(ctx context.Context, connections int) error {
+	if a.clients != nil {
+		return a.clients.Warm(ctx)
+	}
+	return dbutils.WarmSQLPool(ctx, a.db, connections)
+}
+
+// ConnectClients opens a connection pool for every client
+func (a *Adapter) ConnectClients(ctx context.Context, clients, connections int) error {
+	pools, err := dbutils.OpenClientPools(ctx, clients, connections, func(ctx context.Context) (*sql.DB, error) {
+		return a.open(ctx, a.dsn)
+	})
+	if err != nil {
+		return err
+	}
+	a.clients = pools
+	return nil
+}
+
+// ConnectionStats returns connection churn and pool statistics
+func (a *Adapter) ConnectionStats() map[string]float64 {
+	metrics := a.tracker.Metrics(a.db)
+	a.clients.AddMetrics(metrics)
+	return metrics
+}
+
+// DataDir returns the directory of the container holding the data
+func (a *Adapter) DataDir() string {
+	return dataDir
+}
+
+// Container returns the managed Docker container, if any
+func (a *Adapter) Container() *docker.Container {
+	return a.container
+}
+
+// open opens a connection pool to the server, tracking the connections opened
+// by the driver
+func (a *Adapter) open(ctx context.Context, dsn string) (*sql.DB, error) {
+	connector, err := mssqldriver.NewConnector(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SQL Server endpoint: %w", err)
+	}
+	connector.Dialer = a.tracker
+	db := sql.OpenDB(connector)
+
+	// Set connection pool parameters
+	if err := a.tuning.ApplyPool(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	// Test connection
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping SQL Server: %w", err)
+	}
+	return db, nil
+}
+
+// pool returns the connection pool of the client issuing an operation
+func (a *Adapter) pool(ctx context.Context) *sql.DB {
+	return a.clients.Pool(ctx, a.db)
+}
+
+// createTable creates the benchmark table, storing the documents as JSON text
+func (a *Adapter) createTable(ctx context.Context) error {
+	// SQL Server has no CREATE TABLE IF NOT EXISTS
+	query := fmt.Sprintf(`
+		IF OBJECT_ID(N'%s', N'U') IS NULL
+		CREATE TABLE %s (
+			id NVARCHAR(255) PRIMARY KEY,
+			data NVARCHAR(MAX) CHECK (ISJSON(data) = 1)
+		)
+	`, a.table, a.table)
+
+	_, err := a.db.ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to create table: %w", err)
+	}
+
+	return nil
+}
+
+// startContainer starts a SQL Server Docker container
+func (a *Adapter) startContainer(ctx context.Context) (*docker.Container, error) {
+	// Generate unique container name with timestamp
+	containerName := fmt.Sprintf("%s-%d", containerNamePrefix, time.Now().Unix())
+
+	// Configure container
+	ports := map[string]string{
+		"1433/tcp": defaultPort,
+	}
+
+	env := []string{
+		"ACCEPT_EULA=Y",
+		"MSSQL_PID=Developer",
+		fmt.Sprintf("MSSQL_SA_PASSWORD=%s", defaultPassword),
+	}
+
+	fmt.Fprintf(a.out, "Starting SQL Server container '%s' with image '%s'...\n", containerName, a.image)
+
+	// Create and start container with the common utility
+	container, err := dbutils.CreateContainerWithRetry(ctx, a.out, containerName, a.image, ports, a.privileged, env, a.steps)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start SQL Server container: %w", err)
+	}
+
+	fmt.Fprintf(a.out, "SQL Server container started, waiting for it to be ready...\n")
+
+	// Wait for SQL Server to accept logins, then create the benchmark database
+	checkFunc := func(ctx context.Context) error {
+		db, err := sql.Open("sqlserver", localDSN("master"))
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+
+		if err := db.PingContext(ctx); err != nil {
+			return err
+		}
+
+		_, err = db.ExecContext(ctx, fmt.Sprintf("IF DB_ID(N'%s') IS NULL CREATE DATABASE %s", defaultDatabase, defaultDatabase))
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(a.out, "SQL Server is ready!\n")
+		return nil
+	}
+
+	waitStart := time.Now()
+	if err := container.WaitForHealthy(ctx, 120*time.Second, checkFunc); err != nil {
+		// Clean up container if health check fails
+		_ = container.Stop(ctx)
+		return nil, fmt.Errorf("SQL Server health check failed: %w", err)
+	}
+	a.steps.Record("readiness_wait", waitStart)
+
+	return container, nil
+}
+
+// endpointDSN returns the connection string of the endpoint, a sqlserver://
+// URL, an ADO connection string or a bare host[:port], completed by the
+// connection options
+func (a *Adapter) endpointDSN() (string, error) {
+	if a.conn.IsZero() {
+		return a.endpoint, nil
+	}
+	if a.conn.TLSCert != "" {
+		return "", fmt.Errorf("SQL Server does not support TLS client certificates")
+	}
+	endpoint := a.endpoint
+	if !strings.HasPrefix(endpoint, "sqlserver://") && !strings.Contains(endpoint, "=") {
+		endpoint = "sqlserver://" + endpoint
+	}
+
+	// Settings other than the credentials and port, which URLs carry apart
+	var params [][2]string
+	if a.conn.Database != "" {
+		params = append(params, [2]string{"database", a.conn.Database})
+	}
+	if a.conn.TLS {
+		params = append(params, [2]string{"encrypt", "true"})
+		if a.conn.TLSSkipVerify {
+			params = append(params, [2]string{"trustservercertificate", "true"})
+		} else if a.conn.TLSCA != "" {
+			params = append(params, [2]string{"certificate", a.conn.TLSCA})
+		}
+	}
+
+	// ADO connection strings take the last value of a key, and cannot quote
+	// the separator
+	if !strings.HasPrefix(endpoint, "sqlserver://") {
+		params = append(params,
+			[2]string{"user id", a.conn.Username},
+			[2]string{"password", a.conn.Password})
+		if a.conn.Port > 0 {
+			params = append(params, [2]string{"port", fmt.Sprint(a.conn.Port)})
+		}
+		for _, param := range params {
+			if param[1] == "" {
+				continue
+			}
+			if strings.Contains(param[1], ";") {
+				return "", fmt.Errorf("the %s of an ADO connection string cannot contain a semicolon, use a sqlserver:// endpoint", param[0])
+			}
+			endpoint += fmt.Sprintf(";%s=%s", param[0], param[1])
+		}
+		return endpoint, nil
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid SQL Server endpoint: %w", err)
+	}
+	if a.conn.Username != "" || a.conn.Password != "" {
+		user := u.User.Username()
+		password, _ := u.User.Password()
+		if a.conn.Username != "" {
+			user = a.conn.Username
+		}
+		if a.conn.Password != "" {
+			password = a.conn.Password
+		}
+		u.User = url.UserPassword(user, password)
+	}
+	if a.conn.Port > 0 {
+		u.Host = a.conn.HostPort(u.Host, "")
+	}
+	query := u.Query()
+	for _, param := range params {
+		query.Set(param[0], param[1])
+	}
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// localDSN returns the connection URL of the managed container for a database
+func localDSN(database string) string {
+	u := url.URL{
+		Scheme:   "sqlserver",
+		User:     url.UserPassword(defaultUser, defaultPassword),
+		Host:     dbutils.HostAddress(defaultPort),
+		RawQuery: url.Values{"database": {database}}.Encode(),
+	}
+	return u.String()
+}
+
+// placeholders returns a comma separated list of n numbered query parameters,
+// as the sqlserver driver does not accept "?"
+func placeholders(n int) string {
+	params := make([]string, n)
+	for i := range params {
+		params[i] = fmt.Sprintf("@p%d", i+1)
+	}
+	return strings.Join(params, ", ")
+}
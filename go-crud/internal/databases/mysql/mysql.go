@@ -3,15 +3,20 @@ package mysql
 import (
 	"context"
 	"database/sql"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"net"
+	"os"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	_ "github.com/go-sql-driver/mysql"
 	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+	"github.com/surrealdb/go-crud-bench/internal/codec"
 	"github.com/surrealdb/go-crud-bench/internal/config"
 	"github.com/surrealdb/go-crud-bench/internal/dbutils"
 	"github.com/surrealdb/go-crud-bench/internal/docker"
@@ -23,12 +28,21 @@ const (
 	
 	// Default MySQL port
 	defaultPort = "3306"
-	
+
+	// Directory of the container holding the MySQL data
+	dataDir = "/var/lib/mysql"
+
 	// Default MySQL credentials
 	defaultUser     = "root"
 	defaultPassword = "mysql"
 	defaultDatabase = "bench"
-	
+
+	// Name the TLS configuration of the connection options is registered with
+	tlsConfigName = "crud-bench"
+
+	// Prefix of the names the dialers tracking the connections are registered with
+	dialNetworkPrefix = "crud-bench-tcp"
+
 	// Table name
 	tableName = "bench_table"
 	
@@ -36,6 +50,18 @@ const (
 	containerNamePrefix = "crud-bench-mysql"
 )
 
+// dialNetworks counts the dialers registered, so that every adapter tracks
+// its connections through a network name of its own
+var dialNetworks atomic.Int64
+
+// preset contains the default tuning settings for MySQL
+var preset = map[string]string{
+	"max_open_conns":        "100",
+	"max_idle_conns":        "20",
+	"conn_max_lifetime":     "1h",
+	"transaction_isolation": "REPEATABLE-READ",
+}
+
 // setupLogSilencer disables noisy MySQL driver logs during container startup
 func setupLogSilencer() {
 	// Create a silent logger that discards all output
@@ -49,25 +75,62 @@ type Adapter struct {
 	db         *sql.DB
 	container  *docker.Container
 	endpoint   string
+	conn       config.ConnectionOptions
 	image      string
 	privileged bool
 	containerID string
+	tracker    *dbutils.ConnTracker
+	clients    *dbutils.ClientPools
+	dsn        string
+	database   string
+	table      string
+	namespaced bool
+	tuning     dbutils.Tuning
+	queryLog   *dbutils.QueryLog
+	steps      *dbutils.Steps
+	out        io.Writer
 }
 
 // NewAdapter creates a new MySQL adapter
-func NewAdapter(endpoint, image string, privileged bool) *Adapter {
+func NewAdapter(endpoint, image string, privileged bool, conn config.ConnectionOptions) *Adapter {
 	// Silence MySQL driver logs during container startup
 	setupLogSilencer()
 	
 	if image == "" {
 		image = defaultImage
 	}
+	database := defaultDatabase
+	if conn.Database != "" {
+		database = conn.Database
+	}
 	
 	return &Adapter{
 		endpoint:   endpoint,
+		conn:       conn,
+		database:   database,
 		image:      image,
 		privileged: privileged,
+		tracker:    dbutils.NewConnTracker(),
+		out:        os.Stdout,
+		table:      tableName,
+		tuning:     preset,
+	}
+}
+
+// SetNamespace isolates the benchmark in its own table on a shared endpoint
+func (a *Adapter) SetNamespace(namespace string) {
+	a.table = fmt.Sprintf("%s_%s", tableName, namespace)
+	a.namespaced = true
+}
+
+// Tune applies tuning overrides on top of the MySQL preset
+func (a *Adapter) Tune(overrides map[string]string) (map[string]string, error) {
+	tuning, err := dbutils.NewTuning(preset, overrides)
+	if err != nil {
+		return nil, err
 	}
+	a.tuning = tuning
+	return tuning, nil
 }
 
 // Initialize sets up the MySQL database
@@ -83,37 +146,55 @@ func (a *Adapter) Initialize(ctx context.Context) error {
 		
 		a.container = container
 		a.containerID = container.ID
-		dsn = fmt.Sprintf("%s:%s@tcp(127.0.0.1:%s)/", defaultUser, defaultPassword, defaultPort)
+		dsn = fmt.Sprintf("%s:%s@tcp(%s)/", defaultUser, defaultPassword, dbutils.HostAddress(defaultPort))
 	} else {
-		// Use provided endpoint
-		dsn = a.endpoint
+		// Use provided endpoint, completed by the connection options
+		var err error
+		if dsn, err = a.endpointDSN(a.endpoint); err != nil {
+			return err
+		}
 	}
 	
-	// Connect to MySQL server
-	db, err := sql.Open("mysql", dsn)
+	// Apply the session isolation level to every pooled connection
+	dsnConfig, err := mysqldriver.ParseDSN(dsn)
 	if err != nil {
-		return fmt.Errorf("failed to connect to MySQL: %w", err)
+		return fmt.Errorf("invalid MySQL endpoint: %w", err)
 	}
+	if dsnConfig.Params == nil {
+		dsnConfig.Params = map[string]string{}
+	}
+	dsnConfig.Params["transaction_isolation"] = fmt.Sprintf("'%s'", a.tuning["transaction_isolation"])
+
+	// Track connections opened by the driver through a network of its own, as
+	// the dialers registered with the driver are shared by the whole process
+	if dsnConfig.Net == "tcp" {
+		network := fmt.Sprintf("%s-%d", dialNetworkPrefix, dialNetworks.Add(1))
+		mysqldriver.RegisterDialContext(network, func(ctx context.Context, addr string) (net.Conn, error) {
+			return a.tracker.DialContext(ctx, "tcp", addr)
+		})
+		dsnConfig.Net = network
+	}
+	dsn = dsnConfig.FormatDSN()
 	
-	// Set connection pool parameters
-	db.SetMaxOpenConns(100)
-	db.SetMaxIdleConns(20)
-	db.SetConnMaxLifetime(time.Hour)
-	
-	// Test connection
-	if err := db.PingContext(ctx); err != nil {
-		return fmt.Errorf("failed to ping MySQL: %w", err)
+	// Connect to MySQL server
+	connectStart := time.Now()
+	db, err := a.open(ctx, dsn)
+	if err != nil {
+		return err
 	}
 	
 	a.db = db
-	
+	a.dsn = dsn
+	a.steps.Record("connect", connectStart)
+
 	// Create database if it doesn't exist
-	if _, err := db.ExecContext(ctx, fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s", defaultDatabase)); err != nil {
+	schemaStart := time.Now()
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s", a.database)); err != nil {
 		return fmt.Errorf("failed to create database: %w", err)
 	}
 	
 	// Use the database
-	if _, err := db.ExecContext(ctx, fmt.Sprintf("USE %s", defaultDatabase)); err != nil {
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("USE %s", a.database)); err != nil {
 		return fmt.Errorf("failed to use database: %w", err)
 	}
 	
@@ -121,13 +202,26 @@ func (a *Adapter) Initialize(ctx context.Context) error {
 	if err := a.createTable(ctx); err != nil {
 		return fmt.Errorf("failed to create table: %w", err)
 	}
-	
+	a.steps.Record("schema_create", schemaStart)
+
 	return nil
 }
 
 // Cleanup performs cleanup operations
 func (a *Adapter) Cleanup(ctx context.Context) error {
-	// Close database connection
+	// Drop the namespaced table so that shared endpoints are left clean
+	if a.db != nil && a.namespaced {
+		dropStart := time.Now()
+		if _, err := a.db.ExecContext(ctx, fmt.Sprintf("DROP TABLE IF EXISTS %s", a.table)); err != nil {
+			return fmt.Errorf("failed to drop table %s: %w", a.table, err)
+		}
+		a.steps.Record("schema_drop", dropStart)
+	}
+
+	// Close database connections
+	if err := a.clients.Close(); err != nil {
+		return fmt.Errorf("failed to close MySQL client connections: %w", err)
+	}
 	if a.db != nil {
 		if err := a.db.Close(); err != nil {
 			return fmt.Errorf("failed to close MySQL connection: %w", err)
@@ -136,23 +230,43 @@ func (a *Adapter) Cleanup(ctx context.Context) error {
 	
 	// Stop and remove container if it was started
 	if a.container != nil {
-		fmt.Printf("Cleaning up MySQL container %s...\n", a.containerID)
+		fmt.Fprintf(a.out, "Cleaning up MySQL container %s...\n", a.containerID)
+		stopStart := time.Now()
 		if err := a.container.Stop(ctx); err != nil {
 			return fmt.Errorf("failed to stop MySQL container: %w", err)
 		}
+		a.steps.Record("container_stop", stopStart)
 	}
-	
+
 	return nil
 }
 
 // Create inserts a new record
 func (a *Adapter) Create(ctx context.Context, key string, value map[string]interface{}) error {
-	// Convert value to JSON
-	jsonData, err := json.Marshal(value)
+	query, values, err := a.insertQuery(key, value)
 	if err != nil {
-		return fmt.Errorf("failed to marshal value to JSON: %w", err)
+		return err
+	}
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, values...)
+	_, err = a.pool(ctx).ExecContext(ctx, query, values...)
+	if err != nil {
+		return fmt.Errorf("failed to insert record: %w", err)
 	}
 	
+	return nil
+}
+
+// insertQuery builds the statement inserting a record, copying the known
+// first-level fields into their own columns
+func (a *Adapter) insertQuery(key string, value map[string]interface{}) (string, []interface{}, error) {
+	// Convert value to JSON
+	jsonData, err := codec.Marshal(value)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to marshal value to JSON: %w", err)
+	}
+
 	// Extract first-level fields for columns
 	columns := []string{"id"}
 	placeholders := []string{"?"}
@@ -179,28 +293,23 @@ func (a *Adapter) Create(ctx context.Context, key string, value map[string]inter
 	// Prepare SQL statement
 	query := fmt.Sprintf(
 		"INSERT INTO %s (%s) VALUES (%s)",
-		tableName,
+		a.table,
 		strings.Join(columns, ", "),
 		strings.Join(placeholders, ", "),
 	)
-	
-	// Execute query
-	_, err = a.db.ExecContext(ctx, query, values...)
-	if err != nil {
-		return fmt.Errorf("failed to insert record: %w", err)
-	}
-	
-	return nil
+
+	return query, values, nil
 }
 
 // Read retrieves a record
 func (a *Adapter) Read(ctx context.Context, key string) (map[string]interface{}, error) {
 	// Prepare SQL statement
-	query := fmt.Sprintf("SELECT data FROM %s WHERE id = ?", tableName)
-	
+	query := fmt.Sprintf("SELECT data FROM %s WHERE id = ?", a.table)
+
 	// Execute query
+	a.queryLog.Log(a.Name(), query, key)
 	var jsonData string
-	err := a.db.QueryRowContext(ctx, query, key).Scan(&jsonData)
+	err := a.pool(ctx).QueryRowContext(ctx, query, key).Scan(&jsonData)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("record not found: %s", key)
@@ -210,17 +319,50 @@ func (a *Adapter) Read(ctx context.Context, key string) (map[string]interface{},
 	
 	// Parse JSON data
 	var result map[string]interface{}
-	if err := json.Unmarshal([]byte(jsonData), &result); err != nil {
+	if err := codec.Unmarshal([]byte(jsonData), &result); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal JSON data: %w", err)
 	}
 	
 	return result, nil
 }
 
+// ReadMulti retrieves several records with a single IN list query
+func (a *Adapter) ReadMulti(ctx context.Context, keys []string) ([]map[string]interface{}, error) {
+	query := fmt.Sprintf("SELECT data FROM %s WHERE id IN (%s)", a.table, dbutils.Placeholders(len(keys), false))
+	args := dbutils.KeyArgs(keys)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, args...)
+	records, err := dbutils.QueryJSON(ctx, a.pool(ctx), query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read records: %w", err)
+	}
+
+	return records, nil
+}
+
+// Exists checks whether a record exists without fetching its data
+func (a *Adapter) Exists(ctx context.Context, key string) (bool, error) {
+	query := fmt.Sprintf("SELECT 1 FROM %s WHERE id = ?", a.table)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, key)
+	var found int
+	err := a.pool(ctx).QueryRowContext(ctx, query, key).Scan(&found)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check record: %w", err)
+	}
+
+	return true, nil
+}
+
 // Update updates a record
 func (a *Adapter) Update(ctx context.Context, key string, value map[string]interface{}) error {
 	// Convert value to JSON
-	jsonData, err := json.Marshal(value)
+	jsonData, err := codec.Marshal(value)
 	if err != nil {
 		return fmt.Errorf("failed to marshal value to JSON: %w", err)
 	}
@@ -250,12 +392,13 @@ func (a *Adapter) Update(ctx context.Context, key string, value map[string]inter
 	// Prepare SQL statement
 	query := fmt.Sprintf(
 		"UPDATE %s SET %s WHERE id = ?",
-		tableName,
+		a.table,
 		strings.Join(setClauses, ", "),
 	)
 	
 	// Execute query
-	_, err = a.db.ExecContext(ctx, query, values...)
+	a.queryLog.Log(a.Name(), query, values...)
+	_, err = a.pool(ctx).ExecContext(ctx, query, values...)
 	if err != nil {
 		return fmt.Errorf("failed to update record: %w", err)
 	}
@@ -263,13 +406,93 @@ func (a *Adapter) Update(ctx context.Context, key string, value map[string]inter
 	return nil
 }
 
+// CompareAndSet replaces the data of a record only if its stored version still
+// matches, reporting whether the update was applied
+func (a *Adapter) CompareAndSet(ctx context.Context, key string, expected int64, value map[string]interface{}) (bool, error) {
+	// Convert value to JSON
+	jsonData, err := codec.Marshal(value)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal value to JSON: %w", err)
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET data = ? WHERE id = ? AND COALESCE(CAST(JSON_EXTRACT(data, '$._version') AS SIGNED), 0) = ?", a.table)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, string(jsonData), key, expected)
+	result, err := a.pool(ctx).ExecContext(ctx, query, string(jsonData), key, expected)
+	if err != nil {
+		return false, fmt.Errorf("failed to update record: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to update record: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// CreateCounters creates the counter table and resets the given counters to zero
+func (a *Adapter) CreateCounters(ctx context.Context, counters []string) error {
+	table := a.table + "_counters"
+	query := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (id VARCHAR(255) PRIMARY KEY, n BIGINT NOT NULL)", table)
+	a.queryLog.Log(a.Name(), query)
+	if _, err := a.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to create counter table: %w", err)
+	}
+
+	query = fmt.Sprintf("REPLACE INTO %s (id, n) VALUES (?, 0)", table)
+	for _, counter := range counters {
+		a.queryLog.Log(a.Name(), query, counter)
+		if _, err := a.db.ExecContext(ctx, query, counter); err != nil {
+			return fmt.Errorf("failed to reset counter %s: %w", counter, err)
+		}
+	}
+	
+	return nil
+}
+
+// Increment atomically adds one to a counter
+func (a *Adapter) Increment(ctx context.Context, counter string) error {
+	query := fmt.Sprintf("UPDATE %s_counters SET n = n + 1 WHERE id = ?", a.table)
+	a.queryLog.Log(a.Name(), query, counter)
+	if _, err := a.pool(ctx).ExecContext(ctx, query, counter); err != nil {
+		return fmt.Errorf("failed to increment counter: %w", err)
+	}
+
+	return nil
+}
+
+// Counter returns the current value of a counter
+func (a *Adapter) Counter(ctx context.Context, counter string) (int64, error) {
+	query := fmt.Sprintf("SELECT n FROM %s_counters WHERE id = ?", a.table)
+	a.queryLog.Log(a.Name(), query, counter)
+	var n int64
+	if err := a.db.QueryRowContext(ctx, query, counter).Scan(&n); err != nil {
+		return 0, fmt.Errorf("failed to read counter: %w", err)
+	}
+
+	return n, nil
+}
+
+// DropCounters drops the counter table
+func (a *Adapter) DropCounters(ctx context.Context, counters []string) error {
+	query := fmt.Sprintf("DROP TABLE IF EXISTS %s_counters", a.table)
+	a.queryLog.Log(a.Name(), query)
+	if _, err := a.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to drop counter table: %w", err)
+	}
+
+	return nil
+}
+
 // Delete removes a record
 func (a *Adapter) Delete(ctx context.Context, key string) error {
 	// Prepare SQL statement
-	query := fmt.Sprintf("DELETE FROM %s WHERE id = ?", tableName)
-	
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = ?", a.table)
+
 	// Execute query
-	_, err := a.db.ExecContext(ctx, query, key)
+	a.queryLog.Log(a.Name(), query, key)
+	_, err := a.pool(ctx).ExecContext(ctx, query, key)
 	if err != nil {
 		return fmt.Errorf("failed to delete record: %w", err)
 	}
@@ -277,8 +500,229 @@ func (a *Adapter) Delete(ctx context.Context, key string) error {
 	return nil
 }
 
+// DeleteBatch removes several records with a single IN list statement
+func (a *Adapter) DeleteBatch(ctx context.Context, keys []string) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id IN (%s)", a.table, dbutils.Placeholders(len(keys), false))
+	args := dbutils.KeyArgs(keys)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, args...)
+	if _, err := a.pool(ctx).ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to delete records: %w", err)
+	}
+
+	return nil
+}
+
+// Truncate removes every record with TRUNCATE TABLE, which recreates the table
+func (a *Adapter) Truncate(ctx context.Context) error {
+	query := fmt.Sprintf("TRUNCATE TABLE %s", a.table)
+
+	a.queryLog.Log(a.Name(), query)
+	if _, err := a.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to truncate table: %w", err)
+	}
+
+	return nil
+}
+
+// SoftDelete marks a record as deleted by setting deleted_at with JSON_SET
+func (a *Adapter) SoftDelete(ctx context.Context, key string) error {
+	query := fmt.Sprintf("UPDATE %s SET data = JSON_SET(data, '$.deleted_at', ?) WHERE id = ?", a.table)
+	deletedAt := time.Now().UTC().Format(time.RFC3339Nano)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, deletedAt, key)
+	if _, err := a.pool(ctx).ExecContext(ctx, query, deletedAt, key); err != nil {
+		return fmt.Errorf("failed to soft delete record: %w", err)
+	}
+
+	return nil
+}
+
+// ReadLive retrieves a record unless its deleted_at field is set
+func (a *Adapter) ReadLive(ctx context.Context, key string) (map[string]interface{}, bool, error) {
+	query := fmt.Sprintf("SELECT data FROM %s WHERE id = ? AND JSON_EXTRACT(data, '$.deleted_at') IS NULL", a.table)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, key)
+	var jsonData string
+	err := a.pool(ctx).QueryRowContext(ctx, query, key).Scan(&jsonData)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to read record: %w", err)
+	}
+
+	// Parse JSON data
+	var result map[string]interface{}
+	if err := codec.Unmarshal([]byte(jsonData), &result); err != nil {
+		return nil, false, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+	}
+
+	return result, true, nil
+}
+
+// CountLive counts the records whose deleted_at field is not set
+func (a *Adapter) CountLive(ctx context.Context) (int, error) {
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE JSON_EXTRACT(data, '$.deleted_at') IS NULL", a.table)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query)
+	var count int
+	if err := a.pool(ctx).QueryRowContext(ctx, query).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count live records: %w", err)
+	}
+
+	return count, nil
+}
+
+// CreateVersions creates the empty history table of versioned writes, keyed
+// by the record key and version so that the latest version is an index seek
+func (a *Adapter) CreateVersions(ctx context.Context) error {
+	if err := a.DropVersions(ctx); err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("CREATE TABLE %s_versions (id VARCHAR(255) NOT NULL, version INTEGER NOT NULL, data JSON, PRIMARY KEY (id, version))", a.table)
+	a.queryLog.Log(a.Name(), query)
+	if _, err := a.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to create history table: %w", err)
+	}
+
+	return nil
+}
+
+// AppendVersion inserts a new version row of a record
+func (a *Adapter) AppendVersion(ctx context.Context, key string, version int, value map[string]interface{}) error {
+	jsonData, err := codec.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal value to JSON: %w", err)
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s_versions (id, version, data) VALUES (?, ?, ?)", a.table)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, key, version)
+	if _, err := a.pool(ctx).ExecContext(ctx, query, key, version, string(jsonData)); err != nil {
+		return fmt.Errorf("failed to append version: %w", err)
+	}
+
+	return nil
+}
+
+// ReadLatest retrieves the version row of a record with the highest version
+func (a *Adapter) ReadLatest(ctx context.Context, key string) (map[string]interface{}, int, error) {
+	query := fmt.Sprintf("SELECT version, data FROM %s_versions WHERE id = ? ORDER BY version DESC LIMIT 1", a.table)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, key)
+	var version int
+	var jsonData string
+	err := a.pool(ctx).QueryRowContext(ctx, query, key).Scan(&version, &jsonData)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, 0, fmt.Errorf("record not found: %s", key)
+		}
+		return nil, 0, fmt.Errorf("failed to read latest version: %w", err)
+	}
+
+	// Parse JSON data
+	var result map[string]interface{}
+	if err := codec.Unmarshal([]byte(jsonData), &result); err != nil {
+		return nil, 0, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+	}
+
+	return result, version, nil
+}
+
+// DropVersions drops the history table
+func (a *Adapter) DropVersions(ctx context.Context) error {
+	query := fmt.Sprintf("DROP TABLE IF EXISTS %s_versions", a.table)
+	a.queryLog.Log(a.Name(), query)
+	if _, err := a.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to drop history table: %w", err)
+	}
+
+	return nil
+}
+
+// CreateChildTable creates the child table of the relational workload, whose
+// rows reference their parent record with a foreign key cascading deletes
+func (a *Adapter) CreateChildTable(ctx context.Context) error {
+	if err := a.DropChildTable(ctx); err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("CREATE TABLE %s_children (parent_id VARCHAR(255) NOT NULL, seq INTEGER NOT NULL, data JSON, PRIMARY KEY (parent_id, seq), FOREIGN KEY (parent_id) REFERENCES %s (id) ON DELETE CASCADE)", a.table, a.table)
+	a.queryLog.Log(a.Name(), query)
+	if _, err := a.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to create child table: %w", err)
+	}
+
+	return nil
+}
+
+// CreateWithChildren inserts a record and its child rows in a transaction
+func (a *Adapter) CreateWithChildren(ctx context.Context, key string, value map[string]interface{}, children []map[string]interface{}) error {
+	query, values, err := a.insertQuery(key, value)
+	if err != nil {
+		return err
+	}
+	childQuery := fmt.Sprintf("INSERT INTO %s_children (parent_id, seq, data) VALUES (?, ?, ?)", a.table)
+
+	// Execute queries
+	a.queryLog.Log(a.Name(), query, values...)
+	a.queryLog.Log(a.Name(), childQuery, key, len(children))
+	if err := dbutils.InsertWithChildren(ctx, a.pool(ctx), query, values, childQuery, key, children); err != nil {
+		return fmt.Errorf("failed to insert record: %w", err)
+	}
+
+	return nil
+}
+
+// ReadWithChildren retrieves a record left joined with its child rows
+func (a *Adapter) ReadWithChildren(ctx context.Context, key string) (map[string]interface{}, []map[string]interface{}, error) {
+	query := fmt.Sprintf("SELECT p.data, c.data FROM %s p LEFT JOIN %s_children c ON c.parent_id = p.id WHERE p.id = ? ORDER BY c.seq", a.table, a.table)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, key)
+	parent, children, err := dbutils.QueryWithChildren(ctx, a.pool(ctx), query, key)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, fmt.Errorf("record not found: %s", key)
+		}
+		return nil, nil, fmt.Errorf("failed to read record: %w", err)
+	}
+
+	return parent, children, nil
+}
+
+// DropChildTable drops the child table
+func (a *Adapter) DropChildTable(ctx context.Context) error {
+	query := fmt.Sprintf("DROP TABLE IF EXISTS %s_children", a.table)
+	a.queryLog.Log(a.Name(), query)
+	if _, err := a.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to drop child table: %w", err)
+	}
+
+	return nil
+}
+
 // Scan performs a scan operation
 func (a *Adapter) Scan(ctx context.Context, scanConfig config.ScanConfig) (int, error) {
+	// Full-text searches, JSON path and join scans have their own query
+	if field, term, ok := scanConfig.Search(); ok {
+		return a.search(ctx, field, term, scanConfig)
+	}
+	if path, value, ok := scanConfig.JSONPath(); ok {
+		return a.jsonPath(ctx, path, value, scanConfig)
+	}
+	if fanout, ok := scanConfig.Join(); ok {
+		return a.join(ctx, fanout, scanConfig)
+	}
+
 	var query string
 	var args []interface{}
 	var count int
@@ -286,13 +730,13 @@ func (a *Adapter) Scan(ctx context.Context, scanConfig config.ScanConfig) (int,
 	// Build query based on projection type
 	switch scanConfig.Projection {
 	case "ID":
-		query = fmt.Sprintf("SELECT id FROM %s", tableName)
+		query = fmt.Sprintf("SELECT id FROM %s", a.table)
 	case "FULL":
-		query = fmt.Sprintf("SELECT * FROM %s", tableName)
+		query = fmt.Sprintf("SELECT * FROM %s", a.table)
 	case "COUNT":
-		query = fmt.Sprintf("SELECT COUNT(*) FROM %s", tableName)
+		query = fmt.Sprintf("SELECT COUNT(*) FROM %s", a.table)
 	default:
-		return 0, fmt.Errorf("unsupported projection type: %s", scanConfig.Projection)
+		return 0, fmt.Errorf("%w projection type: %s", benchmark.ErrUnsupported, scanConfig.Projection)
 	}
 	
 	// Add LIMIT and OFFSET if specified
@@ -305,8 +749,9 @@ func (a *Adapter) Scan(ctx context.Context, scanConfig config.ScanConfig) (int,
 	}
 	
 	// Execute query
+	a.queryLog.Log(a.Name(), query, args...)
 	if scanConfig.Projection == "COUNT" {
-		err := a.db.QueryRowContext(ctx, query, args...).Scan(&count)
+		err := a.pool(ctx).QueryRowContext(ctx, query, args...).Scan(&count)
 		if err != nil {
 			return 0, fmt.Errorf("failed to execute count scan: %w", err)
 		}
@@ -314,7 +759,7 @@ func (a *Adapter) Scan(ctx context.Context, scanConfig config.ScanConfig) (int,
 	}
 	
 	// For ID and FULL projections, execute query and count rows
-	rows, err := a.db.QueryContext(ctx, query, args...)
+	rows, err := a.pool(ctx).QueryContext(ctx, query, args...)
 	if err != nil {
 		return 0, fmt.Errorf("failed to execute scan: %w", err)
 	}
@@ -332,11 +777,381 @@ func (a *Adapter) Scan(ctx context.Context, scanConfig config.ScanConfig) (int,
 	return count, nil
 }
 
+// join runs a parent-child join scan, joining every parent row of the scan
+// window with at most fanout of its child rows and fetching their data
+func (a *Adapter) join(ctx context.Context, fanout int, scanConfig config.ScanConfig) (int, error) {
+	// Restrict the parent rows with LIMIT and OFFSET if specified
+	parents := a.table
+	if scanConfig.Limit > 0 {
+		window := fmt.Sprintf(" LIMIT %d", scanConfig.Limit)
+		if scanConfig.Start > 0 {
+			window += fmt.Sprintf(" OFFSET %d", scanConfig.Start)
+		}
+		parents = fmt.Sprintf("(SELECT id FROM %s%s)", a.table, window)
+	}
+
+	query := fmt.Sprintf("SELECT p.id, c.data FROM %s p JOIN %s_children c ON c.parent_id = p.id WHERE c.seq < %d", parents, a.table, fanout)
+
+	a.queryLog.Log(a.Name(), query)
+	count, err := dbutils.CountRows(ctx, a.pool(ctx), query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute join scan: %w", err)
+	}
+
+	return count, nil
+}
+
+// search runs a full-text search scan against the FULLTEXT index built by
+// CreateSearchIndex
+func (a *Adapter) search(ctx context.Context, field, term string, scanConfig config.ScanConfig) (int, error) {
+	query := fmt.Sprintf("SELECT id FROM %s WHERE MATCH(%s) AGAINST (? IN NATURAL LANGUAGE MODE)", a.table, searchColumn(field))
+	if scanConfig.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", scanConfig.Limit)
+
+		if scanConfig.Start > 0 {
+			query += fmt.Sprintf(" OFFSET %d", scanConfig.Start)
+		}
+	}
+
+	a.queryLog.Log(a.Name(), query, term)
+	count, err := dbutils.CountRows(ctx, a.pool(ctx), query, term)
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute search: %w", err)
+	}
+
+	return count, nil
+}
+
+// jsonPath runs a JSON path scan, comparing the value extracted from every
+// document with JSON_EXTRACT
+func (a *Adapter) jsonPath(ctx context.Context, path []string, value interface{}, scanConfig config.ScanConfig) (int, error) {
+	jsonData, err := codec.Marshal(value)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal JSON path value: %w", err)
+	}
+
+	query := fmt.Sprintf("SELECT id FROM %s WHERE JSON_EXTRACT(data, '$.%s') = CAST(? AS JSON)", a.table, strings.Join(path, "."))
+	if scanConfig.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", scanConfig.Limit)
+
+		if scanConfig.Start > 0 {
+			query += fmt.Sprintf(" OFFSET %d", scanConfig.Start)
+		}
+	}
+
+	a.queryLog.Log(a.Name(), query, string(jsonData))
+	count, err := dbutils.CountRows(ctx, a.pool(ctx), query, string(jsonData))
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute JSON path scan: %w", err)
+	}
+
+	return count, nil
+}
+
 // Name returns the adapter name
 func (a *Adapter) Name() string {
 	return "mysql"
 }
 
+// ClassifyError maps MySQL error numbers to error classes
+func (a *Adapter) ClassifyError(err error) benchmark.ErrorClass {
+	if errors.Is(err, mysqldriver.ErrInvalidConn) {
+		return benchmark.ErrorClassConnection
+	}
+	var mysqlErr *mysqldriver.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return ""
+	}
+
+	switch mysqlErr.Number {
+	case 1205, 3024:
+		// Lock wait timeout and maximum statement execution time exceeded
+		return benchmark.ErrorClassTimeout
+	case 1040, 1203:
+		// Too many connections
+		return benchmark.ErrorClassConnection
+	case 1048, 1062, 1451, 1452, 3819:
+		// NULL, duplicate key, foreign key and check constraint violations
+		return benchmark.ErrorClassConstraint
+	case 1213:
+		// Deadlock
+		return benchmark.ErrorClassConflict
+	case 1235:
+		// Feature not supported by this version
+		return benchmark.ErrorClassUnsupported
+	default:
+		return benchmark.ErrorClassUnknown
+	}
+}
+
+// CreateIndex builds a secondary index on the given value field
+func (a *Adapter) CreateIndex(ctx context.Context, name string, field string) error {
+	query := fmt.Sprintf("CREATE INDEX %s ON %s (%s)", name, a.table, indexColumn(field))
+
+	_, err := a.db.ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to create index: %w", err)
+	}
+	
+	return nil
+}
+
+// DropIndex removes a secondary index
+func (a *Adapter) DropIndex(ctx context.Context, name string) error {
+	query := fmt.Sprintf("DROP INDEX %s ON %s", name, a.table)
+
+	_, err := a.db.ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to drop index: %w", err)
+	}
+	
+	return nil
+}
+
+// CreateSearchIndex extracts a value field into a stored generated column and
+// builds a FULLTEXT index on it, as InnoDB cannot index JSON paths directly
+func (a *Adapter) CreateSearchIndex(ctx context.Context, field string) error {
+	column := searchColumn(field)
+	query := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s TEXT GENERATED ALWAYS AS (data->>'$.%s') STORED, ADD FULLTEXT INDEX %s (%s)",
+		a.table, column, field, column, column)
+
+	if _, err := a.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to create search index: %w", err)
+	}
+
+	return nil
+}
+
+// DropSearchIndex removes the FULLTEXT index and generated column of a value field
+func (a *Adapter) DropSearchIndex(ctx context.Context, field string) error {
+	column := searchColumn(field)
+	query := fmt.Sprintf("ALTER TABLE %s DROP INDEX %s, DROP COLUMN %s", a.table, column, column)
+
+	if _, err := a.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to drop search index: %w", err)
+	}
+
+	return nil
+}
+
+// Compact rebuilds the table to reclaim space left behind by updates
+func (a *Adapter) Compact(ctx context.Context) error {
+	rows, err := a.db.QueryContext(ctx, fmt.Sprintf("OPTIMIZE TABLE %s", a.table))
+	if err != nil {
+		return fmt.Errorf("failed to optimize table: %w", err)
+	}
+	defer rows.Close()
+	
+	// OPTIMIZE TABLE returns a status result set which must be drained
+	for rows.Next() {
+	}
+	
+	return rows.Err()
+}
+
+// DropNamespace removes the tables of a namespaced run left on the endpoint
+func (a *Adapter) DropNamespace(ctx context.Context, namespace string) ([]string, error) {
+	if a.endpoint == "" {
+		return nil, fmt.Errorf("an endpoint is required to clean up namespaced tables")
+	}
+
+	dsn, err := a.endpointDSN(a.endpoint)
+	if err != nil {
+		return nil, err
+	}
+	// Select the benchmark database in the DSN, as every pooled connection
+	// needs it to drop the unqualified tables
+	dsnConfig, err := mysqldriver.ParseDSN(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MySQL endpoint: %w", err)
+	}
+	dsnConfig.DBName = a.database
+	db, err := sql.Open("mysql", dsnConfig.FormatDSN())
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to MySQL: %w", err)
+	}
+	defer db.Close()
+
+	return dbutils.DropTables(ctx, db, fmt.Sprintf("%s_%s", tableName, namespace),
+		"SELECT table_name FROM information_schema.tables WHERE table_schema = ? AND table_name LIKE ?",
+		a.database, tableName+`\_%`)
+}
+
+// endpointDSN returns the DSN of an endpoint, a DSN or a bare host[:port],
+// completed by the connection options
+func (a *Adapter) endpointDSN(endpoint string) (string, error) {
+	dsn := endpoint
+	if !strings.ContainsAny(dsn, "@/") {
+		dsn = fmt.Sprintf("tcp(%s)/", a.conn.HostPort(dsn, defaultPort))
+	}
+	dsnConfig, err := mysqldriver.ParseDSN(dsn)
+	if err != nil {
+		return "", fmt.Errorf("invalid MySQL endpoint: %w", err)
+	}
+	if a.conn.Username != "" {
+		dsnConfig.User = a.conn.Username
+	}
+	if a.conn.Password != "" {
+		dsnConfig.Passwd = a.conn.Password
+	}
+	if a.conn.Port > 0 {
+		dsnConfig.Addr = a.conn.HostPort(dsnConfig.Addr, defaultPort)
+	}
+	if a.conn.TLS {
+		host, _, _ := net.SplitHostPort(dsnConfig.Addr)
+		tlsConfig, err := a.conn.TLSConfig(host)
+		if err != nil {
+			return "", err
+		}
+		// The configuration verifies the name of the host, so every endpoint
+		// registers its own
+		name := fmt.Sprintf("%s-%s", tlsConfigName, dsnConfig.Addr)
+		if err := mysqldriver.RegisterTLSConfig(name, tlsConfig); err != nil {
+			return "", fmt.Errorf("failed to register TLS configuration: %w", err)
+		}
+		dsnConfig.TLSConfig = name
+	}
+	return dsnConfig.FormatDSN(), nil
+}
+
+// OpenProbe opens a dedicated read connection for the consistency probe, to the
+// given endpoint (e.g. a read replica) or to the benchmarked server otherwise.
+// The endpoint is completed by the connection options like --endpoint.
+func (a *Adapter) OpenProbe(ctx context.Context, endpoint string) (*dbutils.ProbeConn, error) {
+	dsn := a.dsn
+	if endpoint != "" {
+		var err error
+		if dsn, err = a.endpointDSN(endpoint); err != nil {
+			return nil, fmt.Errorf("invalid MySQL probe endpoint: %w", err)
+		}
+	}
+
+	dsnConfig, err := mysqldriver.ParseDSN(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MySQL probe endpoint: %w", err)
+	}
+	if dsnConfig.DBName == "" {
+		dsnConfig.DBName = a.database
+	}
+
+	db, err := sql.Open("mysql", dsnConfig.FormatDSN())
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to MySQL: %w", err)
+	}
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping MySQL: %w", err)
+	}
+
+	return dbutils.NewProbeConn(db, fmt.Sprintf("SELECT data FROM %s WHERE id = ?", a.table)), nil
+}
+
+// indexColumn returns the key part used to index a value field
+func indexColumn(field string) string {
+	switch field {
+	case "text":
+		return "text_val"
+	case "integer":
+		return "integer_val"
+	default:
+		// Fall back to a functional index on the JSON document
+		return fmt.Sprintf("(CAST(data->>'$.%s' AS CHAR(255)) COLLATE utf8mb4_bin)", field)
+	}
+}
+
+// searchColumn returns the generated column holding a value field for full-text search
+func searchColumn(field string) string {
+	return "search_" + field
+}
+
+// SetSteps sets the recorder receiving the durations of the provisioning steps
+func (a *Adapter) SetSteps(steps *dbutils.Steps) {
+	a.steps = steps
+}
+
+// SetOutput sets the writer receiving the container messages of the adapter
+func (a *Adapter) SetOutput(w io.Writer) {
+	a.out = w
+}
+
+// SetQueryLog sets the log receiving every statement executed by the adapter
+func (a *Adapter) SetQueryLog(log *dbutils.QueryLog) {
+	a.queryLog = log
+}
+
+// WarmPool establishes the connections of the pool before the measured
+// phases, or those of the pools of the clients when they are connected
+// separately
+func (a *Adapter) WarmPool(ctx context.Context, connections int) error {
+	if a.clients != nil {
+		return a.clients.Warm(ctx)
+	}
+	return dbutils.WarmSQLPool(ctx, a.db, connections)
+}
+
+// ConnectClients opens a connection pool for every client, connected to the
+// benchmark database on every connection
+func (a *Adapter) ConnectClients(ctx context.Context, clients, connections int) error {
+	dsnConfig, err := mysqldriver.ParseDSN(a.dsn)
+	if err != nil {
+		return fmt.Errorf("invalid MySQL endpoint: %w", err)
+	}
+	dsnConfig.DBName = a.database
+	dsn := dsnConfig.FormatDSN()
+
+	pools, err := dbutils.OpenClientPools(ctx, clients, connections, func(ctx context.Context) (*sql.DB, error) {
+		return a.open(ctx, dsn)
+	})
+	if err != nil {
+		return err
+	}
+	a.clients = pools
+	return nil
+}
+
+// ConnectionStats returns connection churn and pool statistics
+func (a *Adapter) ConnectionStats() map[string]float64 {
+	metrics := a.tracker.Metrics(a.db)
+	a.clients.AddMetrics(metrics)
+	return metrics
+}
+
+// DataDir returns the directory of the container holding the data
+func (a *Adapter) DataDir() string {
+	return dataDir
+}
+
+// Container returns the managed Docker container, if any
+func (a *Adapter) Container() *docker.Container {
+	return a.container
+}
+
+// open opens a connection pool to the server
+func (a *Adapter) open(ctx context.Context, dsn string) (*sql.DB, error) {
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to MySQL: %w", err)
+	}
+	
+	// Set connection pool parameters
+	if err := a.tuning.ApplyPool(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	
+	// Test connection
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping MySQL: %w", err)
+	}
+	return db, nil
+}
+
+// pool returns the connection pool of the client issuing an operation
+func (a *Adapter) pool(ctx context.Context) *sql.DB {
+	return a.clients.Pool(ctx, a.db)
+}
+
 // createTable creates the benchmark table
 func (a *Adapter) createTable(ctx context.Context) error {
 	// Create table with id and data columns
@@ -347,8 +1162,8 @@ func (a *Adapter) createTable(ctx context.Context) error {
 			integer_val INT,
 			data JSON
 		)
-	`, tableName)
-	
+	`, a.table)
+
 	_, err := a.db.ExecContext(ctx, query)
 	if err != nil {
 		return fmt.Errorf("failed to create table: %w", err)
@@ -372,32 +1187,32 @@ func (a *Adapter) startContainer(ctx context.Context) (*docker.Container, error)
 		fmt.Sprintf("MYSQL_DATABASE=%s", defaultDatabase),
 	}
 	
-	fmt.Printf("Starting MySQL container '%s' with image '%s'...\n", containerName, a.image)
+	fmt.Fprintf(a.out, "Starting MySQL container '%s' with image '%s'...\n", containerName, a.image)
 	
 	// Create and start container with the common utility
-	container, err := dbutils.CreateContainerWithRetry(ctx, containerName, a.image, ports, a.privileged, env)
+	container, err := dbutils.CreateContainerWithRetry(ctx, a.out, containerName, a.image, ports, a.privileged, env, a.steps)
 	if err != nil {
 		return nil, fmt.Errorf("failed to start MySQL container: %w", err)
 	}
 	
-	fmt.Printf("MySQL container started, waiting for it to be ready...\n")
+	fmt.Fprintf(a.out, "MySQL container started, waiting for it to be ready...\n")
 	
 	printedStartup := false
 	attemptCount := 0
 	// Wait for MySQL to be ready with increased timeout (90 seconds)
 	checkFunc := func(ctx context.Context) error {
 		if !printedStartup {
-			fmt.Println("MySQL container is starting up...")
+			fmt.Fprintln(a.out, "MySQL container is starting up...")
 			printedStartup = true
 		} else {
 			attemptCount++
-			if attemptCount % 5 == 0 {
+			if attemptCount%5 == 0 {
 				// Print status update every 5 attempts
-				fmt.Println("Still waiting for MySQL to be ready...")
+				fmt.Fprintln(a.out, "Still waiting for MySQL to be ready...")
 			}
 		}
-		
-		db, err := sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(127.0.0.1:%s)/", defaultUser, defaultPassword, defaultPort))
+
+		db, err := sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s)/", defaultUser, defaultPassword, dbutils.HostAddress(defaultPort)))
 		if err != nil {
 			return err
 		}
@@ -437,15 +1252,17 @@ func (a *Adapter) startContainer(ctx context.Context) (*docker.Container, error)
 			return err
 		}
 		
-		fmt.Printf("MySQL is ready!\n")
+		fmt.Fprintf(a.out, "MySQL is ready!\n")
 		return nil
 	}
-	
+
+	waitStart := time.Now()
 	if err := container.WaitForHealthy(ctx, 90*time.Second, checkFunc); err != nil {
 		// Clean up container if health check fails
 		_ = container.Stop(ctx)
 		return nil, fmt.Errorf("MySQL health check failed: %w", err)
 	}
-	
+	a.steps.Record("readiness_wait", waitStart)
+
 	return container, nil
 } 
\ No newline at end of file
@@ -3,60 +3,205 @@ package postgres
 import (
 	"context"
 	"database/sql"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"os"
+	"regexp"
 	"strings"
+	"sync/atomic"
 	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+	"github.com/surrealdb/go-crud-bench/internal/codec"
 	"github.com/surrealdb/go-crud-bench/internal/config"
 	"github.com/surrealdb/go-crud-bench/internal/dbutils"
 	"github.com/surrealdb/go-crud-bench/internal/docker"
 )
 
 const (
-	// Default PostgreSQL Docker image
-	defaultImage = "postgres:15"
-
-	// Default PostgreSQL port
-	defaultPort = "5432"
-
-	// Default PostgreSQL credentials
-	defaultUser     = "postgres"
-	defaultPassword = "postgres"
+	// Default database name
 	defaultDatabase = "bench"
 
 	// Table name
 	tableName = "bench_table"
 
 	// Container name prefix
-	containerNamePrefix = "crud-bench-postgres"
+	containerNamePrefix = "crud-bench"
+
+	// SQLSTATE of transactions aborted by a serialization conflict, which
+	// CockroachDB returns under contention and which are safe to retry
+	serializationFailure = "40001"
 )
 
-// Adapter implements the benchmark.Adapter interface for PostgreSQL
+// variant describes a database speaking the PostgreSQL wire protocol
+type variant struct {
+	name     string
+	label    string
+	image    string
+	port     string
+	user     string
+	password string
+	database string
+	env      []string
+	cmd      []string
+	preset   map[string]string
+
+	// ports overrides the published ports, which default to the port of the variant
+	ports map[string]string
+
+	// dataDir is the directory of the container holding the data
+	dataDir string
+
+	// readyQuery is run against readyDatabase to check that the server is ready
+	readyDatabase string
+	readyQuery    string
+
+	// notify and vacuum report support for LISTEN/NOTIFY and VACUUM
+	notify bool
+	vacuum bool
+}
+
+// variants contains the supported PostgreSQL compatible databases
+var variants = map[string]variant{
+	"postgres": {
+		name:     "postgres",
+		label:    "PostgreSQL",
+		image:    "postgres:15",
+		port:     "5432",
+		user:     "postgres",
+		password: "postgres",
+		database: defaultDatabase,
+		env: []string{
+			"POSTGRES_USER=postgres",
+			"POSTGRES_PASSWORD=postgres",
+			"POSTGRES_DB=" + defaultDatabase,
+		},
+		preset: map[string]string{
+			"max_open_conns":     "100",
+			"max_idle_conns":     "20",
+			"conn_max_lifetime":  "1h",
+			"synchronous_commit": "on",
+		},
+		dataDir:       "/var/lib/postgresql/data",
+		readyDatabase: defaultDatabase,
+		readyQuery:    "CREATE TABLE IF NOT EXISTS health_check (id INT)",
+		notify:        true,
+		vacuum:        true,
+	},
+	"cockroachdb": {
+		name:     "cockroachdb",
+		label:    "CockroachDB",
+		image:    "cockroachdb/cockroach:v24.2.4",
+		port:     "26257",
+		user:     "root",
+		database: defaultDatabase,
+		cmd:      []string{"start-single-node", "--insecure", "--cache=.25", "--max-sql-memory=.25"},
+		preset: map[string]string{
+			"max_open_conns":    "100",
+			"max_idle_conns":    "20",
+			"conn_max_lifetime": "1h",
+			"max_retries":       "10",
+		},
+		dataDir:       "/cockroach/cockroach-data",
+		readyDatabase: "defaultdb",
+		readyQuery:    "CREATE DATABASE IF NOT EXISTS " + defaultDatabase,
+	},
+	"yugabyte-ysql": {
+		name:     "yugabyte-ysql",
+		label:    "YugabyteDB (YSQL)",
+		image:    dbutils.YugabyteImage,
+		port:     "5433",
+		user:     "yugabyte",
+		password: "yugabyte",
+		database: "yugabyte",
+		cmd:      dbutils.YugabyteCommand,
+		ports:    dbutils.YugabytePorts,
+		preset: map[string]string{
+			"max_open_conns":    "100",
+			"max_idle_conns":    "20",
+			"conn_max_lifetime": "1h",
+			"max_retries":       "10",
+		},
+		dataDir:       dbutils.YugabyteDataDir,
+		readyDatabase: "yugabyte",
+		readyQuery:    "CREATE TABLE IF NOT EXISTS health_check (id INT PRIMARY KEY)",
+	},
+}
+
+// Adapter implements the benchmark.Adapter interface for PostgreSQL, CockroachDB
+// and the YSQL API of YugabyteDB
 type Adapter struct {
 	db          *sql.DB
 	container   *docker.Container
+	variant     variant
 	endpoint    string
+	conn        config.ConnectionOptions
 	image       string
 	privileged  bool
 	containerID string
+	tracker     *dbutils.ConnTracker
+	clients     *dbutils.ClientPools
+	dsn         string
+	table       string
+	namespaced  bool
+	tuning      dbutils.Tuning
+	queryLog    *dbutils.QueryLog
+	steps       *dbutils.Steps
+	out         io.Writer
+
+	// overrides are the tuning settings given with --tune, with which the
+	// settings of the endpoint are checked for conflicts
+	overrides map[string]string
+
+	// maxRetries is the number of times an operation aborted by a
+	// serialization failure is retried, counted in retries
+	maxRetries int
+	retries    atomic.Int64
 }
 
-// NewAdapter creates a new PostgreSQL adapter
-func NewAdapter(endpoint, image string, privileged bool) *Adapter {
+// NewAdapter creates a new adapter for the named PostgreSQL compatible
+// database (postgres, cockroachdb or yugabyte-ysql)
+func NewAdapter(name, endpoint, image string, privileged bool, conn config.ConnectionOptions) *Adapter {
+	v := variants[name]
 	if image == "" {
-		image = defaultImage
+		image = v.image
 	}
 
 	return &Adapter{
+		variant:    v,
 		endpoint:   endpoint,
+		conn:       conn,
 		image:      image,
 		privileged: privileged,
+		tracker:    dbutils.NewConnTracker(),
+		out:        os.Stdout,
+		table:      tableName,
+		tuning:     v.preset,
 	}
 }
 
-// Initialize sets up the PostgreSQL database
+// SetNamespace isolates the benchmark in its own table on a shared endpoint
+func (a *Adapter) SetNamespace(namespace string) {
+	a.table = fmt.Sprintf("%s_%s", tableName, namespace)
+	a.namespaced = true
+}
+
+// Tune applies tuning overrides on top of the preset of the database
+func (a *Adapter) Tune(overrides map[string]string) (map[string]string, error) {
+	tuning, err := dbutils.NewTuning(a.variant.preset, overrides)
+	if err != nil {
+		return nil, err
+	}
+	a.tuning = tuning
+	a.overrides = overrides
+	return tuning, nil
+}
+
+// Initialize sets up the database
 func (a *Adapter) Initialize(ctx context.Context) error {
 	var dsn string
 
@@ -64,59 +209,91 @@ func (a *Adapter) Initialize(ctx context.Context) error {
 	if a.endpoint == "" {
 		container, err := a.startContainer(ctx)
 		if err != nil {
-			return fmt.Errorf("failed to start PostgreSQL container: %w", err)
+			return fmt.Errorf("failed to start %s container: %w", a.variant.label, err)
 		}
 
 		a.container = container
 		a.containerID = container.ID
-		dsn = fmt.Sprintf("host=localhost port=%s user=%s password=%s dbname=%s sslmode=disable",
-			defaultPort, defaultUser, defaultPassword, defaultDatabase)
+		dsn = a.localDSN(a.variant.database)
 	} else {
-		// Use provided endpoint
-		dsn = a.endpoint
+		// Use provided endpoint, completed by the connection options
+		var err error
+		if dsn, err = a.endpointDSN(a.endpoint); err != nil {
+			return err
+		}
 	}
 
-	// Connect to PostgreSQL server
-	db, err := sql.Open("postgres", dsn)
-	if err != nil {
-		return fmt.Errorf("failed to connect to PostgreSQL: %w", err)
+	// Apply the commit durability setting as a runtime parameter on every
+	// connection, unless the endpoint sets it, whose setting then takes
+	// precedence and is recorded, with a warning if --tune set another one
+	if synchronousCommit, ok := dsnValue(dsn, "synchronous_commit"); ok {
+		if override, ok := a.overrides["synchronous_commit"]; ok && !strings.EqualFold(override, synchronousCommit) {
+			fmt.Fprintf(a.out, "Warning: the endpoint sets synchronous_commit=%s, which takes precedence over --tune synchronous_commit=%s\n", synchronousCommit, override)
+		}
+		if _, tuned := a.tuning["synchronous_commit"]; tuned {
+			a.tuning["synchronous_commit"] = synchronousCommit
+		}
+	} else if synchronousCommit, ok := a.tuning["synchronous_commit"]; ok {
+		dsn += fmt.Sprintf(" synchronous_commit=%s", synchronousCommit)
+	}
+	if _, ok := a.tuning["max_retries"]; ok {
+		maxRetries, err := a.tuning.Int("max_retries")
+		if err != nil {
+			return err
+		}
+		a.maxRetries = maxRetries
 	}
 
-	// Set connection pool parameters
-	db.SetMaxOpenConns(100)
-	db.SetMaxIdleConns(20)
-	db.SetConnMaxLifetime(time.Hour)
-
-	// Test connection
-	if err := db.PingContext(ctx); err != nil {
-		return fmt.Errorf("failed to ping PostgreSQL: %w", err)
+	// Connect to the server
+	connectStart := time.Now()
+	db, err := a.open(ctx, dsn)
+	if err != nil {
+		return err
 	}
 
 	a.db = db
+	a.dsn = dsn
+	a.steps.Record("connect", connectStart)
 
 	// Create table
+	schemaStart := time.Now()
 	if err := a.createTable(ctx); err != nil {
 		return fmt.Errorf("failed to create table: %w", err)
 	}
+	a.steps.Record("schema_create", schemaStart)
 
 	return nil
 }
 
 // Cleanup performs cleanup operations
 func (a *Adapter) Cleanup(ctx context.Context) error {
-	// Close database connection
+	// Drop the namespaced table so that shared endpoints are left clean
+	if a.db != nil && a.namespaced {
+		dropStart := time.Now()
+		if _, err := a.db.ExecContext(ctx, fmt.Sprintf("DROP TABLE IF EXISTS %s", a.table)); err != nil {
+			return fmt.Errorf("failed to drop table %s: %w", a.table, err)
+		}
+		a.steps.Record("schema_drop", dropStart)
+	}
+
+	// Close database connections
+	if err := a.clients.Close(); err != nil {
+		return fmt.Errorf("failed to close %s client connections: %w", a.variant.label, err)
+	}
 	if a.db != nil {
 		if err := a.db.Close(); err != nil {
-			return fmt.Errorf("failed to close PostgreSQL connection: %w", err)
+			return fmt.Errorf("failed to close %s connection: %w", a.variant.label, err)
 		}
 	}
 
 	// Stop and remove container if it was started
 	if a.container != nil {
-		fmt.Printf("Cleaning up PostgreSQL container %s...\n", a.containerID)
+		fmt.Fprintf(a.out, "Cleaning up %s container %s...\n", a.variant.label, a.containerID)
+		stopStart := time.Now()
 		if err := a.container.Stop(ctx); err != nil {
-			return fmt.Errorf("failed to stop PostgreSQL container: %w", err)
+			return fmt.Errorf("failed to stop %s container: %w", a.variant.label, err)
 		}
+		a.steps.Record("container_stop", stopStart)
 	}
 
 	return nil
@@ -124,10 +301,28 @@ func (a *Adapter) Cleanup(ctx context.Context) error {
 
 // Create inserts a new record
 func (a *Adapter) Create(ctx context.Context, key string, value map[string]interface{}) error {
+	query, values, err := a.insertQuery(key, value)
+	if err != nil {
+		return err
+	}
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, values...)
+	_, err = a.exec(ctx, query, values...)
+	if err != nil {
+		return fmt.Errorf("failed to insert record: %w", err)
+	}
+
+	return nil
+}
+
+// insertQuery builds the statement inserting a record, copying the known
+// first-level fields into their own columns
+func (a *Adapter) insertQuery(key string, value map[string]interface{}) (string, []interface{}, error) {
 	// Convert value to JSON
-	jsonData, err := json.Marshal(value)
+	jsonData, err := codec.Marshal(value)
 	if err != nil {
-		return fmt.Errorf("failed to marshal value to JSON: %w", err)
+		return "", nil, fmt.Errorf("failed to marshal value to JSON: %w", err)
 	}
 
 	// Extract first-level fields for columns
@@ -160,28 +355,23 @@ func (a *Adapter) Create(ctx context.Context, key string, value map[string]inter
 	// Prepare SQL statement
 	query := fmt.Sprintf(
 		"INSERT INTO %s (%s) VALUES (%s)",
-		tableName,
+		a.table,
 		strings.Join(columns, ", "),
 		strings.Join(placeholders, ", "),
 	)
 
-	// Execute query
-	_, err = a.db.ExecContext(ctx, query, values...)
-	if err != nil {
-		return fmt.Errorf("failed to insert record: %w", err)
-	}
-
-	return nil
+	return query, values, nil
 }
 
 // Read retrieves a record
 func (a *Adapter) Read(ctx context.Context, key string) (map[string]interface{}, error) {
 	// Prepare SQL statement
-	query := fmt.Sprintf("SELECT data FROM %s WHERE id = $1", tableName)
+	query := fmt.Sprintf("SELECT data FROM %s WHERE id = $1", a.table)
 
 	// Execute query
+	a.queryLog.Log(a.Name(), query, key)
 	var jsonData string
-	err := a.db.QueryRowContext(ctx, query, key).Scan(&jsonData)
+	err := a.retry(ctx, func() error { return a.pool(ctx).QueryRowContext(ctx, query, key).Scan(&jsonData) })
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("record not found: %s", key)
@@ -191,17 +381,50 @@ func (a *Adapter) Read(ctx context.Context, key string) (map[string]interface{},
 
 	// Parse JSON data
 	var result map[string]interface{}
-	if err := json.Unmarshal([]byte(jsonData), &result); err != nil {
+	if err := codec.Unmarshal([]byte(jsonData), &result); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal JSON data: %w", err)
 	}
 
 	return result, nil
 }
 
+// ReadMulti retrieves several records with a single IN list query
+func (a *Adapter) ReadMulti(ctx context.Context, keys []string) ([]map[string]interface{}, error) {
+	query := fmt.Sprintf("SELECT data FROM %s WHERE id IN (%s)", a.table, dbutils.Placeholders(len(keys), true))
+	args := dbutils.KeyArgs(keys)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, args...)
+	records, err := dbutils.QueryJSON(ctx, a.pool(ctx), query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read records: %w", err)
+	}
+
+	return records, nil
+}
+
+// Exists checks whether a record exists without fetching its data
+func (a *Adapter) Exists(ctx context.Context, key string) (bool, error) {
+	query := fmt.Sprintf("SELECT 1 FROM %s WHERE id = $1", a.table)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, key)
+	var found int
+	err := a.retry(ctx, func() error { return a.pool(ctx).QueryRowContext(ctx, query, key).Scan(&found) })
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check record: %w", err)
+	}
+
+	return true, nil
+}
+
 // Update updates a record
 func (a *Adapter) Update(ctx context.Context, key string, value map[string]interface{}) error {
 	// Convert value to JSON
-	jsonData, err := json.Marshal(value)
+	jsonData, err := codec.Marshal(value)
 	if err != nil {
 		return fmt.Errorf("failed to marshal value to JSON: %w", err)
 	}
@@ -236,13 +459,14 @@ func (a *Adapter) Update(ctx context.Context, key string, value map[string]inter
 	// Prepare SQL statement
 	query := fmt.Sprintf(
 		"UPDATE %s SET %s WHERE id = $%d",
-		tableName,
+		a.table,
 		strings.Join(setClauses, ", "),
 		paramCount,
 	)
 
 	// Execute query
-	_, err = a.db.ExecContext(ctx, query, values...)
+	a.queryLog.Log(a.Name(), query, values...)
+	_, err = a.exec(ctx, query, values...)
 	if err != nil {
 		return fmt.Errorf("failed to update record: %w", err)
 	}
@@ -250,13 +474,154 @@ func (a *Adapter) Update(ctx context.Context, key string, value map[string]inter
 	return nil
 }
 
+// CompareAndSet replaces the data of a record only if its stored version still
+// matches, reporting whether the update was applied
+func (a *Adapter) CompareAndSet(ctx context.Context, key string, expected int64, value map[string]interface{}) (bool, error) {
+	// Convert value to JSON
+	jsonData, err := codec.Marshal(value)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal value to JSON: %w", err)
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET data = $1 WHERE id = $2 AND COALESCE((data->>'_version')::bigint, 0) = $3", a.table)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, string(jsonData), key, expected)
+	result, err := a.exec(ctx, query, string(jsonData), key, expected)
+	if err != nil {
+		return false, fmt.Errorf("failed to update record: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to update record: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// CreateCounters creates the counter table and resets the given counters to zero
+func (a *Adapter) CreateCounters(ctx context.Context, counters []string) error {
+	table := a.table + "_counters"
+	query := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (id VARCHAR(255) PRIMARY KEY, n BIGINT NOT NULL)", table)
+	a.queryLog.Log(a.Name(), query)
+	if _, err := a.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to create counter table: %w", err)
+	}
+
+	query = fmt.Sprintf("INSERT INTO %s (id, n) VALUES ($1, 0) ON CONFLICT (id) DO UPDATE SET n = 0", table)
+	for _, counter := range counters {
+		a.queryLog.Log(a.Name(), query, counter)
+		if _, err := a.db.ExecContext(ctx, query, counter); err != nil {
+			return fmt.Errorf("failed to reset counter %s: %w", counter, err)
+		}
+	}
+
+	return nil
+}
+
+// Increment atomically adds one to a counter
+func (a *Adapter) Increment(ctx context.Context, counter string) error {
+	query := fmt.Sprintf("UPDATE %s_counters SET n = n + 1 WHERE id = $1", a.table)
+	a.queryLog.Log(a.Name(), query, counter)
+	if _, err := a.exec(ctx, query, counter); err != nil {
+		return fmt.Errorf("failed to increment counter: %w", err)
+	}
+
+	return nil
+}
+
+// Counter returns the current value of a counter
+func (a *Adapter) Counter(ctx context.Context, counter string) (int64, error) {
+	query := fmt.Sprintf("SELECT n FROM %s_counters WHERE id = $1", a.table)
+	a.queryLog.Log(a.Name(), query, counter)
+	var n int64
+	if err := a.db.QueryRowContext(ctx, query, counter).Scan(&n); err != nil {
+		return 0, fmt.Errorf("failed to read counter: %w", err)
+	}
+
+	return n, nil
+}
+
+// DropCounters drops the counter table
+func (a *Adapter) DropCounters(ctx context.Context, counters []string) error {
+	query := fmt.Sprintf("DROP TABLE IF EXISTS %s_counters", a.table)
+	a.queryLog.Log(a.Name(), query)
+	if _, err := a.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to drop counter table: %w", err)
+	}
+
+	return nil
+}
+
+// Subscribe delivers the keys of new records through LISTEN/NOTIFY, using a
+// trigger on the table which notifies a channel for every inserted row
+func (a *Adapter) Subscribe(ctx context.Context, handler func(key string)) (func() error, error) {
+	if !a.variant.notify {
+		return nil, fmt.Errorf("%s does not support LISTEN/NOTIFY", a.variant.label)
+	}
+
+	channel := a.table + "_changes"
+	statements := []string{
+		fmt.Sprintf(`CREATE OR REPLACE FUNCTION %s_notify() RETURNS trigger AS $$
+		BEGIN
+			PERFORM pg_notify('%s', NEW.id);
+			RETURN NEW;
+		END
+		$$ LANGUAGE plpgsql`, a.table, channel),
+		fmt.Sprintf("DROP TRIGGER IF EXISTS %s_notify ON %s", a.table, a.table),
+		fmt.Sprintf("CREATE TRIGGER %s_notify AFTER INSERT ON %s FOR EACH ROW EXECUTE FUNCTION %s_notify()", a.table, a.table, a.table),
+	}
+	for _, statement := range statements {
+		a.queryLog.Log(a.Name(), statement)
+		if _, err := a.db.ExecContext(ctx, statement); err != nil {
+			return nil, fmt.Errorf("failed to create notify trigger: %w", err)
+		}
+	}
+
+	// Listen on a dedicated connection
+	listener := pq.NewListener(a.dsn, time.Second, 10*time.Second, nil)
+	if err := listener.Listen(channel); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to listen on %s: %w", channel, err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for notification := range listener.Notify {
+			// A nil notification signals a reconnect, which may have lost events
+			if notification != nil {
+				handler(notification.Extra)
+			}
+		}
+	}()
+
+	stop := func() error {
+		err := listener.Close()
+		<-done
+
+		// Remove the trigger so that later phases are not slowed down
+		drop := fmt.Sprintf("DROP TRIGGER IF EXISTS %s_notify ON %s", a.table, a.table)
+		if _, dropErr := a.db.Exec(drop); dropErr != nil && err == nil {
+			err = fmt.Errorf("failed to drop notify trigger: %w", dropErr)
+		}
+		if _, dropErr := a.db.Exec(fmt.Sprintf("DROP FUNCTION IF EXISTS %s_notify()", a.table)); dropErr != nil && err == nil {
+			err = fmt.Errorf("failed to drop notify function: %w", dropErr)
+		}
+		return err
+	}
+
+	return stop, nil
+}
+
 // Delete removes a record
 func (a *Adapter) Delete(ctx context.Context, key string) error {
 	// Prepare SQL statement
-	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1", tableName)
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1", a.table)
 
 	// Execute query
-	_, err := a.db.ExecContext(ctx, query, key)
+	a.queryLog.Log(a.Name(), query, key)
+	_, err := a.exec(ctx, query, key)
 	if err != nil {
 		return fmt.Errorf("failed to delete record: %w", err)
 	}
@@ -264,8 +629,241 @@ func (a *Adapter) Delete(ctx context.Context, key string) error {
 	return nil
 }
 
+// DeleteBatch removes several records with a single IN list statement
+func (a *Adapter) DeleteBatch(ctx context.Context, keys []string) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id IN (%s)", a.table, dbutils.Placeholders(len(keys), true))
+	args := dbutils.KeyArgs(keys)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, args...)
+	if _, err := a.exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to delete records: %w", err)
+	}
+
+	return nil
+}
+
+// Truncate removes every record with TRUNCATE
+func (a *Adapter) Truncate(ctx context.Context) error {
+	query := fmt.Sprintf("TRUNCATE %s", a.table)
+
+	a.queryLog.Log(a.Name(), query)
+	if _, err := a.exec(ctx, query); err != nil {
+		return fmt.Errorf("failed to truncate table: %w", err)
+	}
+
+	return nil
+}
+
+// SoftDelete marks a record as deleted by setting deleted_at with jsonb_set
+func (a *Adapter) SoftDelete(ctx context.Context, key string) error {
+	query := fmt.Sprintf("UPDATE %s SET data = jsonb_set(data, '{deleted_at}', to_jsonb($2::text)) WHERE id = $1", a.table)
+	deletedAt := time.Now().UTC().Format(time.RFC3339Nano)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, key, deletedAt)
+	if _, err := a.exec(ctx, query, key, deletedAt); err != nil {
+		return fmt.Errorf("failed to soft delete record: %w", err)
+	}
+
+	return nil
+}
+
+// ReadLive retrieves a record unless its deleted_at field is set
+func (a *Adapter) ReadLive(ctx context.Context, key string) (map[string]interface{}, bool, error) {
+	query := fmt.Sprintf("SELECT data FROM %s WHERE id = $1 AND data->>'deleted_at' IS NULL", a.table)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, key)
+	var jsonData string
+	err := a.retry(ctx, func() error { return a.pool(ctx).QueryRowContext(ctx, query, key).Scan(&jsonData) })
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to read record: %w", err)
+	}
+
+	// Parse JSON data
+	var result map[string]interface{}
+	if err := codec.Unmarshal([]byte(jsonData), &result); err != nil {
+		return nil, false, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+	}
+
+	return result, true, nil
+}
+
+// CountLive counts the records whose deleted_at field is not set
+func (a *Adapter) CountLive(ctx context.Context) (int, error) {
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE data->>'deleted_at' IS NULL", a.table)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query)
+	var count int
+	if err := a.retry(ctx, func() error { return a.pool(ctx).QueryRowContext(ctx, query).Scan(&count) }); err != nil {
+		return 0, fmt.Errorf("failed to count live records: %w", err)
+	}
+
+	return count, nil
+}
+
+// CreateVersions creates the empty history table of versioned writes, keyed
+// by the record key and version so that the latest version is an index seek
+func (a *Adapter) CreateVersions(ctx context.Context) error {
+	if err := a.DropVersions(ctx); err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("CREATE TABLE %s_versions (id VARCHAR(255) NOT NULL, version INTEGER NOT NULL, data JSONB, PRIMARY KEY (id, version))", a.table)
+	a.queryLog.Log(a.Name(), query)
+	if _, err := a.exec(ctx, query); err != nil {
+		return fmt.Errorf("failed to create history table: %w", err)
+	}
+
+	return nil
+}
+
+// AppendVersion inserts a new version row of a record
+func (a *Adapter) AppendVersion(ctx context.Context, key string, version int, value map[string]interface{}) error {
+	jsonData, err := codec.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal value to JSON: %w", err)
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s_versions (id, version, data) VALUES ($1, $2, $3)", a.table)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, key, version)
+	if _, err := a.exec(ctx, query, key, version, string(jsonData)); err != nil {
+		return fmt.Errorf("failed to append version: %w", err)
+	}
+
+	return nil
+}
+
+// ReadLatest retrieves the version row of a record with the highest version
+func (a *Adapter) ReadLatest(ctx context.Context, key string) (map[string]interface{}, int, error) {
+	query := fmt.Sprintf("SELECT version, data FROM %s_versions WHERE id = $1 ORDER BY version DESC LIMIT 1", a.table)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, key)
+	var version int
+	var jsonData string
+	err := a.retry(ctx, func() error { return a.pool(ctx).QueryRowContext(ctx, query, key).Scan(&version, &jsonData) })
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, 0, fmt.Errorf("record not found: %s", key)
+		}
+		return nil, 0, fmt.Errorf("failed to read latest version: %w", err)
+	}
+
+	// Parse JSON data
+	var result map[string]interface{}
+	if err := codec.Unmarshal([]byte(jsonData), &result); err != nil {
+		return nil, 0, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+	}
+
+	return result, version, nil
+}
+
+// DropVersions drops the history table
+func (a *Adapter) DropVersions(ctx context.Context) error {
+	query := fmt.Sprintf("DROP TABLE IF EXISTS %s_versions", a.table)
+	a.queryLog.Log(a.Name(), query)
+	if _, err := a.exec(ctx, query); err != nil {
+		return fmt.Errorf("failed to drop history table: %w", err)
+	}
+
+	return nil
+}
+
+// CreateChildTable creates the child table of the relational workload, whose
+// rows reference their parent record with a foreign key cascading deletes
+func (a *Adapter) CreateChildTable(ctx context.Context) error {
+	if err := a.DropChildTable(ctx); err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("CREATE TABLE %s_children (parent_id VARCHAR(255) NOT NULL, seq INTEGER NOT NULL, data JSONB, PRIMARY KEY (parent_id, seq), FOREIGN KEY (parent_id) REFERENCES %s (id) ON DELETE CASCADE)", a.table, a.table)
+	a.queryLog.Log(a.Name(), query)
+	if _, err := a.exec(ctx, query); err != nil {
+		return fmt.Errorf("failed to create child table: %w", err)
+	}
+
+	return nil
+}
+
+// CreateWithChildren inserts a record and its child rows in a transaction
+func (a *Adapter) CreateWithChildren(ctx context.Context, key string, value map[string]interface{}, children []map[string]interface{}) error {
+	query, values, err := a.insertQuery(key, value)
+	if err != nil {
+		return err
+	}
+	childQuery := fmt.Sprintf("INSERT INTO %s_children (parent_id, seq, data) VALUES ($1, $2, $3)", a.table)
+
+	// Execute queries
+	a.queryLog.Log(a.Name(), query, values...)
+	a.queryLog.Log(a.Name(), childQuery, key, len(children))
+	err = a.retry(ctx, func() error {
+		return dbutils.InsertWithChildren(ctx, a.pool(ctx), query, values, childQuery, key, children)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to insert record: %w", err)
+	}
+
+	return nil
+}
+
+// ReadWithChildren retrieves a record left joined with its child rows
+func (a *Adapter) ReadWithChildren(ctx context.Context, key string) (map[string]interface{}, []map[string]interface{}, error) {
+	query := fmt.Sprintf("SELECT p.data, c.data FROM %s p LEFT JOIN %s_children c ON c.parent_id = p.id WHERE p.id = $1 ORDER BY c.seq", a.table, a.table)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, key)
+	var parent map[string]interface{}
+	var children []map[string]interface{}
+	err := a.retry(ctx, func() error {
+		var err error
+		parent, children, err = dbutils.QueryWithChildren(ctx, a.pool(ctx), query, key)
+		return err
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, fmt.Errorf("record not found: %s", key)
+		}
+		return nil, nil, fmt.Errorf("failed to read record: %w", err)
+	}
+
+	return parent, children, nil
+}
+
+// DropChildTable drops the child table
+func (a *Adapter) DropChildTable(ctx context.Context) error {
+	query := fmt.Sprintf("DROP TABLE IF EXISTS %s_children", a.table)
+	a.queryLog.Log(a.Name(), query)
+	if _, err := a.exec(ctx, query); err != nil {
+		return fmt.Errorf("failed to drop child table: %w", err)
+	}
+
+	return nil
+}
+
 // Scan performs a scan operation
 func (a *Adapter) Scan(ctx context.Context, scanConfig config.ScanConfig) (int, error) {
+	// Full-text searches, geo radius, JSON path and join scans have their own query
+	if field, term, ok := scanConfig.Search(); ok {
+		return a.search(ctx, field, term, scanConfig)
+	}
+	if field, lon, lat, radius, ok := scanConfig.GeoWithin(); ok {
+		return a.geoWithin(ctx, field, lon, lat, radius, scanConfig)
+	}
+	if path, value, ok := scanConfig.JSONPath(); ok {
+		return a.jsonPath(ctx, path, value, scanConfig)
+	}
+	if fanout, ok := scanConfig.Join(); ok {
+		return a.join(ctx, fanout, scanConfig)
+	}
+
 	var query string
 	var args []interface{}
 	var count int
@@ -273,13 +871,13 @@ func (a *Adapter) Scan(ctx context.Context, scanConfig config.ScanConfig) (int,
 	// Build query based on projection type
 	switch scanConfig.Projection {
 	case "ID":
-		query = fmt.Sprintf("SELECT id FROM %s", tableName)
+		query = fmt.Sprintf("SELECT id FROM %s", a.table)
 	case "FULL":
-		query = fmt.Sprintf("SELECT * FROM %s", tableName)
+		query = fmt.Sprintf("SELECT * FROM %s", a.table)
 	case "COUNT":
-		query = fmt.Sprintf("SELECT COUNT(*) FROM %s", tableName)
+		query = fmt.Sprintf("SELECT COUNT(*) FROM %s", a.table)
 	default:
-		return 0, fmt.Errorf("unsupported projection type: %s", scanConfig.Projection)
+		return 0, fmt.Errorf("%w projection type: %s", benchmark.ErrUnsupported, scanConfig.Projection)
 	}
 
 	// Add LIMIT and OFFSET if specified
@@ -292,8 +890,9 @@ func (a *Adapter) Scan(ctx context.Context, scanConfig config.ScanConfig) (int,
 	}
 
 	// Execute query
+	a.queryLog.Log(a.Name(), query, args...)
 	if scanConfig.Projection == "COUNT" {
-		err := a.db.QueryRowContext(ctx, query, args...).Scan(&count)
+		err := a.pool(ctx).QueryRowContext(ctx, query, args...).Scan(&count)
 		if err != nil {
 			return 0, fmt.Errorf("failed to execute count scan: %w", err)
 		}
@@ -301,7 +900,7 @@ func (a *Adapter) Scan(ctx context.Context, scanConfig config.ScanConfig) (int,
 	}
 
 	// For ID and FULL projections, execute query and count rows
-	rows, err := a.db.QueryContext(ctx, query, args...)
+	rows, err := a.pool(ctx).QueryContext(ctx, query, args...)
 	if err != nil {
 		return 0, fmt.Errorf("failed to execute scan: %w", err)
 	}
@@ -319,9 +918,347 @@ func (a *Adapter) Scan(ctx context.Context, scanConfig config.ScanConfig) (int,
 	return count, nil
 }
 
+// join runs a parent-child join scan, joining every parent row of the scan
+// window with at most fanout of its child rows and fetching their data
+func (a *Adapter) join(ctx context.Context, fanout int, scanConfig config.ScanConfig) (int, error) {
+	// Restrict the parent rows with LIMIT and OFFSET if specified
+	parents := a.table
+	if scanConfig.Limit > 0 {
+		window := fmt.Sprintf(" LIMIT %d", scanConfig.Limit)
+		if scanConfig.Start > 0 {
+			window += fmt.Sprintf(" OFFSET %d", scanConfig.Start)
+		}
+		parents = fmt.Sprintf("(SELECT id FROM %s%s)", a.table, window)
+	}
+
+	query := fmt.Sprintf("SELECT p.id, c.data FROM %s p JOIN %s_children c ON c.parent_id = p.id WHERE c.seq < %d", parents, a.table, fanout)
+
+	a.queryLog.Log(a.Name(), query)
+	count, err := dbutils.CountRows(ctx, a.pool(ctx), query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute join scan: %w", err)
+	}
+
+	return count, nil
+}
+
+// search runs a full-text search scan, matching the term against the same
+// expression as the index built by CreateSearchIndex
+func (a *Adapter) search(ctx context.Context, field, term string, scanConfig config.ScanConfig) (int, error) {
+	query := fmt.Sprintf("SELECT id FROM %s WHERE %s @@ plainto_tsquery('simple', $1)", a.table, searchVector(field))
+	if scanConfig.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", scanConfig.Limit)
+
+		if scanConfig.Start > 0 {
+			query += fmt.Sprintf(" OFFSET %d", scanConfig.Start)
+		}
+	}
+
+	a.queryLog.Log(a.Name(), query, term)
+	count, err := dbutils.CountRows(ctx, a.pool(ctx), query, term)
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute search: %w", err)
+	}
+
+	return count, nil
+}
+
+// geoWithin runs a geo radius scan, matching the points against the same
+// expression as the index built by CreateGeoIndex
+func (a *Adapter) geoWithin(ctx context.Context, field string, lon, lat, radiusKm float64, scanConfig config.ScanConfig) (int, error) {
+	query := fmt.Sprintf("SELECT id FROM %s WHERE ST_DWithin(%s, ST_SetSRID(ST_MakePoint($1, $2), 4326)::geography, $3)",
+		a.table, geography(field))
+	if scanConfig.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", scanConfig.Limit)
+
+		if scanConfig.Start > 0 {
+			query += fmt.Sprintf(" OFFSET %d", scanConfig.Start)
+		}
+	}
+
+	a.queryLog.Log(a.Name(), query, lon, lat, radiusKm*1000)
+	count, err := dbutils.CountRows(ctx, a.pool(ctx), query, lon, lat, radiusKm*1000)
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute geo scan: %w", err)
+	}
+
+	return count, nil
+}
+
+// jsonPath runs a JSON path scan with a jsonb containment query, matching the
+// documents holding the value at the path
+func (a *Adapter) jsonPath(ctx context.Context, path []string, value interface{}, scanConfig config.ScanConfig) (int, error) {
+	// Nest the value into a document along the path, e.g. {"a":{"b":value}}
+	document := value
+	for i := len(path) - 1; i >= 0; i-- {
+		document = map[string]interface{}{path[i]: document}
+	}
+	jsonData, err := codec.Marshal(document)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal JSON path value: %w", err)
+	}
+
+	query := fmt.Sprintf("SELECT id FROM %s WHERE data @> $1::jsonb", a.table)
+	if scanConfig.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", scanConfig.Limit)
+
+		if scanConfig.Start > 0 {
+			query += fmt.Sprintf(" OFFSET %d", scanConfig.Start)
+		}
+	}
+
+	a.queryLog.Log(a.Name(), query, string(jsonData))
+	count, err := dbutils.CountRows(ctx, a.pool(ctx), query, string(jsonData))
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute JSON path scan: %w", err)
+	}
+
+	return count, nil
+}
+
 // Name returns the adapter name
 func (a *Adapter) Name() string {
-	return "postgres"
+	return a.variant.name
+}
+
+// ClassifyError maps PostgreSQL SQLSTATE codes to error classes
+func (a *Adapter) ClassifyError(err error) benchmark.ErrorClass {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return ""
+	}
+
+	switch {
+	case pqErr.Code == "57014":
+		// query_canceled, raised by statement_timeout
+		return benchmark.ErrorClassTimeout
+	case pqErr.Code == "53300" || pqErr.Code.Class() == "08":
+		return benchmark.ErrorClassConnection
+	case pqErr.Code.Class() == "23":
+		return benchmark.ErrorClassConstraint
+	case pqErr.Code.Class() == "40":
+		// serialization_failure and deadlock_detected
+		return benchmark.ErrorClassConflict
+	case pqErr.Code.Class() == "0A":
+		return benchmark.ErrorClassUnsupported
+	default:
+		return benchmark.ErrorClassUnknown
+	}
+}
+
+// CreateIndex builds a secondary index on the given value field
+func (a *Adapter) CreateIndex(ctx context.Context, name string, field string) error {
+	// Index names are schema wide, so qualify them with the table name
+	query := fmt.Sprintf("CREATE INDEX %s_%s ON %s (%s)", a.table, name, a.table, indexColumn(field))
+
+	_, err := a.db.ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to create index: %w", err)
+	}
+
+	return nil
+}
+
+// DropIndex removes a secondary index
+func (a *Adapter) DropIndex(ctx context.Context, name string) error {
+	query := fmt.Sprintf("DROP INDEX IF EXISTS %s_%s", a.table, name)
+
+	_, err := a.db.ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to drop index: %w", err)
+	}
+
+	return nil
+}
+
+// CreateSearchIndex builds a GIN index on the text search vector of a value field
+func (a *Adapter) CreateSearchIndex(ctx context.Context, field string) error {
+	query := fmt.Sprintf("CREATE INDEX %s_search_%s ON %s USING GIN (%s)", a.table, field, a.table, searchVector(field))
+
+	if _, err := a.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to create search index: %w", err)
+	}
+
+	return nil
+}
+
+// DropSearchIndex removes the full-text index on a value field
+func (a *Adapter) DropSearchIndex(ctx context.Context, field string) error {
+	query := fmt.Sprintf("DROP INDEX IF EXISTS %s_search_%s", a.table, field)
+
+	if _, err := a.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to drop search index: %w", err)
+	}
+
+	return nil
+}
+
+// CreateGeoIndex enables PostGIS and builds a GiST index on the geography of
+// the GeoJSON points of a value field. PostgreSQL needs an image with PostGIS
+// installed, such as postgis/postgis.
+func (a *Adapter) CreateGeoIndex(ctx context.Context, field string) error {
+	queries := []string{
+		"CREATE EXTENSION IF NOT EXISTS postgis",
+		fmt.Sprintf("CREATE INDEX %s_geo_%s ON %s USING GIST ((%s))", a.table, field, a.table, geography(field)),
+	}
+
+	for _, query := range queries {
+		if _, err := a.db.ExecContext(ctx, query); err != nil {
+			return fmt.Errorf("failed to create geo index: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// DropGeoIndex removes the spatial index on a value field
+func (a *Adapter) DropGeoIndex(ctx context.Context, field string) error {
+	query := fmt.Sprintf("DROP INDEX IF EXISTS %s_geo_%s", a.table, field)
+
+	if _, err := a.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to drop geo index: %w", err)
+	}
+
+	return nil
+}
+
+// Compact vacuums the table to reclaim dead tuples left behind by updates
+func (a *Adapter) Compact(ctx context.Context) error {
+	if !a.variant.vacuum {
+		return fmt.Errorf("%s does not support VACUUM", a.variant.label)
+	}
+
+	_, err := a.db.ExecContext(ctx, fmt.Sprintf("VACUUM FULL ANALYZE %s", a.table))
+	if err != nil {
+		return fmt.Errorf("failed to vacuum table: %w", err)
+	}
+
+	return nil
+}
+
+// DropNamespace removes the tables of a namespaced run left on the endpoint
+func (a *Adapter) DropNamespace(ctx context.Context, namespace string) ([]string, error) {
+	if a.endpoint == "" {
+		return nil, fmt.Errorf("an endpoint is required to clean up namespaced tables")
+	}
+
+	dsn, err := a.endpointDSN(a.endpoint)
+	if err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", a.variant.label, err)
+	}
+	defer db.Close()
+
+	return dbutils.DropTables(ctx, db, fmt.Sprintf("%s_%s", tableName, namespace),
+		"SELECT tablename FROM pg_tables WHERE schemaname = current_schema() AND tablename LIKE $1",
+		tableName+`\_%`)
+}
+
+// OpenProbe opens a dedicated read connection for the consistency probe, to the
+// given endpoint (e.g. a read replica) or to the benchmarked server otherwise.
+// The endpoint is completed by the connection options like --endpoint.
+func (a *Adapter) OpenProbe(ctx context.Context, endpoint string) (*dbutils.ProbeConn, error) {
+	dsn := a.dsn
+	if endpoint != "" {
+		var err error
+		if dsn, err = a.endpointDSN(endpoint); err != nil {
+			return nil, fmt.Errorf("invalid %s probe endpoint: %w", a.variant.label, err)
+		}
+	}
+
+	connector, err := pq.NewConnector(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", a.variant.label, err)
+	}
+	db := sql.OpenDB(connector)
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping %s: %w", a.variant.label, err)
+	}
+
+	return dbutils.NewProbeConn(db, fmt.Sprintf("SELECT data FROM %s WHERE id = $1", a.table)), nil
+}
+
+// indexColumn returns the index expression used for a value field
+func indexColumn(field string) string {
+	switch field {
+	case "text":
+		return "text_val"
+	case "integer":
+		return "integer_val"
+	default:
+		// Fall back to an expression index on the JSON document
+		return fmt.Sprintf("(data->>'%s')", field)
+	}
+}
+
+// searchVector returns the text search vector expression of a value field. The
+// simple configuration lowercases words without stemming or stop words.
+func searchVector(field string) string {
+	return fmt.Sprintf("to_tsvector('simple', coalesce(data->>'%s', ''))", field)
+}
+
+// geography returns the geography expression of the GeoJSON points of a value field
+func geography(field string) string {
+	return fmt.Sprintf("ST_GeomFromGeoJSON(data->>'%s')::geography", field)
+}
+
+// SetSteps sets the recorder receiving the durations of the provisioning steps
+func (a *Adapter) SetSteps(steps *dbutils.Steps) {
+	a.steps = steps
+}
+
+// SetOutput sets the writer receiving the container messages of the adapter
+func (a *Adapter) SetOutput(w io.Writer) {
+	a.out = w
+}
+
+// SetQueryLog sets the log receiving every statement executed by the adapter
+func (a *Adapter) SetQueryLog(log *dbutils.QueryLog) {
+	a.queryLog = log
+}
+
+// WarmPool establishes the connections of the pool before the measured
+// phases, or those of the pools of the clients when they are connected
+// separately
+func (a *Adapter) WarmPool(ctx context.Context, connections int) error {
+	if a.clients != nil {
+		return a.clients.Warm(ctx)
+	}
+	return dbutils.WarmSQLPool(ctx, a.db, connections)
+}
+
+// ConnectClients opens a connection pool for every client
+func (a *Adapter) ConnectClients(ctx context.Context, clients, connections int) error {
+	pools, err := dbutils.OpenClientPools(ctx, clients, connections, func(ctx context.Context) (*sql.DB, error) {
+		return a.open(ctx, a.dsn)
+	})
+	if err != nil {
+		return err
+	}
+	a.clients = pools
+	return nil
+}
+
+// ConnectionStats returns connection churn and pool statistics
+func (a *Adapter) ConnectionStats() map[string]float64 {
+	metrics := a.tracker.Metrics(a.db)
+	a.clients.AddMetrics(metrics)
+	metrics["retries"] = float64(a.retries.Load())
+	return metrics
+}
+
+// DataDir returns the directory of the container holding the data
+func (a *Adapter) DataDir() string {
+	return a.variant.dataDir
+}
+
+// Container returns the managed Docker container, if any
+func (a *Adapter) Container() *docker.Container {
+	return a.container
 }
 
 // createTable creates the benchmark table
@@ -334,7 +1271,7 @@ func (a *Adapter) createTable(ctx context.Context) error {
 			integer_val INTEGER,
 			data JSONB
 		)
-	`, tableName)
+	`, a.table)
 
 	_, err := a.db.ExecContext(ctx, query)
 	if err != nil {
@@ -344,49 +1281,45 @@ func (a *Adapter) createTable(ctx context.Context) error {
 	return nil
 }
 
-// startContainer starts a PostgreSQL Docker container
+// startContainer starts a single node Docker container
 func (a *Adapter) startContainer(ctx context.Context) (*docker.Container, error) {
 	// Generate unique container name with timestamp
-	containerName := fmt.Sprintf("%s-%d", containerNamePrefix, time.Now().Unix())
+	containerName := fmt.Sprintf("%s-%s-%d", containerNamePrefix, a.variant.name, time.Now().Unix())
 
 	// Configure container
 	ports := map[string]string{
-		"5432/tcp": defaultPort,
+		a.variant.port + "/tcp": a.variant.port,
 	}
-
-	env := []string{
-		fmt.Sprintf("POSTGRES_USER=%s", defaultUser),
-		fmt.Sprintf("POSTGRES_PASSWORD=%s", defaultPassword),
-		fmt.Sprintf("POSTGRES_DB=%s", defaultDatabase),
+	if a.variant.ports != nil {
+		ports = a.variant.ports
 	}
 
-	fmt.Printf("Starting PostgreSQL container '%s' with image '%s'...\n", containerName, a.image)
-	
+	fmt.Fprintf(a.out, "Starting %s container '%s' with image '%s'...\n", a.variant.label, containerName, a.image)
+
 	// Create and start container with the common utility
-	container, err := dbutils.CreateContainerWithRetry(ctx, containerName, a.image, ports, a.privileged, env)
+	container, err := dbutils.CreateContainerWithCommand(ctx, a.out, containerName, a.image, ports, a.privileged, a.variant.env, a.variant.cmd, a.steps)
 	if err != nil {
-		return nil, fmt.Errorf("failed to start PostgreSQL container: %w", err)
+		return nil, fmt.Errorf("failed to start %s container: %w", a.variant.label, err)
 	}
 
-	fmt.Printf("PostgreSQL container started, waiting for it to be ready...\n")
+	fmt.Fprintf(a.out, "%s container started, waiting for it to be ready...\n", a.variant.label)
 
 	printedStartup := false
 	attemptCount := 0
-	// Wait for PostgreSQL to be ready with increased timeout (90 seconds)
+	// Wait for the server to be ready with increased timeout (90 seconds)
 	checkFunc := func(ctx context.Context) error {
 		if !printedStartup {
-			fmt.Println("PostgreSQL container is starting up...")
+			fmt.Fprintf(a.out, "%s container is starting up...\n", a.variant.label)
 			printedStartup = true
 		} else {
 			attemptCount++
-			if attemptCount % 5 == 0 {
+			if attemptCount%5 == 0 {
 				// Print status update every 5 attempts
-				fmt.Println("Still waiting for PostgreSQL to be ready...")
+				fmt.Fprintf(a.out, "Still waiting for %s to be ready...\n", a.variant.label)
 			}
 		}
 
-		db, err := sql.Open("postgres", fmt.Sprintf("host=localhost port=%s user=%s password=%s dbname=%s sslmode=disable",
-			defaultPort, defaultUser, defaultPassword, defaultDatabase))
+		db, err := sql.Open("postgres", a.localDSN(a.variant.readyDatabase))
 		if err != nil {
 			return err
 		}
@@ -405,22 +1338,172 @@ func (a *Adapter) startContainer(ctx context.Context) (*docker.Container, error)
 			return err
 		}
 
-		// Try to create a simple test table to verify PostgreSQL is really ready
-		_, err = db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS health_check (id INT)")
+		// Run a statement to verify the server is really ready, which also
+		// creates the benchmark database where it is not created on startup
+		_, err = db.ExecContext(ctx, a.variant.readyQuery)
 		if err != nil {
 			// Not printing error message, just returning it
 			return err
 		}
 
-		fmt.Printf("PostgreSQL is ready!\n")
+		fmt.Fprintf(a.out, "%s is ready!\n", a.variant.label)
 		return nil
 	}
 
+	waitStart := time.Now()
 	if err := container.WaitForHealthy(ctx, 90*time.Second, checkFunc); err != nil {
 		// Clean up container if health check fails
 		_ = container.Stop(ctx)
-		return nil, fmt.Errorf("PostgreSQL health check failed: %w", err)
+		return nil, fmt.Errorf("%s health check failed: %w", a.variant.label, err)
 	}
+	a.steps.Record("readiness_wait", waitStart)
 
 	return container, nil
-} 
\ No newline at end of file
+}
+
+// open opens a connection pool to the server, tracking the connections opened
+// by the driver
+func (a *Adapter) open(ctx context.Context, dsn string) (*sql.DB, error) {
+	connector, err := pq.NewConnector(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", a.variant.label, err)
+	}
+	connector.Dialer(a.tracker)
+	db := sql.OpenDB(connector)
+
+	// Set connection pool parameters
+	if err := a.tuning.ApplyPool(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	// Test connection
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping %s: %w", a.variant.label, err)
+	}
+	return db, nil
+}
+
+// pool returns the connection pool of the client issuing an operation
+func (a *Adapter) pool(ctx context.Context) *sql.DB {
+	return a.clients.Pool(ctx, a.db)
+}
+
+// exec runs a statement, retrying it when it is aborted by a serialization failure
+func (a *Adapter) exec(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	var result sql.Result
+	err := a.retry(ctx, func() error {
+		var err error
+		result, err = a.pool(ctx).ExecContext(ctx, query, args...)
+		return err
+	})
+	return result, err
+}
+
+// retry runs an operation, running it again with a jittered exponential backoff
+// while it is aborted by a serialization failure, at most maxRetries times
+func (a *Adapter) retry(ctx context.Context, op func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := op()
+		var pqErr *pq.Error
+		if err == nil || attempt >= a.maxRetries || !errors.As(err, &pqErr) || pqErr.Code != serializationFailure {
+			return err
+		}
+		a.retries.Add(1)
+
+		backoff := time.Millisecond << min(attempt, 6)
+		select {
+		case <-time.After(backoff + time.Duration(rand.Int63n(int64(backoff)))):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// endpointDSN returns the connection string of an endpoint, a URL, a
+// key=value connection string or a bare host[:port], with the connection
+// options appended, as later settings take precedence
+func (a *Adapter) endpointDSN(endpoint string) (string, error) {
+	dsn := endpoint
+	switch {
+	case strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://"):
+		parsed, err := pq.ParseURL(dsn)
+		if err != nil {
+			return "", fmt.Errorf("invalid %s endpoint: %w", a.variant.label, err)
+		}
+		dsn = parsed
+	case !strings.Contains(dsn, "="):
+		host, port, err := net.SplitHostPort(dsn)
+		if err != nil {
+			host, port = dsn, a.variant.port
+		}
+		dsn = fmt.Sprintf("host=%s port=%s sslmode=disable", host, port)
+	}
+
+	if a.conn.Username != "" {
+		dsn += " user=" + quoteDSNValue(a.conn.Username)
+	}
+	if a.conn.Password != "" {
+		dsn += " password=" + quoteDSNValue(a.conn.Password)
+	}
+	if a.conn.Database != "" {
+		dsn += " dbname=" + quoteDSNValue(a.conn.Database)
+	}
+	if a.conn.Port > 0 {
+		dsn += fmt.Sprintf(" port=%d", a.conn.Port)
+	}
+	if a.conn.TLS {
+		// verify-full verifies the server against the given CA or the system
+		// roots, while require only encrypts
+		if a.conn.TLSSkipVerify {
+			dsn += " sslmode=require"
+		} else {
+			dsn += " sslmode=verify-full"
+			if a.conn.TLSCA != "" {
+				dsn += " sslrootcert=" + quoteDSNValue(a.conn.TLSCA)
+			}
+		}
+		if a.conn.TLSCert != "" {
+			dsn += fmt.Sprintf(" sslcert=%s sslkey=%s", quoteDSNValue(a.conn.TLSCert), quoteDSNValue(a.conn.TLSKey))
+		}
+	}
+	return dsn, nil
+}
+
+// dsnValueRegex matches a setting of a key=value connection string, with a
+// quoted or bare value
+var dsnValueRegex = regexp.MustCompile(`(?:^|\s)(\w+)\s*=\s*('(?:[^'\\]|\\.)*'|\S*)`)
+
+// dsnValue returns the value of a setting of a key=value connection string,
+// and false when the connection string does not set it
+func dsnValue(dsn, key string) (string, bool) {
+	for _, match := range dsnValueRegex.FindAllStringSubmatch(dsn, -1) {
+		if match[1] == key {
+			value := match[2]
+			if strings.HasPrefix(value, "'") {
+				value = strings.NewReplacer(`\\`, `\`, `\'`, `'`).Replace(value[1 : len(value)-1])
+			}
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// quoteDSNValue quotes a value of a key=value connection string when it is
+// empty or contains spaces, quotes or backslashes
+func quoteDSNValue(value string) string {
+	if value != "" && !strings.ContainsAny(value, ` '\`) {
+		return value
+	}
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
+
+// localDSN returns the connection string of the managed container for a database
+func (a *Adapter) localDSN(database string) string {
+	dsn := fmt.Sprintf("host=%s port=%s user=%s dbname=%s sslmode=disable", dbutils.HostIP(), a.variant.port, a.variant.user, database)
+	if a.variant.password != "" {
+		dsn += fmt.Sprintf(" password=%s", a.variant.password)
+	}
+	return dsn
+}
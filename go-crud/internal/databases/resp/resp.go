@@ -0,0 +1,853 @@
+package resp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+	"github.com/surrealdb/go-crud-bench/internal/codec"
+	"github.com/surrealdb/go-crud-bench/internal/config"
+	"github.com/surrealdb/go-crud-bench/internal/dbutils"
+	"github.com/surrealdb/go-crud-bench/internal/docker"
+	"github.com/surrealdb/go-crud-bench/internal/generators"
+)
+
+const (
+	// Default RESP port
+	defaultPort = "6379"
+
+	// Prefix of the benchmark keys
+	keyPrefix = "bench"
+
+	// Number of keys requested per SCAN iteration
+	scanBatchSize = 1000
+
+	// Time allowed for the server to accept commands
+	readinessTimeout = 60 * time.Second
+)
+
+// variant describes a database speaking the Redis protocol. Variants only
+// differ in their image and readiness check, so that the client behaviour
+// is identical across all of them.
+type variant struct {
+	name  string
+	label string
+	image string
+	cmd   []string
+	ready func(ctx context.Context, client *redis.Client) error
+}
+
+// variants contains the supported Redis compatible databases
+var variants = map[string]variant{
+	"redis": {
+		name:  "redis",
+		label: "Redis",
+		image: "redis:7.2",
+		cmd:   []string{"redis-server", "--save", "", "--appendonly", "no"},
+		ready: pingReady,
+	},
+	"dragonfly": {
+		name:  "dragonfly",
+		label: "Dragonfly",
+		image: "docker.dragonflydb.io/dragonflydb/dragonfly:v1.21.2",
+		cmd:   []string{"dragonfly", "--logtostderr", "--dbfilename", ""},
+		ready: loadedReady,
+	},
+	"keydb": {
+		name:  "keydb",
+		label: "KeyDB",
+		image: "eqalpha/keydb:x86_64_v6.3.4",
+		cmd:   []string{"keydb-server", "--save", "", "--appendonly", "no", "--server-threads", "2"},
+		ready: loadedReady,
+	},
+}
+
+// preset contains the default tuning settings for Redis compatible databases
+var preset = map[string]string{
+	"pool_size":     "64",
+	"read_timeout":  "5s",
+	"write_timeout": "5s",
+}
+
+// Adapter implements the benchmark.Adapter interface for Redis, Dragonfly and KeyDB
+type Adapter struct {
+	client      *redis.Client
+	container   *docker.Container
+	variant     variant
+	endpoint    string
+	conn        config.ConnectionOptions
+	image       string
+	privileged  bool
+	containerID string
+	tracker     *dbutils.ConnTracker
+	prefix      string
+	namespaced  bool
+	tuning      dbutils.Tuning
+	queryLog    *dbutils.QueryLog
+	steps       *dbutils.Steps
+	out         io.Writer
+}
+
+// NewAdapter creates a new adapter for the named Redis compatible database
+// (redis, dragonfly or keydb)
+func NewAdapter(name, endpoint, image string, privileged bool, conn config.ConnectionOptions) *Adapter {
+	v := variants[name]
+	if image == "" {
+		image = v.image
+	}
+
+	return &Adapter{
+		variant:    v,
+		endpoint:   endpoint,
+		conn:       conn,
+		image:      image,
+		privileged: privileged,
+		tracker:    dbutils.NewConnTracker(),
+		out:        os.Stdout,
+		prefix:     keyPrefix + ":",
+		tuning:     preset,
+	}
+}
+
+// SetNamespace isolates the benchmark under its own key prefix on a shared server
+func (a *Adapter) SetNamespace(namespace string) {
+	a.prefix = fmt.Sprintf("%s_%s:", keyPrefix, namespace)
+	a.namespaced = true
+}
+
+// Tune applies tuning overrides on top of the Redis preset
+func (a *Adapter) Tune(overrides map[string]string) (map[string]string, error) {
+	tuning, err := dbutils.NewTuning(preset, overrides)
+	if err != nil {
+		return nil, err
+	}
+	a.tuning = tuning
+	return tuning, nil
+}
+
+// Initialize sets up the database connection
+func (a *Adapter) Initialize(ctx context.Context) error {
+	addr := dbutils.HostAddress(defaultPort)
+
+	// If no endpoint is provided, start a Docker container
+	if a.endpoint == "" {
+		container, err := a.startContainer(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to start %s container: %w", a.variant.label, err)
+		}
+
+		a.container = container
+		a.containerID = container.ID
+	} else {
+		// Use provided endpoint, either host:port or a redis:// URL
+		addr = a.endpoint
+	}
+
+	connectStart := time.Now()
+	client, err := a.connect(addr)
+	if err != nil {
+		return err
+	}
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return fmt.Errorf("failed to connect to %s: %w", a.variant.label, err)
+	}
+	a.client = client
+	a.steps.Record("connect", connectStart)
+
+	return nil
+}
+
+// Cleanup performs cleanup operations
+func (a *Adapter) Cleanup(ctx context.Context) error {
+	// Remove the namespaced keys so that shared servers are left clean
+	if a.client != nil && a.namespaced {
+		dropStart := time.Now()
+		if _, err := a.deletePrefix(ctx, a.client, a.prefix); err != nil {
+			return fmt.Errorf("failed to delete keys %s*: %w", a.prefix, err)
+		}
+		a.steps.Record("schema_drop", dropStart)
+	}
+
+	// Close database connection
+	if a.client != nil {
+		a.client.Close()
+	}
+
+	// Stop and remove container if it was started
+	if a.container != nil {
+		fmt.Fprintf(a.out, "Cleaning up %s container %s...\n", a.variant.label, a.containerID)
+		stopStart := time.Now()
+		if err := a.container.Stop(ctx); err != nil {
+			return fmt.Errorf("failed to stop %s container: %w", a.variant.label, err)
+		}
+		a.steps.Record("container_stop", stopStart)
+	}
+
+	return nil
+}
+
+// Create inserts a new record, failing if the key already exists
+func (a *Adapter) Create(ctx context.Context, key string, value map[string]interface{}) error {
+	jsonData, err := codec.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal value to JSON: %w", err)
+	}
+
+	a.queryLog.Log(a.Name(), "SET NX", a.prefix+key, string(jsonData))
+	ok, err := a.client.SetNX(ctx, a.prefix+key, jsonData, 0).Result()
+	if err != nil {
+		return fmt.Errorf("failed to insert record: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("record already exists: %s", key)
+	}
+
+	return nil
+}
+
+// Read retrieves a record by key
+func (a *Adapter) Read(ctx context.Context, key string) (map[string]interface{}, error) {
+	a.queryLog.Log(a.Name(), "GET", a.prefix+key)
+	jsonData, err := a.client.Get(ctx, a.prefix+key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, fmt.Errorf("record not found: %s", key)
+		}
+		return nil, fmt.Errorf("failed to read record: %w", err)
+	}
+
+	// Parse JSON data
+	var result map[string]interface{}
+	if err := codec.Unmarshal(jsonData, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+	}
+
+	return result, nil
+}
+
+// ReadMulti retrieves several records with a single MGET
+func (a *Adapter) ReadMulti(ctx context.Context, keys []string) ([]map[string]interface{}, error) {
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = a.prefix + key
+	}
+
+	a.queryLog.Log(a.Name(), "MGET", strings.Join(prefixed, " "))
+	values, err := a.client.MGet(ctx, prefixed...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read records: %w", err)
+	}
+
+	// Missing keys are returned as nil values
+	results := make([]map[string]interface{}, 0, len(values))
+	for _, value := range values {
+		jsonData, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		var result map[string]interface{}
+		if err := codec.Unmarshal([]byte(jsonData), &result); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// Exists checks whether a record exists with EXISTS
+func (a *Adapter) Exists(ctx context.Context, key string) (bool, error) {
+	a.queryLog.Log(a.Name(), "EXISTS", a.prefix+key)
+	count, err := a.client.Exists(ctx, a.prefix+key).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check record: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// Update modifies an existing record
+func (a *Adapter) Update(ctx context.Context, key string, value map[string]interface{}) error {
+	jsonData, err := codec.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal value to JSON: %w", err)
+	}
+
+	a.queryLog.Log(a.Name(), "SET XX", a.prefix+key, string(jsonData))
+	ok, err := a.client.SetXX(ctx, a.prefix+key, jsonData, 0).Result()
+	if err != nil {
+		return fmt.Errorf("failed to update record: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("record not found: %s", key)
+	}
+
+	return nil
+}
+
+// CompareAndSet replaces a record only if its stored version still matches,
+// using an optimistic WATCH/MULTI transaction
+func (a *Adapter) CompareAndSet(ctx context.Context, key string, expected int64, value map[string]interface{}) (bool, error) {
+	jsonData, err := codec.Marshal(value)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal value to JSON: %w", err)
+	}
+
+	applied := false
+	a.queryLog.Log(a.Name(), "WATCH GET MULTI SET EXEC", a.prefix+key, expected, string(jsonData))
+	err = a.client.Watch(ctx, func(tx *redis.Tx) error {
+		current, err := tx.Get(ctx, a.prefix+key).Bytes()
+		if err != nil {
+			return err
+		}
+
+		// Compare the stored version before queueing the write
+		var stored struct {
+			Version int64 `json:"_version"`
+		}
+		if err := codec.Unmarshal(current, &stored); err != nil {
+			return fmt.Errorf("failed to unmarshal JSON data: %w", err)
+		}
+		if stored.Version != expected {
+			return nil
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, a.prefix+key, jsonData, 0)
+			return nil
+		})
+		if err == nil {
+			applied = true
+		}
+		return err
+	}, a.prefix+key)
+
+	// A key modified after WATCH aborts the transaction, which is a conflict
+	if errors.Is(err, redis.TxFailedErr) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to update record: %w", err)
+	}
+
+	return applied, nil
+}
+
+// CreateCounters resets the given counters to zero
+func (a *Adapter) CreateCounters(ctx context.Context, counters []string) error {
+	for _, counter := range counters {
+		a.queryLog.Log(a.Name(), "SET", a.counterKey(counter), 0)
+		if err := a.client.Set(ctx, a.counterKey(counter), 0, 0).Err(); err != nil {
+			return fmt.Errorf("failed to reset counter %s: %w", counter, err)
+		}
+	}
+
+	return nil
+}
+
+// Increment atomically adds one to a counter with INCR
+func (a *Adapter) Increment(ctx context.Context, counter string) error {
+	a.queryLog.Log(a.Name(), "INCR", a.counterKey(counter))
+	if err := a.client.Incr(ctx, a.counterKey(counter)).Err(); err != nil {
+		return fmt.Errorf("failed to increment counter: %w", err)
+	}
+
+	return nil
+}
+
+// Counter returns the current value of a counter
+func (a *Adapter) Counter(ctx context.Context, counter string) (int64, error) {
+	n, err := a.client.Get(ctx, a.counterKey(counter)).Int64()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read counter: %w", err)
+	}
+
+	return n, nil
+}
+
+// DropCounters deletes the counter keys
+func (a *Adapter) DropCounters(ctx context.Context, counters []string) error {
+	keys := make([]string, len(counters))
+	for i, counter := range counters {
+		keys[i] = a.counterKey(counter)
+	}
+	if err := a.client.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("failed to delete counters: %w", err)
+	}
+
+	return nil
+}
+
+// CreateGeoIndex copies the GeoJSON points of a value field of every record
+// into a GEO sorted set. The copy is a snapshot: records written afterwards
+// are not found by geo scans.
+func (a *Adapter) CreateGeoIndex(ctx context.Context, field string) error {
+	a.queryLog.Log(a.Name(), "GEOADD", a.geoKey(field))
+	iter := a.client.Scan(ctx, 0, a.prefix+"*", scanBatchSize).Iterator()
+	keys := make([]string, 0, scanBatchSize)
+	flush := func() error {
+		if len(keys) == 0 {
+			return nil
+		}
+		values, err := a.client.MGet(ctx, keys...).Result()
+		if err != nil {
+			return err
+		}
+
+		locations := make([]*redis.GeoLocation, 0, len(values))
+		for i, value := range values {
+			jsonData, ok := value.(string)
+			if !ok {
+				continue
+			}
+			var record map[string]interface{}
+			if err := codec.Unmarshal([]byte(jsonData), &record); err != nil {
+				return fmt.Errorf("failed to unmarshal JSON data: %w", err)
+			}
+			if lon, lat, ok := generators.PointCoordinates(record[field]); ok {
+				locations = append(locations, &redis.GeoLocation{
+					Name:      strings.TrimPrefix(keys[i], a.prefix),
+					Longitude: lon,
+					Latitude:  lat,
+				})
+			}
+		}
+		keys = keys[:0]
+
+		if len(locations) == 0 {
+			return nil
+		}
+		return a.client.GeoAdd(ctx, a.geoKey(field), locations...).Err()
+	}
+
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+		if len(keys) == scanBatchSize {
+			if err := flush(); err != nil {
+				return fmt.Errorf("failed to create geo index: %w", err)
+			}
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("failed to create geo index: %w", err)
+	}
+	if err := flush(); err != nil {
+		return fmt.Errorf("failed to create geo index: %w", err)
+	}
+
+	return nil
+}
+
+// DropGeoIndex removes the GEO sorted set of a value field
+func (a *Adapter) DropGeoIndex(ctx context.Context, field string) error {
+	a.queryLog.Log(a.Name(), "DEL", a.geoKey(field))
+	if err := a.client.Del(ctx, a.geoKey(field)).Err(); err != nil {
+		return fmt.Errorf("failed to drop geo index: %w", err)
+	}
+
+	return nil
+}
+
+// Subscribe delivers the keys of new records through keyspace notifications,
+// which are enabled on the server for string commands until stop is called,
+// when the previous setting is restored
+func (a *Adapter) Subscribe(ctx context.Context, handler func(key string)) (func() error, error) {
+	previous, err := a.client.ConfigGet(ctx, "notify-keyspace-events").Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keyspace notification setting: %w", err)
+	}
+	events := previous["notify-keyspace-events"]
+	restore := func() error {
+		if err := a.client.ConfigSet(context.Background(), "notify-keyspace-events", events).Err(); err != nil {
+			return fmt.Errorf("failed to restore keyspace notifications: %w", err)
+		}
+		return nil
+	}
+
+	if err := a.client.ConfigSet(ctx, "notify-keyspace-events", "K$").Err(); err != nil {
+		return nil, fmt.Errorf("failed to enable keyspace notifications: %w", err)
+	}
+
+	// Keyspace channels are named after the key, the event being the message
+	prefix := "__keyspace@0__:"
+	pubsub := a.client.PSubscribe(ctx, prefix+a.prefix+"*")
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		_ = restore()
+		return nil, fmt.Errorf("failed to subscribe to keyspace notifications: %w", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for msg := range pubsub.Channel() {
+			if msg.Payload == "set" {
+				handler(strings.TrimPrefix(msg.Channel, prefix+a.prefix))
+			}
+		}
+	}()
+
+	stop := func() error {
+		err := pubsub.Close()
+		<-done
+		if restoreErr := restore(); err == nil {
+			err = restoreErr
+		}
+		return err
+	}
+
+	return stop, nil
+}
+
+// Delete removes a record by key
+func (a *Adapter) Delete(ctx context.Context, key string) error {
+	a.queryLog.Log(a.Name(), "DEL", a.prefix+key)
+	if err := a.client.Del(ctx, a.prefix+key).Err(); err != nil {
+		return fmt.Errorf("failed to delete record: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteBatch removes several records with a single DEL
+func (a *Adapter) DeleteBatch(ctx context.Context, keys []string) error {
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = a.prefix + key
+	}
+
+	a.queryLog.Log(a.Name(), "DEL", strings.Join(prefixed, " "))
+	if err := a.client.Del(ctx, prefixed...).Err(); err != nil {
+		return fmt.Errorf("failed to delete records: %w", err)
+	}
+
+	return nil
+}
+
+// Truncate removes every record with FLUSHDB. On shared endpoints the keys of
+// other runs live in the same database, so it is refused.
+func (a *Adapter) Truncate(ctx context.Context) error {
+	if a.namespaced {
+		return fmt.Errorf("truncating is not supported on shared endpoints")
+	}
+
+	a.queryLog.Log(a.Name(), "FLUSHDB")
+	if err := a.client.FlushDB(ctx).Err(); err != nil {
+		return fmt.Errorf("failed to flush database: %w", err)
+	}
+
+	return nil
+}
+
+// Scan performs a scan operation based on the scan configuration. Keys are
+// iterated with SCAN, which is unordered, and the window is applied by the client.
+func (a *Adapter) Scan(ctx context.Context, scanConfig config.ScanConfig) (int, error) {
+	// Geo radius scans have their own query
+	if field, lon, lat, radius, ok := scanConfig.GeoWithin(); ok {
+		return a.geoWithin(ctx, field, lon, lat, radius, scanConfig)
+	}
+
+	switch scanConfig.Projection {
+	case "ID", "FULL", "COUNT":
+	default:
+		return 0, fmt.Errorf("%w projection type: %s", benchmark.ErrUnsupported, scanConfig.Projection)
+	}
+
+	a.queryLog.Log(a.Name(), "SCAN MATCH", a.prefix+"*")
+	count := 0
+	skipped := 0
+	var cursor uint64
+	for {
+		keys, next, err := a.client.Scan(ctx, cursor, a.prefix+"*", scanBatchSize).Result()
+		if err != nil {
+			return 0, fmt.Errorf("failed to execute scan: %w", err)
+		}
+
+		// Skip the keys before the start of the window
+		if skipped < scanConfig.Start {
+			n := scanConfig.Start - skipped
+			if n > len(keys) {
+				n = len(keys)
+			}
+			keys = keys[n:]
+			skipped += n
+		}
+		if scanConfig.Limit > 0 && count+len(keys) > scanConfig.Limit {
+			keys = keys[:scanConfig.Limit-count]
+		}
+
+		// Fetch the values of the keys for full projections
+		if scanConfig.Projection == "FULL" && len(keys) > 0 {
+			if err := a.client.MGet(ctx, keys...).Err(); err != nil {
+				return 0, fmt.Errorf("failed to fetch scanned records: %w", err)
+			}
+		}
+		count += len(keys)
+
+		cursor = next
+		if cursor == 0 || (scanConfig.Limit > 0 && count >= scanConfig.Limit) {
+			break
+		}
+	}
+
+	return count, nil
+}
+
+// geoWithin runs a geo radius scan with GEOSEARCH on the sorted set built by
+// CreateGeoIndex, honouring the start and limit on the client
+func (a *Adapter) geoWithin(ctx context.Context, field string, lon, lat, radiusKm float64, scanConfig config.ScanConfig) (int, error) {
+	query := &redis.GeoSearchQuery{
+		Longitude:  lon,
+		Latitude:   lat,
+		Radius:     radiusKm,
+		RadiusUnit: "km",
+	}
+	if scanConfig.Limit > 0 {
+		query.Count = scanConfig.Start + scanConfig.Limit
+	}
+
+	a.queryLog.Log(a.Name(), "GEOSEARCH", a.geoKey(field), lon, lat, radiusKm)
+	members, err := a.client.GeoSearch(ctx, a.geoKey(field), query).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute geo scan: %w", err)
+	}
+
+	count := len(members) - scanConfig.Start
+	if count < 0 {
+		count = 0
+	}
+	return count, nil
+}
+
+// Name returns the name of the database
+func (a *Adapter) Name() string {
+	return a.variant.name
+}
+
+// DropNamespace removes the keys of a namespaced run left on the server
+func (a *Adapter) DropNamespace(ctx context.Context, namespace string) ([]string, error) {
+	if a.endpoint == "" {
+		return nil, fmt.Errorf("an endpoint is required to clean up namespaced keys")
+	}
+
+	client, err := a.connect(a.endpoint)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	prefix := fmt.Sprintf("%s_%s", keyPrefix, namespace)
+	deleted, err := a.deletePrefix(ctx, client, prefix+":")
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete keys %s:*: %w", prefix, err)
+	}
+	if deleted == 0 {
+		return nil, nil
+	}
+
+	return []string{prefix}, nil
+}
+
+// SetSteps sets the recorder receiving the durations of the provisioning steps
+func (a *Adapter) SetSteps(steps *dbutils.Steps) {
+	a.steps = steps
+}
+
+// SetOutput sets the writer receiving the container messages of the adapter
+func (a *Adapter) SetOutput(w io.Writer) {
+	a.out = w
+}
+
+// SetQueryLog sets the log receiving the statements issued by the adapter
+func (a *Adapter) SetQueryLog(log *dbutils.QueryLog) {
+	a.queryLog = log
+}
+
+// WarmPool establishes the connections of the pool before the measured
+// phases, pinging the server concurrently
+func (a *Adapter) WarmPool(ctx context.Context, connections int) error {
+	return dbutils.WarmPool(ctx, connections, func(ctx context.Context) error {
+		return a.client.Ping(ctx).Err()
+	})
+}
+
+// ConnectionStats returns the connection churn counters
+func (a *Adapter) ConnectionStats() map[string]float64 {
+	return a.tracker.Metrics(nil)
+}
+
+// Container returns the managed Docker container, or nil for external endpoints
+func (a *Adapter) Container() *docker.Container {
+	return a.container
+}
+
+// connect creates a client for the given address with the tuning settings applied
+func (a *Adapter) connect(addr string) (*redis.Client, error) {
+	poolSize, err := a.tuning.Int("pool_size")
+	if err != nil {
+		return nil, err
+	}
+	readTimeout, err := a.tuning.Duration("read_timeout")
+	if err != nil {
+		return nil, err
+	}
+	writeTimeout, err := a.tuning.Duration("write_timeout")
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &redis.Options{Addr: addr}
+	if strings.Contains(addr, "://") {
+		opts, err = redis.ParseURL(addr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s endpoint: %w", a.variant.label, err)
+		}
+	}
+
+	// The connection options take precedence over the settings of the URL
+	if a.conn.Username != "" {
+		opts.Username = a.conn.Username
+	}
+	if a.conn.Password != "" {
+		opts.Password = a.conn.Password
+	}
+	if a.conn.Database != "" {
+		if opts.DB, err = strconv.Atoi(a.conn.Database); err != nil {
+			return nil, fmt.Errorf("the database of %s must be a database number, got %s", a.variant.label, a.conn.Database)
+		}
+	}
+	if a.conn.Port > 0 {
+		opts.Addr = a.conn.HostPort(opts.Addr, defaultPort)
+	}
+	if a.conn.TLS {
+		host, _, _ := net.SplitHostPort(opts.Addr)
+		if opts.TLSConfig, err = a.conn.TLSConfig(host); err != nil {
+			return nil, err
+		}
+	}
+
+	opts.PoolSize = poolSize
+	opts.ReadTimeout = readTimeout
+	opts.WriteTimeout = writeTimeout
+	opts.Dialer = a.tracker.DialContext
+
+	return redis.NewClient(opts), nil
+}
+
+// deletePrefix removes every key with the given prefix and returns the number deleted
+func (a *Adapter) deletePrefix(ctx context.Context, client *redis.Client, prefix string) (int, error) {
+	deleted := 0
+	var cursor uint64
+	for {
+		keys, next, err := client.Scan(ctx, cursor, prefix+"*", scanBatchSize).Result()
+		if err != nil {
+			return deleted, err
+		}
+		if len(keys) > 0 {
+			if err := client.Del(ctx, keys...).Err(); err != nil {
+				return deleted, err
+			}
+			deleted += len(keys)
+		}
+
+		cursor = next
+		if cursor == 0 {
+			return deleted, nil
+		}
+	}
+}
+
+// startContainer starts a server container of the variant
+func (a *Adapter) startContainer(ctx context.Context) (*docker.Container, error) {
+	label := a.variant.label
+
+	// Generate unique container name with timestamp
+	containerName := fmt.Sprintf("crud-bench-%s-%d", a.variant.name, time.Now().Unix())
+
+	// Configure container
+	ports := map[string]string{
+		"6379/tcp": defaultPort,
+	}
+
+	fmt.Fprintf(a.out, "Starting %s container '%s' with image '%s'...\n", label, containerName, a.image)
+
+	// Create and start container with the common utility
+	container, err := dbutils.CreateContainerWithCommand(ctx, a.out, containerName, a.image, ports, a.privileged, nil, a.variant.cmd, a.steps)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start %s container: %w", label, err)
+	}
+
+	fmt.Fprintf(a.out, "%s container started, waiting for it to be ready...\n", label)
+
+	// Wait until the server passes the readiness check of the variant
+	checkFunc := func(ctx context.Context) error {
+		client := redis.NewClient(&redis.Options{
+			Addr:        dbutils.HostAddress(defaultPort),
+			DialTimeout: 5 * time.Second,
+			MaxRetries:  -1,
+		})
+		defer client.Close()
+
+		if err := a.variant.ready(ctx, client); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(a.out, "%s is ready!\n", label)
+		return nil
+	}
+
+	waitStart := time.Now()
+	if err := container.WaitForHealthy(ctx, readinessTimeout, checkFunc); err != nil {
+		// Clean up container if health check fails
+		_ = container.Stop(ctx)
+		return nil, fmt.Errorf("%s health check failed: %w", label, err)
+	}
+	a.steps.Record("readiness_wait", waitStart)
+
+	return container, nil
+}
+
+// pingReady reports the server ready once it answers PING
+func pingReady(ctx context.Context, client *redis.Client) error {
+	return client.Ping(ctx).Err()
+}
+
+// loadedReady reports the server ready once it answers PING and has
+// finished loading its dataset, as reported by INFO persistence
+func loadedReady(ctx context.Context, client *redis.Client) error {
+	if err := client.Ping(ctx).Err(); err != nil {
+		return err
+	}
+
+	info, err := client.Info(ctx, "persistence").Result()
+	if err != nil {
+		return err
+	}
+	if strings.Contains(info, "loading:1") {
+		return fmt.Errorf("server is still loading its dataset")
+	}
+
+	return nil
+}
+
+// counterKey returns the key of a counter, kept outside the record prefix so
+// that scans do not see it
+func (a *Adapter) counterKey(counter string) string {
+	return strings.TrimSuffix(a.prefix, ":") + "_counters:" + counter
+}
+
+// geoKey returns the key of the GEO sorted set of a value field, kept outside
+// the record prefix so that scans do not see it
+func (a *Adapter) geoKey(field string) string {
+	return strings.TrimSuffix(a.prefix, ":") + "_geo:" + field
+}
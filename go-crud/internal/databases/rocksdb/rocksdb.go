@@ -0,0 +1,397 @@
+//go:build rocksdb
+
+package rocksdb
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/linxGnu/grocksdb"
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+	"github.com/surrealdb/go-crud-bench/internal/codec"
+	"github.com/surrealdb/go-crud-bench/internal/config"
+	"github.com/surrealdb/go-crud-bench/internal/dbutils"
+)
+
+// preset contains the default tuning settings for RocksDB
+var preset = map[string]string{
+	"block_cache_mb":      "512",
+	"write_buffer_mb":     "64",
+	"max_background_jobs": "4",
+	"bloom_bits_per_key":  "10",
+	"sync":                "false",
+}
+
+// Adapter implements the benchmark.Adapter interface for RocksDB, running in-process
+type Adapter struct {
+	db       *grocksdb.DB
+	opts     *grocksdb.Options
+	table    *grocksdb.BlockBasedTableOptions
+	readOpts *grocksdb.ReadOptions
+	writeOpt *grocksdb.WriteOptions
+	endpoint string
+	path     string
+	tempDir  string
+	tuning   dbutils.Tuning
+	queryLog *dbutils.QueryLog
+	steps    *dbutils.Steps
+}
+
+// NewAdapter creates a new RocksDB adapter. The endpoint is the path of the
+// database directory. Without an endpoint the database is created in a
+// temporary directory.
+func NewAdapter(endpoint string) *Adapter {
+	return &Adapter{
+		endpoint: endpoint,
+		tuning:   preset,
+	}
+}
+
+// Tune applies tuning overrides on top of the RocksDB preset
+func (a *Adapter) Tune(overrides map[string]string) (map[string]string, error) {
+	tuning, err := dbutils.NewTuning(preset, overrides)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := strconv.ParseBool(tuning["sync"]); err != nil {
+		return nil, fmt.Errorf("invalid value for tuning setting sync: %w", err)
+	}
+	a.tuning = tuning
+	return tuning, nil
+}
+
+// Initialize opens the RocksDB database
+func (a *Adapter) Initialize(ctx context.Context) error {
+	// Create a temporary database directory if no endpoint is provided
+	a.path = a.endpoint
+	if a.path == "" {
+		dir, err := os.MkdirTemp("", "crud-bench-rocksdb-")
+		if err != nil {
+			return fmt.Errorf("failed to create temporary directory: %w", err)
+		}
+		a.tempDir = dir
+		a.path = dir
+	}
+
+	openStart := time.Now()
+	if err := a.open(); err != nil {
+		return err
+	}
+	a.steps.Record("connect", openStart)
+
+	return nil
+}
+
+// Cleanup closes the database and removes the temporary database directory
+func (a *Adapter) Cleanup(ctx context.Context) error {
+	// Close the database and release the native options
+	if a.db != nil {
+		a.db.Close()
+	}
+	if a.readOpts != nil {
+		a.readOpts.Destroy()
+	}
+	if a.writeOpt != nil {
+		a.writeOpt.Destroy()
+	}
+	if a.opts != nil {
+		a.opts.Destroy()
+	}
+	if a.table != nil {
+		a.table.Destroy()
+	}
+
+	// Remove the temporary database directory
+	if a.tempDir != "" {
+		removeStart := time.Now()
+		if err := os.RemoveAll(a.tempDir); err != nil {
+			return fmt.Errorf("failed to remove temporary database: %w", err)
+		}
+		a.steps.Record("file_remove", removeStart)
+	}
+
+	return nil
+}
+
+// Create inserts a new record
+func (a *Adapter) Create(ctx context.Context, key string, value map[string]interface{}) error {
+	return a.CreateKey(ctx, []byte(key), value)
+}
+
+// Read retrieves a record by key
+func (a *Adapter) Read(ctx context.Context, key string) (map[string]interface{}, error) {
+	return a.ReadKey(ctx, []byte(key))
+}
+
+// ReadMulti retrieves several records with a single MultiGet
+func (a *Adapter) ReadMulti(ctx context.Context, keys []string) ([]map[string]interface{}, error) {
+	encoded := make([][]byte, len(keys))
+	for i, key := range keys {
+		encoded[i] = []byte(key)
+	}
+
+	a.queryLog.Log(a.Name(), "MultiGet", keys)
+	values, err := a.db.MultiGet(a.readOpts, encoded...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read records: %w", err)
+	}
+	defer values.Destroy()
+
+	// Missing keys are returned as empty slices
+	results := make([]map[string]interface{}, 0, len(values))
+	for _, value := range values {
+		if !value.Exists() {
+			continue
+		}
+
+		var result map[string]interface{}
+		if err := codec.Unmarshal(value.Data(), &result); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// Exists checks whether a record exists without decoding its value
+func (a *Adapter) Exists(ctx context.Context, key string) (bool, error) {
+	a.queryLog.Log(a.Name(), "Get", key)
+	value, err := a.db.Get(a.readOpts, []byte(key))
+	if err != nil {
+		return false, fmt.Errorf("failed to check record: %w", err)
+	}
+	defer value.Free()
+
+	return value.Exists(), nil
+}
+
+// Update modifies an existing record
+func (a *Adapter) Update(ctx context.Context, key string, value map[string]interface{}) error {
+	return a.UpdateKey(ctx, []byte(key), value)
+}
+
+// Delete removes a record by key
+func (a *Adapter) Delete(ctx context.Context, key string) error {
+	return a.DeleteKey(ctx, []byte(key))
+}
+
+// Scan performs a scan operation based on the scan configuration, iterating
+// the keys in order from the first and honouring the start and limit
+func (a *Adapter) Scan(ctx context.Context, scanConfig config.ScanConfig) (int, error) {
+	switch scanConfig.Projection {
+	case "ID", "FULL", "COUNT":
+	default:
+		return 0, fmt.Errorf("%w projection type: %s", benchmark.ErrUnsupported, scanConfig.Projection)
+	}
+
+	a.queryLog.Log(a.Name(), "Iterator", scanConfig.Start, scanConfig.Limit)
+	iter := a.db.NewIterator(a.readOpts)
+	defer iter.Close()
+
+	count := 0
+	skipped := 0
+	for iter.SeekToFirst(); iter.Valid(); iter.Next() {
+		// Skip the keys before the start of the window
+		if skipped < scanConfig.Start {
+			skipped++
+			continue
+		}
+
+		// Fetch the key, and the value for full projections
+		_ = iter.Key().Data()
+		if scanConfig.Projection == "FULL" {
+			_ = iter.Value().Data()
+		}
+
+		count++
+		if scanConfig.Limit > 0 && count >= scanConfig.Limit {
+			break
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return 0, fmt.Errorf("failed to execute scan: %w", err)
+	}
+
+	return count, nil
+}
+
+// Name returns the name of the database
+func (a *Adapter) Name() string {
+	return "rocksdb"
+}
+
+// SupportsKeyEncoding reports whether the adapter accepts keys in the given encoding
+func (a *Adapter) SupportsKeyEncoding(encoding benchmark.KeyEncoding) bool {
+	switch encoding {
+	case benchmark.KeyEncodingString, benchmark.KeyEncodingBytes, benchmark.KeyEncodingInt64:
+		return true
+	default:
+		return false
+	}
+}
+
+// CreateKey inserts a new record with a natively encoded key
+func (a *Adapter) CreateKey(ctx context.Context, key []byte, value map[string]interface{}) error {
+	payload, err := benchmark.NewPayload(value)
+	if err != nil {
+		return err
+	}
+	return a.CreatePayload(ctx, key, payload)
+}
+
+// ReadKey retrieves a record with a natively encoded key
+func (a *Adapter) ReadKey(ctx context.Context, key []byte) (map[string]interface{}, error) {
+	a.queryLog.Log(a.Name(), "Get", key)
+	value, err := a.db.Get(a.readOpts, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read record: %w", err)
+	}
+	defer value.Free()
+
+	if !value.Exists() {
+		return nil, fmt.Errorf("record not found: %x", key)
+	}
+
+	// Parse JSON data
+	var result map[string]interface{}
+	if err := codec.Unmarshal(value.Data(), &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+	}
+
+	return result, nil
+}
+
+// UpdateKey updates a record with a natively encoded key
+func (a *Adapter) UpdateKey(ctx context.Context, key []byte, value map[string]interface{}) error {
+	payload, err := benchmark.NewPayload(value)
+	if err != nil {
+		return err
+	}
+	return a.UpdatePayload(ctx, key, payload)
+}
+
+// CreatePayload inserts a new record with a pre-encoded payload
+func (a *Adapter) CreatePayload(ctx context.Context, key []byte, payload *benchmark.Payload) error {
+	a.queryLog.Log(a.Name(), "Put", key, payload)
+	if err := a.db.Put(a.writeOpt, key, payload.Bytes()); err != nil {
+		return fmt.Errorf("failed to insert record: %w", err)
+	}
+
+	return nil
+}
+
+// ReadPayload retrieves the stored payload of a record, copied out of the
+// memory of RocksDB
+func (a *Adapter) ReadPayload(ctx context.Context, key []byte) (*benchmark.Payload, error) {
+	a.queryLog.Log(a.Name(), "Get", key)
+	data, err := a.db.GetBytes(a.readOpts, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read record: %w", err)
+	}
+	if data == nil {
+		return nil, fmt.Errorf("record not found: %x", key)
+	}
+
+	return benchmark.PayloadOf(data), nil
+}
+
+// UpdatePayload replaces a record with a pre-encoded payload
+func (a *Adapter) UpdatePayload(ctx context.Context, key []byte, payload *benchmark.Payload) error {
+	a.queryLog.Log(a.Name(), "Put", key, payload)
+	if err := a.db.Put(a.writeOpt, key, payload.Bytes()); err != nil {
+		return fmt.Errorf("failed to update record: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteKey removes a record with a natively encoded key
+func (a *Adapter) DeleteKey(ctx context.Context, key []byte) error {
+	a.queryLog.Log(a.Name(), "Delete", key)
+	if err := a.db.Delete(a.writeOpt, key); err != nil {
+		return fmt.Errorf("failed to delete record: %w", err)
+	}
+
+	return nil
+}
+
+// Compact runs a manual compaction of the whole key range
+func (a *Adapter) Compact(ctx context.Context) error {
+	a.db.CompactRange(grocksdb.Range{})
+	return nil
+}
+
+// DataFiles returns the database directory
+func (a *Adapter) DataFiles() []string {
+	if a.path == "" {
+		return nil
+	}
+	return []string{a.path}
+}
+
+// Blocking reports that the calls of the adapter block, as the calls into the RocksDB library hold their thread
+func (a *Adapter) Blocking() bool {
+	return true
+}
+
+// SetSteps sets the recorder receiving the durations of the provisioning steps
+func (a *Adapter) SetSteps(steps *dbutils.Steps) {
+	a.steps = steps
+}
+
+// SetQueryLog sets the log receiving the operations issued by the adapter
+func (a *Adapter) SetQueryLog(log *dbutils.QueryLog) {
+	a.queryLog = log
+}
+
+// open opens the database directory with the tuning settings applied
+func (a *Adapter) open() error {
+	blockCache, err := a.tuning.Int("block_cache_mb")
+	if err != nil {
+		return err
+	}
+	writeBuffer, err := a.tuning.Int("write_buffer_mb")
+	if err != nil {
+		return err
+	}
+	backgroundJobs, err := a.tuning.Int("max_background_jobs")
+	if err != nil {
+		return err
+	}
+	bloomBits, err := a.tuning.Int("bloom_bits_per_key")
+	if err != nil {
+		return err
+	}
+	sync, err := strconv.ParseBool(a.tuning["sync"])
+	if err != nil {
+		return fmt.Errorf("invalid value for tuning setting sync: %w", err)
+	}
+
+	a.table = grocksdb.NewDefaultBlockBasedTableOptions()
+	a.table.SetBlockCache(grocksdb.NewLRUCache(uint64(blockCache) << 20))
+	if bloomBits > 0 {
+		a.table.SetFilterPolicy(grocksdb.NewBloomFilterFull(float64(bloomBits)))
+	}
+
+	a.opts = grocksdb.NewDefaultOptions()
+	a.opts.SetCreateIfMissing(true)
+	a.opts.SetWriteBufferSize(uint64(writeBuffer) << 20)
+	a.opts.SetMaxBackgroundJobs(backgroundJobs)
+	a.opts.SetBlockBasedTableFactory(a.table)
+
+	a.readOpts = grocksdb.NewDefaultReadOptions()
+	a.writeOpt = grocksdb.NewDefaultWriteOptions()
+	a.writeOpt.SetSync(sync)
+
+	db, err := grocksdb.OpenDb(a.opts, a.path)
+	if err != nil {
+		return fmt.Errorf("failed to open RocksDB database: %w", err)
+	}
+	a.db = db
+
+	return nil
+}
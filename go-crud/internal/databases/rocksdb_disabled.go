@@ -0,0 +1,15 @@
+//go:build !rocksdb
+
+package databases
+
+import (
+	"fmt"
+
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+)
+
+// newRocksDBAdapter reports that RocksDB support was not compiled in, as it
+// requires the RocksDB C library
+func newRocksDBAdapter(endpoint string) (benchmark.Adapter, error) {
+	return nil, fmt.Errorf("database rocksdb %w (rebuild with -tags rocksdb)", ErrNotBuilt)
+}
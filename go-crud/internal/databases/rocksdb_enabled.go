@@ -0,0 +1,13 @@
+//go:build rocksdb
+
+package databases
+
+import (
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+	"github.com/surrealdb/go-crud-bench/internal/databases/rocksdb"
+)
+
+// newRocksDBAdapter creates the embedded RocksDB adapter
+func newRocksDBAdapter(endpoint string) (benchmark.Adapter, error) {
+	return rocksdb.NewAdapter(endpoint), nil
+}
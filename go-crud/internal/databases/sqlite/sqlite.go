@@ -0,0 +1,899 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+	"github.com/surrealdb/go-crud-bench/internal/codec"
+	"github.com/surrealdb/go-crud-bench/internal/config"
+	"github.com/surrealdb/go-crud-bench/internal/dbutils"
+)
+
+const (
+	// Endpoint selecting an in-memory database
+	memoryEndpoint = ":memory:"
+
+	// Database file name used when no endpoint is provided
+	defaultFile = "crud-bench.db"
+
+	// Table name
+	tableName = "bench_table"
+)
+
+// preset contains the default tuning settings for SQLite
+var preset = map[string]string{
+	"max_open_conns":    "16",
+	"max_idle_conns":    "16",
+	"conn_max_lifetime": "1h",
+	"journal_mode":      "WAL",
+	"synchronous":       "NORMAL",
+	"busy_timeout":      "5000",
+}
+
+// Adapter implements the benchmark.Adapter interface for SQLite, running in-process
+type Adapter struct {
+	db         *sql.DB
+	endpoint   string
+	path       string
+	tempDir    string
+	table      string
+	namespaced bool
+	tuning     dbutils.Tuning
+	queryLog   *dbutils.QueryLog
+	steps      *dbutils.Steps
+}
+
+// NewAdapter creates a new SQLite adapter. The endpoint is the path of the
+// database file, or :memory: for an in-memory database. Without an endpoint
+// a database file is created in a temporary directory.
+func NewAdapter(endpoint string) *Adapter {
+	return &Adapter{
+		endpoint: endpoint,
+		table:    tableName,
+		tuning:   preset,
+	}
+}
+
+// SetNamespace isolates the benchmark in its own table of a shared database file
+func (a *Adapter) SetNamespace(namespace string) {
+	a.table = fmt.Sprintf("%s_%s", tableName, namespace)
+	a.namespaced = true
+}
+
+// Tune applies tuning overrides on top of the SQLite preset
+func (a *Adapter) Tune(overrides map[string]string) (map[string]string, error) {
+	tuning, err := dbutils.NewTuning(preset, overrides)
+	if err != nil {
+		return nil, err
+	}
+	a.tuning = tuning
+	return tuning, nil
+}
+
+// Initialize opens the SQLite database
+func (a *Adapter) Initialize(ctx context.Context) error {
+	// Create a temporary database file if no endpoint is provided
+	switch a.endpoint {
+	case "":
+		dir, err := os.MkdirTemp("", "crud-bench-sqlite-")
+		if err != nil {
+			return fmt.Errorf("failed to create temporary directory: %w", err)
+		}
+		a.tempDir = dir
+		a.path = filepath.Join(dir, defaultFile)
+	case memoryEndpoint:
+		a.path = ""
+	default:
+		a.path = a.endpoint
+	}
+
+	// Connect to SQLite
+	connectStart := time.Now()
+	db, err := sql.Open("sqlite3", a.dsn())
+	if err != nil {
+		return fmt.Errorf("failed to open SQLite database: %w", err)
+	}
+
+	// Set connection pool parameters
+	if err := a.tuning.ApplyPool(db); err != nil {
+		return err
+	}
+
+	// Test connection
+	if err := db.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to open SQLite database: %w", err)
+	}
+
+	a.db = db
+	a.steps.Record("connect", connectStart)
+
+	// Create table
+	schemaStart := time.Now()
+	if err := a.createTable(ctx); err != nil {
+		return fmt.Errorf("failed to create table: %w", err)
+	}
+	a.steps.Record("schema_create", schemaStart)
+
+	return nil
+}
+
+// Cleanup closes the database and removes the temporary database file
+func (a *Adapter) Cleanup(ctx context.Context) error {
+	// Drop the namespaced table so that shared database files are left clean
+	if a.db != nil && a.namespaced {
+		dropStart := time.Now()
+		if _, err := a.db.ExecContext(ctx, fmt.Sprintf("DROP TABLE IF EXISTS %s", a.table)); err != nil {
+			return fmt.Errorf("failed to drop table %s: %w", a.table, err)
+		}
+		a.steps.Record("schema_drop", dropStart)
+	}
+
+	// Close database connection
+	if a.db != nil {
+		if err := a.db.Close(); err != nil {
+			return fmt.Errorf("failed to close SQLite database: %w", err)
+		}
+	}
+
+	// Remove the temporary database file
+	if a.tempDir != "" {
+		removeStart := time.Now()
+		if err := os.RemoveAll(a.tempDir); err != nil {
+			return fmt.Errorf("failed to remove temporary database: %w", err)
+		}
+		a.steps.Record("file_remove", removeStart)
+	}
+
+	return nil
+}
+
+// Create inserts a new record
+func (a *Adapter) Create(ctx context.Context, key string, value map[string]interface{}) error {
+	query, values, err := a.insertQuery(key, value)
+	if err != nil {
+		return err
+	}
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, values...)
+	if _, err := a.db.ExecContext(ctx, query, values...); err != nil {
+		return fmt.Errorf("failed to insert record: %w", err)
+	}
+
+	return nil
+}
+
+// insertQuery builds the statement inserting a record, copying the known
+// first-level fields into their own columns
+func (a *Adapter) insertQuery(key string, value map[string]interface{}) (string, []interface{}, error) {
+	// Convert value to JSON
+	jsonData, err := codec.Marshal(value)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to marshal value to JSON: %w", err)
+	}
+
+	// Extract first-level fields for columns
+	columns := []string{"id"}
+	values := []interface{}{key}
+
+	if textVal, ok := value["text"].(string); ok {
+		columns = append(columns, "text_val")
+		values = append(values, textVal)
+	}
+
+	if intVal, ok := value["integer"].(float64); ok {
+		columns = append(columns, "integer_val")
+		values = append(values, int(intVal))
+	}
+
+	// Add JSON data column
+	columns = append(columns, "data")
+	values = append(values, string(jsonData))
+
+	// Prepare SQL statement
+	query := fmt.Sprintf(
+		"INSERT INTO %s (%s) VALUES (%s)",
+		a.table,
+		strings.Join(columns, ", "),
+		strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", "),
+	)
+
+	return query, values, nil
+}
+
+// Read retrieves a record by key
+func (a *Adapter) Read(ctx context.Context, key string) (map[string]interface{}, error) {
+	query := fmt.Sprintf("SELECT data FROM %s WHERE id = ?", a.table)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, key)
+	var jsonData string
+	err := a.db.QueryRowContext(ctx, query, key).Scan(&jsonData)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("record not found: %s", key)
+		}
+		return nil, fmt.Errorf("failed to read record: %w", err)
+	}
+
+	// Parse JSON data
+	var result map[string]interface{}
+	if err := codec.Unmarshal([]byte(jsonData), &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+	}
+
+	return result, nil
+}
+
+// ReadMulti retrieves several records with a single IN list query
+func (a *Adapter) ReadMulti(ctx context.Context, keys []string) ([]map[string]interface{}, error) {
+	query := fmt.Sprintf("SELECT data FROM %s WHERE id IN (%s)", a.table, dbutils.Placeholders(len(keys), false))
+	args := dbutils.KeyArgs(keys)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, args...)
+	records, err := dbutils.QueryJSON(ctx, a.db, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read records: %w", err)
+	}
+
+	return records, nil
+}
+
+// Exists checks whether a record exists without fetching its data
+func (a *Adapter) Exists(ctx context.Context, key string) (bool, error) {
+	query := fmt.Sprintf("SELECT 1 FROM %s WHERE id = ?", a.table)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, key)
+	var found int
+	err := a.db.QueryRowContext(ctx, query, key).Scan(&found)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check record: %w", err)
+	}
+
+	return true, nil
+}
+
+// Update modifies an existing record
+func (a *Adapter) Update(ctx context.Context, key string, value map[string]interface{}) error {
+	// Convert value to JSON
+	jsonData, err := codec.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal value to JSON: %w", err)
+	}
+
+	// Extract first-level fields for columns
+	setClauses := []string{}
+	values := []interface{}{}
+
+	if textVal, ok := value["text"].(string); ok {
+		setClauses = append(setClauses, "text_val = ?")
+		values = append(values, textVal)
+	}
+
+	if intVal, ok := value["integer"].(float64); ok {
+		setClauses = append(setClauses, "integer_val = ?")
+		values = append(values, int(intVal))
+	}
+
+	// Add JSON data column and key for WHERE clause
+	setClauses = append(setClauses, "data = ?")
+	values = append(values, string(jsonData), key)
+
+	// Prepare SQL statement
+	query := fmt.Sprintf("UPDATE %s SET %s WHERE id = ?", a.table, strings.Join(setClauses, ", "))
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, values...)
+	if _, err := a.db.ExecContext(ctx, query, values...); err != nil {
+		return fmt.Errorf("failed to update record: %w", err)
+	}
+
+	return nil
+}
+
+// CompareAndSet replaces the data of a record only if its stored version still
+// matches, reporting whether the update was applied
+func (a *Adapter) CompareAndSet(ctx context.Context, key string, expected int64, value map[string]interface{}) (bool, error) {
+	// Convert value to JSON
+	jsonData, err := codec.Marshal(value)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal value to JSON: %w", err)
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET data = ? WHERE id = ? AND COALESCE(json_extract(data, '$._version'), 0) = ?", a.table)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, string(jsonData), key, expected)
+	result, err := a.db.ExecContext(ctx, query, string(jsonData), key, expected)
+	if err != nil {
+		return false, fmt.Errorf("failed to update record: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to update record: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// CreateCounters creates the counter table and resets the given counters to zero
+func (a *Adapter) CreateCounters(ctx context.Context, counters []string) error {
+	table := a.table + "_counters"
+	query := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (id VARCHAR(255) PRIMARY KEY, n BIGINT NOT NULL)", table)
+	a.queryLog.Log(a.Name(), query)
+	if _, err := a.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to create counter table: %w", err)
+	}
+
+	query = fmt.Sprintf("INSERT OR REPLACE INTO %s (id, n) VALUES (?, 0)", table)
+	for _, counter := range counters {
+		a.queryLog.Log(a.Name(), query, counter)
+		if _, err := a.db.ExecContext(ctx, query, counter); err != nil {
+			return fmt.Errorf("failed to reset counter %s: %w", counter, err)
+		}
+	}
+
+	return nil
+}
+
+// Increment atomically adds one to a counter
+func (a *Adapter) Increment(ctx context.Context, counter string) error {
+	query := fmt.Sprintf("UPDATE %s_counters SET n = n + 1 WHERE id = ?", a.table)
+	a.queryLog.Log(a.Name(), query, counter)
+	if _, err := a.db.ExecContext(ctx, query, counter); err != nil {
+		return fmt.Errorf("failed to increment counter: %w", err)
+	}
+
+	return nil
+}
+
+// Counter returns the current value of a counter
+func (a *Adapter) Counter(ctx context.Context, counter string) (int64, error) {
+	query := fmt.Sprintf("SELECT n FROM %s_counters WHERE id = ?", a.table)
+	a.queryLog.Log(a.Name(), query, counter)
+	var n int64
+	if err := a.db.QueryRowContext(ctx, query, counter).Scan(&n); err != nil {
+		return 0, fmt.Errorf("failed to read counter: %w", err)
+	}
+
+	return n, nil
+}
+
+// DropCounters drops the counter table
+func (a *Adapter) DropCounters(ctx context.Context, counters []string) error {
+	query := fmt.Sprintf("DROP TABLE IF EXISTS %s_counters", a.table)
+	a.queryLog.Log(a.Name(), query)
+	if _, err := a.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to drop counter table: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a record by key
+func (a *Adapter) Delete(ctx context.Context, key string) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = ?", a.table)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, key)
+	if _, err := a.db.ExecContext(ctx, query, key); err != nil {
+		return fmt.Errorf("failed to delete record: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteBatch removes several records with a single IN list statement
+func (a *Adapter) DeleteBatch(ctx context.Context, keys []string) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id IN (%s)", a.table, dbutils.Placeholders(len(keys), false))
+	args := dbutils.KeyArgs(keys)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, args...)
+	if _, err := a.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to delete records: %w", err)
+	}
+
+	return nil
+}
+
+// Truncate removes every record. SQLite has no TRUNCATE, but a DELETE without
+// a WHERE clause drops the table content at once instead of row by row.
+func (a *Adapter) Truncate(ctx context.Context) error {
+	query := fmt.Sprintf("DELETE FROM %s", a.table)
+
+	a.queryLog.Log(a.Name(), query)
+	if _, err := a.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to truncate table: %w", err)
+	}
+
+	return nil
+}
+
+// SoftDelete marks a record as deleted by setting deleted_at with json_set
+func (a *Adapter) SoftDelete(ctx context.Context, key string) error {
+	query := fmt.Sprintf("UPDATE %s SET data = json_set(data, '$.deleted_at', ?) WHERE id = ?", a.table)
+	deletedAt := time.Now().UTC().Format(time.RFC3339Nano)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, deletedAt, key)
+	if _, err := a.db.ExecContext(ctx, query, deletedAt, key); err != nil {
+		return fmt.Errorf("failed to soft delete record: %w", err)
+	}
+
+	return nil
+}
+
+// ReadLive retrieves a record unless its deleted_at field is set
+func (a *Adapter) ReadLive(ctx context.Context, key string) (map[string]interface{}, bool, error) {
+	query := fmt.Sprintf("SELECT data FROM %s WHERE id = ? AND json_extract(data, '$.deleted_at') IS NULL", a.table)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, key)
+	var jsonData string
+	err := a.db.QueryRowContext(ctx, query, key).Scan(&jsonData)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to read record: %w", err)
+	}
+
+	// Parse JSON data
+	var result map[string]interface{}
+	if err := codec.Unmarshal([]byte(jsonData), &result); err != nil {
+		return nil, false, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+	}
+
+	return result, true, nil
+}
+
+// CountLive counts the records whose deleted_at field is not set
+func (a *Adapter) CountLive(ctx context.Context) (int, error) {
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE json_extract(data, '$.deleted_at') IS NULL", a.table)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query)
+	var count int
+	if err := a.db.QueryRowContext(ctx, query).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count live records: %w", err)
+	}
+
+	return count, nil
+}
+
+// CreateVersions creates the empty history table of versioned writes, keyed
+// by the record key and version so that the latest version is an index seek
+func (a *Adapter) CreateVersions(ctx context.Context) error {
+	if err := a.DropVersions(ctx); err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("CREATE TABLE %s_versions (id TEXT NOT NULL, version INTEGER NOT NULL, data TEXT, PRIMARY KEY (id, version))", a.table)
+	a.queryLog.Log(a.Name(), query)
+	if _, err := a.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to create history table: %w", err)
+	}
+
+	return nil
+}
+
+// AppendVersion inserts a new version row of a record
+func (a *Adapter) AppendVersion(ctx context.Context, key string, version int, value map[string]interface{}) error {
+	jsonData, err := codec.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal value to JSON: %w", err)
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s_versions (id, version, data) VALUES (?, ?, ?)", a.table)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, key, version)
+	if _, err := a.db.ExecContext(ctx, query, key, version, string(jsonData)); err != nil {
+		return fmt.Errorf("failed to append version: %w", err)
+	}
+
+	return nil
+}
+
+// ReadLatest retrieves the version row of a record with the highest version
+func (a *Adapter) ReadLatest(ctx context.Context, key string) (map[string]interface{}, int, error) {
+	query := fmt.Sprintf("SELECT version, data FROM %s_versions WHERE id = ? ORDER BY version DESC LIMIT 1", a.table)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, key)
+	var version int
+	var jsonData string
+	err := a.db.QueryRowContext(ctx, query, key).Scan(&version, &jsonData)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, 0, fmt.Errorf("record not found: %s", key)
+		}
+		return nil, 0, fmt.Errorf("failed to read latest version: %w", err)
+	}
+
+	// Parse JSON data
+	var result map[string]interface{}
+	if err := codec.Unmarshal([]byte(jsonData), &result); err != nil {
+		return nil, 0, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+	}
+
+	return result, version, nil
+}
+
+// DropVersions drops the history table
+func (a *Adapter) DropVersions(ctx context.Context) error {
+	query := fmt.Sprintf("DROP TABLE IF EXISTS %s_versions", a.table)
+	a.queryLog.Log(a.Name(), query)
+	if _, err := a.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to drop history table: %w", err)
+	}
+
+	return nil
+}
+
+// CreateChildTable creates the child table of the relational workload, whose
+// rows reference their parent record with a foreign key cascading deletes
+func (a *Adapter) CreateChildTable(ctx context.Context) error {
+	if err := a.DropChildTable(ctx); err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("CREATE TABLE %s_children (parent_id TEXT NOT NULL, seq INTEGER NOT NULL, data TEXT, PRIMARY KEY (parent_id, seq), FOREIGN KEY (parent_id) REFERENCES %s (id) ON DELETE CASCADE)", a.table, a.table)
+	a.queryLog.Log(a.Name(), query)
+	if _, err := a.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to create child table: %w", err)
+	}
+
+	return nil
+}
+
+// CreateWithChildren inserts a record and its child rows in a transaction
+func (a *Adapter) CreateWithChildren(ctx context.Context, key string, value map[string]interface{}, children []map[string]interface{}) error {
+	query, values, err := a.insertQuery(key, value)
+	if err != nil {
+		return err
+	}
+	childQuery := fmt.Sprintf("INSERT INTO %s_children (parent_id, seq, data) VALUES (?, ?, ?)", a.table)
+
+	// Execute queries
+	a.queryLog.Log(a.Name(), query, values...)
+	a.queryLog.Log(a.Name(), childQuery, key, len(children))
+	if err := dbutils.InsertWithChildren(ctx, a.db, query, values, childQuery, key, children); err != nil {
+		return fmt.Errorf("failed to insert record: %w", err)
+	}
+
+	return nil
+}
+
+// ReadWithChildren retrieves a record left joined with its child rows
+func (a *Adapter) ReadWithChildren(ctx context.Context, key string) (map[string]interface{}, []map[string]interface{}, error) {
+	query := fmt.Sprintf("SELECT p.data, c.data FROM %s p LEFT JOIN %s_children c ON c.parent_id = p.id WHERE p.id = ? ORDER BY c.seq", a.table, a.table)
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query, key)
+	parent, children, err := dbutils.QueryWithChildren(ctx, a.db, query, key)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, fmt.Errorf("record not found: %s", key)
+		}
+		return nil, nil, fmt.Errorf("failed to read record: %w", err)
+	}
+
+	return parent, children, nil
+}
+
+// DropChildTable drops the child table
+func (a *Adapter) DropChildTable(ctx context.Context) error {
+	query := fmt.Sprintf("DROP TABLE IF EXISTS %s_children", a.table)
+	a.queryLog.Log(a.Name(), query)
+	if _, err := a.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to drop child table: %w", err)
+	}
+
+	return nil
+}
+
+// Scan performs a scan operation based on the scan configuration
+func (a *Adapter) Scan(ctx context.Context, scanConfig config.ScanConfig) (int, error) {
+	// Full-text searches, JSON path and join scans have their own query
+	if field, term, ok := scanConfig.Search(); ok {
+		return a.search(ctx, field, term, scanConfig)
+	}
+	if path, value, ok := scanConfig.JSONPath(); ok {
+		return a.jsonPath(ctx, path, value, scanConfig)
+	}
+	if fanout, ok := scanConfig.Join(); ok {
+		return a.join(ctx, fanout, scanConfig)
+	}
+
+	// Add LIMIT and OFFSET if specified
+	var window string
+	if scanConfig.Limit > 0 {
+		window = fmt.Sprintf(" LIMIT %d", scanConfig.Limit)
+
+		if scanConfig.Start > 0 {
+			window += fmt.Sprintf(" OFFSET %d", scanConfig.Start)
+		}
+	}
+
+	// Build query based on projection type
+	var query string
+	switch scanConfig.Projection {
+	case "ID":
+		query = fmt.Sprintf("SELECT id FROM %s%s", a.table, window)
+	case "FULL":
+		query = fmt.Sprintf("SELECT * FROM %s%s", a.table, window)
+	case "COUNT":
+		query = fmt.Sprintf("SELECT COUNT(*) FROM (SELECT id FROM %s%s)", a.table, window)
+	default:
+		return 0, fmt.Errorf("%w projection type: %s", benchmark.ErrUnsupported, scanConfig.Projection)
+	}
+
+	// Execute query
+	a.queryLog.Log(a.Name(), query)
+	if scanConfig.Projection == "COUNT" {
+		var count int
+		if err := a.db.QueryRowContext(ctx, query).Scan(&count); err != nil {
+			return 0, fmt.Errorf("failed to execute count scan: %w", err)
+		}
+		return count, nil
+	}
+
+	// For ID and FULL projections, execute query and count rows
+	rows, err := a.db.QueryContext(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute scan: %w", err)
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		count++
+	}
+
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("error while scanning rows: %w", err)
+	}
+
+	return count, nil
+}
+
+// join runs a parent-child join scan, joining every parent row of the scan
+// window with at most fanout of its child rows and fetching their data
+func (a *Adapter) join(ctx context.Context, fanout int, scanConfig config.ScanConfig) (int, error) {
+	// Restrict the parent rows with LIMIT and OFFSET if specified
+	parents := a.table
+	if scanConfig.Limit > 0 {
+		window := fmt.Sprintf(" LIMIT %d", scanConfig.Limit)
+		if scanConfig.Start > 0 {
+			window += fmt.Sprintf(" OFFSET %d", scanConfig.Start)
+		}
+		parents = fmt.Sprintf("(SELECT id FROM %s%s)", a.table, window)
+	}
+
+	query := fmt.Sprintf("SELECT p.id, c.data FROM %s p JOIN %s_children c ON c.parent_id = p.id WHERE c.seq < %d", parents, a.table, fanout)
+
+	a.queryLog.Log(a.Name(), query)
+	count, err := dbutils.CountRows(ctx, a.db, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute join scan: %w", err)
+	}
+
+	return count, nil
+}
+
+// search runs a full-text search scan against the FTS4 table built by
+// CreateSearchIndex
+func (a *Adapter) search(ctx context.Context, field, term string, scanConfig config.ScanConfig) (int, error) {
+	query := fmt.Sprintf("SELECT id FROM %s WHERE body MATCH ?", a.searchTable(field))
+	if scanConfig.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", scanConfig.Limit)
+
+		if scanConfig.Start > 0 {
+			query += fmt.Sprintf(" OFFSET %d", scanConfig.Start)
+		}
+	}
+
+	a.queryLog.Log(a.Name(), query, term)
+	count, err := dbutils.CountRows(ctx, a.db, query, term)
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute search: %w", err)
+	}
+
+	return count, nil
+}
+
+// jsonPath runs a JSON path scan, comparing the value extracted from every
+// document with json_extract, which returns booleans as integers
+func (a *Adapter) jsonPath(ctx context.Context, path []string, value interface{}, scanConfig config.ScanConfig) (int, error) {
+	if b, ok := value.(bool); ok {
+		value = 0
+		if b {
+			value = 1
+		}
+	}
+
+	query := fmt.Sprintf("SELECT id FROM %s WHERE json_extract(data, '$.%s') = ?", a.table, strings.Join(path, "."))
+	if scanConfig.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", scanConfig.Limit)
+
+		if scanConfig.Start > 0 {
+			query += fmt.Sprintf(" OFFSET %d", scanConfig.Start)
+		}
+	}
+
+	a.queryLog.Log(a.Name(), query, value)
+	count, err := dbutils.CountRows(ctx, a.db, query, value)
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute JSON path scan: %w", err)
+	}
+
+	return count, nil
+}
+
+// Name returns the name of the database
+func (a *Adapter) Name() string {
+	return "sqlite"
+}
+
+// ClassifyError maps SQLite errors to error classes. The result codes are
+// only defined when the driver is built with cgo, so the classes are matched
+// on the message of the result code.
+func (a *Adapter) ClassifyError(err error) benchmark.ErrorClass {
+	message := err.Error()
+	switch {
+	case strings.Contains(message, "constraint failed"):
+		return benchmark.ErrorClassConstraint
+	case strings.Contains(message, "database is locked"), strings.Contains(message, "database table is locked"):
+		// The database is locked by a concurrent writer
+		return benchmark.ErrorClassConflict
+	default:
+		return ""
+	}
+}
+
+// CreateIndex builds a secondary index on the given value field
+func (a *Adapter) CreateIndex(ctx context.Context, name string, field string) error {
+	// Index names are database wide, so qualify them with the table name
+	query := fmt.Sprintf("CREATE INDEX %s_%s ON %s (%s)", a.table, name, a.table, indexColumn(field))
+
+	if _, err := a.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to create index: %w", err)
+	}
+
+	return nil
+}
+
+// DropIndex removes a secondary index
+func (a *Adapter) DropIndex(ctx context.Context, name string) error {
+	query := fmt.Sprintf("DROP INDEX IF EXISTS %s_%s", a.table, name)
+
+	if _, err := a.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to drop index: %w", err)
+	}
+
+	return nil
+}
+
+// CreateSearchIndex copies a value field of every record into an FTS4 table.
+// The copy is a snapshot: records written afterwards are not searchable.
+func (a *Adapter) CreateSearchIndex(ctx context.Context, field string) error {
+	table := a.searchTable(field)
+	queries := []string{
+		fmt.Sprintf("CREATE VIRTUAL TABLE %s USING fts4(id, body, notindexed=id)", table),
+		fmt.Sprintf("INSERT INTO %s (id, body) SELECT id, json_extract(data, '$.%s') FROM %s", table, field, a.table),
+	}
+
+	for _, query := range queries {
+		if _, err := a.db.ExecContext(ctx, query); err != nil {
+			return fmt.Errorf("failed to create search index: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// DropSearchIndex removes the FTS4 table of a value field
+func (a *Adapter) DropSearchIndex(ctx context.Context, field string) error {
+	query := fmt.Sprintf("DROP TABLE IF EXISTS %s", a.searchTable(field))
+
+	if _, err := a.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to drop search index: %w", err)
+	}
+
+	return nil
+}
+
+// searchTable returns the name of the FTS4 table holding a value field
+func (a *Adapter) searchTable(field string) string {
+	return fmt.Sprintf("%s_search_%s", a.table, field)
+}
+
+// Compact rebuilds the database file, reclaiming free pages
+func (a *Adapter) Compact(ctx context.Context) error {
+	if _, err := a.db.ExecContext(ctx, "VACUUM"); err != nil {
+		return fmt.Errorf("failed to vacuum database: %w", err)
+	}
+
+	return nil
+}
+
+// DataFiles returns the database file and its write-ahead log
+func (a *Adapter) DataFiles() []string {
+	if a.path == "" {
+		return nil
+	}
+	return []string{a.path, a.path + "-wal"}
+}
+
+// Blocking reports that the calls of the adapter block, as the calls into the SQLite library hold their thread
+func (a *Adapter) Blocking() bool {
+	return true
+}
+
+// SetSteps sets the recorder receiving the durations of the provisioning steps
+func (a *Adapter) SetSteps(steps *dbutils.Steps) {
+	a.steps = steps
+}
+
+// SetQueryLog sets the log receiving the statements issued by the adapter
+func (a *Adapter) SetQueryLog(log *dbutils.QueryLog) {
+	a.queryLog = log
+}
+
+// dsn returns the data source name including the pragma tuning settings
+func (a *Adapter) dsn() string {
+	// Foreign keys are enforced for the child table of the relational workload
+	pragmas := fmt.Sprintf("_journal_mode=%s&_synchronous=%s&_busy_timeout=%s&_foreign_keys=1",
+		a.tuning["journal_mode"], a.tuning["synchronous"], a.tuning["busy_timeout"])
+
+	// Pooled connections must share a single in-memory database
+	if a.path == "" {
+		return "file:crud-bench?mode=memory&cache=shared&" + pragmas
+	}
+	return fmt.Sprintf("file:%s?%s", a.path, pragmas)
+}
+
+// indexColumn returns the column or expression indexed for a value field
+func indexColumn(field string) string {
+	switch field {
+	case "text":
+		return "text_val"
+	case "integer":
+		return "integer_val"
+	default:
+		// Fall back to an expression index on the JSON document
+		return fmt.Sprintf("json_extract(data, '$.%s')", field)
+	}
+}
+
+// createTable creates the benchmark table
+func (a *Adapter) createTable(ctx context.Context) error {
+	query := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			id TEXT PRIMARY KEY,
+			text_val TEXT,
+			integer_val INTEGER,
+			data TEXT
+		)
+	`, a.table)
+
+	if _, err := a.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to create table: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,956 @@
+package surrealdb
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+	"github.com/surrealdb/go-crud-bench/internal/config"
+	"github.com/surrealdb/go-crud-bench/internal/dbutils"
+	"github.com/surrealdb/go-crud-bench/internal/docker"
+	surrealdb "github.com/surrealdb/surrealdb.go"
+	"github.com/surrealdb/surrealdb.go/pkg/connection"
+	"github.com/surrealdb/surrealdb.go/pkg/connection/gorillaws"
+	surrealhttp "github.com/surrealdb/surrealdb.go/pkg/connection/http"
+	"github.com/surrealdb/surrealdb.go/pkg/models"
+)
+
+const (
+	// Default SurrealDB Docker image
+	defaultImage = "surrealdb/surrealdb:latest"
+
+	// Default SurrealDB port
+	defaultPort = "8000"
+
+	// Default SurrealDB credentials
+	defaultUser     = "root"
+	defaultPassword = "root"
+
+	// Namespace and database used for the benchmark
+	defaultNamespace = "bench"
+	defaultDatabase  = "bench"
+
+	// Table name
+	tableName = "bench_table"
+
+	// Container name prefix
+	containerNamePrefix = "crud-bench-surrealdb"
+)
+
+// storageEngines maps the database variants to the storage engine the server is started with
+var storageEngines = map[string]string{
+	"surrealdb":           "memory",
+	"surrealdb-memory":    "memory",
+	"surrealdb-rocksdb":   "rocksdb:/tmp/crud-bench.db",
+	"surrealdb-surrealkv": "surrealkv:/tmp/crud-bench.db",
+}
+
+// dataDir is the directory of the container holding the data of the on-disk storage engines
+const dataDir = "/tmp/crud-bench.db"
+
+// Adapter implements the benchmark.Adapter interface for SurrealDB with the
+// official Go SDK, sending its RPC requests over HTTP
+type Adapter struct {
+	db          *surrealdb.DB
+	client      *http.Client
+	container   *docker.Container
+	variant     string
+	endpoint    string
+	conn        config.ConnectionOptions
+	image       string
+	privileged  bool
+	containerID string
+	url         string
+	user        string
+	password    string
+	database    string
+	tracker     *dbutils.ConnTracker
+	table       string
+	namespaced  bool
+	queryLog    *dbutils.QueryLog
+	steps       *dbutils.Steps
+	out         io.Writer
+}
+
+// NewAdapter creates a new SurrealDB adapter for the given variant
+func NewAdapter(variant, endpoint, image string, privileged bool, conn config.ConnectionOptions) *Adapter {
+	if image == "" {
+		image = defaultImage
+	}
+	database := defaultDatabase
+	if conn.Database != "" {
+		database = conn.Database
+	}
+
+	return &Adapter{
+		variant:    variant,
+		endpoint:   endpoint,
+		conn:       conn,
+		image:      image,
+		privileged: privileged,
+		database:   database,
+		tracker:    dbutils.NewConnTracker(),
+		out:        os.Stdout,
+		table:      tableName,
+	}
+}
+
+// SetNamespace isolates the benchmark in its own table on a shared endpoint
+func (a *Adapter) SetNamespace(namespace string) {
+	a.table = fmt.Sprintf("%s_%s", tableName, namespace)
+	a.namespaced = true
+}
+
+// Initialize sets up the SurrealDB database
+func (a *Adapter) Initialize(ctx context.Context) error {
+	a.user, a.password = defaultUser, defaultPassword
+
+	// If no endpoint is provided, start a Docker container
+	if a.endpoint == "" {
+		container, err := a.startContainer(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to start SurrealDB container: %w", err)
+		}
+
+		a.container = container
+		a.containerID = container.ID
+		a.url = fmt.Sprintf("http://%s", dbutils.HostAddress(defaultPort))
+	} else {
+		// Use provided endpoint, taking credentials from it when present
+		endpoint := a.endpoint
+		if !strings.Contains(endpoint, "://") {
+			endpoint = "http://" + endpoint
+		}
+		parsed, err := url.Parse(endpoint)
+		if err != nil {
+			return fmt.Errorf("invalid SurrealDB endpoint: %w", err)
+		}
+		switch parsed.Scheme {
+		case "ws":
+			parsed.Scheme = "http"
+		case "wss":
+			parsed.Scheme = "https"
+		}
+		if parsed.User != nil {
+			a.user = parsed.User.Username()
+			a.password, _ = parsed.User.Password()
+			parsed.User = nil
+		}
+
+		// The connection options take precedence over the endpoint
+		if a.conn.Username != "" {
+			a.user = a.conn.Username
+		}
+		if a.conn.Password != "" {
+			a.password = a.conn.Password
+		}
+		if a.conn.Port > 0 {
+			parsed.Host = a.conn.HostPort(parsed.Host, "")
+		}
+		if a.conn.TLS {
+			parsed.Scheme = "https"
+		}
+		parsed.Path = strings.TrimSuffix(strings.TrimSuffix(parsed.Path, "/rpc"), "/")
+		a.url = parsed.String()
+	}
+
+	// Track connections opened by the HTTP client, verifying HTTPS servers
+	// as set by the connection options
+	tlsConfig, err := a.conn.TLSConfig("")
+	if err != nil {
+		return err
+	}
+	a.client = &http.Client{
+		Transport: &http.Transport{
+			DialContext:         a.tracker.DialContext,
+			TLSClientConfig:     tlsConfig,
+			MaxIdleConnsPerHost: 100,
+		},
+	}
+
+	// Connect the SDK through the tracked HTTP client and sign in
+	connectStart := time.Now()
+	db, err := a.connect(ctx, surrealhttp.New(a.connectionConfig()).SetHTTPClient(a.client))
+	if err != nil {
+		return err
+	}
+	a.db = db
+
+	// Test connection
+	if _, err := query[any](ctx, a, "INFO FOR DB", nil); err != nil {
+		return fmt.Errorf("failed to query SurrealDB: %w", err)
+	}
+	a.steps.Record("connect", connectStart)
+
+	return nil
+}
+
+// Cleanup performs cleanup operations
+func (a *Adapter) Cleanup(ctx context.Context) error {
+	// Remove the namespaced table so that shared endpoints are left clean
+	if a.db != nil && a.namespaced {
+		dropStart := time.Now()
+		if _, err := query[any](ctx, a, fmt.Sprintf("REMOVE TABLE %s", a.table), nil); err != nil {
+			return fmt.Errorf("failed to remove table %s: %w", a.table, err)
+		}
+		a.steps.Record("schema_drop", dropStart)
+	}
+
+	// Close idle connections
+	if a.client != nil {
+		a.client.CloseIdleConnections()
+	}
+
+	// Stop and remove container if it was started
+	if a.container != nil {
+		fmt.Fprintf(a.out, "Cleaning up SurrealDB container %s...\n", a.containerID)
+		stopStart := time.Now()
+		if err := a.container.Stop(ctx); err != nil {
+			return fmt.Errorf("failed to stop SurrealDB container: %w", err)
+		}
+		a.steps.Record("container_stop", stopStart)
+	}
+
+	return nil
+}
+
+// Create inserts a new record
+func (a *Adapter) Create(ctx context.Context, key string, value map[string]interface{}) error {
+	if _, err := query[any](ctx, a, "CREATE $id CONTENT $value RETURN NONE", map[string]interface{}{"id": a.thing(key), "value": value}); err != nil {
+		return fmt.Errorf("failed to insert record: %w", err)
+	}
+
+	return nil
+}
+
+// Read retrieves a record by key
+func (a *Adapter) Read(ctx context.Context, key string) (map[string]interface{}, error) {
+	records, err := query[[]map[string]interface{}](ctx, a, "SELECT * FROM $id", map[string]interface{}{"id": a.thing(key)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read record: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("record not found: %s", key)
+	}
+
+	// Return only the stored value, as the other adapters do
+	record := records[0]
+	delete(record, "id")
+	return record, nil
+}
+
+// ReadMulti retrieves several records by selecting from a list of record ids
+func (a *Adapter) ReadMulti(ctx context.Context, keys []string) ([]map[string]interface{}, error) {
+	records, err := query[[]map[string]interface{}](ctx, a, "SELECT * FROM $ids", map[string]interface{}{"ids": a.things(keys)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read records: %w", err)
+	}
+
+	// Return only the stored values, as the other adapters do
+	for _, record := range records {
+		delete(record, "id")
+	}
+	return records, nil
+}
+
+// Exists checks whether a record exists by selecting only its id
+func (a *Adapter) Exists(ctx context.Context, key string) (bool, error) {
+	records, err := query[[]map[string]interface{}](ctx, a, "SELECT id FROM $id", map[string]interface{}{"id": a.thing(key)})
+	if err != nil {
+		return false, fmt.Errorf("failed to check record: %w", err)
+	}
+
+	return len(records) > 0, nil
+}
+
+// Update modifies an existing record
+func (a *Adapter) Update(ctx context.Context, key string, value map[string]interface{}) error {
+	if _, err := query[any](ctx, a, "UPDATE $id CONTENT $value RETURN NONE", map[string]interface{}{"id": a.thing(key), "value": value}); err != nil {
+		return fmt.Errorf("failed to update record: %w", err)
+	}
+
+	return nil
+}
+
+// CompareAndSet replaces a record only if its stored version still matches,
+// reporting whether the update was applied
+func (a *Adapter) CompareAndSet(ctx context.Context, key string, expected int64, value map[string]interface{}) (bool, error) {
+	vars := map[string]interface{}{"id": a.thing(key), "value": value, "expected": expected}
+	records, err := query[[]map[string]interface{}](ctx, a, "UPDATE $id CONTENT $value WHERE (_version ?? 0) = $expected RETURN id", vars)
+	if err != nil {
+		return false, fmt.Errorf("failed to update record: %w", err)
+	}
+
+	// A record whose version no longer matches is not returned
+	return len(records) > 0, nil
+}
+
+// CreateCounters creates the given counters at zero in the counter table
+func (a *Adapter) CreateCounters(ctx context.Context, counters []string) error {
+	if _, err := query[any](ctx, a, fmt.Sprintf("DELETE %s_counters", a.table), nil); err != nil {
+		return fmt.Errorf("failed to clear counter table: %w", err)
+	}
+
+	// Statements are sent one by one, as only the first result is checked
+	for _, counter := range counters {
+		if _, err := query[any](ctx, a, "CREATE $id CONTENT { n: 0 }", map[string]interface{}{"id": a.counter(counter)}); err != nil {
+			return fmt.Errorf("failed to create counter %s: %w", counter, err)
+		}
+	}
+
+	return nil
+}
+
+// Increment atomically adds one to a counter
+func (a *Adapter) Increment(ctx context.Context, counter string) error {
+	if _, err := query[any](ctx, a, "UPDATE $id SET n += 1 RETURN NONE", map[string]interface{}{"id": a.counter(counter)}); err != nil {
+		return fmt.Errorf("failed to increment counter: %w", err)
+	}
+
+	return nil
+}
+
+// Counter returns the current value of a counter
+func (a *Adapter) Counter(ctx context.Context, counter string) (int64, error) {
+	records, err := query[[]struct {
+		N int64 `json:"n"`
+	}](ctx, a, "SELECT n FROM $id", map[string]interface{}{"id": a.counter(counter)})
+	if err != nil {
+		return 0, fmt.Errorf("failed to read counter: %w", err)
+	}
+	if len(records) == 0 {
+		return 0, fmt.Errorf("counter not found: %s", counter)
+	}
+
+	return records[0].N, nil
+}
+
+// DropCounters removes the counter table
+func (a *Adapter) DropCounters(ctx context.Context, counters []string) error {
+	if _, err := query[any](ctx, a, fmt.Sprintf("REMOVE TABLE %s_counters", a.table), nil); err != nil {
+		return fmt.Errorf("failed to remove counter table: %w", err)
+	}
+
+	return nil
+}
+
+// Subscribe delivers the keys of new records through a live query on the
+// table, opened by the SDK on a WebSocket connection to the server, as the
+// HTTP connection of the benchmark cannot deliver notifications
+func (a *Adapter) Subscribe(ctx context.Context, handler func(key string)) (func() error, error) {
+	// The WebSocket connection dials with the default dialer of the SDK,
+	// which verifies secure servers as set by the connection options
+	tlsConfig, err := a.conn.TLSConfig("")
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		dialer := *gorillaws.DefaultDialer
+		dialer.TLSClientConfig = tlsConfig
+		gorillaws.DefaultDialer = &dialer
+	}
+
+	conf := a.connectionConfig()
+	wsURL := conf.URL
+	wsURL.Scheme = strings.Replace(wsURL.Scheme, "http", "ws", 1)
+	conf.BaseURL = fmt.Sprintf("%s://%s", wsURL.Scheme, wsURL.Host)
+	conf.URL = wsURL
+	db, err := a.connect(ctx, gorillaws.New(conf))
+	if err != nil {
+		return nil, fmt.Errorf("failed to start live query: %w", err)
+	}
+
+	a.queryLog.Log(a.Name(), "live", a.table)
+	liveID, err := surrealdb.Live(ctx, db, models.Table(a.table), false)
+	if err != nil {
+		db.Close(ctx)
+		return nil, fmt.Errorf("failed to start live query: %w", err)
+	}
+	notifications, err := db.LiveNotifications(liveID.String())
+	if err != nil {
+		db.Close(ctx)
+		return nil, fmt.Errorf("failed to start live query: %w", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for notification := range notifications {
+			if notification.Action != connection.CreateAction {
+				continue
+			}
+			record, ok := notification.Result.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if id, ok := record["id"].(models.RecordID); ok {
+				if key, ok := id.ID.(string); ok {
+					handler(key)
+				}
+			}
+		}
+	}()
+
+	stop := func() error {
+		// Closing the connection also ends the live query, so a failure to
+		// kill it is not reported
+		_ = surrealdb.Kill(context.Background(), db, liveID.String())
+		_ = db.CloseLiveNotifications(liveID.String())
+		err := db.Close(context.Background())
+		<-done
+		return err
+	}
+
+	return stop, nil
+}
+
+// Delete removes a record by key
+func (a *Adapter) Delete(ctx context.Context, key string) error {
+	if _, err := query[any](ctx, a, "DELETE $id", map[string]interface{}{"id": a.thing(key)}); err != nil {
+		return fmt.Errorf("failed to delete record: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteBatch removes several records by deleting a list of record ids
+func (a *Adapter) DeleteBatch(ctx context.Context, keys []string) error {
+	if _, err := query[any](ctx, a, "DELETE $ids", map[string]interface{}{"ids": a.things(keys)}); err != nil {
+		return fmt.Errorf("failed to delete records: %w", err)
+	}
+
+	return nil
+}
+
+// Truncate removes every record of the table, keeping its definition
+func (a *Adapter) Truncate(ctx context.Context) error {
+	if _, err := query[any](ctx, a, fmt.Sprintf("DELETE %s", a.table), nil); err != nil {
+		return fmt.Errorf("failed to truncate table: %w", err)
+	}
+
+	return nil
+}
+
+// SoftDelete marks a record as deleted by setting deleted_at to the current time
+func (a *Adapter) SoftDelete(ctx context.Context, key string) error {
+	if _, err := query[any](ctx, a, "UPDATE $id SET deleted_at = time::now() RETURN NONE", map[string]interface{}{"id": a.thing(key)}); err != nil {
+		return fmt.Errorf("failed to soft delete record: %w", err)
+	}
+
+	return nil
+}
+
+// ReadLive retrieves a record unless its deleted_at field is set
+func (a *Adapter) ReadLive(ctx context.Context, key string) (map[string]interface{}, bool, error) {
+	records, err := query[[]map[string]interface{}](ctx, a, "SELECT * FROM $id WHERE deleted_at IS NONE", map[string]interface{}{"id": a.thing(key)})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read record: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, false, nil
+	}
+
+	// Return only the stored value, as the other adapters do
+	record := records[0]
+	delete(record, "id")
+	return record, true, nil
+}
+
+// CountLive counts the records whose deleted_at field is not set
+func (a *Adapter) CountLive(ctx context.Context) (int, error) {
+	rows, err := query[[]countRow](ctx, a, fmt.Sprintf("SELECT count() FROM %s WHERE deleted_at IS NONE GROUP ALL", a.table), nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count live records: %w", err)
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	return rows[0].Count, nil
+}
+
+// CreateVersions empties the history table of versioned writes and indexes
+// it on the key and version, so that the latest version is an index seek
+func (a *Adapter) CreateVersions(ctx context.Context) error {
+	statements := []string{
+		fmt.Sprintf("DELETE %s_versions", a.table),
+		fmt.Sprintf("DEFINE INDEX %s_versions_key ON TABLE %s_versions FIELDS key, version", a.table, a.table),
+	}
+	for _, statement := range statements {
+		if _, err := query[any](ctx, a, statement, nil); err != nil {
+			return fmt.Errorf("failed to create history table: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// AppendVersion creates a new version record of a record, with the key and
+// version as its array id
+func (a *Adapter) AppendVersion(ctx context.Context, key string, version int, value map[string]interface{}) error {
+	vars := map[string]interface{}{
+		"id":    models.NewRecordID(a.table+"_versions", []interface{}{key, version}),
+		"value": map[string]interface{}{"key": key, "version": version, "data": value},
+	}
+	if _, err := query[any](ctx, a, "CREATE $id CONTENT $value RETURN NONE", vars); err != nil {
+		return fmt.Errorf("failed to append version: %w", err)
+	}
+
+	return nil
+}
+
+// ReadLatest retrieves the version record of a record with the highest version
+func (a *Adapter) ReadLatest(ctx context.Context, key string) (map[string]interface{}, int, error) {
+	records, err := query[[]struct {
+		Version int                    `json:"version"`
+		Data    map[string]interface{} `json:"data"`
+	}](ctx, a, fmt.Sprintf("SELECT version, data FROM %s_versions WHERE key = $key ORDER BY version DESC LIMIT 1", a.table), map[string]interface{}{"key": key})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read latest version: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, 0, fmt.Errorf("record not found: %s", key)
+	}
+
+	return records[0].Data, records[0].Version, nil
+}
+
+// DropVersions removes the history table
+func (a *Adapter) DropVersions(ctx context.Context) error {
+	if _, err := query[any](ctx, a, fmt.Sprintf("REMOVE TABLE %s_versions", a.table), nil); err != nil {
+		return fmt.Errorf("failed to drop history table: %w", err)
+	}
+
+	return nil
+}
+
+// CreateEdges relates a record to each of the given records through the edge
+// table, with a single RELATE statement
+func (a *Adapter) CreateEdges(ctx context.Context, from string, to []string) error {
+	vars := map[string]interface{}{"from": a.thing(from), "to": a.things(to)}
+	if _, err := query[any](ctx, a, fmt.Sprintf("RELATE $from->%s_edge->$to RETURN NONE", a.table), vars); err != nil {
+		return fmt.Errorf("failed to create edges: %w", err)
+	}
+
+	return nil
+}
+
+// Traverse follows the outgoing edges of a record for the given number of
+// hops with a graph path, counting the distinct records it ends on
+func (a *Adapter) Traverse(ctx context.Context, start string, hops int) (int, error) {
+	path := strings.Repeat(fmt.Sprintf("->%s_edge->%s", a.table, a.table), hops)
+	counts, err := query[[]int](ctx, a, fmt.Sprintf("SELECT VALUE array::len(array::distinct(%s)) FROM $id", path), map[string]interface{}{"id": a.thing(start)})
+	if err != nil {
+		return 0, fmt.Errorf("failed to traverse graph: %w", err)
+	}
+	if len(counts) == 0 {
+		return 0, fmt.Errorf("record not found: %s", start)
+	}
+
+	return counts[0], nil
+}
+
+// DropEdges removes the edge table
+func (a *Adapter) DropEdges(ctx context.Context) error {
+	if _, err := query[any](ctx, a, fmt.Sprintf("REMOVE TABLE %s_edge", a.table), nil); err != nil {
+		return fmt.Errorf("failed to drop edges: %w", err)
+	}
+
+	return nil
+}
+
+// Scan performs a scan operation based on the scan configuration
+func (a *Adapter) Scan(ctx context.Context, scanConfig config.ScanConfig) (int, error) {
+	// Full-text searches and JSON path scans have their own query
+	if field, term, ok := scanConfig.Search(); ok {
+		return a.search(ctx, field, term, scanConfig)
+	}
+	if path, value, ok := scanConfig.JSONPath(); ok {
+		return a.jsonPath(ctx, path, value, scanConfig)
+	}
+
+	// Add LIMIT and START if specified
+	var window string
+	if scanConfig.Limit > 0 {
+		window = fmt.Sprintf(" LIMIT %d", scanConfig.Limit)
+
+		if scanConfig.Start > 0 {
+			window += fmt.Sprintf(" START %d", scanConfig.Start)
+		}
+	}
+
+	// Build query based on projection type
+	var statement string
+	switch scanConfig.Projection {
+	case "ID":
+		statement = fmt.Sprintf("SELECT id FROM %s%s", a.table, window)
+	case "FULL":
+		statement = fmt.Sprintf("SELECT * FROM %s%s", a.table, window)
+	case "COUNT":
+		statement = fmt.Sprintf("SELECT count() FROM %s GROUP ALL", a.table)
+		if window != "" {
+			statement = fmt.Sprintf("SELECT count() FROM (SELECT id FROM %s%s) GROUP ALL", a.table, window)
+		}
+	default:
+		return 0, fmt.Errorf("%w projection type: %s", benchmark.ErrUnsupported, scanConfig.Projection)
+	}
+
+	if scanConfig.Projection == "COUNT" {
+		rows, err := query[[]countRow](ctx, a, statement, nil)
+		if err != nil {
+			return 0, fmt.Errorf("failed to execute scan: %w", err)
+		}
+		if len(rows) == 0 {
+			return 0, nil
+		}
+		return rows[0].Count, nil
+	}
+
+	rows, err := query[[]map[string]interface{}](ctx, a, statement, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute scan: %w", err)
+	}
+
+	return len(rows), nil
+}
+
+// search runs a full-text search scan with the matches operator, which uses
+// the search index built by CreateSearchIndex
+func (a *Adapter) search(ctx context.Context, field, term string, scanConfig config.ScanConfig) (int, error) {
+	statement := fmt.Sprintf("SELECT id FROM %s WHERE %s @@ $term", a.table, field)
+	if scanConfig.Limit > 0 {
+		statement += fmt.Sprintf(" LIMIT %d", scanConfig.Limit)
+
+		if scanConfig.Start > 0 {
+			statement += fmt.Sprintf(" START %d", scanConfig.Start)
+		}
+	}
+
+	rows, err := query[[]map[string]interface{}](ctx, a, statement, map[string]interface{}{"term": term})
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute search: %w", err)
+	}
+
+	return len(rows), nil
+}
+
+// jsonPath runs a JSON path scan with an equality condition on the field path
+func (a *Adapter) jsonPath(ctx context.Context, path []string, value interface{}, scanConfig config.ScanConfig) (int, error) {
+	statement := fmt.Sprintf("SELECT id FROM %s WHERE %s = $value", a.table, strings.Join(path, "."))
+	if scanConfig.Limit > 0 {
+		statement += fmt.Sprintf(" LIMIT %d", scanConfig.Limit)
+
+		if scanConfig.Start > 0 {
+			statement += fmt.Sprintf(" START %d", scanConfig.Start)
+		}
+	}
+
+	rows, err := query[[]map[string]interface{}](ctx, a, statement, map[string]interface{}{"value": value})
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute JSON path scan: %w", err)
+	}
+
+	return len(rows), nil
+}
+
+// Name returns the name of the database
+func (a *Adapter) Name() string {
+	return a.variant
+}
+
+// ClassifyError maps SurrealDB errors to error classes. The server only
+// returns error messages, so the classes are matched on the message.
+func (a *Adapter) ClassifyError(err error) benchmark.ErrorClass {
+	message := err.Error()
+	switch {
+	case strings.Contains(message, "already exists"):
+		return benchmark.ErrorClassConstraint
+	case strings.Contains(message, "can be retried"), strings.Contains(message, "conflict"):
+		return benchmark.ErrorClassConflict
+	default:
+		return ""
+	}
+}
+
+// CreateIndex builds a secondary index on the given value field
+func (a *Adapter) CreateIndex(ctx context.Context, name string, field string) error {
+	statement := fmt.Sprintf("DEFINE INDEX %s ON TABLE %s FIELDS %s", name, a.table, field)
+
+	if _, err := query[any](ctx, a, statement, nil); err != nil {
+		return fmt.Errorf("failed to create index: %w", err)
+	}
+
+	return nil
+}
+
+// DropIndex removes a secondary index
+func (a *Adapter) DropIndex(ctx context.Context, name string) error {
+	statement := fmt.Sprintf("REMOVE INDEX %s ON TABLE %s", name, a.table)
+
+	if _, err := query[any](ctx, a, statement, nil); err != nil {
+		return fmt.Errorf("failed to drop index: %w", err)
+	}
+
+	return nil
+}
+
+// CreateSearchIndex defines a lowercasing analyzer and a BM25 search index on
+// a value field. The statements are sent separately, as only the result of
+// the first statement of a query is checked.
+func (a *Adapter) CreateSearchIndex(ctx context.Context, field string) error {
+	statements := []string{
+		fmt.Sprintf("DEFINE ANALYZER %s_search TOKENIZERS blank FILTERS lowercase", a.table),
+		fmt.Sprintf("DEFINE INDEX search_%s ON TABLE %s FIELDS %s SEARCH ANALYZER %s_search BM25", field, a.table, field, a.table),
+	}
+
+	for _, statement := range statements {
+		if _, err := query[any](ctx, a, statement, nil); err != nil {
+			return fmt.Errorf("failed to create search index: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// DropSearchIndex removes the search index on a value field and its analyzer,
+// which is defined on the database rather than on the table
+func (a *Adapter) DropSearchIndex(ctx context.Context, field string) error {
+	statements := []string{
+		fmt.Sprintf("REMOVE INDEX search_%s ON TABLE %s", field, a.table),
+		fmt.Sprintf("REMOVE ANALYZER IF EXISTS %s_search", a.table),
+	}
+
+	for _, statement := range statements {
+		if _, err := query[any](ctx, a, statement, nil); err != nil {
+			return fmt.Errorf("failed to drop search index: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// DropNamespace removes the tables of a namespaced run left on the endpoint
+func (a *Adapter) DropNamespace(ctx context.Context, namespace string) ([]string, error) {
+	if a.endpoint == "" {
+		return nil, fmt.Errorf("an endpoint is required to clean up namespaced tables")
+	}
+	if err := a.Initialize(ctx); err != nil {
+		return nil, err
+	}
+	defer a.client.CloseIdleConnections()
+
+	info, err := query[struct {
+		Tables map[string]interface{} `json:"tables"`
+		TB     map[string]interface{} `json:"tb"`
+	}](ctx, a, "INFO FOR DB", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+	if info.Tables == nil {
+		// SurrealDB 1.x reports tables under a different key
+		info.Tables = info.TB
+	}
+
+	// Drop the tables one by one
+	var dropped []string
+	for table := range info.Tables {
+		if !dbutils.InNamespace(table, fmt.Sprintf("%s_%s", tableName, namespace)) {
+			continue
+		}
+		if _, err := query[any](ctx, a, fmt.Sprintf("REMOVE TABLE %s", table), nil); err != nil {
+			return dropped, fmt.Errorf("failed to drop table %s: %w", table, err)
+		}
+		dropped = append(dropped, table)
+	}
+
+	return dropped, nil
+}
+
+// SetSteps sets the recorder receiving the durations of the provisioning steps
+func (a *Adapter) SetSteps(steps *dbutils.Steps) {
+	a.steps = steps
+}
+
+// SetOutput sets the writer receiving the container messages of the adapter
+func (a *Adapter) SetOutput(w io.Writer) {
+	a.out = w
+}
+
+// SetQueryLog sets the log receiving the statements issued by the adapter
+func (a *Adapter) SetQueryLog(log *dbutils.QueryLog) {
+	a.queryLog = log
+}
+
+// WarmPool establishes the keep-alive connections of the HTTP client before
+// the measured phases, querying the server concurrently
+func (a *Adapter) WarmPool(ctx context.Context, connections int) error {
+	return dbutils.WarmPool(ctx, connections, func(ctx context.Context) error {
+		_, err := query[any](ctx, a, "RETURN true", nil)
+		return err
+	})
+}
+
+// ConnectionStats returns the HTTP connection churn counters
+func (a *Adapter) ConnectionStats() map[string]float64 {
+	return a.tracker.Metrics(nil)
+}
+
+// DataDir returns the directory of the container holding the data, or an
+// empty string for the memory storage engine
+func (a *Adapter) DataDir() string {
+	if storageEngines[a.variant] == "memory" {
+		return ""
+	}
+	return dataDir
+}
+
+// Container returns the managed Docker container, or nil for external endpoints
+func (a *Adapter) Container() *docker.Container {
+	return a.container
+}
+
+// thing returns the record id of a key
+func (a *Adapter) thing(key string) models.RecordID {
+	return models.NewRecordID(a.table, key)
+}
+
+// things returns the record ids of keys
+func (a *Adapter) things(keys []string) []models.RecordID {
+	things := make([]models.RecordID, len(keys))
+	for i, key := range keys {
+		things[i] = a.thing(key)
+	}
+	return things
+}
+
+// countRow is the result of a count() grouped over the whole table
+type countRow struct {
+	Count int `json:"count"`
+}
+
+// connectionConfig returns the SDK configuration of a connection to the server
+func (a *Adapter) connectionConfig() *connection.Config {
+	u, _ := url.Parse(a.url)
+	return connection.NewConfig(u)
+}
+
+// connect connects the SDK over the given connection, selects the namespace
+// and database and signs in
+func (a *Adapter) connect(ctx context.Context, conn connection.Connection) (*surrealdb.DB, error) {
+	db, err := surrealdb.FromConnection(ctx, conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to SurrealDB: %w", err)
+	}
+	// HTTP requests carry the namespace and database, which are selected
+	// before signing in
+	if err := db.Use(ctx, defaultNamespace, a.database); err != nil {
+		db.Close(ctx)
+		return nil, fmt.Errorf("failed to select the SurrealDB database: %w", err)
+	}
+	if _, err := db.SignIn(ctx, surrealdb.Auth{Username: a.user, Password: a.password}); err != nil {
+		db.Close(ctx)
+		return nil, fmt.Errorf("failed to sign in to SurrealDB: %w", err)
+	}
+	return db, nil
+}
+
+// query executes a single SurrealQL statement with its parameters and
+// returns its result
+func query[T any](ctx context.Context, a *Adapter, statement string, vars map[string]interface{}) (T, error) {
+	var result T
+	if a.queryLog != nil {
+		names := make([]string, 0, len(vars))
+		for name := range vars {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		args := make([]interface{}, len(names))
+		for i, name := range names {
+			args[i] = vars[name]
+		}
+		a.queryLog.Log(a.Name(), statement, args...)
+	}
+
+	results, err := surrealdb.Query[T](ctx, a.db, statement, vars)
+	if err != nil {
+		return result, err
+	}
+	if len(*results) == 0 {
+		return result, fmt.Errorf("empty response")
+	}
+
+	return (*results)[0].Result, nil
+}
+
+// startContainer starts a SurrealDB container with the storage engine of the variant
+func (a *Adapter) startContainer(ctx context.Context) (*docker.Container, error) {
+	// Generate unique container name with timestamp
+	containerName := fmt.Sprintf("%s-%d", containerNamePrefix, time.Now().Unix())
+
+	// Configure container
+	ports := map[string]string{
+		"8000/tcp": defaultPort,
+	}
+
+	cmd := []string{
+		"start",
+		"--user", defaultUser,
+		"--pass", defaultPassword,
+		"--bind", "0.0.0.0:8000",
+		storageEngines[a.variant],
+	}
+
+	fmt.Fprintf(a.out, "Starting SurrealDB container '%s' with image '%s'...\n", containerName, a.image)
+
+	// Create and start container with the common utility
+	container, err := dbutils.CreateContainerWithCommand(ctx, a.out, containerName, a.image, ports, a.privileged, nil, cmd, a.steps)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start SurrealDB container: %w", err)
+	}
+
+	fmt.Fprintf(a.out, "SurrealDB container started, waiting for it to be ready...\n")
+
+	// Wait for the health endpoint to respond
+	checkFunc := func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+			fmt.Sprintf("http://%s/health", dbutils.HostAddress(defaultPort)), nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("unexpected status %d", resp.StatusCode)
+		}
+
+		fmt.Fprintf(a.out, "SurrealDB is ready!\n")
+		return nil
+	}
+
+	waitStart := time.Now()
+	if err := container.WaitForHealthy(ctx, 60*time.Second, checkFunc); err != nil {
+		// Clean up container if health check fails
+		_ = container.Stop(ctx)
+		return nil, fmt.Errorf("SurrealDB health check failed: %w", err)
+	}
+	a.steps.Record("readiness_wait", waitStart)
+
+	return container, nil
+}
+
+// counter returns the record id of a counter in the counter table
+func (a *Adapter) counter(name string) models.RecordID {
+	return models.NewRecordID(a.table+"_counters", name)
+}
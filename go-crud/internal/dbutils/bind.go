@@ -0,0 +1,40 @@
+package dbutils
+
+import (
+	"net"
+)
+
+// bindAddresses are the host addresses the ports of managed containers are
+// published on
+var bindAddresses = []string{"0.0.0.0"}
+
+// SetBindAddresses publishes the ports of the containers created from now on
+// on the given host addresses, IPv4 or IPv6, instead of every IPv4 interface
+func SetBindAddresses(addresses []string) {
+	if len(addresses) > 0 {
+		bindAddresses = addresses
+	}
+}
+
+// HostIP returns the address adapters connect to their managed container on:
+// the first bind address, or the loopback address of its family when it is
+// the unspecified address
+func HostIP() string {
+	ip := net.ParseIP(bindAddresses[0])
+	switch {
+	case ip == nil:
+		return bindAddresses[0]
+	case ip.IsUnspecified() && ip.To4() != nil:
+		return "127.0.0.1"
+	case ip.IsUnspecified():
+		return "::1"
+	default:
+		return ip.String()
+	}
+}
+
+// HostAddress returns the host:port address of a port published by a managed
+// container, bracketing IPv6 addresses
+func HostAddress(port string) string {
+	return net.JoinHostPort(HostIP(), port)
+}
@@ -0,0 +1,99 @@
+package dbutils
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// clientKey is the context key of the benchmark client issuing an operation
+type clientKey struct{}
+
+// WithClient returns a context carrying the benchmark client issuing the
+// operations made with it
+func WithClient(ctx context.Context, client int) context.Context {
+	return context.WithValue(ctx, clientKey{}, client)
+}
+
+// ClientOf returns the benchmark client carried by the context, and false
+// when it carries none
+func ClientOf(ctx context.Context) (int, bool) {
+	client, ok := ctx.Value(clientKey{}).(int)
+	return client, ok
+}
+
+// ClientPools holds a connection pool for every benchmark client, so that
+// the clients connect to the database like separate client processes
+type ClientPools struct {
+	pools       []*sql.DB
+	connections int
+}
+
+// OpenClientPools opens a pool of the given number of connections for each of
+// the given number of clients, closing the pools opened already when one fails
+func OpenClientPools(ctx context.Context, clients, connections int, open func(ctx context.Context) (*sql.DB, error)) (*ClientPools, error) {
+	p := &ClientPools{pools: make([]*sql.DB, 0, clients), connections: connections}
+	for i := 0; i < clients; i++ {
+		db, err := open(ctx)
+		if err != nil {
+			p.Close()
+			return nil, err
+		}
+		p.pools = append(p.pools, db)
+	}
+	return p, nil
+}
+
+// Pool returns the pool of the client carried by the context, or the shared
+// pool when the context carries no client or the clients share the pool
+func (p *ClientPools) Pool(ctx context.Context, shared *sql.DB) *sql.DB {
+	if p == nil {
+		return shared
+	}
+	client, ok := ClientOf(ctx)
+	if !ok {
+		return shared
+	}
+	return p.pools[client%len(p.pools)]
+}
+
+// Warm establishes the connections of every pool
+func (p *ClientPools) Warm(ctx context.Context) error {
+	for _, db := range p.pools {
+		if err := WarmSQLPool(ctx, db, p.connections); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddMetrics adds the statistics of the pools of the clients to the pool
+// statistics of the shared pool returned by ConnTracker.Metrics
+func (p *ClientPools) AddMetrics(metrics map[string]float64) {
+	if p == nil {
+		return
+	}
+	for _, db := range p.pools {
+		stats := db.Stats()
+		metrics["conn_open"] += float64(stats.OpenConnections)
+		metrics["pool_wait_count"] += float64(stats.WaitCount)
+		metrics["pool_wait_ms"] += float64(stats.WaitDuration.Milliseconds())
+		metrics["pool_idle_closed"] += float64(stats.MaxIdleClosed + stats.MaxIdleTimeClosed)
+		metrics["pool_lifetime_closed"] += float64(stats.MaxLifetimeClosed)
+	}
+}
+
+// Close closes the pools of the clients
+func (p *ClientPools) Close() error {
+	if p == nil {
+		return nil
+	}
+	var errs []error
+	for _, db := range p.pools {
+		if err := db.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	p.pools = nil
+	return errors.Join(errs...)
+}
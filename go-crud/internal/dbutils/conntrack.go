@@ -0,0 +1,109 @@
+package dbutils
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// defaultKeepAlive is the TCP keepalive period used for tracked connections
+const defaultKeepAlive = 30 * time.Second
+
+// ConnTracker is a network dialer which counts the connections opened and
+// closed by a database driver, so that connection churn can be reported
+type ConnTracker struct {
+	dialer     net.Dialer
+	opened     atomic.Int64
+	closed     atomic.Int64
+	handshakes atomic.Int64
+}
+
+// NewConnTracker creates a new connection tracking dialer
+func NewConnTracker() *ConnTracker {
+	return &ConnTracker{
+		dialer: net.Dialer{KeepAlive: defaultKeepAlive},
+	}
+}
+
+// Dial opens a tracked connection
+func (t *ConnTracker) Dial(network, address string) (net.Conn, error) {
+	return t.DialContext(context.Background(), network, address)
+}
+
+// DialTimeout opens a tracked connection with a timeout
+func (t *ConnTracker) DialTimeout(network, address string, timeout time.Duration) (net.Conn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return t.DialContext(ctx, network, address)
+}
+
+// DialContext opens a tracked connection using the provided context
+func (t *ConnTracker) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	conn, err := t.dialer.DialContext(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+	t.opened.Add(1)
+	return &trackedConn{Conn: conn, tracker: t}, nil
+}
+
+// Metrics returns the connection counters, combined with the pool statistics
+// of the given database handle when one is provided. Every opened connection
+// performs a TLS handshake when TLS is enabled, counted as tls_handshakes, so
+// a high number of opened connections relative to operations indicates poor
+// session reuse.
+func (t *ConnTracker) Metrics(db *sql.DB) map[string]float64 {
+	metrics := map[string]float64{
+		"conn_opened":    float64(t.opened.Load()),
+		"conn_closed":    float64(t.closed.Load()),
+		"tls_handshakes": float64(t.handshakes.Load()),
+	}
+
+	if db != nil {
+		stats := db.Stats()
+		metrics["conn_open"] = float64(stats.OpenConnections)
+		metrics["pool_wait_count"] = float64(stats.WaitCount)
+		metrics["pool_wait_ms"] = float64(stats.WaitDuration.Milliseconds())
+		metrics["pool_idle_closed"] = float64(stats.MaxIdleClosed + stats.MaxIdleTimeClosed)
+		metrics["pool_lifetime_closed"] = float64(stats.MaxLifetimeClosed)
+	}
+
+	return metrics
+}
+
+// trackedConn records when a tracked connection is closed
+type trackedConn struct {
+	net.Conn
+	tracker *ConnTracker
+	once    sync.Once
+}
+
+// Write writes to the underlying connection, counting the TLS handshakes
+// started by the client hellos written to it
+func (c *trackedConn) Write(b []byte) (int, error) {
+	if isClientHello(b) {
+		c.tracker.handshakes.Add(1)
+	}
+	return c.Conn.Write(b)
+}
+
+// Close closes the underlying connection
+func (c *trackedConn) Close() error {
+	c.once.Do(func() {
+		c.tracker.closed.Add(1)
+	})
+	return c.Conn.Close()
+}
+
+// isClientHello reports whether a write starts with a TLS handshake record
+// holding a client hello. SQL Server wraps the handshake in the payload of a
+// pre-login packet, whose 8-byte header is skipped.
+func isClientHello(b []byte) bool {
+	if len(b) > 8 && b[0] == 0x12 {
+		b = b[8:]
+	}
+	return len(b) > 5 && b[0] == 0x16 && b[1] == 0x03 && b[5] == 0x01
+}
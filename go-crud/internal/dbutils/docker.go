@@ -3,16 +3,30 @@ package dbutils
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/surrealdb/go-crud-bench/internal/docker"
 )
 
+// replaySpec is the container specification replayed by a rerun, replacing
+// the settings of the adapters when set
+var replaySpec *docker.Spec
+
+// ReplayContainer makes the containers created from now on follow the given
+// specification, so that a rerun provisions an environment identical to the
+// recorded one rather than the current defaults of the adapter
+func ReplayContainer(spec *docker.Spec) {
+	replaySpec = spec
+}
+
 // EnsureDockerImage checks if the specified Docker image is available locally
-// and pulls it if necessary. Returns true if the image was pulled.
-func EnsureDockerImage(imageName string) (bool, error) {
+// and pulls it if necessary, printing the progress of the pull to out. Returns
+// true if the image was pulled.
+func EnsureDockerImage(out io.Writer, imageName string) (bool, error) {
 	// Check if image exists locally
 	cmd := exec.Command("docker", "image", "inspect", imageName)
 	cmd.Stderr = os.Stderr
@@ -22,9 +36,9 @@ func EnsureDockerImage(imageName string) (bool, error) {
 	}
 
 	// Image doesn't exist, pull it
-	fmt.Printf("Image not found locally, pulling %s...\n", imageName)
+	fmt.Fprintf(out, "Image not found locally, pulling %s...\n", imageName)
 	pullCmd := exec.Command("docker", "pull", imageName)
-	pullCmd.Stdout = os.Stdout
+	pullCmd.Stdout = out
 	pullCmd.Stderr = os.Stderr
 	
 	if err := pullCmd.Run(); err != nil {
@@ -34,36 +48,80 @@ func EnsureDockerImage(imageName string) (bool, error) {
 	return true, nil
 }
 
+// ImageDigest returns the repository digest of a local Docker image, such as
+// postgres@sha256:..., or an empty string for images built locally which were
+// never pushed or pulled
+func ImageDigest(imageName string) (string, error) {
+	out, err := exec.Command("docker", "image", "inspect", "--format", "{{range .RepoDigests}}{{println .}}{{end}}", imageName).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect Docker image %s: %w", imageName, err)
+	}
+	digest, _, _ := strings.Cut(strings.TrimSpace(string(out)), "\n")
+	return digest, nil
+}
+
 // CreateContainerWithRetry creates and starts a Docker container with automatic image pulling
-// if needed. It handles retries if the image is not available.
+// if needed. It handles retries if the image is not available. The image pull and container
+// start durations are recorded in steps, which may be nil, and the progress messages are
+// printed to out.
 func CreateContainerWithRetry(
 	ctx context.Context, 
+	out io.Writer,
 	containerName string,
 	imageName string,
 	ports map[string]string,
 	privileged bool,
-	env []string) (*docker.Container, error) {
+	env []string,
+	steps *Steps) (*docker.Container, error) {
+	return CreateContainerWithCommand(ctx, out, containerName, imageName, ports, privileged, env, nil, steps)
+}
+
+// CreateContainerWithCommand is like CreateContainerWithRetry but overrides the
+// command of the image, for databases configured through command line arguments
+func CreateContainerWithCommand(
+	ctx context.Context,
+	out io.Writer,
+	containerName string,
+	imageName string,
+	ports map[string]string,
+	privileged bool,
+	env []string,
+	cmd []string,
+	steps *Steps) (*docker.Container, error) {
 	
+	// Replay the recorded image, pinned to its digest
+	if replaySpec != nil {
+		imageName = replaySpec.Reference()
+		fmt.Fprintf(out, "Replaying the recorded container specification with image %s\n", imageName)
+	}
+
 	// First, ensure the image is available
-	if _, err := EnsureDockerImage(imageName); err != nil {
+	pullStart := time.Now()
+	if _, err := EnsureDockerImage(out, imageName); err != nil {
 		return nil, err
 	}
+	steps.Record("image_pull", pullStart)
+	defer steps.Record("container_start", time.Now())
 
 	// Create container
 	container, err := docker.NewContainer(containerName, imageName, ports, privileged, env)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create container: %w", err)
 	}
+	container.Cmd = cmd
+	container.BindIPs = bindAddresses
+	container.Output = out
+	replaySpec.Apply(container)
 
 	// Start container with retry if needed
 	if err := container.Start(ctx); err != nil {
 		// If container start fails, try manual image pull and retry
 		if strings.Contains(err.Error(), "No such image") {
-			fmt.Printf("Container start failed, trying to pull image %s manually...\n", imageName)
+			fmt.Fprintf(out, "Container start failed, trying to pull image %s manually...\n", imageName)
 			
 			// Manual pull as a fallback
 			pullCmd := exec.Command("docker", "pull", imageName)
-			pullCmd.Stdout = os.Stdout
+			pullCmd.Stdout = out
 			pullCmd.Stderr = os.Stderr
 			if err := pullCmd.Run(); err != nil {
 				return nil, fmt.Errorf("manual docker pull failed: %w", err)
@@ -74,6 +132,10 @@ func CreateContainerWithRetry(
 			if err != nil {
 				return nil, fmt.Errorf("failed to create container after image pull: %w", err)
 			}
+			container.Cmd = cmd
+			container.BindIPs = bindAddresses
+			container.Output = out
+			replaySpec.Apply(container)
 			
 			if err := container.Start(ctx); err != nil {
 				return nil, fmt.Errorf("failed to start container after image pull: %w", err)
@@ -0,0 +1,57 @@
+package dbutils
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Placeholders returns a comma separated list of n query placeholders, either
+// "?" or numbered from "$1" for drivers that require positional parameters
+func Placeholders(n int, numbered bool) string {
+	placeholders := make([]string, n)
+	for i := range placeholders {
+		if numbered {
+			placeholders[i] = fmt.Sprintf("$%d", i+1)
+		} else {
+			placeholders[i] = "?"
+		}
+	}
+	return strings.Join(placeholders, ", ")
+}
+
+// KeyArgs converts keys into query arguments
+func KeyArgs(keys []string) []interface{} {
+	args := make([]interface{}, len(keys))
+	for i, key := range keys {
+		args[i] = key
+	}
+	return args
+}
+
+// QueryJSON executes a query selecting a single JSON column and decodes every row
+func QueryJSON(ctx context.Context, db *sql.DB, query string, args ...interface{}) ([]map[string]interface{}, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []map[string]interface{}
+	for rows.Next() {
+		var jsonData string
+		if err := rows.Scan(&jsonData); err != nil {
+			return nil, err
+		}
+
+		var record map[string]interface{}
+		if err := json.Unmarshal([]byte(jsonData), &record); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+		}
+		records = append(records, record)
+	}
+
+	return records, rows.Err()
+}
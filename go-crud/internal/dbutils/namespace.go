@@ -0,0 +1,52 @@
+package dbutils
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// InNamespace reports whether a table belongs to the given namespaced benchmark
+// table, being the table itself or one of the tables named after it
+func InNamespace(name, table string) bool {
+	return name == table || strings.HasPrefix(name, table+"_")
+}
+
+// DropTables drops the tables returned by the given listing query which belong
+// to the given namespaced benchmark table. It is used to clean up the tables
+// of a namespaced run left behind on a shared endpoint.
+func DropTables(ctx context.Context, db *sql.DB, table string, listQuery string, args ...interface{}) ([]string, error) {
+	rows, err := db.QueryContext(ctx, listQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to list tables: %w", err)
+		}
+		if InNamespace(name, table) {
+			tables = append(tables, name)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+
+	// Drop the tables one by one, the benchmark table last as the child tables
+	// reference it
+	slices.SortFunc(tables, func(a, b string) int { return len(b) - len(a) })
+	for i, table := range tables {
+		if _, err := db.ExecContext(ctx, fmt.Sprintf("DROP TABLE IF EXISTS %s", table)); err != nil {
+			return tables[:i], fmt.Errorf("failed to drop table %s: %w", table, err)
+		}
+	}
+
+	return tables, nil
+}
@@ -0,0 +1,56 @@
+package dbutils
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+)
+
+// WarmPool runs the given number of pings concurrently, so that a driver
+// pool which opens a connection for every concurrent request establishes
+// them before the measured operations do
+func WarmPool(ctx context.Context, connections int, ping func(ctx context.Context) error) error {
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+	for i := 0; i < connections; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := ping(ctx); err != nil {
+				once.Do(func() { firstErr = err })
+			}
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// WarmSQLPool establishes the given number of connections of a database
+// handle, capped to its maximum of open connections. Every connection is held
+// until all of them are open, so that the pool cannot hand out the same one
+// twice, and they are then returned to the pool as idle connections.
+func WarmSQLPool(ctx context.Context, db *sql.DB, connections int) error {
+	if limit := db.Stats().MaxOpenConnections; limit > 0 && connections > limit {
+		connections = limit
+	}
+
+	var mu sync.Mutex
+	conns := make([]*sql.Conn, 0, connections)
+	defer func() {
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}()
+
+	return WarmPool(ctx, connections, func(ctx context.Context) error {
+		conn, err := db.Conn(ctx)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		conns = append(conns, conn)
+		mu.Unlock()
+		return conn.PingContext(ctx)
+	})
+}
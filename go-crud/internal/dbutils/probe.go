@@ -0,0 +1,49 @@
+package dbutils
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// ProbeConn reads records through a dedicated connection, separate from the
+// pool used for writes, so that read-after-write visibility can be measured
+type ProbeConn struct {
+	db    *sql.DB
+	query string
+}
+
+// NewProbeConn wraps a database handle in a probe connection. The handle is
+// limited to a single connection and query must select the JSON data of one
+// record by its id.
+func NewProbeConn(db *sql.DB, query string) *ProbeConn {
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	return &ProbeConn{db: db, query: query}
+}
+
+// Read reads a record through the probe connection
+func (p *ProbeConn) Read(ctx context.Context, key string) (map[string]interface{}, error) {
+	var jsonData string
+	err := p.db.QueryRowContext(ctx, p.query, key).Scan(&jsonData)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("record not found: %s", key)
+		}
+		return nil, fmt.Errorf("failed to read record: %w", err)
+	}
+
+	// Parse JSON data
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(jsonData), &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+	}
+
+	return result, nil
+}
+
+// Close closes the probe connection
+func (p *ProbeConn) Close() error {
+	return p.db.Close()
+}
@@ -0,0 +1,60 @@
+package dbutils
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// QueryLog prints the first statements issued by an adapter in each benchmark
+// phase, so users can verify exactly what is executed. A nil QueryLog is valid
+// and discards everything.
+type QueryLog struct {
+	mu     sync.Mutex
+	out    io.Writer
+	limit  int
+	params bool
+	phase  string
+	count  int
+}
+
+// NewQueryLog creates a query log printing up to limit statements per phase
+// to out, including statement parameters only when params is true
+func NewQueryLog(out io.Writer, limit int, params bool) *QueryLog {
+	return &QueryLog{out: out, limit: limit, params: params}
+}
+
+// SetPhase starts logging statements for a new phase
+func (l *QueryLog) SetPhase(phase string) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.phase = phase
+	l.count = 0
+}
+
+// Log records a statement and its parameters
+func (l *QueryLog) Log(adapter, statement string, args ...interface{}) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.count >= l.limit {
+		return
+	}
+	l.count++
+
+	statement = strings.Join(strings.Fields(statement), " ")
+	switch {
+	case len(args) == 0:
+		fmt.Fprintf(l.out, "[%s %s #%d] %s\n", adapter, l.phase, l.count, statement)
+	case l.params:
+		fmt.Fprintf(l.out, "[%s %s #%d] %s %v\n", adapter, l.phase, l.count, statement, args)
+	default:
+		fmt.Fprintf(l.out, "[%s %s #%d] %s [%d parameters redacted]\n", adapter, l.phase, l.count, statement, len(args))
+	}
+}
@@ -0,0 +1,81 @@
+package dbutils
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// InsertWithChildren inserts a parent row and its child rows in a single
+// transaction. The child query takes the parent key, the position of the
+// child and its JSON data as arguments.
+func InsertWithChildren(ctx context.Context, db *sql.DB, parentQuery string, parentArgs []interface{}, childQuery, key string, children []map[string]interface{}) error {
+	childData := make([]string, len(children))
+	for i, child := range children {
+		jsonData, err := json.Marshal(child)
+		if err != nil {
+			return fmt.Errorf("failed to marshal value to JSON: %w", err)
+		}
+		childData[i] = string(jsonData)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, parentQuery, parentArgs...); err != nil {
+		return err
+	}
+	for i, data := range childData {
+		if _, err := tx.ExecContext(ctx, childQuery, key, i, data); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// QueryWithChildren executes a query selecting the JSON data of a parent row
+// left joined with the JSON data of its child rows, and decodes the parent
+// and every child. It returns sql.ErrNoRows if the parent does not exist.
+func QueryWithChildren(ctx context.Context, db *sql.DB, query string, args ...interface{}) (map[string]interface{}, []map[string]interface{}, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var parent map[string]interface{}
+	var children []map[string]interface{}
+	for rows.Next() {
+		var parentData string
+		var childData sql.NullString
+		if err := rows.Scan(&parentData, &childData); err != nil {
+			return nil, nil, err
+		}
+
+		if parent == nil {
+			if err := json.Unmarshal([]byte(parentData), &parent); err != nil {
+				return nil, nil, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+			}
+		}
+		if childData.Valid {
+			var child map[string]interface{}
+			if err := json.Unmarshal([]byte(childData.String), &child); err != nil {
+				return nil, nil, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+			}
+			children = append(children, child)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	if parent == nil {
+		return nil, nil, sql.ErrNoRows
+	}
+
+	return parent, children, nil
+}
@@ -0,0 +1,22 @@
+package dbutils
+
+import (
+	"context"
+	"database/sql"
+)
+
+// CountRows executes a query and returns the number of rows it returned,
+// without decoding them
+func CountRows(ctx context.Context, db *sql.DB, query string, args ...interface{}) (int, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		count++
+	}
+	return count, rows.Err()
+}
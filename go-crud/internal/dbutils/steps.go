@@ -0,0 +1,42 @@
+package dbutils
+
+import (
+	"sync"
+	"time"
+)
+
+// Step is the duration of a single provisioning step, such as pulling the
+// image, starting the container or creating the schema
+type Step struct {
+	Name     string        `json:"name"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Steps records the provisioning steps of an adapter. A nil Steps is valid
+// and discards everything.
+type Steps struct {
+	mu    sync.Mutex
+	steps []Step
+}
+
+// Record records a step which started at the given time and has just finished
+func (s *Steps) Record(name string, start time.Time) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.steps = append(s.steps, Step{Name: name, Duration: time.Since(start)})
+}
+
+// Take returns the steps recorded so far and clears them
+func (s *Steps) Take() []Step {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	steps := s.steps
+	s.steps = nil
+	return steps
+}
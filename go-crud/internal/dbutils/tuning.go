@@ -0,0 +1,75 @@
+package dbutils
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Tuning holds the effective tuning settings of an adapter: its curated
+// preset with any user supplied overrides applied on top
+type Tuning map[string]string
+
+// NewTuning applies overrides to a preset, rejecting settings the preset does not define
+func NewTuning(preset map[string]string, overrides map[string]string) (Tuning, error) {
+	tuning := Tuning{}
+	for k, v := range preset {
+		tuning[k] = v
+	}
+
+	for k, v := range overrides {
+		if _, ok := preset[k]; !ok {
+			keys := make([]string, 0, len(preset))
+			for key := range preset {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			return nil, fmt.Errorf("unknown tuning setting %q (valid settings: %v)", k, keys)
+		}
+		tuning[k] = v
+	}
+
+	return tuning, nil
+}
+
+// Int returns a setting as an integer
+func (t Tuning) Int(key string) (int, error) {
+	v, err := strconv.Atoi(t[key])
+	if err != nil {
+		return 0, fmt.Errorf("invalid value for tuning setting %s: %w", key, err)
+	}
+	return v, nil
+}
+
+// Duration returns a setting as a duration
+func (t Tuning) Duration(key string) (time.Duration, error) {
+	v, err := time.ParseDuration(t[key])
+	if err != nil {
+		return 0, fmt.Errorf("invalid value for tuning setting %s: %w", key, err)
+	}
+	return v, nil
+}
+
+// ApplyPool configures a database/sql connection pool from the max_open_conns,
+// max_idle_conns and conn_max_lifetime settings
+func (t Tuning) ApplyPool(db *sql.DB) error {
+	maxOpen, err := t.Int("max_open_conns")
+	if err != nil {
+		return err
+	}
+	maxIdle, err := t.Int("max_idle_conns")
+	if err != nil {
+		return err
+	}
+	lifetime, err := t.Duration("conn_max_lifetime")
+	if err != nil {
+		return err
+	}
+
+	db.SetMaxOpenConns(maxOpen)
+	db.SetMaxIdleConns(maxIdle)
+	db.SetConnMaxLifetime(lifetime)
+	return nil
+}
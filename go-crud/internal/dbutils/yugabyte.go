@@ -0,0 +1,20 @@
+package dbutils
+
+// YugabyteDB serves its PostgreSQL compatible YSQL API and its Cassandra
+// compatible YCQL API from the same yugabyted process, so the YSQL and YCQL
+// adapters start the same single node container, publishing both ports
+
+// YugabyteImage is the default YugabyteDB image
+const YugabyteImage = "yugabytedb/yugabyte:2.20.7.1-b10"
+
+// YugabyteCommand starts a single node in the foreground
+var YugabyteCommand = []string{"bin/yugabyted", "start", "--background=false"}
+
+// YugabyteDataDir is the directory yugabyted stores the data of the node in
+const YugabyteDataDir = "/root/var/data"
+
+// YugabytePorts publishes the YSQL and YCQL ports of the container
+var YugabytePorts = map[string]string{
+	"5433/tcp": "5433",
+	"9042/tcp": "9042",
+}
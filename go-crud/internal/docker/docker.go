@@ -1,14 +1,18 @@
 package docker
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"os"
 	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/docker/go-connections/nat"
 )
 
@@ -20,7 +24,24 @@ type Container struct {
 	Ports      map[string]string
 	Privileged bool
 	Env        []string
+	Cmd        []string
 	Client     *client.Client
+
+	// Binds, resource limits and shared memory size, set when replaying a
+	// recorded container specification
+	Binds    []string
+	Memory   int64
+	NanoCPUs int64
+	CPUSet   string
+	ShmSize  int64
+
+	// BindIPs are the host addresses the ports are published on, every IPv4
+	// interface when empty
+	BindIPs []string
+
+	// Output receives the messages printed while pulling, stopping and
+	// removing the container, standard output when nil
+	Output io.Writer
 }
 
 // NewContainer creates a new Docker container configuration
@@ -40,6 +61,29 @@ func NewContainer(name, image string, ports map[string]string, privileged bool,
 	}, nil
 }
 
+// output returns the writer receiving the messages of the container
+func (c *Container) output() io.Writer {
+	if c.Output == nil {
+		return os.Stdout
+	}
+	return c.Output
+}
+
+// Ping checks that the Docker daemon is reachable and returns its version
+func Ping(ctx context.Context) (string, error) {
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return "", fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	defer cli.Close()
+
+	version, err := cli.ServerVersion(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to reach Docker daemon: %w", err)
+	}
+	return version.Version, nil
+}
+
 // Start starts the Docker container
 func (c *Container) Start(ctx context.Context) error {
 	// Check if image exists, pull if not
@@ -56,13 +100,20 @@ func (c *Container) Start(ctx context.Context) error {
 				break
 			}
 		}
+		// Images pinned by digest are referenced as repository@sha256:...
+		for _, digest := range img.RepoDigests {
+			if digest == c.Image {
+				imageExists = true
+				break
+			}
+		}
 		if imageExists {
 			break
 		}
 	}
 	
 	if !imageExists {
-		fmt.Printf("Pulling Docker image %s...\n", c.Image)
+		fmt.Fprintf(c.output(), "Pulling Docker image %s...\n", c.Image)
 		_, err := c.Client.ImagePull(ctx, c.Image, types.ImagePullOptions{})
 		if err != nil {
 			return fmt.Errorf("failed to pull Docker image %s: %w", c.Image, err)
@@ -73,14 +124,18 @@ func (c *Container) Start(ctx context.Context) error {
 	portBindings := nat.PortMap{}
 	exposedPorts := nat.PortSet{}
 
+	bindIPs := c.BindIPs
+	if len(bindIPs) == 0 {
+		bindIPs = []string{"0.0.0.0"}
+	}
 	for containerPort, hostPort := range c.Ports {
 		port := nat.Port(containerPort)
 		exposedPorts[port] = struct{}{}
-		portBindings[port] = []nat.PortBinding{
-			{
-				HostIP:   "0.0.0.0",
+		for _, ip := range bindIPs {
+			portBindings[port] = append(portBindings[port], nat.PortBinding{
+				HostIP:   ip,
 				HostPort: hostPort,
-			},
+			})
 		}
 	}
 
@@ -91,11 +146,19 @@ func (c *Container) Start(ctx context.Context) error {
 			Image:        c.Image,
 			ExposedPorts: exposedPorts,
 			Env:          c.Env,
+			Cmd:          c.Cmd,
 		},
 		&container.HostConfig{
 			PortBindings: portBindings,
 			Privileged:   c.Privileged,
 			AutoRemove:   true, // Automatically remove container when it stops
+			Binds:        c.Binds,
+			ShmSize:      c.ShmSize,
+			Resources: container.Resources{
+				Memory:     c.Memory,
+				NanoCPUs:   c.NanoCPUs,
+				CpusetCpus: c.CPUSet,
+			},
 		},
 		&network.NetworkingConfig{},
 		nil,
@@ -121,7 +184,7 @@ func (c *Container) Stop(ctx context.Context) error {
 		return nil
 	}
 
-	fmt.Printf("Stopping container %s...\n", c.ID)
+	fmt.Fprintf(c.output(), "Stopping container %s...\n", c.ID)
 	
 	// Stop container
 	timeout := 30 * time.Second
@@ -136,7 +199,7 @@ func (c *Container) Stop(ctx context.Context) error {
 		return fmt.Errorf("failed to remove container: %w", err)
 	}
 
-	fmt.Printf("Container %s stopped and removed\n", c.ID)
+	fmt.Fprintf(c.output(), "Container %s stopped and removed\n", c.ID)
 	return nil
 }
 
@@ -166,4 +229,44 @@ func (c *Container) WaitForHealthy(ctx context.Context, timeout time.Duration, c
 	}
 	
 	return fmt.Errorf("container health check timed out after %v", timeout)
-} 
\ No newline at end of file
+}
+
+// Exec runs a command inside the running container and returns its combined output
+func (c *Container) Exec(ctx context.Context, cmd []string) (string, error) {
+	if c.ID == "" {
+		return "", fmt.Errorf("container is not running")
+	}
+
+	// Create exec instance
+	exec, err := c.Client.ContainerExecCreate(ctx, c.ID, types.ExecConfig{
+		Cmd:          cmd,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create exec: %w", err)
+	}
+
+	// Attach to the exec instance and collect its output
+	resp, err := c.Client.ContainerExecAttach(ctx, exec.ID, types.ExecStartCheck{})
+	if err != nil {
+		return "", fmt.Errorf("failed to attach to exec: %w", err)
+	}
+	defer resp.Close()
+
+	var output bytes.Buffer
+	if _, err := stdcopy.StdCopy(&output, &output, resp.Reader); err != nil {
+		return "", fmt.Errorf("failed to read exec output: %w", err)
+	}
+
+	// Check exit code
+	inspect, err := c.Client.ContainerExecInspect(ctx, exec.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect exec: %w", err)
+	}
+	if inspect.ExitCode != 0 {
+		return output.String(), fmt.Errorf("command %v exited with code %d: %s", cmd, inspect.ExitCode, bytes.TrimSpace(output.Bytes()))
+	}
+
+	return output.String(), nil
+}
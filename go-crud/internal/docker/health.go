@@ -0,0 +1,62 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// State is the state of a container, as reported by the Docker daemon
+type State struct {
+	Running   bool
+	ExitCode  int
+	OOMKilled bool
+	Error     string
+}
+
+// State inspects the container and returns its current state
+func (c *Container) State(ctx context.Context) (*State, error) {
+	inspect, err := c.Client.ContainerInspect(ctx, c.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect container: %w", err)
+	}
+	if inspect.State == nil {
+		return nil, fmt.Errorf("container %s has no state", c.Name)
+	}
+	return &State{
+		Running:   inspect.State.Running,
+		ExitCode:  inspect.State.ExitCode,
+		OOMKilled: inspect.State.OOMKilled,
+		Error:     inspect.State.Error,
+	}, nil
+}
+
+// Logs returns the last lines the container wrote to its standard output and
+// error, which remain available after the container stopped
+func (c *Container) Logs(ctx context.Context, lines int) ([]string, error) {
+	reader, err := c.Client.ContainerLogs(ctx, c.ID, types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Tail:       strconv.Itoa(lines),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read container logs: %w", err)
+	}
+	defer reader.Close()
+
+	// Containers are created without a TTY, so both streams are multiplexed
+	var output bytes.Buffer
+	if _, err := stdcopy.StdCopy(&output, &output, reader); err != nil {
+		return nil, fmt.Errorf("failed to read container logs: %w", err)
+	}
+	text := strings.TrimRight(output.String(), "\n")
+	if text == "" {
+		return nil, nil
+	}
+	return strings.Split(text, "\n"), nil
+}
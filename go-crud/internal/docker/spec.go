@@ -0,0 +1,98 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Spec is the create specification of a container, recorded with the results
+// so that a rerun can provision an identical container
+type Spec struct {
+	Image       string            `json:"image"`
+	ImageDigest string            `json:"image_digest,omitempty"`
+	ImageID     string            `json:"image_id,omitempty"`
+	Env         []string          `json:"env,omitempty"`
+	Cmd         []string          `json:"cmd,omitempty"`
+	Ports       map[string]string `json:"ports,omitempty"`
+	Privileged  bool              `json:"privileged,omitempty"`
+	Mounts      []string          `json:"mounts,omitempty"`
+	Memory      int64             `json:"memory,omitempty"`
+	NanoCPUs    int64             `json:"nano_cpus,omitempty"`
+	CPUSet      string            `json:"cpuset,omitempty"`
+	ShmSize     int64             `json:"shm_size,omitempty"`
+}
+
+// Spec inspects the running container and returns its effective create
+// specification, including the environment and command inherited from the
+// image and the digest of the image
+func (c *Container) Spec(ctx context.Context) (*Spec, error) {
+	if c.ID == "" {
+		return nil, fmt.Errorf("container is not running")
+	}
+	info, err := c.Client.ContainerInspect(ctx, c.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect container: %w", err)
+	}
+
+	spec := &Spec{
+		Image:   c.Image,
+		ImageID: info.Image,
+		Ports:   make(map[string]string),
+	}
+	if info.Config != nil {
+		spec.Env = info.Config.Env
+		spec.Cmd = info.Config.Cmd
+	}
+	if host := info.HostConfig; host != nil {
+		for port, bindings := range host.PortBindings {
+			if len(bindings) > 0 {
+				spec.Ports[string(port)] = bindings[0].HostPort
+			}
+		}
+		spec.Privileged = host.Privileged
+		spec.Mounts = host.Binds
+		spec.Memory = host.Memory
+		spec.NanoCPUs = host.NanoCPUs
+		spec.CPUSet = host.CpusetCpus
+		spec.ShmSize = host.ShmSize
+	}
+	sort.Strings(spec.Mounts)
+
+	// Images built locally have no repository digest and are pinned by their ID only
+	image, _, err := c.Client.ImageInspectWithRaw(ctx, info.Image)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect image %s: %w", c.Image, err)
+	}
+	if len(image.RepoDigests) > 0 {
+		spec.ImageDigest = image.RepoDigests[0]
+	}
+	return spec, nil
+}
+
+// Reference returns the image reference to provision, pinned to the digest
+// of the image when it is known
+func (s *Spec) Reference() string {
+	if s.ImageDigest != "" {
+		return s.ImageDigest
+	}
+	return s.Image
+}
+
+// Apply sets the image and create settings of a container to those of the
+// specification. A nil specification leaves the container unchanged.
+func (s *Spec) Apply(c *Container) {
+	if s == nil {
+		return
+	}
+	c.Image = s.Reference()
+	c.Env = s.Env
+	c.Cmd = s.Cmd
+	c.Ports = s.Ports
+	c.Privileged = s.Privileged
+	c.Binds = s.Mounts
+	c.Memory = s.Memory
+	c.NanoCPUs = s.NanoCPUs
+	c.CPUSet = s.CPUSet
+	c.ShmSize = s.ShmSize
+}
@@ -0,0 +1,169 @@
+package generators
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand"
+)
+
+// zipfianTheta is the skew of the Zipfian distribution, the YCSB default
+const zipfianTheta = 0.99
+
+// Shares of the hotspot distribution, the hot keys receiving most accesses
+const (
+	hotspotKeys       = 0.2
+	hotspotOperations = 0.8
+)
+
+// accessOrderDraws bounds the draws made to order a key space, as a multiple
+// of its size
+const accessOrderDraws = 4
+
+// KeyChooser picks the index of the next key accessed from a key space of a
+// fixed size. Choosers are safe for concurrent use.
+type KeyChooser interface {
+	Next() int
+}
+
+// UniformKeyChooser picks every key with the same probability
+type UniformKeyChooser struct {
+	n int
+}
+
+// Next picks a key uniformly at random
+func (c *UniformKeyChooser) Next() int {
+	return rand.Intn(c.n)
+}
+
+// ZipfianKeyChooser picks keys following a Zipfian distribution, so that a
+// few keys are accessed far more often than the rest. The popular keys are
+// scattered over the key space by hashing, as in YCSB's scrambled Zipfian
+// generator, rather than being the first keys created.
+type ZipfianKeyChooser struct {
+	n     int
+	alpha float64
+	zetan float64
+	eta   float64
+	half  float64
+}
+
+// NewZipfianKeyChooser creates a Zipfian key chooser over n keys, following
+// Gray et al., "Quickly Generating Billion-Record Synthetic Databases"
+func NewZipfianKeyChooser(n int) *ZipfianKeyChooser {
+	zetan := zeta(n, zipfianTheta)
+	zeta2 := zeta(2, zipfianTheta)
+	return &ZipfianKeyChooser{
+		n:     n,
+		alpha: 1 / (1 - zipfianTheta),
+		zetan: zetan,
+		eta:   (1 - math.Pow(2/float64(n), 1-zipfianTheta)) / (1 - zeta2/zetan),
+		half:  1 + math.Pow(0.5, zipfianTheta),
+	}
+}
+
+// Next picks a key following the Zipfian distribution
+func (c *ZipfianKeyChooser) Next() int {
+	rank := c.rank()
+
+	// Scatter the ranks over the key space
+	h := fnv.New64a()
+	var buf [8]byte
+	for i := range buf {
+		buf[i] = byte(rank >> (8 * i))
+	}
+	h.Write(buf[:])
+	return int(h.Sum64() % uint64(c.n))
+}
+
+// rank picks the popularity rank of a key, 0 being the most popular
+func (c *ZipfianKeyChooser) rank() int {
+	u := rand.Float64()
+	uz := u * c.zetan
+
+	var rank int
+	switch {
+	case uz < 1:
+		rank = 0
+	case uz < c.half:
+		rank = 1
+	default:
+		rank = min(int(float64(c.n)*math.Pow(c.eta*u-c.eta+1, c.alpha)), c.n-1)
+	}
+	return rank
+}
+
+// LatestKeyChooser picks the most recently created keys most often, their
+// popularity following a Zipfian distribution from the last key created
+type LatestKeyChooser struct {
+	zipfian *ZipfianKeyChooser
+}
+
+// Next picks a key following the Zipfian distribution from the last key
+func (c *LatestKeyChooser) Next() int {
+	return c.zipfian.n - 1 - c.zipfian.rank()
+}
+
+// HotspotKeyChooser picks the keys of a hot set, the first 20% of the keys
+// created, for 80% of the accesses and the other keys for the rest, each
+// uniformly within its set
+type HotspotKeyChooser struct {
+	n   int
+	hot int
+}
+
+// Next picks a key from the hot set or the cold set
+func (c *HotspotKeyChooser) Next() int {
+	if c.hot == c.n || rand.Float64() < hotspotOperations {
+		return rand.Intn(c.hot)
+	}
+	return c.hot + rand.Intn(c.n-c.hot)
+}
+
+// zeta returns the sum of 1/i^theta for i from 1 to n
+func zeta(n int, theta float64) float64 {
+	sum := 0.0
+	for i := 1; i <= n; i++ {
+		sum += 1 / math.Pow(float64(i), theta)
+	}
+	return sum
+}
+
+// NewKeyChooser creates a key chooser over n keys for the given distribution
+func NewKeyChooser(distribution string, n int) (KeyChooser, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("cannot choose keys from an empty key space")
+	}
+	switch distribution {
+	case "", "uniform":
+		return &UniformKeyChooser{n: n}, nil
+	case "zipfian":
+		return NewZipfianKeyChooser(n), nil
+	case "latest":
+		return &LatestKeyChooser{zipfian: NewZipfianKeyChooser(n)}, nil
+	case "hotspot":
+		return &HotspotKeyChooser{n: n, hot: max(1, int(float64(n)*hotspotKeys))}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key distribution: %s", distribution)
+	}
+}
+
+// AccessOrder returns every index of a key space of n keys once, in the order
+// in which the chooser first picks them, so that the popular keys come first.
+// The keys not picked within a bounded number of draws follow in index order.
+func AccessOrder(c KeyChooser, n int) []int {
+	order := make([]int, 0, n)
+	seen := make([]bool, n)
+	for draws := 0; draws < accessOrderDraws*n && len(order) < n; draws++ {
+		if i := c.Next(); !seen[i] {
+			seen[i] = true
+			order = append(order, i)
+		}
+	}
+	for i := 0; i < n; i++ {
+		if !seen[i] {
+			order = append(order, i)
+		}
+	}
+	return order
+}
@@ -0,0 +1,69 @@
+package generators
+
+import (
+	"math"
+)
+
+// EarthRadiusKm is the mean radius of the Earth used for geo calculations
+const EarthRadiusKm = 6371.0
+
+// GeoPoint returns a GeoJSON point with the given coordinates
+func GeoPoint(lon, lat float64) map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "Point",
+		"coordinates": []interface{}{lon, lat},
+	}
+}
+
+// RandomGeoPoint generates a GeoJSON point uniformly distributed over the Earth
+func RandomGeoPoint(rnd Random) map[string]interface{} {
+	lon := rnd.Float64()*360 - 180
+	lat := math.Asin(2*rnd.Float64()-1) * 180 / math.Pi
+	return GeoPoint(lon, lat)
+}
+
+// RandomGeoPointNear generates a GeoJSON point uniformly distributed over the
+// area within radiusKm of the given centre
+func RandomGeoPointNear(rnd Random, lon, lat, radiusKm float64) map[string]interface{} {
+	// Pick an angular distance so that points are spread evenly over the cap
+	maxAngle := math.Min(radiusKm/EarthRadiusKm, math.Pi)
+	angle := math.Acos(1 - rnd.Float64()*(1-math.Cos(maxAngle)))
+	bearing := rnd.Float64() * 2 * math.Pi
+
+	// Move from the centre along the bearing by the angular distance
+	lat1 := lat * math.Pi / 180
+	lon1 := lon * math.Pi / 180
+	lat2 := math.Asin(math.Sin(lat1)*math.Cos(angle) + math.Cos(lat1)*math.Sin(angle)*math.Cos(bearing))
+	lon2 := lon1 + math.Atan2(math.Sin(bearing)*math.Sin(angle)*math.Cos(lat1), math.Cos(angle)-math.Sin(lat1)*math.Sin(lat2))
+
+	// Normalise the longitude to [-180, 180)
+	lonDeg := math.Mod(lon2*180/math.Pi+540, 360) - 180
+	return GeoPoint(lonDeg, lat2*180/math.Pi)
+}
+
+// DistanceKm returns the great-circle distance between two points using the
+// haversine formula
+func DistanceKm(lon1, lat1, lon2, lat2 float64) float64 {
+	phi1 := lat1 * math.Pi / 180
+	phi2 := lat2 * math.Pi / 180
+	dPhi := (lat2 - lat1) * math.Pi / 180
+	dLambda := (lon2 - lon1) * math.Pi / 180
+
+	h := math.Sin(dPhi/2)*math.Sin(dPhi/2) + math.Cos(phi1)*math.Cos(phi2)*math.Sin(dLambda/2)*math.Sin(dLambda/2)
+	return 2 * EarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// PointCoordinates returns the longitude and latitude of a GeoJSON point
+func PointCoordinates(value interface{}) (lon, lat float64, ok bool) {
+	point, ok := value.(map[string]interface{})
+	if !ok || point["type"] != "Point" {
+		return 0, 0, false
+	}
+	coordinates, ok := point["coordinates"].([]interface{})
+	if !ok || len(coordinates) != 2 {
+		return 0, 0, false
+	}
+	lon, lonOK := coordinates[0].(float64)
+	lat, latOK := coordinates[1].(float64)
+	return lon, lat, lonOK && latOK
+}
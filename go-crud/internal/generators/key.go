@@ -28,7 +28,7 @@ type StringKeyGenerator struct {
 
 // Generate creates a new string key
 func (g *StringKeyGenerator) Generate(index int) string {
-	return RandomString(g.Length)
+	return RandomString(RecordRandom("keys", index), g.Length)
 }
 
 // UUIDKeyGenerator generates UUID keys
@@ -36,7 +36,7 @@ type UUIDKeyGenerator struct{}
 
 // Generate creates a new UUID key
 func (g *UUIDKeyGenerator) Generate(index int) string {
-	return uuid.New().String()
+	return uuid.Must(uuid.NewRandomFromReader(RecordRandom("keys", index))).String()
 }
 
 // NewKeyGenerator creates a new key generator based on the key type
@@ -74,9 +74,14 @@ func GenerateKeys(keyType string, count int, random bool) ([]string, error) {
 		indices[i] = i
 	}
 	
-	// Randomize indices if requested
+	// Randomize indices if requested, in the same order every time once the
+	// generators are seeded
 	if random {
-		rand.Shuffle(count, func(i, j int) {
+		shuffle := rand.Shuffle
+		if seed != 0 {
+			shuffle = rand.New(rand.NewSource(seed)).Shuffle
+		}
+		shuffle(count, func(i, j int) {
 			indices[i], indices[j] = indices[j], indices[i]
 		})
 	}
@@ -0,0 +1,173 @@
+package generators
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+)
+
+// profileSampleLimit is the number of records sampled by the dataset profiler
+const profileSampleLimit = 10000
+
+// FieldProfile describes the values generated for a field of the records
+type FieldProfile struct {
+	Name          string   `json:"name"`
+	Types         []string `json:"types"`
+	Distinct      int      `json:"distinct"`
+	DistinctRatio float64  `json:"distinct_ratio"`
+	AvgBytes      float64  `json:"avg_bytes"`
+	NullRate      float64  `json:"null_rate"`
+}
+
+// DatasetProfile describes the records generated for a benchmark, so that
+// readers can judge the realism and compressibility of the data. The figures
+// are taken from a sample of the records and extrapolated to all of them.
+type DatasetProfile struct {
+	Records        int            `json:"records"`
+	Sampled        int            `json:"sampled"`
+	AvgRecordBytes float64        `json:"avg_record_bytes"`
+	LogicalBytes   int64          `json:"logical_bytes"`
+	Fields         []FieldProfile `json:"fields"`
+}
+
+// fieldStats accumulates the sampled values of a field
+type fieldStats struct {
+	types    map[string]bool
+	distinct map[string]struct{}
+	bytes    int64
+	present  int
+}
+
+// Profiler samples the records generated for a benchmark to build a dataset
+// profile. Nested objects are profiled field by field with dotted names,
+// while arrays are profiled as a single value. Profilers are safe for
+// concurrent use.
+type Profiler struct {
+	mu      sync.Mutex
+	records int
+	stride  int
+	sampled int
+	bytes   int64
+	fields  map[string]*fieldStats
+}
+
+// NewProfiler creates a profiler for the given number of records, sampling
+// evenly spread records when there are more than the sample limit
+func NewProfiler(records int) *Profiler {
+	stride := records / profileSampleLimit
+	if stride < 1 {
+		stride = 1
+	}
+	return &Profiler{records: records, stride: stride, fields: make(map[string]*fieldStats)}
+}
+
+// Observe profiles the value generated for the record at the given index if
+// it is sampled. A nil profiler ignores every value.
+func (p *Profiler) Observe(index int, value map[string]interface{}) {
+	if p == nil || index%p.stride != 0 {
+		return
+	}
+
+	// Encode outside the lock, the record size being that of its JSON encoding
+	encoded := make(map[string][]byte)
+	flatten("", value, encoded)
+	record, _ := json.Marshal(value)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sampled++
+	p.bytes += int64(len(record))
+	for name, data := range encoded {
+		stats, ok := p.fields[name]
+		if !ok {
+			stats = &fieldStats{types: make(map[string]bool), distinct: make(map[string]struct{})}
+			p.fields[name] = stats
+		}
+		stats.types[jsonType(data)] = true
+		if string(data) == "null" {
+			continue
+		}
+		stats.present++
+		stats.bytes += int64(len(data))
+		stats.distinct[string(data)] = struct{}{}
+	}
+}
+
+// Profile returns the profile of the sampled records, or nil if no record
+// has been sampled
+func (p *Profiler) Profile() *DatasetProfile {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.sampled == 0 {
+		return nil
+	}
+
+	avg := float64(p.bytes) / float64(p.sampled)
+	profile := &DatasetProfile{
+		Records:        p.records,
+		Sampled:        p.sampled,
+		AvgRecordBytes: avg,
+		LogicalBytes:   int64(avg * float64(p.records)),
+	}
+	for name, stats := range p.fields {
+		field := FieldProfile{
+			Name:          name,
+			Distinct:      len(stats.distinct),
+			DistinctRatio: float64(len(stats.distinct)) / float64(p.sampled),
+			NullRate:      1 - float64(stats.present)/float64(p.sampled),
+		}
+		if stats.present > 0 {
+			field.AvgBytes = float64(stats.bytes) / float64(stats.present)
+		}
+		for t := range stats.types {
+			field.Types = append(field.Types, t)
+		}
+		sort.Strings(field.Types)
+		profile.Fields = append(profile.Fields, field)
+	}
+	sort.Slice(profile.Fields, func(i, j int) bool { return profile.Fields[i].Name < profile.Fields[j].Name })
+	return profile
+}
+
+// flatten encodes every leaf field of a value, naming nested fields with
+// dotted paths
+func flatten(prefix string, value map[string]interface{}, out map[string][]byte) {
+	for k, v := range value {
+		name := k
+		if prefix != "" {
+			name = prefix + "." + k
+		}
+		if nested, ok := v.(map[string]interface{}); ok {
+			flatten(name, nested, out)
+			continue
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			continue
+		}
+		out[name] = data
+	}
+}
+
+// jsonType returns the JSON type of an encoded value
+func jsonType(data []byte) string {
+	switch {
+	case len(data) == 0:
+		return "unknown"
+	case data[0] == '"':
+		return "string"
+	case data[0] == '[':
+		return "array"
+	case data[0] == '{':
+		return "object"
+	case data[0] == 't' || data[0] == 'f':
+		return "bool"
+	case data[0] == 'n':
+		return "null"
+	default:
+		return "number"
+	}
+}
@@ -0,0 +1,88 @@
+package generators
+
+import (
+	crand "crypto/rand"
+	"math/rand"
+)
+
+// Random is the source of the random numbers of a generated key or value
+type Random interface {
+	Intn(n int) int
+	Int31() int32
+	Float32() float32
+	Float64() float64
+	Read(p []byte) (int, error)
+}
+
+// sharedRandom draws from the shared generator of math/rand, and reads UUID
+// bytes from the system generator
+type sharedRandom struct{}
+
+func (sharedRandom) Intn(n int) int             { return rand.Intn(n) }
+func (sharedRandom) Int31() int32               { return rand.Int31() }
+func (sharedRandom) Float32() float32           { return rand.Float32() }
+func (sharedRandom) Float64() float64           { return rand.Float64() }
+func (sharedRandom) Read(p []byte) (int, error) { return crand.Read(p) }
+
+// seed is the seed given to Seed, zero while the generators are not seeded
+var seed int64
+
+// RecordRandom returns the source of the random numbers of the record with
+// the given index in a stream of records, such as the values written by a
+// phase. Once the generators are seeded every record has its own generator,
+// derived from the seed, the stream and the index, so that the record gets
+// the same key or value whichever worker generates it and in whatever order.
+// Otherwise the shared generator is returned.
+func RecordRandom(stream string, index int) Random {
+	if seed == 0 {
+		return sharedRandom{}
+	}
+
+	// FNV-1a hash of the stream name
+	h := uint64(14695981039346656037)
+	for i := 0; i < len(stream); i++ {
+		h ^= uint64(stream[i])
+		h *= 1099511628211
+	}
+	return &recordRandom{state: mix64(mix64(mix64(uint64(seed))^h) ^ uint64(index))}
+}
+
+// recordRandom is a splitmix64 generator, which is cheap enough to create
+// for every record within the measured operations
+type recordRandom struct {
+	state uint64
+}
+
+// mix64 is the splitmix64 finalizer, spreading every bit of x over the result
+func mix64(x uint64) uint64 {
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+func (r *recordRandom) next() uint64 {
+	r.state += 0x9e3779b97f4a7c15
+	return mix64(r.state)
+}
+
+func (r *recordRandom) Intn(n int) int {
+	if n <= 0 {
+		panic("invalid argument to Intn")
+	}
+	return int(r.next() % uint64(n))
+}
+
+func (r *recordRandom) Int31() int32     { return int32(r.next() >> 33) }
+func (r *recordRandom) Float32() float32 { return float32(r.next()>>40) / (1 << 24) }
+func (r *recordRandom) Float64() float64 { return float64(r.next()>>11) / (1 << 53) }
+
+func (r *recordRandom) Read(p []byte) (int, error) {
+	for i := 0; i < len(p); i += 8 {
+		v := r.next()
+		for j := i; j < len(p) && j < i+8; j++ {
+			p[j] = byte(v)
+			v >>= 8
+		}
+	}
+	return len(p), nil
+}
@@ -3,8 +3,9 @@ package generators
 import (
 	"encoding/json"
 	"fmt"
-	"math/rand"
+	"maps"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -23,40 +24,43 @@ var (
 	enumRegex       = regexp.MustCompile(`enum:(.+)`)
 	intEnumRegex    = regexp.MustCompile(`int:(.+)`)
 	floatEnumRegex  = regexp.MustCompile(`float:(.+)`)
+	searchRegex     = regexp.MustCompile(`^search:(\d+):(.+)$`)
+	geoNearRegex    = regexp.MustCompile(`^geo:(-?\d+(?:\.\d+)?),(-?\d+(?:\.\d+)?),(\d+(?:\.\d+)?)$`)
 )
 
-// Initialize random seed
-func init() {
-	rand.Seed(time.Now().UnixNano())
+// Seed seeds the random generators of keys and values, so that the keys,
+// their order and the values of every record are reproducible
+func Seed(s int64) {
+	seed = s
 }
 
 // RandomString generates a random string of the specified length
-func RandomString(length int) string {
+func RandomString(rnd Random, length int) string {
 	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
 	b := make([]byte, length)
 	for i := range b {
-		b[i] = charset[rand.Intn(len(charset))]
+		b[i] = charset[rnd.Intn(len(charset))]
 	}
 	return string(b)
 }
 
 // RandomWord generates a random word of the specified length
-func RandomWord(minLen, maxLen int) string {
+func RandomWord(rnd Random, minLen, maxLen int) string {
 	length := minLen
 	if maxLen > minLen {
-		length = minLen + rand.Intn(maxLen-minLen+1)
+		length = minLen + rnd.Intn(maxLen-minLen+1)
 	}
-	return RandomString(length)
+	return RandomString(rnd, length)
 }
 
 // RandomText generates random text made of words
-func RandomText(length int) string {
+func RandomText(rnd Random, length int) string {
 	words := []string{}
 	currentLength := 0
 	
 	for currentLength < length {
 		// Generate a word between 2 and 10 characters
-		wordLen := 2 + rand.Intn(9)
+		wordLen := 2 + rnd.Intn(9)
 		if currentLength + wordLen + 1 > length {
 			wordLen = length - currentLength
 			if wordLen <= 0 {
@@ -64,7 +68,7 @@ func RandomText(length int) string {
 			}
 		}
 		
-		word := RandomString(wordLen)
+		word := RandomString(rnd, wordLen)
 		words = append(words, word)
 		currentLength += wordLen + 1 // +1 for space
 	}
@@ -72,63 +76,85 @@ func RandomText(length int) string {
 	return strings.Join(words, " ")
 }
 
+// SearchText generates random text made of words, with one of the given terms
+// inserted at a random position so that full-text searches have known matches
+func SearchText(rnd Random, length int, terms []string) string {
+	words := strings.Fields(RandomText(rnd, length))
+	term := terms[rnd.Intn(len(terms))]
+	i := rnd.Intn(len(words) + 1)
+	words = append(words[:i], append([]string{term}, words[i:]...)...)
+	return strings.Join(words, " ")
+}
+
 // ParseValue parses a template string and generates a value
-func ParseValue(template string) interface{} {
+func ParseValue(rnd Random, template string) interface{} {
 	switch {
+	case searchRegex.MatchString(template):
+		matches := searchRegex.FindStringSubmatch(template)
+		length, _ := strconv.Atoi(matches[1])
+		return SearchText(rnd, length, strings.Split(matches[2], ","))
+	case template == "geo":
+		return RandomGeoPoint(rnd)
+	case geoNearRegex.MatchString(template):
+		matches := geoNearRegex.FindStringSubmatch(template)
+		lon, _ := strconv.ParseFloat(matches[1], 64)
+		lat, _ := strconv.ParseFloat(matches[2], 64)
+		radius, _ := strconv.ParseFloat(matches[3], 64)
+		return RandomGeoPointNear(rnd, lon, lat, radius)
 	case template == "int":
-		return rand.Int31()
+		return rnd.Int31()
 	case intRangeRegex.MatchString(template):
 		matches := intRangeRegex.FindStringSubmatch(template)
 		min, _ := strconv.Atoi(matches[1])
 		max, _ := strconv.Atoi(matches[2])
-		return min + rand.Intn(max-min+1)
+		return min + rnd.Intn(max-min+1)
 	case template == "float":
-		return rand.Float32()
+		return rnd.Float32()
 	case floatRangeRegex.MatchString(template):
 		matches := floatRangeRegex.FindStringSubmatch(template)
 		min, _ := strconv.ParseFloat(matches[1], 32)
 		max, _ := strconv.ParseFloat(matches[2], 32)
-		return min + rand.Float64()*(max-min)
+		return min + rnd.Float64()*(max-min)
 	case template == "bool":
-		return rand.Intn(2) == 1
+		return rnd.Intn(2) == 1
 	case template == "uuid":
-		return uuid.New().String()
+		return uuid.Must(uuid.NewRandomFromReader(rnd)).String()
 	case template == "datetime":
 		return time.Now().Format(time.RFC3339)
 	case stringRegex.MatchString(template):
 		matches := stringRegex.FindStringSubmatch(template)
 		length, _ := strconv.Atoi(matches[1])
-		return RandomString(length)
+		return RandomString(rnd, length)
 	case stringRangeRegex.MatchString(template):
 		matches := stringRangeRegex.FindStringSubmatch(template)
 		min, _ := strconv.Atoi(matches[1])
 		max, _ := strconv.Atoi(matches[2])
-		length := min + rand.Intn(max-min+1)
-		return RandomString(length)
+		length := min + rnd.Intn(max-min+1)
+		return RandomString(rnd, length)
 	case textRegex.MatchString(template):
 		matches := textRegex.FindStringSubmatch(template)
 		length, _ := strconv.Atoi(matches[1])
-		return RandomText(length)
+		return RandomText(rnd, length)
 	case textRangeRegex.MatchString(template):
 		matches := textRangeRegex.FindStringSubmatch(template)
 		min, _ := strconv.Atoi(matches[1])
 		max, _ := strconv.Atoi(matches[2])
-		length := min + rand.Intn(max-min+1)
-		return RandomText(length)
+		length := min + rnd.Intn(max-min+1)
+		return RandomText(rnd, length)
 	case enumRegex.MatchString(template):
 		matches := enumRegex.FindStringSubmatch(template)
 		options := strings.Split(matches[1], ",")
-		return options[rand.Intn(len(options))]
+		return options[rnd.Intn(len(options))]
 	case intEnumRegex.MatchString(template):
 		matches := intEnumRegex.FindStringSubmatch(template)
 		options := strings.Split(matches[1], ",")
-		selected := options[rand.Intn(len(options))]
+		selected := options[rnd.Intn(len(options))]
 		val, _ := strconv.Atoi(selected)
 		return val
 	case floatEnumRegex.MatchString(template):
 		matches := floatEnumRegex.FindStringSubmatch(template)
 		options := strings.Split(matches[1], ",")
-		selected := options[rand.Intn(len(options))]
+		selected := options[rnd.Intn(len(options))]
 		val, _ := strconv.ParseFloat(selected, 32)
 		return val
 	default:
@@ -136,8 +162,9 @@ func ParseValue(template string) interface{} {
 	}
 }
 
-// ProcessTemplate processes a JSON template and replaces placeholders with random values
-func ProcessTemplate(template string) (map[string]interface{}, error) {
+// ParseTemplate parses a JSON template without replacing its placeholders. The
+// result is passed to ProcessValue to generate a value for every record.
+func ParseTemplate(template string) (map[string]interface{}, error) {
 	var data map[string]interface{}
 	
 	// Parse the JSON template
@@ -145,27 +172,46 @@ func ProcessTemplate(template string) (map[string]interface{}, error) {
 		return nil, fmt.Errorf("invalid JSON template: %w", err)
 	}
 	
-	// Process the template recursively
-	ProcessValue(data)
-	
 	return data, nil
 }
 
-// ProcessValue recursively processes values in the template
-func ProcessValue(v interface{}) interface{} {
+// ProcessTemplate processes a JSON template and replaces placeholders with random values
+func ProcessTemplate(template string) (map[string]interface{}, error) {
+	data, err := ParseTemplate(template)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Process the template recursively
+	return NewValue(RecordRandom("sample", 0), data), nil
+}
+
+// NewValue generates a value from a parsed template with the given source of
+// random numbers
+func NewValue(rnd Random, template map[string]interface{}) map[string]interface{} {
+	return ProcessValue(rnd, template).(map[string]interface{})
+}
+
+// ProcessValue recursively processes values in the template, returning a new
+// value and leaving the template untouched so that it can be reused. The
+// fields are processed in order, so that a seeded source of random numbers
+// generates the same value every time.
+func ProcessValue(rnd Random, v interface{}) interface{} {
 	switch val := v.(type) {
 	case map[string]interface{}:
-		for k, v := range val {
-			val[k] = ProcessValue(v)
+		out := make(map[string]interface{}, len(val))
+		for _, k := range slices.Sorted(maps.Keys(val)) {
+			out[k] = ProcessValue(rnd, val[k])
 		}
-		return val
+		return out
 	case []interface{}:
+		out := make([]interface{}, len(val))
 		for i, v := range val {
-			val[i] = ProcessValue(v)
+			out[i] = ProcessValue(rnd, v)
 		}
-		return val
+		return out
 	case string:
-		return ParseValue(val)
+		return ParseValue(rnd, val)
 	default:
 		return val
 	}
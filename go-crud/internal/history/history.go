@@ -0,0 +1,340 @@
+// Package history appends benchmark runs to a local SQLite database and
+// queries them back, so that results can be compared and trended across runs
+// without keeping track of results files
+package history
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+	"github.com/surrealdb/go-crud-bench/internal/config"
+)
+
+// schema creates the tables of a history database. Every run keeps its whole
+// results document, and the results of its phases are also stored as rows so
+// that they can be trended with plain queries.
+const schema = `
+CREATE TABLE IF NOT EXISTS runs (
+	id            INTEGER PRIMARY KEY AUTOINCREMENT,
+	started       TEXT NOT NULL,
+	database      TEXT NOT NULL,
+	name          TEXT NOT NULL,
+	workload_hash TEXT NOT NULL,
+	duration_ns   INTEGER NOT NULL,
+	workload      TEXT NOT NULL,
+	environment   TEXT NOT NULL,
+	results       TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS phases (
+	run_id      INTEGER NOT NULL REFERENCES runs(id) ON DELETE CASCADE,
+	operation   TEXT NOT NULL,
+	name        TEXT NOT NULL,
+	duration_ns INTEGER NOT NULL,
+	count       INTEGER NOT NULL,
+	errors      INTEGER NOT NULL,
+	ops_per_sec REAL NOT NULL,
+	p50_ns      INTEGER,
+	p95_ns      INTEGER,
+	p99_ns      INTEGER,
+	skipped     TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS phases_by_name ON phases (name, run_id);
+`
+
+// Environment describes the host and client a run was executed on
+type Environment struct {
+	Host      string   `json:"host"`
+	OS        string   `json:"os"`
+	Arch      string   `json:"arch"`
+	CPUs      int      `json:"cpus"`
+	GoVersion string   `json:"go_version"`
+	Args      []string `json:"args"`
+}
+
+// CurrentEnvironment describes the running client
+func CurrentEnvironment() Environment {
+	host, _ := os.Hostname()
+	return Environment{
+		Host:      host,
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		CPUs:      runtime.NumCPU(),
+		GoVersion: runtime.Version(),
+		Args:      os.Args[1:],
+	}
+}
+
+// Run is a benchmark run stored in the history
+type Run struct {
+	ID           int64
+	Started      time.Time
+	Database     string
+	Name         string
+	WorkloadHash string
+	Duration     time.Duration
+	Environment  Environment
+
+	// Workload is the workload description and Document the results file of
+	// the run, both stored as JSON
+	Workload interface{}
+	Document interface{}
+
+	// Results holds the results of the phases of the run
+	Results []benchmark.Result
+}
+
+// Filter selects the runs returned by a query, empty fields matching every run
+type Filter struct {
+	Database     string
+	Name         string
+	WorkloadHash string
+	// Limit is the maximum number of runs returned, the most recent ones, 0
+	// returning every run
+	Limit int
+}
+
+// Point is the result of a phase in one run, as trended across runs
+type Point struct {
+	RunID        int64
+	Started      time.Time
+	Database     string
+	WorkloadHash string
+	Count        int
+	Errors       int64
+	Throughput   float64
+	Duration     time.Duration
+	P50          time.Duration
+	P95          time.Duration
+	P99          time.Duration
+}
+
+// Store is an open history database
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens the history store, creating its database and tables when they
+// do not exist yet
+func Open(store *config.HistoryStore) (*Store, error) {
+	if store.Scheme != "sqlite" {
+		return nil, fmt.Errorf("unsupported history store: %s", store)
+	}
+	db, err := sql.Open("sqlite3", "file:"+store.Path+"?_foreign_keys=on&_busy_timeout=5000")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history %s: %w", store, err)
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create the history tables in %s: %w", store, err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the history database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Record appends a run and the results of its phases, returning its ID
+func (s *Store) Record(ctx context.Context, run Run) (int64, error) {
+	workload, err := json.Marshal(run.Workload)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal workload: %w", err)
+	}
+	environment, err := json.Marshal(run.Environment)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal environment: %w", err)
+	}
+	document, err := json.Marshal(run.Document)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal results: %w", err)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `INSERT INTO runs (started, database, name, workload_hash, duration_ns, workload, environment, results)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		run.Started.UTC().Format(time.RFC3339Nano), run.Database, run.Name, run.WorkloadHash, int64(run.Duration),
+		string(workload), string(environment), string(document))
+	if err != nil {
+		return 0, fmt.Errorf("failed to record run: %w", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get run ID: %w", err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `INSERT INTO phases (run_id, operation, name, duration_ns, count, errors, ops_per_sec, p50_ns, p95_ns, p99_ns, skipped)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare phase insert: %w", err)
+	}
+	defer stmt.Close()
+	for _, result := range run.Results {
+		var p50, p95, p99 sql.NullInt64
+		if l := result.Latency; l != nil {
+			p50 = sql.NullInt64{Int64: int64(l.P50), Valid: true}
+			p95 = sql.NullInt64{Int64: int64(l.P95), Valid: true}
+			p99 = sql.NullInt64{Int64: int64(l.P99), Valid: true}
+		}
+		if _, err := stmt.ExecContext(ctx, id, string(result.Operation), result.Name, int64(result.Duration),
+			result.Count, result.Errors, result.Throughput(), p50, p95, p99, result.Skipped); err != nil {
+			return 0, fmt.Errorf("failed to record phase %s: %w", result.Name, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit run: %w", err)
+	}
+	return id, nil
+}
+
+// where builds the condition on the runs matching the filter
+func (f Filter) where() (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	add := func(column, value string) {
+		if value != "" {
+			conditions = append(conditions, "r."+column+" = ?")
+			args = append(args, value)
+		}
+	}
+	add("database", f.Database)
+	add("name", f.Name)
+	add("workload_hash", f.WorkloadHash)
+	if len(conditions) == 0 {
+		return "1 = 1", nil
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
+// Runs returns the runs matching the filter, most recent first, with the
+// results of their phases but without their workload and results documents
+func (s *Store) Runs(ctx context.Context, filter Filter) ([]Run, error) {
+	where, args := filter.where()
+	query := `SELECT r.id, r.started, r.database, r.name, r.workload_hash, r.duration_ns, r.environment
+		FROM runs r WHERE ` + where + ` ORDER BY r.id DESC`
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query runs: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []Run
+	for rows.Next() {
+		var run Run
+		var started, environment string
+		var duration int64
+		if err := rows.Scan(&run.ID, &started, &run.Database, &run.Name, &run.WorkloadHash, &duration, &environment); err != nil {
+			return nil, fmt.Errorf("failed to read run: %w", err)
+		}
+		run.Started, _ = time.Parse(time.RFC3339Nano, started)
+		run.Duration = time.Duration(duration)
+		if err := json.Unmarshal([]byte(environment), &run.Environment); err != nil {
+			return nil, fmt.Errorf("failed to parse the environment of run %d: %w", run.ID, err)
+		}
+		runs = append(runs, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query runs: %w", err)
+	}
+
+	for i := range runs {
+		if runs[i].Results, err = s.results(ctx, runs[i].ID); err != nil {
+			return nil, err
+		}
+	}
+	return runs, nil
+}
+
+// results returns the results of the phases of a run
+func (s *Store) results(ctx context.Context, id int64) ([]benchmark.Result, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT operation, name, duration_ns, count, errors, p50_ns, p95_ns, p99_ns, skipped
+		FROM phases WHERE run_id = ? ORDER BY rowid`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the phases of run %d: %w", id, err)
+	}
+	defer rows.Close()
+
+	var results []benchmark.Result
+	for rows.Next() {
+		var result benchmark.Result
+		var operation string
+		var duration int64
+		var p50, p95, p99 sql.NullInt64
+		if err := rows.Scan(&operation, &result.Name, &duration, &result.Count, &result.Errors, &p50, &p95, &p99, &result.Skipped); err != nil {
+			return nil, fmt.Errorf("failed to read the phases of run %d: %w", id, err)
+		}
+		result.Operation = benchmark.Operation(operation)
+		result.Duration = time.Duration(duration)
+		if p50.Valid {
+			result.Latency = &benchmark.LatencyStats{
+				P50: time.Duration(p50.Int64),
+				P95: time.Duration(p95.Int64),
+				P99: time.Duration(p99.Int64),
+			}
+		}
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query the phases of run %d: %w", id, err)
+	}
+	return results, nil
+}
+
+// Trend returns the results of the named phase in the runs matching the
+// filter, oldest first. Runs which skipped the phase are left out.
+func (s *Store) Trend(ctx context.Context, phase string, filter Filter) ([]Point, error) {
+	where, args := filter.where()
+	query := `SELECT r.id, r.started, r.database, r.workload_hash, p.count, p.errors, p.ops_per_sec, p.duration_ns,
+		COALESCE(p.p50_ns, 0), COALESCE(p.p95_ns, 0), COALESCE(p.p99_ns, 0)
+		FROM phases p JOIN runs r ON r.id = p.run_id
+		WHERE p.name = ? AND p.skipped = '' AND ` + where + ` ORDER BY r.id DESC`
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+	rows, err := s.db.QueryContext(ctx, query, append([]interface{}{phase}, args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the trend of %s: %w", phase, err)
+	}
+	defer rows.Close()
+
+	var points []Point
+	for rows.Next() {
+		var p Point
+		var started string
+		var duration, p50, p95, p99 int64
+		if err := rows.Scan(&p.RunID, &started, &p.Database, &p.WorkloadHash, &p.Count, &p.Errors, &p.Throughput,
+			&duration, &p50, &p95, &p99); err != nil {
+			return nil, fmt.Errorf("failed to read the trend of %s: %w", phase, err)
+		}
+		p.Started, _ = time.Parse(time.RFC3339Nano, started)
+		p.Duration = time.Duration(duration)
+		p.P50, p.P95, p.P99 = time.Duration(p50), time.Duration(p95), time.Duration(p99)
+		points = append(points, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query the trend of %s: %w", phase, err)
+	}
+
+	// Return the most recent runs in chronological order
+	for i, j := 0, len(points)-1; i < j; i, j = i+1, j-1 {
+		points[i], points[j] = points[j], points[i]
+	}
+	return points, nil
+}
@@ -0,0 +1,147 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"strings"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// textAnchor is the horizontal alignment of a text label around its position
+type textAnchor string
+
+const (
+	anchorStart  textAnchor = "start"
+	anchorMiddle textAnchor = "middle"
+	anchorEnd    textAnchor = "end"
+)
+
+// charWidth is the width in pixels of a character of the chart font, used to
+// lay out and truncate labels identically in both formats
+const charWidth = 7
+
+// canvas is a minimal drawing surface which charts are rendered to, with one
+// implementation per image format
+type canvas interface {
+	// line draws a straight line of the given width
+	line(x1, y1, x2, y2 float64, c color.RGBA, width float64)
+
+	// rect fills a rectangle
+	rect(x, y, w, h float64, c color.RGBA)
+
+	// text draws a label with its baseline at y
+	text(x, y float64, s string, anchor textAnchor)
+
+	// encode writes the finished image
+	encode(w io.Writer) error
+}
+
+// newCanvas creates a blank canvas of the given size for an image format
+func newCanvas(format string, width, height int) (canvas, error) {
+	switch format {
+	case "svg":
+		return newSVGCanvas(width, height), nil
+	case "png":
+		return newPNGCanvas(width, height), nil
+	default:
+		return nil, fmt.Errorf("unsupported chart format: %s", format)
+	}
+}
+
+// svgCanvas renders a chart as a standalone SVG document
+type svgCanvas struct {
+	width, height int
+	body          strings.Builder
+}
+
+// newSVGCanvas creates an SVG canvas with a white background
+func newSVGCanvas(width, height int) *svgCanvas {
+	c := &svgCanvas{width: width, height: height}
+	c.rect(0, 0, float64(width), float64(height), color.RGBA{255, 255, 255, 255})
+	return c
+}
+
+func (c *svgCanvas) line(x1, y1, x2, y2 float64, col color.RGBA, width float64) {
+	fmt.Fprintf(&c.body, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-width="%.1f"/>`+"\n",
+		x1, y1, x2, y2, hexColor(col), width)
+}
+
+func (c *svgCanvas) rect(x, y, w, h float64, col color.RGBA) {
+	fmt.Fprintf(&c.body, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"/>`+"\n", x, y, w, h, hexColor(col))
+}
+
+func (c *svgCanvas) text(x, y float64, s string, anchor textAnchor) {
+	fmt.Fprintf(&c.body, `<text x="%.1f" y="%.1f" text-anchor="%s">`, x, y, anchor)
+	_ = xml.EscapeText(&c.body, []byte(s))
+	c.body.WriteString("</text>\n")
+}
+
+func (c *svgCanvas) encode(w io.Writer) error {
+	_, err := fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="monospace" font-size="11.5">`+"\n%s</svg>\n",
+		c.width, c.height, c.width, c.height, c.body.String())
+	return err
+}
+
+// pngCanvas renders a chart as a PNG image with the built-in bitmap font
+type pngCanvas struct {
+	img *image.RGBA
+}
+
+// newPNGCanvas creates a PNG canvas with a white background
+func newPNGCanvas(width, height int) *pngCanvas {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+	return &pngCanvas{img: img}
+}
+
+func (c *pngCanvas) line(x1, y1, x2, y2 float64, col color.RGBA, width float64) {
+	// Stamp a square brush along the line, one pixel at a time
+	size := int(math.Max(1, math.Round(width)))
+	brush := image.NewUniform(col)
+	steps := int(math.Max(math.Abs(x2-x1), math.Abs(y2-y1)))
+	if steps == 0 {
+		steps = 1
+	}
+	for i := 0; i <= steps; i++ {
+		t := float64(i) / float64(steps)
+		x := int(math.Floor(x1 + (x2-x1)*t - float64(size-1)/2))
+		y := int(math.Floor(y1 + (y2-y1)*t - float64(size-1)/2))
+		draw.Draw(c.img, image.Rect(x, y, x+size, y+size), brush, image.Point{}, draw.Over)
+	}
+}
+
+func (c *pngCanvas) rect(x, y, w, h float64, col color.RGBA) {
+	r := image.Rect(int(math.Round(x)), int(math.Round(y)), int(math.Round(x+w)), int(math.Round(y+h)))
+	draw.Draw(c.img, r, image.NewUniform(col), image.Point{}, draw.Over)
+}
+
+func (c *pngCanvas) text(x, y float64, s string, anchor textAnchor) {
+	d := &font.Drawer{Dst: c.img, Src: image.Black, Face: basicfont.Face7x13}
+	width := float64(d.MeasureString(s).Round())
+	switch anchor {
+	case anchorMiddle:
+		x -= width / 2
+	case anchorEnd:
+		x -= width
+	}
+	d.Dot = fixed.P(int(math.Round(x)), int(math.Round(y)))
+	d.DrawString(s)
+}
+
+func (c *pngCanvas) encode(w io.Writer) error {
+	return png.Encode(w, c.img)
+}
+
+// hexColor formats a colour for SVG attributes
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
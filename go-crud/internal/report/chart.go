@@ -0,0 +1,264 @@
+package report
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+)
+
+// Chart dimensions in pixels
+const (
+	chartWidth  = 960
+	chartHeight = 480
+	plotLeft    = 90
+	plotRight   = 180
+	plotTop     = 50
+	plotBottom  = 60
+	chartTicks  = 5
+)
+
+var (
+	axisColor = color.RGBA{60, 60, 60, 255}
+	gridColor = color.RGBA{225, 225, 225, 255}
+
+	// palette contains the series colours, reused in order
+	palette = []color.RGBA{
+		{31, 119, 180, 255},
+		{255, 127, 14, 255},
+		{44, 160, 44, 255},
+		{214, 39, 40, 255},
+		{148, 103, 189, 255},
+		{140, 86, 75, 255},
+		{227, 119, 194, 255},
+		{127, 127, 127, 255},
+		{188, 189, 34, 255},
+		{23, 190, 207, 255},
+	}
+)
+
+// WriteCharts renders the latency and throughput charts of a run to files
+// named after base, returning the paths written. The throughput chart is
+// skipped when no phase ran long enough to report progress.
+func WriteCharts(base, format, unit string, results []benchmark.Result, timeline *Timeline) ([]string, error) {
+	var paths []string
+
+	charts := []struct {
+		name   string
+		render func(canvas) bool
+	}{
+		{"latency", func(c canvas) bool { return drawLatencyChart(c, results, unit) }},
+		{"throughput", func(c canvas) bool { return drawThroughputChart(c, timeline.Phases(), timeline.Annotations()) }},
+	}
+	for _, chart := range charts {
+		c, err := newCanvas(format, chartWidth, chartHeight)
+		if err != nil {
+			return paths, err
+		}
+		if !chart.render(c) {
+			continue
+		}
+
+		path := fmt.Sprintf("%s-%s.%s", base, chart.name, format)
+		f, err := os.Create(path)
+		if err != nil {
+			return paths, fmt.Errorf("failed to create chart: %w", err)
+		}
+		if err := c.encode(f); err != nil {
+			f.Close()
+			return paths, fmt.Errorf("failed to write chart %s: %w", path, err)
+		}
+		if err := f.Close(); err != nil {
+			return paths, fmt.Errorf("failed to write chart %s: %w", path, err)
+		}
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
+
+// drawLatencyChart draws the p50, p95 and p99 latencies of every phase as
+// grouped bars, reporting whether there was anything to draw
+func drawLatencyChart(c canvas, results []benchmark.Result, unit string) bool {
+	var measured []benchmark.Result
+	var top time.Duration
+	for _, r := range results {
+		if r.Latency != nil {
+			measured = append(measured, r)
+			if r.Latency.P99 > top {
+				top = r.Latency.P99
+			}
+		}
+	}
+	if len(measured) == 0 {
+		return false
+	}
+
+	unitLabel := unit
+	if unitLabel == "" {
+		unitLabel = "ms"
+	}
+	yMax := niceCeil(float64(top))
+	drawAxes(c, "Latency percentiles per operation", "latency ("+unitLabel+")", yMax, func(v float64) string {
+		return FormatDuration(time.Duration(v), unit)
+	})
+
+	// Draw one group of bars per phase
+	names := []string{"p50", "p95", "p99"}
+	width := float64(chartWidth - plotLeft - plotRight)
+	height := float64(chartHeight - plotTop - plotBottom)
+	group := width / float64(len(measured))
+	bar := group * 0.8 / float64(len(names))
+	for i, r := range measured {
+		x := plotLeft + group*float64(i) + group*0.1
+		for j, d := range []time.Duration{r.Latency.P50, r.Latency.P95, r.Latency.P99} {
+			h := height * float64(d) / yMax
+			c.rect(x+bar*float64(j), plotTop+height-h, bar, h, palette[j])
+		}
+		c.text(plotLeft+group*(float64(i)+0.5), chartHeight-plotBottom+16, truncate(r.Name, int(group/charWidth)), anchorMiddle)
+	}
+
+	drawLegend(c, names)
+	return true
+}
+
+// drawThroughputChart draws the operations per second of every phase over the
+// run, one line per phase, with a vertical marker for every annotation,
+// reporting whether there was anything to draw
+func drawThroughputChart(c canvas, phases []TimelinePhase, notes []TimelineAnnotation) bool {
+	type point struct{ t, rate float64 }
+
+	// Convert the cumulative progress reports into rates between reports
+	var series [][]point
+	var names []string
+	var tMax, rateMax float64
+	for _, phase := range phases {
+		var points []point
+		var prev TimelineSample
+		for _, s := range phase.Samples {
+			interval := (s.Elapsed - prev.Elapsed).Seconds()
+			if interval <= 0 {
+				continue
+			}
+			rate := float64(s.Completed-prev.Completed) / interval
+			t := (phase.Offset + s.Elapsed).Seconds()
+			points = append(points, point{t, rate})
+			tMax = math.Max(tMax, t)
+			rateMax = math.Max(rateMax, rate)
+			prev = s
+		}
+		if len(points) > 0 {
+			series = append(series, points)
+			names = append(names, phase.Name)
+		}
+	}
+	if len(series) == 0 {
+		return false
+	}
+
+	yMax := niceCeil(rateMax)
+	drawAxes(c, "Throughput over time", "ops/sec", yMax, func(v float64) string {
+		return fmt.Sprintf("%.0f", v)
+	})
+
+	// Draw the time axis labels
+	xMax := niceCeil(tMax)
+	width := float64(chartWidth - plotLeft - plotRight)
+	height := float64(chartHeight - plotTop - plotBottom)
+	for i := 0; i <= chartTicks; i++ {
+		v := xMax * float64(i) / chartTicks
+		c.text(plotLeft+width*float64(i)/chartTicks, chartHeight-plotBottom+16, fmt.Sprintf("%gs", v), anchorMiddle)
+	}
+	c.text(plotLeft+width/2, chartHeight-plotBottom+40, "time since start (s)", anchorMiddle)
+
+	// Mark the changes made to the running benchmark
+	for _, note := range notes {
+		t := note.Offset.Seconds()
+		if t > xMax {
+			continue
+		}
+		x := plotLeft + width*t/xMax
+		c.line(x, plotTop, x, plotTop+height, axisColor, 1)
+		c.text(x+4, plotTop+12, note.Text, anchorStart)
+	}
+
+	// Draw one line per phase, with a marker on every report
+	for i, points := range series {
+		col := palette[i%len(palette)]
+		for j, p := range points {
+			x := plotLeft + width*p.t/xMax
+			y := plotTop + height - height*p.rate/yMax
+			if j > 0 {
+				prev := points[j-1]
+				c.line(plotLeft+width*prev.t/xMax, plotTop+height-height*prev.rate/yMax, x, y, col, 2)
+			}
+			c.rect(x-2, y-2, 4, 4, col)
+		}
+	}
+
+	drawLegend(c, names)
+	return true
+}
+
+// drawAxes draws the title, the horizontal grid lines with their labels and
+// the axes of a chart whose values range from 0 to yMax
+func drawAxes(c canvas, title, yLabel string, yMax float64, format func(float64) string) {
+	width := float64(chartWidth - plotLeft - plotRight)
+	height := float64(chartHeight - plotTop - plotBottom)
+
+	c.text(chartWidth/2, plotTop/2+4, title, anchorMiddle)
+	c.text(plotLeft, plotTop-12, yLabel, anchorEnd)
+	for i := 0; i <= chartTicks; i++ {
+		y := plotTop + height - height*float64(i)/chartTicks
+		if i > 0 {
+			c.line(plotLeft, y, plotLeft+width, y, gridColor, 1)
+		}
+		c.text(plotLeft-8, y+4, format(yMax*float64(i)/chartTicks), anchorEnd)
+	}
+	c.line(plotLeft, plotTop, plotLeft, plotTop+height, axisColor, 1)
+	c.line(plotLeft, plotTop+height, plotLeft+width, plotTop+height, axisColor, 1)
+}
+
+// drawLegend lists the series names with their colours right of the plot
+func drawLegend(c canvas, names []string) {
+	x := float64(chartWidth - plotRight + 20)
+	for i, name := range names {
+		y := float64(plotTop + 18*i)
+		c.rect(x, y, 10, 10, palette[i%len(palette)])
+		c.text(x+16, y+10, truncate(name, (plotRight-40)/charWidth), anchorStart)
+	}
+}
+
+// niceCeil rounds a positive value up to 1, 2 or 5 times a power of ten, so
+// that the axis ticks fall on round numbers
+func niceCeil(v float64) float64 {
+	if v <= 0 {
+		return 1
+	}
+	magnitude := math.Pow(10, math.Floor(math.Log10(v)))
+	for _, step := range []float64{1, 2, 5, 10} {
+		if v <= step*magnitude {
+			return step * magnitude
+		}
+	}
+	return 10 * magnitude
+}
+
+// truncate shortens a label to at most n characters, marking cut labels with
+// a trailing "~" as the PNG font only covers ASCII
+func truncate(s string, n int) string {
+	if n < 1 {
+		return ""
+	}
+	if len(s) <= n {
+		return s
+	}
+	if n == 1 {
+		return s[:1]
+	}
+	return strings.TrimSpace(s[:n-1]) + "~"
+}
@@ -0,0 +1,87 @@
+package report
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Sidecar holds the checksum and optional signature of a results file
+type Sidecar struct {
+	File      string `json:"file"`
+	SHA256    string `json:"sha256"`
+	Algorithm string `json:"algorithm,omitempty"`
+	PublicKey string `json:"public_key,omitempty"`
+	Signature string `json:"signature,omitempty"`
+}
+
+// WriteSidecar computes the SHA-256 checksum of the results file and writes it to
+// a "<file>.sig.json" sidecar. When keyPath is set, the checksum is also signed
+// with the Ed25519 private key (PKCS #8, PEM encoded) stored at that path.
+func WriteSidecar(resultsPath, keyPath string) (string, error) {
+	data, err := os.ReadFile(resultsPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read results file: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	sidecar := Sidecar{
+		File:   filepath.Base(resultsPath),
+		SHA256: hex.EncodeToString(sum[:]),
+	}
+
+	// Sign the checksum if a key was provided
+	if keyPath != "" {
+		key, err := loadSigningKey(keyPath)
+		if err != nil {
+			return "", err
+		}
+		sidecar.Algorithm = "ed25519"
+		sidecar.PublicKey = base64.StdEncoding.EncodeToString(key.Public().(ed25519.PublicKey))
+		sidecar.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(key, sum[:]))
+	}
+
+	jsonData, err := json.MarshalIndent(sidecar, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal checksum sidecar: %w", err)
+	}
+
+	sidecarPath := resultsPath + ".sig.json"
+	if err := os.WriteFile(sidecarPath, jsonData, 0644); err != nil {
+		return "", fmt.Errorf("failed to write checksum sidecar: %w", err)
+	}
+
+	return sidecarPath, nil
+}
+
+// loadSigningKey reads an Ed25519 private key from a PEM encoded PKCS #8 file
+func loadSigningKey(path string) (ed25519.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("signing key %s is not PEM encoded", path)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing key: %w", err)
+	}
+
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("signing key %s is not an Ed25519 key", path)
+	}
+
+	return edKey, nil
+}
@@ -0,0 +1,53 @@
+package report
+
+import (
+	"io"
+	"os"
+)
+
+// ANSI colour codes used in console output. Every code has the same length so
+// that coloured and uncoloured cells stay aligned in tabulated output.
+const (
+	colorNone   = "\x1b[39m"
+	colorRed    = "\x1b[31m"
+	colorYellow = "\x1b[33m"
+	colorGreen  = "\x1b[32m"
+	colorReset  = "\x1b[0m"
+)
+
+// ColorEnabled reports whether console output written to w should be coloured
+// for the given mode, honouring the NO_COLOR convention (https://no-color.org)
+// in auto mode
+func ColorEnabled(mode string, w io.Writer) bool {
+	switch mode {
+	case "always":
+		return true
+	case "never":
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	f, ok := w.(*os.File)
+	return ok && IsTerminal(f)
+}
+
+// IsTerminal reports whether the file is a terminal
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// painter wraps text in ANSI colour codes when colour output is enabled
+type painter bool
+
+// paint colours the given text
+func (p painter) paint(color, text string) string {
+	if !p {
+		return text
+	}
+	return color + text + colorReset
+}
@@ -0,0 +1,135 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+)
+
+// PrintComparison writes the wall time, throughput, median and tail latencies
+// of every phase in a baseline and a candidate results file side by side, and
+// returns
+// the number of regressions. A change is only highlighted, improvements in
+// green and regressions in red, beyond the tolerance the policy sets for the
+// metric class and phase, which is none without a policy.
+func PrintComparison(w io.Writer, baseline, candidate *ResultsFile, policy *Policy, opts TableOptions) int {
+	if baseline.WorkloadHash != candidate.WorkloadHash {
+		fmt.Fprintf(w, "Warning: the results were produced by different workloads (%s vs %s)\n\n",
+			shortHash(baseline.WorkloadHash), shortHash(candidate.WorkloadHash))
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	p := painter(opts.Color)
+	unit := opts.Unit
+
+	header := []string{
+		"OPERATION", "NAME",
+		fmt.Sprintf("BASELINE WALL (%s)", unit), fmt.Sprintf("CANDIDATE WALL (%s)", unit), "CHANGE",
+		"BASELINE OPS/SEC", "CANDIDATE OPS/SEC", "CHANGE",
+		fmt.Sprintf("BASELINE P50 (%s)", unit), fmt.Sprintf("CANDIDATE P50 (%s)", unit), "CHANGE",
+		fmt.Sprintf("BASELINE P95 (%s)", unit), fmt.Sprintf("CANDIDATE P95 (%s)", unit), "CHANGE",
+		fmt.Sprintf("BASELINE P99 (%s)", unit), fmt.Sprintf("CANDIDATE P99 (%s)", unit), "CHANGE",
+	}
+	rule := make([]string, len(header))
+	for i, h := range header {
+		rule[i] = strings.Repeat("-", len(h))
+	}
+	writeRow(tw, p, header, nil)
+	writeRow(tw, p, rule, nil)
+
+	// Match phases by operation and name, in the order of the baseline
+	candidates := map[string]benchmark.Result{}
+	for _, result := range candidate.Operations {
+		candidates[string(result.Operation)+"/"+result.Name] = result
+	}
+	baselines := map[string]bool{}
+	for _, result := range baseline.Operations {
+		baselines[string(result.Operation)+"/"+result.Name] = true
+	}
+
+	regressions := 0
+	for _, result := range baseline.Operations {
+		row := []string{string(result.Operation), result.Name}
+		other, ok := candidates[string(result.Operation)+"/"+result.Name]
+		if !ok {
+			row = append(row, FormatDuration(result.Duration, unit), "-", "-", fmt.Sprintf("%.0f", result.Throughput()), "-", "-")
+			writeRow(tw, p, row, nil)
+			continue
+		}
+		if result.Skipped != "" || other.Skipped != "" {
+			row = append(row, "-", "-", "-", skippedOr(result), skippedOr(other), "-")
+			writeRow(tw, p, row, nil)
+			continue
+		}
+
+		colors := map[int]string{}
+		compare := func(class string, before, after float64, format func(float64) string, higherIsBetter bool) {
+			column := len(row) + 2
+			row = append(row, format(before), format(after), "-")
+			if before <= 0 {
+				return
+			}
+			delta := (after - before) / before * 100
+			row[column] = fmt.Sprintf("%+.1f%%", delta)
+			if math.Abs(delta) <= policy.Tolerance(result.Name, class) {
+				return
+			}
+			if (delta > 0) == higherIsBetter {
+				colors[column] = colorGreen
+			} else {
+				colors[column] = colorRed
+				regressions++
+			}
+		}
+
+		duration := func(v float64) string {
+			return FormatDuration(time.Duration(v), unit)
+		}
+		compare(MetricDuration, float64(result.Duration), float64(other.Duration), duration, false)
+		compare(MetricThroughput, result.Throughput(), other.Throughput(), func(v float64) string {
+			return fmt.Sprintf("%.0f", v)
+		}, true)
+		if result.Latency != nil && other.Latency != nil {
+			compare(MetricP50, float64(result.Latency.P50), float64(other.Latency.P50), duration, false)
+			compare(MetricP95, float64(result.Latency.P95), float64(other.Latency.P95), duration, false)
+			compare(MetricP99, float64(result.Latency.P99), float64(other.Latency.P99), duration, false)
+		}
+		writeRow(tw, p, row, colors)
+	}
+
+	// List the phases only the candidate ran after those of the baseline
+	for _, result := range candidate.Operations {
+		if baselines[string(result.Operation)+"/"+result.Name] {
+			continue
+		}
+		row := []string{string(result.Operation), result.Name, "-", FormatDuration(result.Duration, unit), "-", "-", skippedOr(result), "-"}
+		writeRow(tw, p, row, nil)
+	}
+
+	tw.Flush()
+	return regressions
+}
+
+// skippedOr formats the throughput of a result, or marks a skipped scan
+func skippedOr(result benchmark.Result) string {
+	if result.Skipped != "" {
+		return "skipped"
+	}
+	return fmt.Sprintf("%.0f", result.Throughput())
+}
+
+// shortHash abbreviates a workload hash for display
+func shortHash(hash string) string {
+	if hash == "" {
+		return "unknown"
+	}
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
@@ -0,0 +1,123 @@
+package report
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+)
+
+// resultExtensions maps the results file formats to their file extensions
+var resultExtensions = map[string]string{
+	"csv":      "csv",
+	"markdown": "md",
+}
+
+// WriteResults writes the results in the given format to a file named after
+// base, returning the path of the file. The title heads Markdown tables.
+func WriteResults(base, format, title, unit string, results []benchmark.Result) (string, error) {
+	ext, ok := resultExtensions[format]
+	if !ok {
+		return "", fmt.Errorf("unsupported results format: %s", format)
+	}
+	path := fmt.Sprintf("%s.%s", base, ext)
+
+	f, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to create results file: %w", err)
+	}
+	switch format {
+	case "csv":
+		err = WriteCSV(f, results)
+	case "markdown":
+		err = WriteMarkdown(f, title, unit, results)
+	}
+	if closeErr := f.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to write results file: %w", err)
+	}
+	return path, nil
+}
+
+// WriteCSV writes the results as CSV, one row per phase, for spreadsheets.
+// Durations are in milliseconds, and the percentiles are left empty for
+// phases which do not record per-operation latencies.
+func WriteCSV(w io.Writer, results []benchmark.Result) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{
+		"operation", "name", "duration_ms", "count", "throughput", "errors",
+		"min_ms", "mean_ms", "p50_ms", "p90_ms", "p95_ms", "p99_ms", "p999_ms", "max_ms", "error", "skipped",
+	})
+	for _, result := range results {
+		latencies := make([]string, 8)
+		if l := result.Latency; l != nil {
+			for i, d := range []time.Duration{l.Min, l.Mean, l.P50, l.P90, l.P95, l.P99, l.P999, l.Max} {
+				latencies[i] = csvMillis(d)
+			}
+		}
+		var message string
+		if result.Error != nil {
+			message = result.Error.Error()
+		}
+
+		row := []string{
+			string(result.Operation), result.Name, csvMillis(result.Duration),
+			fmt.Sprintf("%d", result.Count), fmt.Sprintf("%.2f", result.Throughput()), fmt.Sprintf("%d", result.Errors),
+		}
+		row = append(row, latencies...)
+		cw.Write(append(row, message, result.Skipped))
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteMarkdown writes the results as a GitHub flavoured Markdown table under
+// the given title, with durations in the given unit
+func WriteMarkdown(w io.Writer, title, unit string, results []benchmark.Result) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "### %s\n\n", title)
+	fmt.Fprintf(&b, "| Operation | Name | Wall (%[1]s) | Ops | Ops/sec | P50 (%[1]s) | P95 (%[1]s) | P99 (%[1]s) | Max (%[1]s) | Errors |\n", unit)
+	b.WriteString("|---|---|--:|--:|--:|--:|--:|--:|--:|--:|\n")
+	for _, result := range results {
+		if result.Error != nil {
+			fmt.Fprintf(&b, "| %s | %s | %s | ERROR: %s | | | | | | |\n", result.Operation, result.Name,
+				FormatDuration(result.Duration, unit), markdownEscape(result.Error.Error()))
+			continue
+		}
+		if result.Skipped != "" {
+			fmt.Fprintf(&b, "| %s | %s | - | SKIPPED: %s | | | | | | |\n", result.Operation, markdownEscape(result.Name),
+				markdownEscape(result.Skipped))
+			continue
+		}
+		p50, p95, p99, max := "-", "-", "-", "-"
+		if l := result.Latency; l != nil {
+			p50 = FormatDuration(l.P50, unit)
+			p95 = FormatDuration(l.P95, unit)
+			p99 = FormatDuration(l.P99, unit)
+			max = FormatDuration(l.Max, unit)
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %d | %.0f | %s | %s | %s | %s | %d |\n", result.Operation, markdownEscape(result.Name),
+			FormatDuration(result.Duration, unit), result.Count, result.Throughput(), p50, p95, p99, max, result.Errors)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// csvMillis formats a duration in milliseconds at full precision, as
+// spreadsheets format the numbers themselves
+func csvMillis(d time.Duration) string {
+	return strconv.FormatFloat(float64(d)/float64(time.Millisecond), 'f', -1, 64)
+}
+
+// markdownEscape keeps text from breaking out of a Markdown table cell
+func markdownEscape(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.ReplaceAll(s, "\n", " ")
+}
@@ -0,0 +1,250 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"os"
+
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+)
+
+// HTMLReport describes a run rendered to a self-contained HTML page
+type HTMLReport struct {
+	// Title heads the page
+	Title string
+	// Metadata lists the settings of the run as label and value pairs, in order
+	Metadata [][2]string
+	// Unit is the time unit used for durations (s, ms, us)
+	Unit     string
+	Results  []benchmark.Result
+	Timeline *Timeline
+	// Reference optionally compares the run with published reference results
+	Reference *ReferenceComparison
+}
+
+// htmlChart is an SVG chart embedded in the page
+type htmlChart struct {
+	Title string
+	SVG   template.HTML
+}
+
+// htmlReferenceRow is a row of the reference comparison of the page
+type htmlReferenceRow struct {
+	Operation, Name, Throughput, RefThroughput, Ratio, P99, RefP99 string
+	Plausible                                                      bool
+}
+
+// htmlRow is a row of the results table of the page
+type htmlRow struct {
+	Operation, Name, Wall, Count, Throughput string
+	P50, P95, P99, Max, Errors, Error        string
+	Skipped                                  string
+}
+
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em auto; max-width: 1000px; color: #222; }
+h1 { font-size: 1.6em; }
+h2 { font-size: 1.2em; margin-top: 2em; border-bottom: 1px solid #ddd; padding-bottom: .3em; }
+table { border-collapse: collapse; font-size: .9em; }
+th, td { padding: .3em .8em; border-bottom: 1px solid #eee; text-align: left; }
+td.num { text-align: right; font-family: monospace; }
+td.error { color: #c62828; }
+td.skipped, td.implausible { color: #9e6a03; }
+svg { max-width: 100%; height: auto; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<table>
+{{range .Metadata}}<tr><th>{{index . 0}}</th><td>{{index . 1}}</td></tr>
+{{end}}</table>
+<h2>Results</h2>
+<table>
+<tr><th>Operation</th><th>Name</th><th>Wall ({{.Unit}})</th><th>Ops</th><th>Ops/sec</th><th>P50 ({{.Unit}})</th><th>P95 ({{.Unit}})</th><th>P99 ({{.Unit}})</th><th>Max ({{.Unit}})</th><th>Errors</th></tr>
+{{range .Rows}}<tr><td>{{.Operation}}</td><td>{{.Name}}</td><td class="num">{{.Wall}}</td>{{if .Error}}<td class="error" colspan="7">ERROR: {{.Error}}</td>{{else if .Skipped}}<td class="skipped" colspan="7">SKIPPED: {{.Skipped}}</td>{{else}}<td class="num">{{.Count}}</td><td class="num">{{.Throughput}}</td><td class="num">{{.P50}}</td><td class="num">{{.P95}}</td><td class="num">{{.P99}}</td><td class="num">{{.Max}}</td><td class="num">{{.Errors}}</td>{{end}}</tr>
+{{end}}</table>
+{{with .Reference}}<h2>Your run vs reference</h2>
+<p>Measured on {{.Reference.Profile}}, published by {{.Source}}.</p>
+<table>
+<tr><th>Operation</th><th>Name</th><th>Ops/sec</th><th>Reference</th><th>Ratio</th><th>P99 ({{$.Unit}})</th><th>Reference P99 ({{$.Unit}})</th></tr>
+{{range $.ReferenceRows}}<tr><td>{{.Operation}}</td><td>{{.Name}}</td><td class="num">{{.Throughput}}</td><td class="num">{{.RefThroughput}}</td><td class="num{{if not .Plausible}} implausible{{end}}">{{.Ratio}}</td><td class="num">{{.P99}}</td><td class="num">{{.RefP99}}</td></tr>
+{{end}}</table>
+<p>Caveats:</p>
+<ul>
+{{range .Caveats}}<li>{{.}}</li>
+{{end}}</ul>
+{{end}}{{range .Charts}}<h2>{{.Title}}</h2>
+{{.SVG}}
+{{end}}</body>
+</html>
+`))
+
+// WriteHTML renders the report to a self-contained HTML page with the run
+// metadata, the results table, the throughput and latency charts of every
+// phase and its latency histogram, embedded as SVG
+func WriteHTML(path string, report HTMLReport) error {
+	if report.Unit == "" {
+		report.Unit = "ms"
+	}
+	unit := report.Unit
+
+	rows := make([]htmlRow, 0, len(report.Results))
+	for _, result := range report.Results {
+		row := htmlRow{
+			Operation: string(result.Operation),
+			Name:      result.Name,
+			Wall:      FormatDuration(result.Duration, unit),
+		}
+		if result.Error != nil {
+			row.Error = result.Error.Error()
+			rows = append(rows, row)
+			continue
+		}
+		if result.Skipped != "" {
+			row.Wall = "-"
+			row.Skipped = result.Skipped
+			rows = append(rows, row)
+			continue
+		}
+		row.Count = fmt.Sprintf("%d", result.Count)
+		row.Throughput = fmt.Sprintf("%.0f", result.Throughput())
+		row.P50, row.P95, row.P99, row.Max = "-", "-", "-", "-"
+		if l := result.Latency; l != nil {
+			row.P50 = FormatDuration(l.P50, unit)
+			row.P95 = FormatDuration(l.P95, unit)
+			row.P99 = FormatDuration(l.P99, unit)
+			row.Max = FormatDuration(l.Max, unit)
+		}
+		row.Errors = fmt.Sprintf("%d", result.Errors)
+		rows = append(rows, row)
+	}
+
+	var referenceRows []htmlReferenceRow
+	if report.Reference != nil {
+		for _, row := range report.Reference.Rows {
+			referenceRows = append(referenceRows, htmlReferenceRow{
+				Operation:     row.Operation,
+				Name:          row.Name,
+				Throughput:    fmt.Sprintf("%.0f", row.Throughput),
+				RefThroughput: fmt.Sprintf("%.0f", row.RefThroughput),
+				Ratio:         fmt.Sprintf("%.2fx", row.Ratio()),
+				P99:           formatOptional(row.P99, unit),
+				RefP99:        formatOptional(row.RefP99, unit),
+				Plausible:     row.Plausible(),
+			})
+		}
+	}
+
+	// Render the charts which have something to draw
+	var charts []htmlChart
+	add := func(title string, draw func(c canvas) bool) {
+		c := newSVGCanvas(chartWidth, chartHeight)
+		if !draw(c) {
+			return
+		}
+		var b bytes.Buffer
+		c.encode(&b)
+		charts = append(charts, htmlChart{Title: title, SVG: template.HTML(b.String())})
+	}
+	add("Throughput per phase", func(c canvas) bool { return drawThroughputBars(c, report.Results) })
+	add("Latency percentiles", func(c canvas) bool { return drawLatencyChart(c, report.Results, unit) })
+	if report.Timeline != nil {
+		add("Throughput over time", func(c canvas) bool {
+			return drawThroughputChart(c, report.Timeline.Phases(), report.Timeline.Annotations())
+		})
+	}
+	for _, result := range report.Results {
+		add(fmt.Sprintf("Latency histogram of %s %s", result.Operation, result.Name), func(c canvas) bool {
+			return drawHistogramChart(c, result, unit)
+		})
+	}
+
+	var b bytes.Buffer
+	if err := htmlTemplate.Execute(&b, struct {
+		HTMLReport
+		Rows          []htmlRow
+		ReferenceRows []htmlReferenceRow
+		Charts        []htmlChart
+	}{report, rows, referenceRows, charts}); err != nil {
+		return fmt.Errorf("failed to render HTML report: %w", err)
+	}
+	if err := os.WriteFile(path, b.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write HTML report: %w", err)
+	}
+	return nil
+}
+
+// drawThroughputBars draws the operations per second of every phase as bars,
+// reporting whether there was anything to draw
+func drawThroughputBars(c canvas, results []benchmark.Result) bool {
+	var measured []benchmark.Result
+	var top float64
+	for _, r := range results {
+		if r.Error == nil && r.Count > 0 {
+			measured = append(measured, r)
+			top = max(top, r.Throughput())
+		}
+	}
+	if len(measured) == 0 {
+		return false
+	}
+
+	yMax := niceCeil(top)
+	drawAxes(c, "Throughput per phase", "ops/sec", yMax, func(v float64) string {
+		return fmt.Sprintf("%.0f", v)
+	})
+
+	width := float64(chartWidth - plotLeft - plotRight)
+	height := float64(chartHeight - plotTop - plotBottom)
+	group := width / float64(len(measured))
+	for i, r := range measured {
+		h := height * r.Throughput() / yMax
+		c.rect(plotLeft+group*float64(i)+group*0.1, plotTop+height-h, group*0.8, h, palette[0])
+		c.text(plotLeft+group*(float64(i)+0.5), chartHeight-plotBottom+16, truncate(r.Name, int(group/charWidth)), anchorMiddle)
+	}
+	return true
+}
+
+// drawHistogramChart draws the number of operations of a phase in every
+// latency bucket, the buckets doubling in width, reporting whether there was
+// anything to draw
+func drawHistogramChart(c canvas, result benchmark.Result, unit string) bool {
+	buckets := result.Histogram
+	if len(buckets) == 0 {
+		return false
+	}
+	var top int64
+	for _, b := range buckets {
+		top = max(top, b.Count)
+	}
+
+	yMax := niceCeil(float64(top))
+	drawAxes(c, fmt.Sprintf("Latency histogram of %s", result.Name), "operations", yMax, func(v float64) string {
+		return fmt.Sprintf("%.0f", v)
+	})
+
+	width := float64(chartWidth - plotLeft - plotRight)
+	height := float64(chartHeight - plotTop - plotBottom)
+	bar := width / float64(len(buckets))
+
+	// Label as many bucket bounds as fit under the bars
+	every := 1
+	for bar*float64(every) < float64(charWidth*8) {
+		every++
+	}
+	for i, b := range buckets {
+		h := height * float64(b.Count) / yMax
+		c.rect(plotLeft+bar*float64(i)+1, plotTop+height-h, bar-2, h, palette[0])
+		if i%every == 0 {
+			c.text(plotLeft+bar*(float64(i)+0.5), chartHeight-plotBottom+16, "≤"+FormatDuration(b.Le, unit), anchorMiddle)
+		}
+	}
+	c.text(plotLeft+width/2, chartHeight-plotBottom+40, fmt.Sprintf("latency (%s)", unit), anchorMiddle)
+	return true
+}
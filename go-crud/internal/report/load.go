@@ -0,0 +1,52 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+	"github.com/surrealdb/go-crud-bench/internal/docker"
+)
+
+// ResultsFile is the subset of a saved results file read back by the report commands
+type ResultsFile struct {
+	Database     string                 `json:"database"`
+	Samples      int                    `json:"samples"`
+	Clients      int                    `json:"clients"`
+	Threads      int                    `json:"threads"`
+	Duration     string                 `json:"duration"`
+	WorkloadHash string                 `json:"workload_hash"`
+	Container    *docker.Spec           `json:"container"`
+	NoiseFloor   *benchmark.Calibration `json:"noise_floor"`
+	Operations   []benchmark.Result     `json:"-"`
+}
+
+// storedResult decodes a saved result, whose error is not stored in a form
+// which can be decoded back into an error value
+type storedResult struct {
+	benchmark.Result
+	Error json.RawMessage
+}
+
+// LoadResults reads a results file written by a benchmark run
+func LoadResults(path string) (*ResultsFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read results file: %w", err)
+	}
+
+	var file struct {
+		ResultsFile
+		Operations []storedResult `json:"operations"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse results file %s: %w", path, err)
+	}
+
+	results := file.ResultsFile
+	for _, stored := range file.Operations {
+		results.Operations = append(results.Operations, stored.Result)
+	}
+	return &results, nil
+}
@@ -0,0 +1,120 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+)
+
+// MatrixEntry holds the results of one database of a matrix run, or the error
+// which stopped it
+type MatrixEntry struct {
+	Database string             `json:"database"`
+	Results  []benchmark.Result `json:"operations,omitempty"`
+	Error    string             `json:"error,omitempty"`
+}
+
+// PrintMatrix writes the throughput and P99 latency of every phase of a
+// matrix run with one column per database, the phases being aligned by
+// operation and name. The highest throughput and lowest P99 of a phase are
+// highlighted in green.
+func PrintMatrix(w io.Writer, entries []MatrixEntry, opts TableOptions) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	p := painter(opts.Color)
+	unit := opts.Unit
+
+	header := []string{"OPERATION", "NAME"}
+	for _, entry := range entries {
+		header = append(header, strings.ToUpper(entry.Database)+" OPS/SEC")
+	}
+	for _, entry := range entries {
+		header = append(header, fmt.Sprintf("%s P99 (%s)", strings.ToUpper(entry.Database), unit))
+	}
+	rule := make([]string, len(header))
+	for i, h := range header {
+		rule[i] = strings.Repeat("-", len(h))
+	}
+	writeRow(tw, p, header, nil)
+	writeRow(tw, p, rule, nil)
+
+	// List the phases in the order they first appear, and index the results
+	// of every database by phase
+	var phases []benchmark.Result
+	seen := map[string]bool{}
+	indexed := make([]map[string]benchmark.Result, len(entries))
+	for i, entry := range entries {
+		indexed[i] = map[string]benchmark.Result{}
+		for _, result := range entry.Results {
+			key := string(result.Operation) + "/" + result.Name
+			indexed[i][key] = result
+			if !seen[key] {
+				seen[key] = true
+				phases = append(phases, result)
+			}
+		}
+	}
+
+	for _, phase := range phases {
+		key := string(phase.Operation) + "/" + phase.Name
+		row := []string{string(phase.Operation), phase.Name}
+		colors := map[int]string{}
+
+		// Find the best throughput and P99 among the databases which ran the phase
+		best, bestAt := 0.0, -1
+		fastest, fastestAt := int64(0), -1
+		for i := range entries {
+			result, ok := indexed[i][key]
+			if !ok || result.Error != nil || result.Skipped != "" {
+				continue
+			}
+			if t := result.Throughput(); t > best {
+				best, bestAt = t, i
+			}
+			if l := result.Latency; l != nil && l.P99 > 0 && (fastestAt < 0 || int64(l.P99) < fastest) {
+				fastest, fastestAt = int64(l.P99), i
+			}
+		}
+
+		for i, entry := range entries {
+			result, ok := indexed[i][key]
+			switch {
+			case entry.Error != "" && !ok:
+				row = append(row, "failed")
+				colors[len(row)-1] = colorRed
+			case !ok:
+				row = append(row, "-")
+			case result.Skipped != "":
+				row = append(row, "skipped")
+				colors[len(row)-1] = colorYellow
+			default:
+				row = append(row, fmt.Sprintf("%.0f", result.Throughput()))
+				if i == bestAt && len(entries) > 1 {
+					colors[len(row)-1] = colorGreen
+				}
+			}
+		}
+		for i := range entries {
+			result, ok := indexed[i][key]
+			if !ok || result.Latency == nil || result.Skipped != "" {
+				row = append(row, "-")
+				continue
+			}
+			row = append(row, FormatDuration(result.Latency.P99, unit))
+			if i == fastestAt && len(entries) > 1 {
+				colors[len(row)-1] = colorGreen
+			}
+		}
+		writeRow(tw, p, row, colors)
+	}
+
+	tw.Flush()
+
+	for _, entry := range entries {
+		if entry.Error != "" {
+			fmt.Fprintf(w, "%s failed: %s\n", entry.Database, p.paint(colorRed, entry.Error))
+		}
+	}
+}
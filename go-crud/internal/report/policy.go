@@ -0,0 +1,108 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Metric classes compared between results files
+const (
+	MetricDuration   = "duration"
+	MetricThroughput = "throughput"
+	MetricP50        = "p50"
+	MetricP95        = "p95"
+	MetricP99        = "p99"
+)
+
+// ValidMetricClasses contains the metric classes a policy sets tolerances for
+var ValidMetricClasses = []string{MetricDuration, MetricThroughput, MetricP50, MetricP95, MetricP99}
+
+// Policy holds the tolerances of a comparison, in percent of the baseline,
+// within which a change of a metric is considered noise rather than a
+// regression or an improvement. Tail latencies legitimately vary more than
+// throughput, so every metric class has a tolerance of its own, and phases
+// may override the default tolerances of some classes.
+type Policy struct {
+	Default map[string]float64            `json:"default"`
+	Phases  map[string]map[string]float64 `json:"phases"`
+}
+
+// LoadPolicy reads a comparison policy from a JSON file
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy: %w", err)
+	}
+	var policy Policy
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("failed to parse policy %s: %w", path, err)
+	}
+	if err := validateTolerances(policy.Default); err != nil {
+		return nil, fmt.Errorf("invalid default tolerances in policy %s: %w", path, err)
+	}
+	for phase, tolerances := range policy.Phases {
+		if err := validateTolerances(tolerances); err != nil {
+			return nil, fmt.Errorf("invalid tolerances for phase %s in policy %s: %w", phase, path, err)
+		}
+	}
+	return &policy, nil
+}
+
+// validateTolerances checks the metric classes and values of tolerances
+func validateTolerances(tolerances map[string]float64) error {
+	classes := make([]string, 0, len(tolerances))
+	for class := range tolerances {
+		classes = append(classes, class)
+	}
+	sort.Strings(classes)
+	for _, class := range classes {
+		valid := false
+		for _, c := range ValidMetricClasses {
+			if class == c {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("unknown metric class %s, expected one of %s", class, strings.Join(ValidMetricClasses, ", "))
+		}
+		if tolerances[class] < 0 {
+			return fmt.Errorf("tolerance of %s must not be negative", class)
+		}
+	}
+	return nil
+}
+
+// WithThreshold returns a policy tolerating changes up to the threshold, in
+// percent of the baseline, for every metric class the policy does not set a
+// default tolerance for. A nil policy gets the threshold for every class.
+func (p *Policy) WithThreshold(threshold float64) *Policy {
+	policy := &Policy{Default: map[string]float64{}}
+	if p != nil {
+		policy.Phases = p.Phases
+		for class, tolerance := range p.Default {
+			policy.Default[class] = tolerance
+		}
+	}
+	for _, class := range ValidMetricClasses {
+		if _, ok := policy.Default[class]; !ok {
+			policy.Default[class] = threshold
+		}
+	}
+	return policy
+}
+
+// Tolerance returns the tolerance of a metric class for the named phase. A
+// nil policy tolerates no change.
+func (p *Policy) Tolerance(phase, class string) float64 {
+	if p == nil {
+		return 0
+	}
+	if tolerance, ok := p.Phases[phase][class]; ok {
+		return tolerance
+	}
+	return p.Default[class]
+}
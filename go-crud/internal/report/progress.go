@@ -0,0 +1,211 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+)
+
+// progressHeartbeat is how often the progress file is rewritten, even when no
+// event has been received, so that its timestamp shows the client is alive
+const progressHeartbeat = time.Second
+
+// Progress is the content of the progress file
+type Progress struct {
+	State     string    `json:"state"`
+	Database  string    `json:"database"`
+	Run       int       `json:"run"`
+	PID       int       `json:"pid"`
+	Phase     string    `json:"phase"`
+	Completed int64     `json:"completed"`
+	Errors    int64     `json:"errors"`
+	Percent   float64   `json:"percent"`
+	Rate      float64   `json:"ops_per_sec"`
+	Elapsed   float64   `json:"elapsed_sec"`
+	Started   time.Time `json:"started"`
+	Updated   time.Time `json:"updated"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// ProgressFile keeps a small JSON file up to date with the progress of the
+// run, for external watchdogs. It implements benchmark.Observer and forwards
+// every event to the next observer.
+type ProgressFile struct {
+	mu       sync.Mutex
+	path     string
+	next     benchmark.Observer
+	samples  int
+	progress Progress
+
+	// lastCompleted and lastTime hold the completed operations and time of the
+	// previous progress event, from which the current throughput is derived
+	lastCompleted int64
+	lastTime      time.Time
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewProgressFile creates the progress file at path, rewriting it every
+// second until it is closed. Phases are expected to run the given number of
+// samples when the percentage complete is computed.
+func NewProgressFile(path string, next benchmark.Observer, database string, samples, run int) (*ProgressFile, error) {
+	now := time.Now()
+	p := &ProgressFile{
+		path:    path,
+		next:    next,
+		samples: samples,
+		progress: Progress{
+			State:    "starting",
+			Database: database,
+			Run:      run,
+			PID:      os.Getpid(),
+			Started:  now,
+		},
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	if err := p.write(); err != nil {
+		return nil, err
+	}
+
+	go p.heartbeat()
+	return p, nil
+}
+
+// heartbeat rewrites the progress file until the file is closed
+func (p *ProgressFile) heartbeat() {
+	defer close(p.done)
+	ticker := time.NewTicker(progressHeartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			_ = p.write()
+		}
+	}
+}
+
+// PhaseStart records the start of a phase
+func (p *ProgressFile) PhaseStart(phase string) {
+	p.mu.Lock()
+	p.progress.State = "running"
+	p.progress.Phase = phase
+	p.progress.Completed = 0
+	p.progress.Errors = 0
+	p.progress.Percent = 0
+	p.progress.Rate = 0
+	p.lastCompleted = 0
+	p.lastTime = time.Now()
+	p.mu.Unlock()
+	_ = p.write()
+
+	if p.next != nil {
+		p.next.PhaseStart(phase)
+	}
+}
+
+// Progress records the operations completed in the running phase and the
+// throughput since the previous report
+func (p *ProgressFile) Progress(phase string, completed, failed int64, elapsed time.Duration) {
+	p.mu.Lock()
+	now := time.Now()
+	if interval := now.Sub(p.lastTime); interval > 0 {
+		p.progress.Rate = float64(completed-p.lastCompleted) / interval.Seconds()
+	}
+	p.lastCompleted = completed
+	p.lastTime = now
+	p.progress.Completed = completed
+	p.progress.Errors = failed
+	if p.samples > 0 {
+		p.progress.Percent = min(100, float64(completed)/float64(p.samples)*100)
+	}
+	p.mu.Unlock()
+	_ = p.write()
+
+	if p.next != nil {
+		p.next.Progress(phase, completed, failed, elapsed)
+	}
+}
+
+// PhaseEnd records the end of a phase
+func (p *ProgressFile) PhaseEnd(phase string, results []benchmark.Result, err error) {
+	p.mu.Lock()
+	if err == nil {
+		var completed, failed int64
+		for _, result := range results {
+			completed += int64(result.Count)
+			failed += result.Errors
+		}
+		p.progress.Completed = completed
+		p.progress.Errors = failed
+		p.progress.Percent = 100
+	}
+	p.mu.Unlock()
+	_ = p.write()
+
+	if p.next != nil {
+		p.next.PhaseEnd(phase, results, err)
+	}
+}
+
+// Annotate forwards a change made to the running benchmark
+func (p *ProgressFile) Annotate(annotation benchmark.Annotation) {
+	if annotator, ok := p.next.(benchmark.Annotator); ok {
+		annotator.Annotate(annotation)
+	}
+}
+
+// Close stops the heartbeat and writes the final state of the run
+func (p *ProgressFile) Close(err error) error {
+	close(p.stop)
+	<-p.done
+
+	p.mu.Lock()
+	p.progress.State = "completed"
+	if err != nil {
+		p.progress.State = "failed"
+		p.progress.Error = err.Error()
+	}
+	p.progress.Rate = 0
+	p.mu.Unlock()
+	return p.write()
+}
+
+// write replaces the progress file atomically, so that readers never see a
+// partially written file
+func (p *ProgressFile) write() error {
+	p.mu.Lock()
+	p.progress.Updated = time.Now()
+	p.progress.Elapsed = p.progress.Updated.Sub(p.progress.Started).Seconds()
+	data, err := json.MarshalIndent(p.progress, "", "  ")
+	p.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode progress: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(p.path), ".progress-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to write progress file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write progress file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write progress file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), p.path); err != nil {
+		return fmt.Errorf("failed to write progress file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+)
+
+// progressPlainInterval is how often the progress is printed when the output
+// is not a terminal, so that logs are not flooded with a line every second
+const progressPlainInterval = 10 * time.Second
+
+// timedPhases contains the phases which run for the configured duration
+// instead of once per sample
+var timedPhases = map[string]bool{"read": true, "update": true, "mixed": true}
+
+// ProgressLine shows the progress of the running phase on the console, with
+// the completed operations, the current throughput, the errors and the
+// estimated time remaining. On a terminal a single line is rewritten on every
+// progress event and cleared at the end of the phase, while other outputs get
+// a plain line every few seconds. It implements benchmark.Observer and
+// forwards every event to the next observer.
+type ProgressLine struct {
+	mu       sync.Mutex
+	w        io.Writer
+	next     benchmark.Observer
+	terminal bool
+	samples  int
+	duration time.Duration
+
+	// lastCompleted and lastTime hold the completed operations and time of the
+	// previous progress event, from which the current throughput is derived
+	lastCompleted int64
+	lastTime      time.Time
+
+	// printed is when the last plain line was printed, and shown is set while
+	// a line is left on the terminal
+	printed time.Time
+	shown   bool
+}
+
+// NewProgressLine creates a progress display writing to w, rewriting a single
+// line when w is a terminal. Phases are expected to run the given number of
+// samples, or for the given duration when it is set and the phase is timed,
+// when the time remaining is estimated.
+func NewProgressLine(w io.Writer, terminal bool, next benchmark.Observer, samples int, duration time.Duration) *ProgressLine {
+	return &ProgressLine{
+		w:        w,
+		next:     next,
+		terminal: terminal,
+		samples:  samples,
+		duration: duration,
+	}
+}
+
+// PhaseStart resets the throughput for the new phase
+func (p *ProgressLine) PhaseStart(phase string) {
+	p.mu.Lock()
+	p.lastCompleted = 0
+	p.lastTime = time.Now()
+	p.printed = p.lastTime
+	p.mu.Unlock()
+
+	if p.next != nil {
+		p.next.PhaseStart(phase)
+	}
+}
+
+// Progress shows the operations completed in the running phase, the
+// throughput since the previous report, the errors and the time remaining
+func (p *ProgressLine) Progress(phase string, completed, failed int64, elapsed time.Duration) {
+	p.mu.Lock()
+	now := time.Now()
+	var rate float64
+	if interval := now.Sub(p.lastTime); interval > 0 {
+		rate = float64(completed-p.lastCompleted) / interval.Seconds()
+	}
+	p.lastCompleted = completed
+	p.lastTime = now
+
+	line := p.format(phase, completed, failed, elapsed, rate)
+	switch {
+	case p.terminal:
+		// Return to the start of the line, so that console output written
+		// before the next event overwrites it rather than following it
+		fmt.Fprintf(p.w, "\r\x1b[K%s\r", line)
+		p.shown = true
+	case now.Sub(p.printed) >= progressPlainInterval:
+		fmt.Fprintln(p.w, line)
+		p.printed = now
+	}
+	p.mu.Unlock()
+
+	if p.next != nil {
+		p.next.Progress(phase, completed, failed, elapsed)
+	}
+}
+
+// PhaseEnd clears the progress line of the phase
+func (p *ProgressLine) PhaseEnd(phase string, results []benchmark.Result, err error) {
+	p.mu.Lock()
+	if p.shown {
+		fmt.Fprint(p.w, "\r\x1b[K")
+		p.shown = false
+	}
+	p.mu.Unlock()
+
+	if p.next != nil {
+		p.next.PhaseEnd(phase, results, err)
+	}
+}
+
+// Annotate forwards a change made to the running benchmark
+func (p *ProgressLine) Annotate(annotation benchmark.Annotation) {
+	if annotator, ok := p.next.(benchmark.Annotator); ok {
+		annotator.Annotate(annotation)
+	}
+}
+
+// format renders the progress of a phase as a single line
+func (p *ProgressLine) format(phase string, completed, failed int64, elapsed time.Duration, rate float64) string {
+	// Estimate the time remaining from the configured duration of timed
+	// phases, and from the current throughput otherwise
+	eta := "-"
+	ops := fmt.Sprintf("%d ops", completed)
+	switch {
+	case p.duration > 0 && timedPhases[phase]:
+		eta = max(0, p.duration-elapsed).Round(time.Second).String()
+	case p.samples > 0:
+		ops = fmt.Sprintf("%d/%d ops (%.1f%%)", completed, p.samples, min(100, float64(completed)/float64(p.samples)*100))
+		if remaining := int64(p.samples) - completed; remaining > 0 && rate > 0 {
+			eta = time.Duration(float64(remaining) / rate * float64(time.Second)).Round(time.Second).String()
+		}
+	}
+	return fmt.Sprintf("%s: %s, %.0f ops/sec, %d errors, elapsed %s, ETA %s",
+		phase, ops, rate, failed, elapsed.Round(time.Second), eta)
+}
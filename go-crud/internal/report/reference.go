@@ -0,0 +1,241 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"runtime"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+)
+
+// referenceRange is the factor by which the throughput of a phase may differ
+// from the reference before it is flagged as outside the plausible range
+const referenceRange = 2.0
+
+// referenceTimeout bounds the download of a published reference set
+const referenceTimeout = 10 * time.Second
+
+// ReferenceSet holds published reference results of common databases on
+// described hardware profiles
+type ReferenceSet struct {
+	// Source describes where the references were published
+	Source     string      `json:"source"`
+	Published  string      `json:"published,omitempty"`
+	References []Reference `json:"references"`
+}
+
+// Reference holds the results of the phases of one database on one hardware
+// profile
+type Reference struct {
+	Database     string                    `json:"database"`
+	Profile      string                    `json:"profile"`
+	CPUs         int                       `json:"cpus,omitempty"`
+	Clients      int                       `json:"clients,omitempty"`
+	Threads      int                       `json:"threads,omitempty"`
+	WorkloadHash string                    `json:"workload_hash,omitempty"`
+	Phases       map[string]ReferencePhase `json:"phases"`
+}
+
+// ReferencePhase holds the reference throughput and latencies of a phase,
+// named as in the results table
+type ReferencePhase struct {
+	Throughput float64 `json:"ops_per_sec"`
+	P50        float64 `json:"p50_ms,omitempty"`
+	P99        float64 `json:"p99_ms,omitempty"`
+}
+
+// ReferenceComparison compares the results of a run with the closest
+// reference of its database
+type ReferenceComparison struct {
+	Source    string
+	Reference Reference
+	// Caveats lists the differences between the run and the reference which
+	// make the comparison less meaningful
+	Caveats []string
+	Rows    []ReferenceRow
+}
+
+// ReferenceRow compares a phase of the run with the reference
+type ReferenceRow struct {
+	Operation     string
+	Name          string
+	Throughput    float64
+	RefThroughput float64
+	P99           time.Duration
+	RefP99        time.Duration
+}
+
+// Ratio returns the throughput of the run as a multiple of the reference
+func (r ReferenceRow) Ratio() float64 {
+	if r.RefThroughput <= 0 {
+		return 0
+	}
+	return r.Throughput / r.RefThroughput
+}
+
+// Plausible reports whether the throughput is within the plausible range of
+// the reference
+func (r ReferenceRow) Plausible() bool {
+	ratio := r.Ratio()
+	return ratio >= 1/referenceRange && ratio <= referenceRange
+}
+
+// LoadReferences reads a reference set from a local file or downloads it
+// from an http or https URL
+func LoadReferences(ctx context.Context, location string) (*ReferenceSet, error) {
+	var data []byte
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		ctx, cancel := context.WithTimeout(ctx, referenceTimeout)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reference URL %s: %w", location, err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch references: %w", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to fetch references from %s: %s", location, resp.Status)
+		}
+		if data, err = io.ReadAll(resp.Body); err != nil {
+			return nil, fmt.Errorf("failed to fetch references: %w", err)
+		}
+	} else {
+		var err error
+		if data, err = os.ReadFile(location); err != nil {
+			return nil, fmt.Errorf("failed to read references: %w", err)
+		}
+	}
+
+	var set ReferenceSet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse references %s: %w", location, err)
+	}
+	if set.Source == "" {
+		set.Source = location
+	}
+	return &set, nil
+}
+
+// Compare compares the results of a run with the reference of its database
+// which matches the workload and the host best, returning nil when the set
+// has no reference for the database
+func (s *ReferenceSet) Compare(database, workloadHash string, clients, threads int, results []benchmark.Result) *ReferenceComparison {
+	// Prefer a reference of the same workload, then the closest CPU count
+	var best *Reference
+	score := func(ref *Reference) int {
+		points := 0
+		if ref.WorkloadHash != "" && ref.WorkloadHash == workloadHash {
+			points += 1000
+		}
+		if ref.CPUs > 0 {
+			points -= abs(ref.CPUs - runtime.NumCPU())
+		}
+		return points
+	}
+	for i := range s.References {
+		ref := &s.References[i]
+		if ref.Database == database && (best == nil || score(ref) > score(best)) {
+			best = ref
+		}
+	}
+	if best == nil {
+		return nil
+	}
+
+	cmp := &ReferenceComparison{Source: s.Source, Reference: *best}
+	cmp.Caveats = append(cmp.Caveats, fmt.Sprintf("the reference was measured on %s; hardware, storage, kernel and database configuration change the results considerably", best.Profile))
+	if best.WorkloadHash != workloadHash {
+		cmp.Caveats = append(cmp.Caveats, "the reference was produced by a different workload")
+	}
+	if best.CPUs > 0 && best.CPUs != runtime.NumCPU() {
+		cmp.Caveats = append(cmp.Caveats, fmt.Sprintf("the reference host has %d CPUs, this host %d", best.CPUs, runtime.NumCPU()))
+	}
+	if (best.Clients > 0 && best.Clients != clients) || (best.Threads > 0 && best.Threads != threads) {
+		cmp.Caveats = append(cmp.Caveats, fmt.Sprintf("the reference ran %d clients with %d threads, this run %d with %d", best.Clients, best.Threads, clients, threads))
+	}
+
+	for _, result := range results {
+		phase, ok := best.Phases[result.Name]
+		if !ok || result.Error != nil || result.Skipped != "" {
+			continue
+		}
+		row := ReferenceRow{
+			Operation:     string(result.Operation),
+			Name:          result.Name,
+			Throughput:    result.Throughput(),
+			RefThroughput: phase.Throughput,
+			RefP99:        time.Duration(phase.P99 * float64(time.Millisecond)),
+		}
+		if result.Latency != nil {
+			row.P99 = result.Latency.P99
+		}
+		cmp.Rows = append(cmp.Rows, row)
+	}
+	return cmp
+}
+
+// PrintReference writes the comparison of a run with its reference, with
+// the caveats of the comparison. Phases whose throughput is outside the
+// plausible range of the reference are highlighted in yellow.
+func PrintReference(w io.Writer, cmp *ReferenceComparison, opts TableOptions) {
+	p := painter(opts.Color)
+	unit := opts.Unit
+
+	fmt.Fprintf(w, "\nYour run vs reference (%s, %s):\n", cmp.Reference.Profile, cmp.Source)
+	if len(cmp.Rows) == 0 {
+		fmt.Fprintln(w, "  the reference has none of the phases of this run")
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	header := []string{"OPERATION", "NAME", "OPS/SEC", "REFERENCE", "RATIO", fmt.Sprintf("P99 (%s)", unit), fmt.Sprintf("REFERENCE P99 (%s)", unit)}
+	rule := make([]string, len(header))
+	for i, h := range header {
+		rule[i] = strings.Repeat("-", len(h))
+	}
+	writeRow(tw, p, header, nil)
+	writeRow(tw, p, rule, nil)
+	for _, row := range cmp.Rows {
+		colors := map[int]string{}
+		if !row.Plausible() {
+			colors[4] = colorYellow
+		}
+		writeRow(tw, p, []string{
+			row.Operation, row.Name,
+			fmt.Sprintf("%.0f", row.Throughput), fmt.Sprintf("%.0f", row.RefThroughput), fmt.Sprintf("%.2fx", row.Ratio()),
+			formatOptional(row.P99, unit), formatOptional(row.RefP99, unit),
+		}, colors)
+	}
+	tw.Flush()
+
+	fmt.Fprintf(w, "Ratios outside %.1fx either way are highlighted. Caveats:\n", referenceRange)
+	for _, caveat := range cmp.Caveats {
+		fmt.Fprintf(w, "  - %s\n", caveat)
+	}
+}
+
+// formatOptional formats a duration which may be missing
+func formatOptional(d time.Duration, unit string) string {
+	if d <= 0 {
+		return "-"
+	}
+	return FormatDuration(d, unit)
+}
+
+// abs returns the absolute value of an integer
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,80 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+)
+
+// event is a single JSON line written in json-stream mode
+type event struct {
+	Event     string             `json:"event"`
+	Time      time.Time          `json:"time"`
+	Phase     string             `json:"phase,omitempty"`
+	Completed int64              `json:"completed,omitempty"`
+	Errors    int64              `json:"errors,omitempty"`
+	Elapsed   time.Duration      `json:"elapsed,omitempty"`
+	Rate      float64            `json:"ops_per_sec,omitempty"`
+	Results   []benchmark.Result `json:"results,omitempty"`
+	Text      string             `json:"text,omitempty"`
+	Error     string             `json:"error,omitempty"`
+}
+
+// JSONStream writes benchmark lifecycle events as JSON lines, implementing benchmark.Observer
+type JSONStream struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewJSONStream creates a JSON lines event stream writing to w
+func NewJSONStream(w io.Writer) *JSONStream {
+	return &JSONStream{enc: json.NewEncoder(w)}
+}
+
+// PhaseStart emits a phase_start event
+func (s *JSONStream) PhaseStart(phase string) {
+	s.emit(event{Event: "phase_start", Phase: phase})
+}
+
+// Progress emits a progress event
+func (s *JSONStream) Progress(phase string, completed, failed int64, elapsed time.Duration) {
+	rate := 0.0
+	if elapsed > 0 {
+		rate = float64(completed) / elapsed.Seconds()
+	}
+	s.emit(event{Event: "progress", Phase: phase, Completed: completed, Errors: failed, Elapsed: elapsed, Rate: rate})
+}
+
+// PhaseEnd emits a phase_end event
+func (s *JSONStream) PhaseEnd(phase string, results []benchmark.Result, err error) {
+	e := event{Event: "phase_end", Phase: phase, Results: results}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	s.emit(e)
+}
+
+// Annotate emits an annotation event for a change made to the running benchmark
+func (s *JSONStream) Annotate(annotation benchmark.Annotation) {
+	s.emit(event{Event: "annotation", Phase: annotation.Phase, Text: annotation.Text})
+}
+
+// RunEnd emits the final run_end event
+func (s *JSONStream) RunEnd(duration time.Duration, results []benchmark.Result, err error) {
+	e := event{Event: "run_end", Elapsed: duration, Results: results}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	s.emit(e)
+}
+
+// emit writes a single event line
+func (s *JSONStream) emit(e event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e.Time = time.Now()
+	_ = s.enc.Encode(e)
+}
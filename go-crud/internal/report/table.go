@@ -0,0 +1,172 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+)
+
+// FormatDuration formats a duration as a number in the given time unit
+func FormatDuration(d time.Duration, unit string) string {
+	switch unit {
+	case "s":
+		return fmt.Sprintf("%.3f", d.Seconds())
+	case "us":
+		return fmt.Sprintf("%.1f", float64(d)/float64(time.Microsecond))
+	default:
+		return fmt.Sprintf("%.3f", float64(d)/float64(time.Millisecond))
+	}
+}
+
+// noiseFloorShare is the share of the noise floor above which a phase is
+// likely limited by the client rather than the database
+const noiseFloorShare = 0.5
+
+// TableOptions controls how the console results table is rendered
+type TableOptions struct {
+	// Unit is the time unit used for durations (s, ms, us)
+	Unit string
+	// Color enables ANSI colour highlighting
+	Color bool
+	// SLO contains the configured latency bounds, used to highlight violations
+	SLO []time.Duration
+}
+
+// PrintTable writes the results as an aligned console table with one row per
+// phase. Errors are highlighted in red and SLO violations in yellow.
+func PrintTable(w io.Writer, results []benchmark.Result, opts TableOptions) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	p := painter(opts.Color)
+	unit := opts.Unit
+
+	// The slowest SLO bound marks the point beyond which latencies are violations
+	var limit time.Duration
+	if len(opts.SLO) > 0 {
+		limit = opts.SLO[len(opts.SLO)-1]
+	}
+
+	header := []string{"OPERATION", "NAME", fmt.Sprintf("WALL (%s)", unit), "OPS", "OPS/SEC"}
+	for _, latency := range []string{"MIN", "MEAN", "P50", "P90", "P95", "P99", "P999", "MAX"} {
+		header = append(header, fmt.Sprintf("%s (%s)", latency, unit))
+	}
+	header = append(header, "ERRORS")
+	rule := make([]string, len(header))
+	for i, h := range header {
+		rule[i] = strings.Repeat("-", len(h))
+	}
+	writeRow(tw, p, header, nil)
+	writeRow(tw, p, rule, nil)
+
+	for _, result := range results {
+		if result.Error != nil {
+			row := []string{string(result.Operation), result.Name, FormatDuration(result.Duration, unit), fmt.Sprintf("ERROR: %v", result.Error)}
+			writeRow(tw, p, row, map[int]string{3: colorRed})
+			continue
+		}
+		if result.Skipped != "" {
+			row := []string{string(result.Operation), result.Name, "-", fmt.Sprintf("SKIPPED: %s", result.Skipped)}
+			writeRow(tw, p, row, map[int]string{3: colorYellow})
+			continue
+		}
+
+		colors := map[int]string{}
+
+		// Latency columns are only available for phases which record per-operation latencies
+		min, mean, p50, p90, p95, p99, p999, max := "-", "-", "-", "-", "-", "-", "-", "-"
+		if l := result.Latency; l != nil {
+			min = FormatDuration(l.Min, unit)
+			mean = FormatDuration(l.Mean, unit)
+			p50 = FormatDuration(l.P50, unit)
+			p90 = FormatDuration(l.P90, unit)
+			p95 = FormatDuration(l.P95, unit)
+			p99 = FormatDuration(l.P99, unit)
+			p999 = FormatDuration(l.P999, unit)
+			max = FormatDuration(l.Max, unit)
+			if limit > 0 && l.P99 >= limit {
+				colors[10] = colorYellow
+			}
+			if limit > 0 && l.P999 >= limit {
+				colors[11] = colorYellow
+			}
+			if limit > 0 && l.Max >= limit {
+				colors[12] = colorYellow
+			}
+		}
+		if result.Errors > 0 {
+			colors[13] = colorRed
+		}
+
+		row := []string{
+			string(result.Operation), result.Name, FormatDuration(result.Duration, unit),
+			fmt.Sprintf("%d", result.Count), fmt.Sprintf("%.0f", result.Throughput()),
+			min, mean, p50, p90, p95, p99, p999, max, fmt.Sprintf("%d", result.Errors),
+		}
+		writeRow(tw, p, row, colors)
+	}
+
+	tw.Flush()
+}
+
+// PrintHints writes the bottleneck hints of the results below the table, one
+// line per hint prefixed with the result it applies to, and nothing when
+// there are no hints
+func PrintHints(w io.Writer, results []benchmark.Result, color bool) {
+	p := painter(color)
+	printed := false
+	for _, result := range results {
+		for _, hint := range result.Hints {
+			if !printed {
+				fmt.Fprintln(w, "\nHints:")
+				printed = true
+			}
+			fmt.Fprintf(w, "  %s %s: %s\n", result.Operation, result.Name, p.paint(colorYellow, hint.Message))
+		}
+	}
+}
+
+// PrintNoiseFloor writes the throughput of every phase as a share of the
+// noise floor measured by crud-bench calibrate, highlighting phases which come
+// close to it and were therefore likely limited by the client, and nothing
+// when the host has not been calibrated
+func PrintNoiseFloor(w io.Writer, results []benchmark.Result, calibration *benchmark.Calibration, color bool) {
+	if calibration == nil {
+		return
+	}
+	p := painter(color)
+	fmt.Fprintf(w, "\nNoise floor (calibrated %s with %d clients and %d threads, loopback echo %.0f ops/sec):\n",
+		calibration.Created.Format("2006-01-02"), calibration.Clients, calibration.Threads, calibration.Loopback.Throughput)
+	for _, result := range results {
+		dry := benchmark.Floor(calibration.Dry, result.Operation, result.Name)
+		if result.Error != nil || result.Skipped != "" || dry == nil || dry.Throughput <= 0 {
+			continue
+		}
+		share := result.Throughput() / dry.Throughput
+		line := fmt.Sprintf("%.1f%% of the dry adapter (%.0f ops/sec)", share*100, dry.Throughput)
+		if m := benchmark.Floor(calibration.Map, result.Operation, result.Name); m != nil && m.Throughput > 0 {
+			mapShare := result.Throughput() / m.Throughput
+			line += fmt.Sprintf(", %.1f%% of the map adapter (%.0f ops/sec)", mapShare*100, m.Throughput)
+			share = max(share, mapShare)
+		}
+		if share >= noiseFloorShare {
+			line = p.paint(colorYellow, line+", close to the client limit")
+		}
+		fmt.Fprintf(w, "  %s %s: %s\n", result.Operation, result.Name, line)
+	}
+}
+
+// writeRow writes a single tab-separated row, colouring the cells listed in colors
+func writeRow(w io.Writer, p painter, cells []string, colors map[int]string) {
+	painted := make([]string, len(cells))
+	for i, cell := range cells {
+		color, ok := colors[i]
+		if !ok {
+			color = colorNone
+		}
+		painted[i] = p.paint(color, cell)
+	}
+	fmt.Fprintln(w, strings.Join(painted, "\t"))
+}
@@ -0,0 +1,106 @@
+package report
+
+import (
+	"sync"
+	"time"
+
+	"github.com/surrealdb/go-crud-bench/internal/benchmark"
+)
+
+// TimelineSample is a progress report received while a phase runs
+type TimelineSample struct {
+	Elapsed   time.Duration
+	Completed int64
+}
+
+// TimelinePhase holds the progress reports of a single phase
+type TimelinePhase struct {
+	Name    string
+	Offset  time.Duration
+	Samples []TimelineSample
+}
+
+// TimelineAnnotation is a change made to the running benchmark, at an offset
+// from the start of the run
+type TimelineAnnotation struct {
+	Offset time.Duration
+	Text   string
+}
+
+// Timeline records the progress of every phase for the throughput chart. It
+// implements benchmark.Observer and forwards every event to the next observer.
+type Timeline struct {
+	mu          sync.Mutex
+	next        benchmark.Observer
+	start       time.Time
+	phases      []TimelinePhase
+	annotations []TimelineAnnotation
+}
+
+// NewTimeline creates a timeline starting now, forwarding events to next if it
+// is not nil
+func NewTimeline(next benchmark.Observer) *Timeline {
+	return &Timeline{next: next, start: time.Now()}
+}
+
+// PhaseStart starts recording a new phase
+func (t *Timeline) PhaseStart(phase string) {
+	t.mu.Lock()
+	t.phases = append(t.phases, TimelinePhase{Name: phase, Offset: time.Since(t.start)})
+	t.mu.Unlock()
+
+	if t.next != nil {
+		t.next.PhaseStart(phase)
+	}
+}
+
+// Progress records a progress report of the running phase
+func (t *Timeline) Progress(phase string, completed, failed int64, elapsed time.Duration) {
+	t.mu.Lock()
+	if n := len(t.phases); n > 0 && t.phases[n-1].Name == phase {
+		t.phases[n-1].Samples = append(t.phases[n-1].Samples, TimelineSample{Elapsed: elapsed, Completed: completed})
+	}
+	t.mu.Unlock()
+
+	if t.next != nil {
+		t.next.Progress(phase, completed, failed, elapsed)
+	}
+}
+
+// PhaseEnd forwards the end of a phase
+func (t *Timeline) PhaseEnd(phase string, results []benchmark.Result, err error) {
+	if t.next != nil {
+		t.next.PhaseEnd(phase, results, err)
+	}
+}
+
+// Annotate records a change made to the running benchmark
+func (t *Timeline) Annotate(annotation benchmark.Annotation) {
+	t.mu.Lock()
+	t.annotations = append(t.annotations, TimelineAnnotation{Offset: annotation.Time.Sub(t.start), Text: annotation.Text})
+	t.mu.Unlock()
+
+	if annotator, ok := t.next.(benchmark.Annotator); ok {
+		annotator.Annotate(annotation)
+	}
+}
+
+// Phases returns the phases recorded so far
+func (t *Timeline) Phases() []TimelinePhase {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	phases := make([]TimelinePhase, len(t.phases))
+	copy(phases, t.phases)
+	return phases
+}
+
+// Annotations returns the annotations recorded so far
+func (t *Timeline) Annotations() []TimelineAnnotation {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	annotations := make([]TimelineAnnotation, len(t.annotations))
+	copy(annotations, t.annotations)
+	return annotations
+}
@@ -0,0 +1,118 @@
+package upload
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/surrealdb/go-crud-bench/internal/config"
+)
+
+// Default object storage endpoints per scheme. GCS is reached through its
+// S3-compatible XML API, which authenticates with HMAC keys.
+const (
+	s3Endpoint  = "s3.amazonaws.com"
+	gcsEndpoint = "storage.googleapis.com"
+)
+
+// Files uploads the results file of a run and the other files it wrote to the
+// target and returns the URLs of the objects. Every object is stored below a
+// directory named after the checksum of the results file, so uploading the
+// same run again is a no-op and different runs never overwrite each other.
+func Files(ctx context.Context, target *config.UploadTarget, results string, others []string) ([]string, error) {
+	dir, err := checksum(results)
+	if err != nil {
+		return nil, err
+	}
+	paths := append([]string{results}, others...)
+
+	client, err := newClient(target)
+	if err != nil {
+		return nil, err
+	}
+
+	var urls []string
+	for _, path := range paths {
+		key := target.Key(dir + "/" + filepath.Base(path))
+
+		// Skip objects which were already uploaded
+		if _, err := client.StatObject(ctx, target.Bucket, key, minio.StatObjectOptions{}); err == nil {
+			urls = append(urls, target.URL(key))
+			continue
+		} else if minio.ToErrorResponse(err).Code != "NoSuchKey" {
+			return urls, fmt.Errorf("failed to check %s: %w", target.URL(key), err)
+		}
+
+		opts := minio.PutObjectOptions{ContentType: mime.TypeByExtension(filepath.Ext(path))}
+		if _, err := client.FPutObject(ctx, target.Bucket, key, path, opts); err != nil {
+			return urls, fmt.Errorf("failed to upload %s: %w", path, err)
+		}
+		urls = append(urls, target.URL(key))
+	}
+
+	return urls, nil
+}
+
+// newClient creates an object storage client for the target. Credentials are
+// read from the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY variables, the AWS
+// credentials file or, on S3, the instance role. AWS_ENDPOINT_URL points S3
+// uploads at a compatible service such as MinIO.
+func newClient(target *config.UploadTarget) (*minio.Client, error) {
+	endpoint, secure := s3Endpoint, true
+	providers := []credentials.Provider{
+		&credentials.EnvAWS{},
+		&credentials.FileAWSCredentials{},
+	}
+
+	switch target.Scheme {
+	case "s3":
+		if custom := os.Getenv("AWS_ENDPOINT_URL"); custom != "" {
+			u, err := url.Parse(custom)
+			if err != nil || u.Host == "" {
+				return nil, fmt.Errorf("invalid AWS_ENDPOINT_URL: %s", custom)
+			}
+			endpoint, secure = u.Host, u.Scheme != "http"
+		}
+		providers = append(providers, &credentials.IAM{Client: &http.Client{Transport: http.DefaultTransport}})
+	case "gs":
+		endpoint = gcsEndpoint
+	default:
+		return nil, fmt.Errorf("unsupported upload scheme: %s", target.Scheme)
+	}
+
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewChainCredentials(providers),
+		Secure: secure,
+		Region: os.Getenv("AWS_REGION"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s client: %w", target.Scheme, err)
+	}
+
+	return client, nil
+}
+
+// checksum returns the first 16 hex digits of the SHA-256 checksum of a file
+func checksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("failed to hash %s: %w", path, err)
+	}
+
+	return hex.EncodeToString(h.Sum(nil))[:16], nil
+}
@@ -0,0 +1,192 @@
+// Package middleware wraps the calls crud-bench issues to the database
+// adapters, so that programs embedding crud-bench can add behaviour around
+// them, such as refreshing an authentication token, tracing calls or changing
+// the values written, without changing the adapters. Middleware installed
+// with Use before cli.Execute wraps every call of every benchmark run.
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/surrealdb/go-crud-bench/internal/config"
+)
+
+// Operation represents a benchmark operation type
+type Operation string
+
+const (
+	// OperationCreate represents a create operation
+	OperationCreate Operation = "CREATE"
+	// OperationRead represents a read operation
+	OperationRead Operation = "READ"
+	// OperationUpdate represents an update operation
+	OperationUpdate Operation = "UPDATE"
+	// OperationDelete represents a delete operation
+	OperationDelete Operation = "DELETE"
+	// OperationScan represents a scan operation
+	OperationScan Operation = "SCAN"
+)
+
+// ScanConfig is the configuration of a scan
+type ScanConfig = config.ScanConfig
+
+// Call is an adapter operation passing through the middleware chain
+type Call struct {
+	// Operation is the kind of operation, or the operation of the phase for
+	// the calls of the secondary phases, such as CAS or TRAVERSE
+	Operation Operation
+
+	// Key is the key of the record, empty for scans and batches
+	Key string
+
+	// Keys are the keys of the records of batched calls
+	Keys []string
+
+	// Value is the value written by creates and updates, which middleware
+	// may replace before calling the next handler
+	Value map[string]interface{}
+
+	// Values are the values written by batched creates
+	Values []map[string]interface{}
+
+	// Scan is the configuration of scans
+	Scan *ScanConfig
+
+	// Record is the record returned by reads once the adapter has been called
+	Record map[string]interface{}
+
+	// Payload is the pre-encoded value of creates and updates, or the record
+	// returned by reads, on the payload path, where middleware replacing the
+	// value must replace the payload too
+	Payload *Payload
+
+	// Count is the number of rows returned by scans, records returned by
+	// batched reads and records reached by traversals once the adapter has
+	// been called
+	Count int
+
+	// Index is the position of the key in the keys of the phase, with which
+	// the key is passed to the adapter in its native encoding, so middleware
+	// cannot replace the key of creates, reads, updates and deletes
+	Index int
+}
+
+// Handler executes an adapter call
+type Handler func(ctx context.Context, call *Call) error
+
+// Middleware wraps the handler of every adapter call, so that code can run
+// before and after the call, change its context or value, retry it, or fail
+// it without calling the next handler
+type Middleware func(next Handler) Handler
+
+var (
+	installedMu sync.Mutex
+	installed   []Middleware
+)
+
+// Use installs middleware around the adapter calls of every benchmark run
+// started afterwards, the first middleware installed being the outermost
+func Use(middleware ...Middleware) {
+	installedMu.Lock()
+	defer installedMu.Unlock()
+	installed = append(installed, middleware...)
+}
+
+// Installed returns the middleware installed with Use
+func Installed() []Middleware {
+	installedMu.Lock()
+	defer installedMu.Unlock()
+	return append([]Middleware(nil), installed...)
+}
+
+// Timeout fails every call which takes longer than the timeout
+func Timeout(timeout time.Duration) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) error {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			return next(ctx, call)
+		}
+	}
+}
+
+// Limit bounds the number of calls in flight at once, further calls waiting
+// for a call to complete before they are issued
+func Limit(limit int) Middleware {
+	slots := make(chan struct{}, limit)
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) error {
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			defer func() { <-slots }()
+			return next(ctx, call)
+		}
+	}
+}
+
+// logValueLimit is the number of bytes of the values printed by the log
+// middleware, longer values being truncated
+const logValueLimit = 200
+
+// Log writes a line to w for every call with its sequence number, operation,
+// key, value or returned record, duration and outcome
+func Log(w io.Writer) Middleware {
+	var seq atomic.Int64
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) error {
+			n := seq.Add(1)
+			start := time.Now()
+			err := next(ctx, call)
+			duration := time.Since(start)
+
+			var detail string
+			switch {
+			case call.Scan != nil:
+				detail = fmt.Sprintf("scan=%s rows=%d", call.Scan.Name, call.Count)
+			case call.Keys != nil:
+				detail = fmt.Sprintf("keys=%d", len(call.Keys))
+			case call.Record != nil:
+				detail = fmt.Sprintf("key=%s record=%s", call.Key, logValue(call.Record))
+			case call.Value != nil:
+				detail = fmt.Sprintf("key=%s value=%s", call.Key, logValue(call.Value))
+			case call.Payload != nil:
+				detail = fmt.Sprintf("key=%s record=%s", call.Key, logTruncate(call.Payload.Bytes()))
+			default:
+				detail = fmt.Sprintf("key=%s", call.Key)
+			}
+			outcome := "ok"
+			if err != nil {
+				outcome = "error: " + err.Error()
+			}
+			fmt.Fprintf(w, "#%d %s %s %v %s\n", n, strings.ToLower(string(call.Operation)), detail, duration, outcome)
+			return err
+		}
+	}
+}
+
+// logValue encodes a value for the log middleware, truncated to the limit
+func logValue(value map[string]interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("<%v>", err)
+	}
+	return logTruncate(data)
+}
+
+// logTruncate truncates an encoded value for the log middleware to the limit
+func logTruncate(data []byte) string {
+	if len(data) > logValueLimit {
+		return string(data[:logValueLimit]) + "..."
+	}
+	return string(data)
+}
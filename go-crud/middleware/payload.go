@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/surrealdb/go-crud-bench/internal/codec"
+)
+
+// Payload is a record encoded as a JSON document. Its fields are only decoded
+// when one of the typed accessors is called.
+type Payload struct {
+	data   []byte
+	fields map[string]interface{}
+}
+
+// NewPayload encodes a record with the selected JSON encoder
+func NewPayload(value map[string]interface{}) (*Payload, error) {
+	data, err := codec.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal value to JSON: %w", err)
+	}
+	return &Payload{data: data}, nil
+}
+
+// PayloadOf wraps the JSON encoding of a record, as stored by an adapter
+func PayloadOf(data []byte) *Payload {
+	return &Payload{data: data}
+}
+
+// Bytes returns the JSON encoding of the record
+func (p *Payload) Bytes() []byte {
+	return p.data
+}
+
+// String returns the JSON encoding of the record as text, for logging
+func (p *Payload) String() string {
+	return string(p.data)
+}
+
+// Fields decodes the fields of the record
+func (p *Payload) Fields() (map[string]interface{}, error) {
+	if p.fields == nil {
+		if err := codec.Unmarshal(p.data, &p.fields); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+		}
+	}
+	return p.fields, nil
+}
+
+// field returns the named top-level field of the record
+func (p *Payload) field(name string) (interface{}, bool) {
+	fields, err := p.Fields()
+	if err != nil {
+		return nil, false
+	}
+	v, ok := fields[name]
+	return v, ok
+}
+
+// StringField returns the named field if it is a string
+func (p *Payload) StringField(name string) (string, bool) {
+	v, _ := p.field(name)
+	s, ok := v.(string)
+	return s, ok
+}
+
+// IntField returns the named field if it is an integral number
+func (p *Payload) IntField(name string) (int64, bool) {
+	v, _ := p.field(name)
+	f, ok := v.(float64)
+	if !ok || f != math.Trunc(f) {
+		return 0, false
+	}
+	return int64(f), true
+}
+
+// FloatField returns the named field if it is a number
+func (p *Payload) FloatField(name string) (float64, bool) {
+	v, _ := p.field(name)
+	f, ok := v.(float64)
+	return f, ok
+}
+
+// BoolField returns the named field if it is a boolean
+func (p *Payload) BoolField(name string) (bool, bool) {
+	v, _ := p.field(name)
+	b, ok := v.(bool)
+	return b, ok
+}